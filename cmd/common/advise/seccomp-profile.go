@@ -15,15 +15,97 @@
 package advise
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/spf13/cobra"
+
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	ociseccomp "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/profile"
 )
 
 func NewSeccompProfileCmd(runCmd func(*cobra.Command, []string) error) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:          "seccomp-profile",
 		Short:        "Generate seccomp profiles based on recorded syscalls activity",
 		Args:         cobra.NoArgs,
 		SilenceUsage: true,
 		RunE:         runCmd,
 	}
+
+	cmd.AddCommand(newSeccompProfileMergeCmd())
+	cmd.AddCommand(newSeccompProfileDiffCmd())
+
+	return cmd
+}
+
+func newSeccompProfileMergeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "merge <profile> <profile>...",
+		Short:        "Merge OCI seccomp profiles into one allowing all their syscalls",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles := []*specs.LinuxSeccomp{}
+			for _, path := range args {
+				profile, err := ReadSeccompProfile(path)
+				if err != nil {
+					return err
+				}
+				profiles = append(profiles, profile)
+			}
+
+			merged, err := ociseccomp.Merge(profiles...)
+			if err != nil {
+				return err
+			}
+			output, err := json.MarshalIndent(merged, "", "  ")
+			if err != nil {
+				return commonutils.WrapInErrMarshalOutput(err)
+			}
+			fmt.Println(string(output))
+
+			return nil
+		},
+	}
+}
+
+func newSeccompProfileDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "diff <base-profile> <recorded-profile>",
+		Short:        "Show the syscalls allowed by a recorded OCI seccomp profile but not by a base one",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			base, err := ReadSeccompProfile(args[0])
+			if err != nil {
+				return err
+			}
+			recorded, err := ReadSeccompProfile(args[1])
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(ociseccomp.FormatRules(ociseccomp.Diff(base, recorded)))
+
+			return nil
+		},
+	}
+}
+
+// ReadSeccompProfile reads the OCI seccomp profile of a file
+func ReadSeccompProfile(path string) (*specs.LinuxSeccomp, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profile, err := ociseccomp.Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return profile, nil
 }
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	bioTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/profile/block-io/types"
@@ -111,15 +112,25 @@ func (p *BlockIOParser) DisplayResultsCallback(traceOutputMode string, results [
 	}
 
 	var output string
-	if p.OutputMode == utils.OutputModeJSON {
+	switch p.OutputMode {
+	case utils.OutputModeJSON:
 		output = results[0] + "\n"
-	} else {
+	case utils.OutputModeYAML:
+		b, err := yaml.JSONToYAML([]byte(results[0]))
+		if err != nil {
+			return utils.WrapInErrMarshalOutput(err)
+		}
+
+		output = string(b)
+	case utils.OutputModeColumns, utils.OutputModeCustomColumns:
 		var report bioTypes.Report
 		if err := json.Unmarshal([]byte(results[0]), &report); err != nil {
 			return utils.WrapInErrUnmarshalOutput(err, results[0])
 		}
 
 		output = reportToString(report)
+	default:
+		return utils.WrapInErrOutputModeNotSupported(p.OutputMode)
 	}
 
 	fmt.Printf("%s", output)
@@ -0,0 +1,181 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/pprof/profile"
+
+	cpuTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/profile/cpu/types"
+)
+
+const (
+	OutputModePprof      = "pprof"
+	OutputModeFolded     = "folded"
+	OutputModeFlameGraph = "flamegraph"
+)
+
+// CPUOutputModes are the output modes supported by the profile cpu gadget
+// besides the common ones.
+var CPUOutputModes = []string{OutputModePprof, OutputModeFolded, OutputModeFlameGraph}
+
+const samplingPeriod = int64(time.Second / cpuTypes.SampleFrequency)
+
+// reverseStack returns a copy of the given stack going from the outermost
+// frame to the innermost one, the reports contain the innermost frame first.
+func reverseStack(stack []string) []string {
+	reversed := make([]string, 0, len(stack))
+	for i := len(stack) - 1; i >= 0; i-- {
+		reversed = append(reversed, stack[i])
+	}
+	return reversed
+}
+
+type foldedStack struct {
+	frames []string
+	count  uint64
+}
+
+// foldStacks aggregates the reports, that can come from different nodes, by
+// command and stack trace. The frames of each stack start with the command
+// and go from the outermost user space frame to the innermost kernel one.
+// The result is sorted by stack.
+func foldStacks(reports []cpuTypes.Report) []foldedStack {
+	counts := map[string]*foldedStack{}
+
+	for _, report := range reports {
+		frames := []string{report.Comm}
+		frames = append(frames, reverseStack(report.UserStack)...)
+		frames = append(frames, reverseStack(report.KernelStack)...)
+
+		key := strings.Join(frames, ";")
+		stack, ok := counts[key]
+		if !ok {
+			stack = &foldedStack{frames: frames}
+			counts[key] = stack
+		}
+		stack.count += report.Count
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	stacks := make([]foldedStack, 0, len(keys))
+	for _, key := range keys {
+		stacks = append(stacks, *counts[key])
+	}
+
+	return stacks
+}
+
+// writeFolded writes the reports in the collapsed stacks format used by
+// flamegraph.pl and speedscope: one line per stack with the frames separated
+// by semicolons followed by the number of samples.
+func writeFolded(w io.Writer, reports []cpuTypes.Report) error {
+	for _, stack := range foldStacks(reports) {
+		if _, err := fmt.Fprintf(w, "%s %d\n", strings.Join(stack.frames, ";"), stack.count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildPprof converts the reports into a pprof profile. Each report is a
+// sample labeled with the command, process and Kubernetes information.
+func buildPprof(reports []cpuTypes.Report) *profile.Profile {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "cpu", Unit: "nanoseconds"},
+		},
+		PeriodType: &profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:     samplingPeriod,
+	}
+
+	// key: function name
+	locations := map[string]*profile.Location{}
+	location := func(name string) *profile.Location {
+		if loc, ok := locations[name]; ok {
+			return loc
+		}
+
+		fn := &profile.Function{
+			ID:         uint64(len(p.Function) + 1),
+			Name:       name,
+			SystemName: name,
+		}
+		p.Function = append(p.Function, fn)
+
+		loc := &profile.Location{
+			ID:   uint64(len(p.Location) + 1),
+			Line: []profile.Line{{Function: fn}},
+		}
+		p.Location = append(p.Location, loc)
+		locations[name] = loc
+
+		return loc
+	}
+
+	for _, report := range reports {
+		sample := &profile.Sample{
+			Value:    []int64{int64(report.Count), int64(report.Count) * samplingPeriod},
+			Label:    map[string][]string{},
+			NumLabel: map[string][]int64{"pid": {int64(report.Pid)}},
+		}
+
+		// pprof expects the innermost frame first, like in the reports
+		for _, frame := range report.KernelStack {
+			sample.Location = append(sample.Location, location(frame))
+		}
+		for _, frame := range report.UserStack {
+			sample.Location = append(sample.Location, location(frame))
+		}
+
+		for key, value := range map[string]string{
+			"comm":      report.Comm,
+			"node":      report.Node,
+			"namespace": report.Namespace,
+			"pod":       report.Pod,
+			"container": report.Container,
+		} {
+			if value != "" {
+				sample.Label[key] = []string{value}
+			}
+		}
+
+		p.Sample = append(p.Sample, sample)
+	}
+
+	return p
+}
+
+// writePprof writes the reports as a gzipped profile.proto.
+func writePprof(w io.Writer, reports []cpuTypes.Report) error {
+	p := buildPprof(reports)
+	if err := p.CheckValid(); err != nil {
+		return fmt.Errorf("building pprof profile: %w", err)
+	}
+
+	return p.Write(w)
+}
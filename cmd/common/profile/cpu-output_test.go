@@ -0,0 +1,115 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"gotest.tools/v3/assert"
+
+	cpuTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/profile/cpu/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// Reports as received from two different nodes
+var testReports = []cpuTypes.Report{
+	{
+		CommonData:  eventtypes.CommonData{Node: "node1", Namespace: "default", Pod: "mypod", Container: "app"},
+		Comm:        "app",
+		Pid:         42,
+		UserStack:   []string{"main.work", "main.main", "runtime.main"},
+		KernelStack: []string{"do_syscall_64", "entry_SYSCALL_64_after_hwframe"},
+		Count:       3,
+	},
+	{
+		CommonData: eventtypes.CommonData{Node: "node1", Namespace: "default", Pod: "mypod", Container: "app"},
+		Comm:       "app",
+		Pid:        42,
+		UserStack:  []string{"main.idle", "main.main", "runtime.main"},
+		Count:      1,
+	},
+	{
+		CommonData:  eventtypes.CommonData{Node: "node2", Namespace: "default", Pod: "mypod2", Container: "app"},
+		Comm:        "app",
+		Pid:         1234,
+		UserStack:   []string{"main.work", "main.main", "runtime.main"},
+		KernelStack: []string{"do_syscall_64", "entry_SYSCALL_64_after_hwframe"},
+		Count:       2,
+	},
+}
+
+func TestWriteFolded(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := writeFolded(&b, testReports)
+	assert.NilError(t, err)
+
+	expected := "app;runtime.main;main.main;main.idle 1\n" +
+		"app;runtime.main;main.main;main.work;entry_SYSCALL_64_after_hwframe;do_syscall_64 5\n"
+	assert.Equal(t, b.String(), expected)
+}
+
+func TestWritePprof(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := writePprof(&b, testReports)
+	assert.NilError(t, err)
+
+	p, err := profile.Parse(&b)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(p.Sample), 3)
+	assert.Equal(t, p.Period, samplingPeriod)
+	// Functions are shared between samples
+	assert.Equal(t, len(p.Function), 6)
+
+	s := p.Sample[0]
+	assert.DeepEqual(t, s.Value, []int64{3, 3 * samplingPeriod})
+	assert.DeepEqual(t, s.Label["pod"], []string{"mypod"})
+	assert.DeepEqual(t, s.NumLabel["pid"], []int64{42})
+	assert.Equal(t, len(s.Location), 5)
+	assert.Equal(t, s.Location[0].Line[0].Function.Name, "do_syscall_64")
+	assert.Equal(t, s.Location[4].Line[0].Function.Name, "runtime.main")
+}
+
+func TestWriteFlameGraph(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := writeFlameGraph(&b, testReports)
+	assert.NilError(t, err)
+
+	// Check it's well-formed XML
+	d := xml.NewDecoder(bytes.NewReader(b.Bytes()))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+	}
+
+	svg := b.String()
+	assert.Assert(t, strings.Contains(svg, "<title>all (6 samples, 100.00%)</title>"))
+	assert.Assert(t, strings.Contains(svg, "<title>main.work (5 samples, 83.33%)</title>"))
+	assert.Assert(t, strings.Contains(svg, "<title>main.idle (1 samples, 16.67%)</title>"))
+}
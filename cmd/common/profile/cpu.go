@@ -65,8 +65,13 @@ func NewCPUCmd(runCmd func(*cobra.Command, []string) error, flags *CPUFlags) *co
 }
 
 func (p *CPUParser) DisplayResultsCallback(traceOutputMode string, results []string) error {
-	if p.OutputConfig.OutputMode != utils.OutputModeJSON {
-		fmt.Println(p.BuildColumnsHeader())
+	switch p.OutputConfig.OutputMode {
+	case OutputModePprof, OutputModeFolded, OutputModeFlameGraph:
+		return p.writeProfile(results)
+	}
+
+	if header := p.BuildHeader(); header != "" {
+		fmt.Println(header)
 	}
 
 	for _, r := range results {
@@ -76,8 +81,39 @@ func (p *CPUParser) DisplayResultsCallback(traceOutputMode string, results []str
 		}
 
 		for _, report := range reports {
-			fmt.Println(p.TransformReport(&report))
+			if line := p.TransformReport(&report); line != "" {
+				fmt.Println(line)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeProfile writes a single profile with the reports of all the results,
+// i.e. of all the nodes, in one of the CPUOutputModes.
+func (p *CPUParser) writeProfile(results []string) error {
+	allReports := []cpuTypes.Report{}
+	for _, r := range results {
+		var reports []cpuTypes.Report
+		if err := json.Unmarshal([]byte(r), &reports); err != nil {
+			return utils.WrapInErrUnmarshalOutput(err, r)
 		}
+
+		allReports = append(allReports, reports...)
+	}
+
+	var err error
+	switch p.OutputConfig.OutputMode {
+	case OutputModePprof:
+		err = writePprof(os.Stdout, allReports)
+	case OutputModeFolded:
+		err = writeFolded(os.Stdout, allReports)
+	case OutputModeFlameGraph:
+		err = writeFlameGraph(os.Stdout, allReports)
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s output: %w", p.OutputConfig.OutputMode, err)
 	}
 
 	return nil
@@ -99,26 +135,16 @@ func getReverseStringSlice(toReverse []string) string {
 }
 
 func (p *CPUParser) TransformReport(report *cpuTypes.Report) string {
-	switch p.OutputConfig.OutputMode {
-	case utils.OutputModeJSON:
-		b, err := json.Marshal(report)
-		if err != nil {
-			fmt.Fprint(os.Stderr, fmt.Sprint(utils.WrapInErrMarshalOutput(err)))
-			return ""
-		}
+	if !p.OutputConfig.IsColumnsOutput() {
+		return p.TransformEntry(report)
+	}
 
-		return string(b)
-	case utils.OutputModeColumns:
-		fallthrough
-	case utils.OutputModeCustomColumns:
-		otherCols := p.TransformIntoColumns(report)
-		if p.CPUFlags.ProfileUserOnly {
-			return otherCols + getReverseStringSlice(report.UserStack)
-		} else if p.CPUFlags.ProfileKernelOnly {
-			return otherCols + getReverseStringSlice(report.KernelStack)
-		} else {
-			return otherCols + getReverseStringSlice(report.KernelStack) + getReverseStringSlice(report.UserStack)
-		}
+	otherCols := p.TransformIntoColumns(report)
+	if p.CPUFlags.ProfileUserOnly {
+		return otherCols + getReverseStringSlice(report.UserStack)
+	} else if p.CPUFlags.ProfileKernelOnly {
+		return otherCols + getReverseStringSlice(report.KernelStack)
+	} else {
+		return otherCols + getReverseStringSlice(report.KernelStack) + getReverseStringSlice(report.UserStack)
 	}
-	return ""
 }
@@ -0,0 +1,168 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"sort"
+
+	cpuTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/profile/cpu/types"
+)
+
+const (
+	flameGraphWidth   = 1200.0
+	flameGraphPadding = 10.0
+	frameHeight       = 16.0
+	titleHeight       = 32.0
+	fontSize          = 12.0
+	// Approximation of the width of a character in pixels, used to decide
+	// how much of the name fits in a frame.
+	charWidth = fontSize * 0.59
+	// Frames narrower than this, in pixels, aren't drawn.
+	minFrameWidth = 0.1
+)
+
+type flameNode struct {
+	name     string
+	count    uint64
+	children map[string]*flameNode
+}
+
+func (n *flameNode) child(name string) *flameNode {
+	c, ok := n.children[name]
+	if !ok {
+		c = &flameNode{name: name, children: map[string]*flameNode{}}
+		n.children[name] = c
+	}
+	return c
+}
+
+func (n *flameNode) depth() int {
+	depth := 0
+	for _, c := range n.children {
+		if d := c.depth(); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
+
+// sortedChildren returns the children sorted by name, as flamegraph.pl does.
+func (n *flameNode) sortedChildren() []*flameNode {
+	children := make([]*flameNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// frameColor returns a color in the warm palette of flamegraph.pl. It's
+// derived from the name so the same function has the same color in all the
+// frames.
+func frameColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	v := h.Sum32()
+
+	r := 205 + v%50
+	g := (v >> 8) % 230
+	b := (v >> 16) % 55
+	return fmt.Sprintf("rgb(%d,%d,%d)", r, g, b)
+}
+
+type flameGraphWriter struct {
+	w      *bufio.Writer
+	total  uint64
+	height float64
+	scale  float64
+}
+
+func (fw *flameGraphWriter) writeFrame(n *flameNode, x float64, level int) {
+	width := float64(n.count) * fw.scale
+	if width < minFrameWidth {
+		return
+	}
+
+	y := fw.height - flameGraphPadding - float64(level+1)*frameHeight
+	title := fmt.Sprintf("%s (%d samples, %.2f%%)", n.name, n.count, 100*float64(n.count)/float64(fw.total))
+
+	label := ""
+	if chars := int((width - 6) / charWidth); chars >= 3 {
+		label = n.name
+		if len(label) > chars {
+			label = label[:chars-2] + ".."
+		}
+	}
+
+	fmt.Fprintf(fw.w, "<g><title>%s</title>", html.EscapeString(title))
+	fmt.Fprintf(fw.w, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" rx="2" ry="2"/>`,
+		x, y, width, frameHeight-1, frameColor(n.name))
+	if label != "" {
+		fmt.Fprintf(fw.w, `<text x="%.1f" y="%.1f">%s</text>`, x+3, y+frameHeight-5, html.EscapeString(label))
+	}
+	fmt.Fprintln(fw.w, "</g>")
+
+	for _, c := range n.sortedChildren() {
+		fw.writeFrame(c, x, level+1)
+		x += float64(c.count) * fw.scale
+	}
+}
+
+// writeFlameGraph writes the reports as a self-contained SVG flame graph.
+// The name and number of samples of each frame are shown when hovering it.
+func writeFlameGraph(w io.Writer, reports []cpuTypes.Report) error {
+	root := &flameNode{name: "all", children: map[string]*flameNode{}}
+	for _, stack := range foldStacks(reports) {
+		root.count += stack.count
+
+		n := root
+		for _, frame := range stack.frames {
+			n = n.child(frame)
+			n.count += stack.count
+		}
+	}
+
+	fw := &flameGraphWriter{
+		w:      bufio.NewWriter(w),
+		total:  root.count,
+		height: titleHeight + float64(root.depth())*frameHeight + 2*flameGraphPadding,
+	}
+	if root.count != 0 {
+		fw.scale = (flameGraphWidth - 2*flameGraphPadding) / float64(root.count)
+	}
+
+	fmt.Fprintln(fw.w, `<?xml version="1.0" standalone="no"?>`)
+	fmt.Fprintf(fw.w, `<svg version="1.1" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" xmlns="http://www.w3.org/2000/svg">`+"\n",
+		flameGraphWidth, fw.height, flameGraphWidth, fw.height)
+	fmt.Fprintf(fw.w, `<style>text { font-family: Verdana, sans-serif; font-size: %.0fpx; fill: rgb(0,0,0); }</style>`+"\n", fontSize)
+	fmt.Fprintf(fw.w, `<rect x="0" y="0" width="100%%" height="100%%" fill="rgb(245,245,245)"/>`+"\n")
+	fmt.Fprintf(fw.w, `<text x="%.0f" y="%.0f" text-anchor="middle" style="font-size: %.0fpx">CPU Flame Graph</text>`+"\n",
+		flameGraphWidth/2, titleHeight-8, fontSize+5)
+
+	if root.count != 0 {
+		fw.writeFrame(root, flameGraphPadding, 0)
+	}
+
+	fmt.Fprintln(fw.w, "</svg>")
+
+	return fw.w.Flush()
+}
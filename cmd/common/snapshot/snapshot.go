@@ -42,8 +42,9 @@ type SnapshotParser[Event any] interface {
 	// SortEvents sorts a slice of events based on a predefined prioritization.
 	SortEvents(*[]*Event)
 
-	// TransformIntoTable is called to transform headers and events into a table.
-	TransformIntoTable([]*Event) string
+	// TransformEntries is called to transform the events into the output mode
+	// requested by the user, e.g. a table.
+	TransformEntries([]*Event) (string, error)
 
 	// GetOutputConfig returns the output configuration.
 	GetOutputConfig() *commonutils.OutputConfig
@@ -68,9 +69,7 @@ func (g *SnapshotGadgetPrinter[Event]) PrintEvents(allEvents []*Event) error {
 
 		fmt.Printf("%s\n", b)
 		return nil
-	case commonutils.OutputModeColumns:
-		fallthrough
-	case commonutils.OutputModeCustomColumns:
+	default:
 		allEventsTrimmed := []*Event{}
 		for _, e := range allEvents {
 			baseEvent := (*e).GetBaseEvent()
@@ -82,7 +81,12 @@ func (g *SnapshotGadgetPrinter[Event]) PrintEvents(allEvents []*Event) error {
 		}
 		allEvents = allEventsTrimmed
 
-		fmt.Println(g.Parser.TransformIntoTable(allEvents))
+		output, err := g.Parser.TransformEntries(allEvents)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(output)
 	}
 
 	return nil
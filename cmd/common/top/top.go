@@ -17,11 +17,12 @@ package top
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
+	"sigs.k8s.io/yaml"
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/sort"
@@ -44,41 +45,72 @@ type TopEvent interface {
 // TopParser defines the interface that every top-gadget parser has to
 // implement.
 type TopParser[Stats any] interface {
-	// BuildColumnsHeader returns a header to be used when the user requests to
-	// present the output in columns.
-	BuildColumnsHeader() string
-	TransformIntoColumns(*Stats) string
+	// BuildHeader returns the header to print before the stats in the output
+	// mode requested by the user, if any.
+	BuildHeader() string
+	// TransformEntry transforms the stats of an entry into a line in the output
+	// mode requested by the user.
+	TransformEntry(*Stats) string
+
+	// Filter returns the stats that have to be printed.
+	Filter([]*Stats) []*Stats
 }
 
 type CommonTopFlags struct {
+	commonutils.GroupFlags
+
 	OutputInterval int
 	MaxRows        int
 	SortBy         string
 	ParsedSortBy   []string
 }
 
+// TracerMaxRows returns the number of rows the tracer has to report. All of
+// them are needed to compute the aggregations when grouping, MaxRows is then
+// applied to the groups.
+func (f *CommonTopFlags) TracerMaxRows() int {
+	if f.IsGrouping() {
+		return math.MaxInt32
+	}
+	return f.MaxRows
+}
+
 type TopGadget[Stats any] struct {
 	CommonTopFlags *CommonTopFlags
 	OutputConfig   *commonutils.OutputConfig
 	Parser         TopParser[Stats]
 	ColMap         columns.ColumnMap[Stats]
+
+	headerPrinted bool
 }
 
 func (g *TopGadget[Stats]) PrintHeader() {
-	if g.OutputConfig.OutputMode == commonutils.OutputModeJSON {
+	if g.OutputConfig.IsColumnsOutput() {
+		commonutils.RefreshScreen()
+		fmt.Println(g.Parser.BuildHeader())
 		return
 	}
 
-	if term.IsTerminal(int(os.Stdout.Fd())) {
-		commonutils.ClearScreen()
-	} else {
-		fmt.Println("")
+	// In other formats, the output isn't refreshed but appended, so the
+	// header (if any) is printed only once
+	if g.headerPrinted {
+		return
 	}
+	g.headerPrinted = true
 
-	fmt.Println(g.Parser.BuildColumnsHeader())
+	if header := g.Parser.BuildHeader(); header != "" {
+		fmt.Println(header)
+	}
 }
 
 func (g *TopGadget[Stats]) PrintStats(stats []*Stats) {
+	stats = g.Parser.Filter(stats)
+	stats, err := commonutils.GroupEntries(&g.CommonTopFlags.GroupFlags, g.ColMap, stats)
+	if err != nil {
+		fmt.Fprint(os.Stderr, fmt.Sprint(commonutils.WrapInErrGenGadgetOutput(err)))
+		return
+	}
+
 	top.SortStats(stats, g.CommonTopFlags.ParsedSortBy, &g.ColMap)
 
 	sliceEnd := g.CommonTopFlags.MaxRows
@@ -95,12 +127,17 @@ func (g *TopGadget[Stats]) PrintStats(stats []*Stats) {
 			fmt.Fprint(os.Stderr, fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
 		}
 		fmt.Println(string(b))
-
-	case commonutils.OutputModeColumns:
-		fallthrough
-	case commonutils.OutputModeCustomColumns:
+	case commonutils.OutputModeYAML:
+		b, err := yaml.Marshal(stats)
+		if err != nil {
+			fmt.Fprint(os.Stderr, fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
+		}
+		fmt.Print("---\n" + string(b))
+	default:
 		for _, stat := range stats {
-			fmt.Println(g.Parser.TransformIntoColumns(stat))
+			if line := g.Parser.TransformEntry(stat); line != "" {
+				fmt.Println(line)
+			}
 		}
 	}
 }
@@ -118,6 +155,7 @@ func AddCommonTopFlags[Stats any](
 		"",
 		strings.Join(sortBySliceDefault, ","),
 		fmt.Sprintf("Sort by columns. Join multiple columns with ','. Prefix a column with '-' to sort in descending order. Available columns: (%s)", strings.Join(validCols, ", ")))
+	commonutils.AddGroupFlags(command, &commonTopFlags.GroupFlags)
 }
 
 func NewCommonTopCmd() *cobra.Command {
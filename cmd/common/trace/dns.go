@@ -15,13 +15,38 @@
 package trace
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
+
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
 )
 
-func NewDNSCmd(runCmd func(*cobra.Command, []string) error) *cobra.Command {
-	return &cobra.Command{
+type DNSFlags struct {
+	DNSTimeout time.Duration
+}
+
+func NewDNSCmd(runCmd func(*cobra.Command, []string) error, flags *DNSFlags) *cobra.Command {
+	cmd := &cobra.Command{
 		Use:   "dns",
 		Short: "Trace DNS requests",
-		RunE:  runCmd,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flags.DNSTimeout <= 0 {
+				return commonutils.WrapInErrInvalidArg("--dns-timeout",
+					fmt.Errorf("%s is not a positive duration", flags.DNSTimeout))
+			}
+
+			return nil
+		},
+		RunE: runCmd,
 	}
+
+	cmd.Flags().DurationVar(
+		&flags.DNSTimeout, "dns-timeout", types.DNSTimeoutDefault,
+		"Time after which a query without response is reported as timed out",
+	)
+
+	return cmd
 }
@@ -17,6 +17,7 @@ package trace
 import (
 	"github.com/spf13/cobra"
 
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
 
@@ -33,12 +34,20 @@ type TraceEvent interface {
 // TraceParser defines the interface that every trace-gadget parser has to
 // implement.
 type TraceParser[Event any] interface {
-	// Transform is called to transform an event to columns.
-	TransformIntoColumns(event *Event) string
+	// TransformEntry is called to transform an event to the output mode
+	// requested by the user.
+	TransformEntry(event *Event) string
 
-	// BuildColumnsHeader returns a header to be used when the user requests to
-	// present the output in columns.
-	BuildColumnsHeader() string
+	// BuildHeader returns a header to be printed before the events, if the
+	// output mode requested by the user has one.
+	BuildHeader() string
+
+	// Match returns whether the event has to be printed.
+	Match(event *Event) bool
+
+	// NewSummarizer returns a Summarizer if the user requested to print a
+	// summary of the events instead of each of them, nil otherwise.
+	NewSummarizer() *commonutils.Summarizer[Event]
 }
 
 func NewCommonTraceCmd() *cobra.Command {
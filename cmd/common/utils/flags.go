@@ -29,29 +29,58 @@ const (
 	OutputModeColumns       = "columns"
 	OutputModeJSON          = "json"
 	OutputModeCustomColumns = "custom-columns"
+	OutputModeYAML          = "yaml"
+	OutputModeCSV           = "csv"
+	OutputModeTSV           = "tsv"
+	OutputModeJSONLSchema   = "jsonl-schema"
 )
 
-var SupportedOutputModes = []string{OutputModeColumns, OutputModeJSON, OutputModeCustomColumns}
+var SupportedOutputModes = []string{
+	OutputModeColumns,
+	OutputModeJSON,
+	OutputModeCustomColumns,
+	OutputModeYAML,
+	OutputModeCSV,
+	OutputModeTSV,
+	OutputModeJSONLSchema,
+}
 
 // OutputConfig contains the flags that describes how to print the gadget's output
 type OutputConfig struct {
 	// OutputMode specifies the format output should be printed
 	OutputMode string
 
-	// List of columns to print (only meaningful when OutputMode is "columns=...")
+	// List of columns to print (only meaningful when OutputMode is
+	// "custom-columns=...", "csv=...", "tsv=..." or "jsonl-schema=...")
 	CustomColumns []string
 
 	// Verbose prints additional information
 	Verbose bool
+
+	// AdditionalOutputModes are the output modes supported by a specific
+	// gadget besides the common ones. The gadget is in charge of handling
+	// them. It has to be set before calling AddOutputFlags().
+	AdditionalOutputModes []string
+
+	// Filter is an expression that events have to match to be printed, see
+	// the pkg/columns/filter package for the syntax
+	Filter string
+
+	// Summary describes how to summarize the events instead of printing each
+	// of them
+	Summary SummaryConfig
 }
 
 func AddOutputFlags(command *cobra.Command, outputConfig *OutputConfig) {
+	supportedOutputModes := append([]string{}, SupportedOutputModes...)
+	supportedOutputModes = append(supportedOutputModes, outputConfig.AdditionalOutputModes...)
+
 	command.PersistentFlags().StringVarP(
 		&outputConfig.OutputMode,
 		"output",
 		"o",
 		OutputModeColumns,
-		fmt.Sprintf("Output format (%s).", strings.Join(SupportedOutputModes, ", ")),
+		fmt.Sprintf("Output format (%s). The columns to print can be chosen with custom-columns, csv, tsv and jsonl-schema, e.g. csv=pid,comm.", strings.Join(supportedOutputModes, ", ")),
 	)
 
 	command.PersistentFlags().BoolVarP(
@@ -62,43 +91,74 @@ func AddOutputFlags(command *cobra.Command, outputConfig *OutputConfig) {
 	)
 }
 
+// AddFilterFlag adds the flag to filter the events printed by the gadget. It's
+// only meaningful for gadgets whose parser is a GadgetParser.
+func AddFilterFlag(command *cobra.Command, outputConfig *OutputConfig) {
+	command.PersistentFlags().StringVarP(
+		&outputConfig.Filter,
+		"filter",
+		"",
+		"",
+		`Print only events matching the expression, e.g. 'pod~"^api" and (dport==443 or dport==8443) and not comm in ("curl","wget")'`,
+	)
+}
+
 func (config *OutputConfig) ParseOutputConfig() error {
 	if config.Verbose {
 		log.StandardLogger().SetLevel(log.DebugLevel)
 	}
 
-	switch {
-	case config.OutputMode == OutputModeColumns:
-		fallthrough
-	case config.OutputMode == OutputModeJSON:
+	mode, columns, hasColumns := strings.Cut(config.OutputMode, "=")
+
+	switch mode {
+	case OutputModeColumns, OutputModeJSON, OutputModeYAML:
+		if hasColumns {
+			return WrapInErrOutputModeNotSupported(config.OutputMode)
+		}
 		return nil
-	case strings.HasPrefix(config.OutputMode, OutputModeCustomColumns):
-		parts := strings.Split(config.OutputMode, "=")
-		if len(parts) != 2 {
-			return WrapInErrInvalidArg(OutputModeCustomColumns,
-				errors.New("expects a comma separated list of columns to use"))
+	case OutputModeCustomColumns, OutputModeCSV, OutputModeTSV, OutputModeJSONLSchema:
+		if !hasColumns {
+			if mode == OutputModeCustomColumns {
+				return WrapInErrInvalidArg(OutputModeCustomColumns,
+					errors.New("expects a comma separated list of columns to use"))
+			}
+			// Use the default columns of the gadget
+			return nil
 		}
 
-		cols := strings.Split(strings.ToLower(parts[1]), ",")
+		cols := strings.Split(strings.ToLower(columns), ",")
 		for _, col := range cols {
 			if len(col) == 0 {
-				return WrapInErrInvalidArg(OutputModeCustomColumns,
+				return WrapInErrInvalidArg(mode,
 					errors.New("column can't be empty"))
 			}
 		}
 
 		config.CustomColumns = cols
-		config.OutputMode = OutputModeCustomColumns
+		config.OutputMode = mode
 		return nil
 	default:
+		for _, mode := range config.AdditionalOutputModes {
+			if config.OutputMode == mode {
+				return nil
+			}
+		}
 		return WrapInErrOutputModeNotSupported(config.OutputMode)
 	}
 }
 
+// IsColumnsOutput returns whether the output is printed in columns, as
+// opposed to a machine readable format.
+func (config *OutputConfig) IsColumnsOutput() bool {
+	return config.OutputMode == OutputModeColumns || config.OutputMode == OutputModeCustomColumns
+}
+
 type RuntimesSocketPathConfig struct {
 	Docker     string
 	Containerd string
 	Crio       string
+	Podman     string
+	CRI        string
 }
 
 func AddRuntimesSocketPathFlags(command *cobra.Command, config *RuntimesSocketPathConfig) {
@@ -122,4 +182,18 @@ func AddRuntimesSocketPathFlags(command *cobra.Command, config *RuntimesSocketPa
 		runtimeclient.CrioDefaultSocketPath,
 		"CRI-O CRI Unix socket path",
 	)
+
+	command.PersistentFlags().StringVarP(
+		&config.Podman,
+		"podman-socketpath", "",
+		runtimeclient.PodmanDefaultSocketPath,
+		"Podman API Unix socket path",
+	)
+
+	command.PersistentFlags().StringVarP(
+		&config.CRI,
+		"cri-socketpath", "",
+		"",
+		fmt.Sprintf("CRI Unix socket path of another runtime, used with the %q runtime", runtimeclient.CRIName),
+	)
 }
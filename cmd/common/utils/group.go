@@ -0,0 +1,117 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/group"
+)
+
+// GroupFlags contains the flags to summarize the entries printed by a gadget
+// by grouping them.
+type GroupFlags struct {
+	// GroupBy is the comma separated list of columns to group by
+	GroupBy string
+
+	// Aggregations override the way columns are aggregated, in the
+	// column:type format
+	Aggregations []string
+
+	// Parsed representation of GroupBy and Aggregations, set by
+	// ParseGroupFlags()
+	ParsedGroupBy      []string
+	ParsedAggregations map[string]columns.GroupType
+}
+
+func AddGroupFlags(command *cobra.Command, flags *GroupFlags) {
+	command.Flags().StringVarP(
+		&flags.GroupBy,
+		"group-by", "",
+		"",
+		"Group entries by columns. Join multiple columns with ','",
+	)
+	command.Flags().StringSliceVarP(
+		&flags.Aggregations,
+		"agg", "",
+		nil,
+		fmt.Sprintf("Aggregate a column in a specific way when grouping, in the format column:type (e.g. lat:p99). Supported types: %s",
+			strings.Join(columns.GroupTypeNames(), ", ")),
+	)
+}
+
+// ParseGroupFlags verifies the flags against the columns of the gadget and
+// sets the parsed fields.
+func ParseGroupFlags[T any](flags *GroupFlags, colMap columns.ColumnMap[T]) error {
+	if flags.GroupBy == "" {
+		if len(flags.Aggregations) != 0 {
+			return WrapInErrInvalidArg("--agg", errors.New("can only be used along with --group-by"))
+		}
+		return nil
+	}
+
+	groupBy := strings.Split(strings.ToLower(flags.GroupBy), ",")
+	for _, col := range groupBy {
+		if _, ok := colMap.GetColumn(col); !ok {
+			return WrapInErrInvalidArg("--group-by", fmt.Errorf("invalid column %q", col))
+		}
+	}
+
+	aggregations, err := group.ParseAggregations(colMap, flags.Aggregations)
+	if err != nil {
+		return WrapInErrInvalidArg("--agg", err)
+	}
+
+	flags.ParsedGroupBy = groupBy
+	flags.ParsedAggregations = aggregations
+
+	return nil
+}
+
+// IsGrouping returns whether the user requested to group the entries.
+func (flags *GroupFlags) IsGrouping() bool {
+	return len(flags.ParsedGroupBy) != 0
+}
+
+// GroupEntries groups the entries having the same values in all the columns
+// requested by the user. They are returned unchanged if no grouping was
+// requested.
+func GroupEntries[T any](flags *GroupFlags, colMap columns.ColumnMap[T], entries []*T) ([]*T, error) {
+	if !flags.IsGrouping() {
+		return entries, nil
+	}
+
+	groups, err := CountGroups(flags, colMap, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make([]*T, 0, len(groups))
+	for _, g := range groups {
+		grouped = append(grouped, g.Entry)
+	}
+	return grouped, nil
+}
+
+// CountGroups works like GroupEntries, but it also returns the number of
+// entries in each group. It's only meaningful when IsGrouping() is true.
+func CountGroups[T any](flags *GroupFlags, colMap columns.ColumnMap[T], entries []*T) ([]group.Group[T], error) {
+	return group.GroupEntriesByColumns(colMap, entries, flags.ParsedGroupBy, flags.ParsedAggregations)
+}
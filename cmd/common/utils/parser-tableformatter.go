@@ -15,10 +15,17 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"sigs.k8s.io/yaml"
+
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/filter"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/formatter/csvcolumns"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/formatter/jsoncolumns"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/formatter/textcolumns"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/sort"
 )
@@ -41,10 +48,15 @@ type GadgetParserOptions struct {
 }
 
 // GadgetParser is a parser that helps printing the gadget output in columns
-// using the columns and formatter/textcolumns packages.
+// using the columns and formatter/textcolumns packages. It also supports the
+// machine readable output modes, see TransformEntry().
 type GadgetParser[T any] struct {
-	formatter *textcolumns.TextColumnsFormatter[T]
-	colsMap   columns.ColumnMap[T]
+	formatter     *textcolumns.TextColumnsFormatter[T]
+	csvFormatter  *csvcolumns.CSVColumnsFormatter[T]
+	jsonFormatter *jsoncolumns.JSONColumnsFormatter[T]
+	colsMap       columns.ColumnMap[T]
+	filter        *filter.FilterSpec[T]
+	outputConfig  *OutputConfig
 }
 
 func NewGadgetParser[T any](outputConfig *OutputConfig, cols *columns.Columns[T], options ...Option) (*GadgetParser[T], error) {
@@ -64,24 +76,62 @@ func NewGadgetParser[T any](outputConfig *OutputConfig, cols *columns.Columns[T]
 		colsMap = cols.GetColumnMap(columns.Or(columns.WithTag(opts.metadataTag), columns.WithNoTags()))
 	}
 
-	var formatter *textcolumns.TextColumnsFormatter[T]
+	// nil means the default columns of each formatter
+	var validCols []string
 	if len(outputConfig.CustomColumns) != 0 {
-		validCols, invalidCols := cols.VerifyColumnNames(outputConfig.CustomColumns)
+		var invalidCols []string
+		validCols, invalidCols = cols.VerifyColumnNames(outputConfig.CustomColumns)
 		if len(invalidCols) != 0 {
 			return nil, fmt.Errorf("invalid columns: %s", strings.Join(invalidCols, ", "))
 		}
+	}
+
+	formatter := textcolumns.NewFormatter(
+		colsMap,
+		textcolumns.WithDefaultColumns(validCols),
+	)
 
-		formatter = textcolumns.NewFormatter(
+	var csvFormatter *csvcolumns.CSVColumnsFormatter[T]
+	var jsonFormatter *jsoncolumns.JSONColumnsFormatter[T]
+	switch outputConfig.OutputMode {
+	case OutputModeCSV:
+		csvFormatter = csvcolumns.NewFormatter(
 			colsMap,
-			textcolumns.WithDefaultColumns(validCols),
+			csvcolumns.WithDefaultColumns(validCols),
 		)
-	} else {
-		formatter = textcolumns.NewFormatter(colsMap)
+	case OutputModeTSV:
+		csvFormatter = csvcolumns.NewFormatter(
+			colsMap,
+			csvcolumns.WithDefaultColumns(validCols),
+			csvcolumns.WithSeparator(csvcolumns.SeparatorTab),
+		)
+	case OutputModeJSONLSchema:
+		jsonFormatter = jsoncolumns.NewFormatter(
+			colsMap,
+			jsoncolumns.WithDefaultColumns(validCols),
+		)
+	}
+
+	var entryFilter *filter.FilterSpec[T]
+	if outputConfig.Filter != "" {
+		var err error
+		entryFilter, err = filter.GetFilterFromExpression(colsMap, outputConfig.Filter)
+		if err != nil {
+			return nil, WrapInErrInvalidArg("--filter", err)
+		}
+	}
+
+	if err := parseSummaryConfig(&outputConfig.Summary, colsMap); err != nil {
+		return nil, err
 	}
 
 	return &GadgetParser[T]{
-		formatter: formatter,
-		colsMap:   colsMap,
+		formatter:     formatter,
+		csvFormatter:  csvFormatter,
+		jsonFormatter: jsonFormatter,
+		colsMap:       colsMap,
+		filter:        entryFilter,
+		outputConfig:  outputConfig,
 	}, nil
 }
 
@@ -101,6 +151,123 @@ func (p *GadgetParser[T]) TransformIntoColumns(entry *T) string {
 	return p.formatter.FormatEntry(entry)
 }
 
+// BuildHeader returns the header to print before the entries in the output
+// mode selected by the user: The header of the columns, a row with the names of
+// the columns for CSV and TSV, or the schema of the entries for jsonl-schema.
+// It's empty for JSON and YAML.
+func (p *GadgetParser[T]) BuildHeader() string {
+	switch p.outputConfig.OutputMode {
+	case OutputModeColumns, OutputModeCustomColumns:
+		return p.formatter.FormatHeader()
+	case OutputModeCSV, OutputModeTSV:
+		return p.csvFormatter.FormatHeader()
+	case OutputModeJSONLSchema:
+		return p.jsonFormatter.FormatSchema()
+	}
+	return ""
+}
+
+// TransformEntry transforms an entry into a line in the output mode selected
+// by the user. For YAML, each entry is a different document. Errors are
+// printed and an empty string is returned.
+func (p *GadgetParser[T]) TransformEntry(entry *T) string {
+	var b []byte
+	var err error
+
+	switch p.outputConfig.OutputMode {
+	case OutputModeColumns, OutputModeCustomColumns:
+		return p.formatter.FormatEntry(entry)
+	case OutputModeCSV, OutputModeTSV:
+		return p.csvFormatter.FormatEntry(entry)
+	case OutputModeJSONLSchema:
+		var line string
+		line, err = p.jsonFormatter.FormatEntry(entry)
+		b = []byte(line)
+	case OutputModeJSON:
+		b, err = json.Marshal(entry)
+	case OutputModeYAML:
+		b, err = yaml.Marshal(entry)
+		b = append([]byte("---\n"), strings.TrimSuffix(string(b), "\n")...)
+	}
+	if err != nil {
+		fmt.Fprint(os.Stderr, fmt.Sprint(WrapInErrMarshalOutput(err)))
+		return ""
+	}
+
+	return string(b)
+}
+
+// TransformEntries transforms a list of entries in the output mode selected by
+// the user: A table for columns, CSV and TSV, an array for JSON and YAML, and
+// the schema followed by a line for each entry for jsonl-schema.
+func (p *GadgetParser[T]) TransformEntries(entries []*T) (string, error) {
+	switch p.outputConfig.OutputMode {
+	case OutputModeColumns, OutputModeCustomColumns:
+		return p.TransformIntoTable(entries), nil
+	case OutputModeCSV, OutputModeTSV:
+		return p.csvFormatter.FormatTable(entries), nil
+	case OutputModeJSON:
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", WrapInErrMarshalOutput(err)
+		}
+		return string(b), nil
+	case OutputModeYAML:
+		b, err := yaml.Marshal(entries)
+		if err != nil {
+			return "", WrapInErrMarshalOutput(err)
+		}
+		return strings.TrimSuffix(string(b), "\n"), nil
+	case OutputModeJSONLSchema:
+		lines := []string{p.jsonFormatter.FormatSchema()}
+		for _, entry := range entries {
+			if entry == nil {
+				continue
+			}
+			line, err := p.jsonFormatter.FormatEntry(entry)
+			if err != nil {
+				return "", WrapInErrMarshalOutput(err)
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+	return "", WrapInErrOutputModeNotSupported(p.outputConfig.OutputMode)
+}
+
+// Match returns whether the entry matches the filter given by the user. All
+// the entries match if there isn't any.
+func (p *GadgetParser[T]) Match(entry *T) bool {
+	if p.filter == nil {
+		return true
+	}
+	return p.filter.Match(entry)
+}
+
+// Filter returns the entries matching the filter given by the user.
+func (p *GadgetParser[T]) Filter(entries []*T) []*T {
+	if p.filter == nil {
+		return entries
+	}
+
+	filtered := make([]*T, 0, len(entries))
+	for _, entry := range entries {
+		if p.filter.Match(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// NewSummarizer returns a Summarizer to print the events as requested by the
+// user, or nil if they didn't request a summary.
+func (p *GadgetParser[T]) NewSummarizer() *Summarizer[T] {
+	if !p.outputConfig.Summary.IsGrouping() {
+		return nil
+	}
+	return newSummarizer(p.outputConfig, p.colsMap)
+}
+
 func (p *GadgetParser[T]) TransformIntoTable(entries []*T) string {
 	// Disable auto-scaling as AdjustWidthsToContent will already manage the
 	// screen size.
@@ -0,0 +1,112 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type formatEvent struct {
+	Comm    string `json:"comm" column:"comm"`
+	Pid     int    `json:"pid" column:"pid"`
+	Latency uint64 `json:"latency" column:"lat,unit:ns,hide"`
+}
+
+func TestGadgetParserOutputModes(t *testing.T) {
+	event := &formatEvent{Comm: "cat, dog", Pid: 42, Latency: 1000}
+
+	type testCase struct {
+		outputMode string
+		header     string
+		entry      string
+		entries    string
+	}
+
+	tests := []testCase{
+		{
+			outputMode: OutputModeCSV,
+			header:     "comm,pid",
+			entry:      `"cat, dog",42`,
+			entries:    "comm,pid\n\"cat, dog\",42",
+		},
+		{
+			outputMode: OutputModeTSV + "=pid,lat",
+			header:     "pid\tlat",
+			entry:      "42\t1000",
+			entries:    "pid\tlat\n42\t1000",
+		},
+		{
+			outputMode: OutputModeJSONLSchema + "=comm,lat",
+			header:     `{"schema":[{"name":"comm","kind":"string"},{"name":"lat","kind":"uint64","unit":"ns"}]}`,
+			entry:      `{"comm":"cat, dog","lat":1000}`,
+			entries:    `{"schema":[{"name":"comm","kind":"string"},{"name":"lat","kind":"uint64","unit":"ns"}]}` + "\n" + `{"comm":"cat, dog","lat":1000}`,
+		},
+		{
+			outputMode: OutputModeYAML,
+			header:     "",
+			entry:      "---\ncomm: cat, dog\nlatency: 1000\npid: 42",
+			entries:    "- comm: cat, dog\n  latency: 1000\n  pid: 42",
+		},
+		{
+			outputMode: OutputModeJSON,
+			header:     "",
+			entry:      `{"comm":"cat, dog","pid":42,"latency":1000}`,
+			entries:    "[\n  {\n    \"comm\": \"cat, dog\",\n    \"pid\": 42,\n    \"latency\": 1000\n  }\n]",
+		},
+	}
+
+	for _, test := range tests {
+		config := &OutputConfig{OutputMode: test.outputMode}
+		if err := config.ParseOutputConfig(); err != nil {
+			t.Fatalf("%s: parsing output config: %v", test.outputMode, err)
+		}
+
+		parser, err := NewGadgetParser(config, columns.MustCreateColumns[formatEvent]())
+		if err != nil {
+			t.Fatalf("%s: creating parser: %v", test.outputMode, err)
+		}
+
+		if header := parser.BuildHeader(); header != test.header {
+			t.Errorf("%s: expected header %q, got %q", test.outputMode, test.header, header)
+		}
+		if entry := parser.TransformEntry(event); entry != test.entry {
+			t.Errorf("%s: expected entry %q, got %q", test.outputMode, test.entry, entry)
+		}
+		entries, err := parser.TransformEntries([]*formatEvent{event})
+		if err != nil {
+			t.Fatalf("%s: transforming entries: %v", test.outputMode, err)
+		}
+		if entries != test.entries {
+			t.Errorf("%s: expected entries %q, got %q", test.outputMode, test.entries, entries)
+		}
+	}
+}
+
+func TestParseOutputConfig(t *testing.T) {
+	for _, outputMode := range []string{
+		"foo",
+		OutputModeJSON + "=comm",
+		OutputModeYAML + "=comm",
+		OutputModeCustomColumns,
+		OutputModeCSV + "=comm,,pid",
+	} {
+		config := &OutputConfig{OutputMode: outputMode}
+		if err := config.ParseOutputConfig(); err == nil {
+			t.Errorf("expected error parsing %q", outputMode)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+
+	"golang.org/x/term"
 )
 
 func ClearScreen() {
@@ -21,3 +23,14 @@ func ClearScreen() {
 		fmt.Print("\033[H\033[2J")
 	}
 }
+
+// RefreshScreen prepares the screen to print a table that replaces the previous
+// one: The screen is cleared if stdout is a terminal, otherwise an empty line
+// is printed to separate them.
+func RefreshScreen() {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		ClearScreen()
+	} else {
+		fmt.Println("")
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/formatter/csvcolumns"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/formatter/textcolumns"
+)
+
+// countColumnWidth is the width of the column with the number of events of
+// each group in the summaries
+const countColumnWidth = 7
+
+// SummaryConfig contains the flags to print a summary of the events, grouped
+// by some columns, instead of each of them.
+type SummaryConfig struct {
+	GroupFlags
+
+	// groupBy is set by --group-by, an alias of --summarize-by to use the
+	// same flags as the top gadgets
+	groupBy string
+
+	// Every is the interval to print the summary of the events received
+	// during it. If it's zero, a single summary is printed once the gadget
+	// finishes.
+	Every time.Duration
+}
+
+// AddSummaryFlags adds the flags to summarize the events printed by the
+// gadget. It's only meaningful for trace gadgets whose parser is a
+// GadgetParser.
+func AddSummaryFlags(command *cobra.Command, outputConfig *OutputConfig) {
+	config := &outputConfig.Summary
+
+	command.PersistentFlags().StringVarP(
+		&config.GroupBy,
+		"summarize-by", "",
+		"",
+		"Print the number of events grouped by columns instead of each event. Join multiple columns with ','",
+	)
+	command.PersistentFlags().StringVarP(
+		&config.groupBy,
+		"group-by", "",
+		"",
+		"Same as --summarize-by",
+	)
+	command.PersistentFlags().StringSliceVarP(
+		&config.Aggregations,
+		"agg", "",
+		nil,
+		fmt.Sprintf("Aggregate a column in a specific way in the summary, in the format column:type (e.g. lat:p99). Supported types: %s",
+			strings.Join(columns.GroupTypeNames(), ", ")),
+	)
+	command.PersistentFlags().DurationVarP(
+		&config.Every,
+		"every", "",
+		0,
+		"Print the summary of the events received in each interval (e.g. 10s) instead of once the gadget finishes",
+	)
+}
+
+func parseSummaryConfig[T any](config *SummaryConfig, colsMap columns.ColumnMap[T]) error {
+	if config.groupBy != "" {
+		if config.GroupBy != "" {
+			return WrapInErrInvalidArg("--group-by", errors.New("can't be used along with --summarize-by"))
+		}
+		config.GroupBy = config.groupBy
+	}
+
+	if config.GroupBy == "" {
+		if len(config.Aggregations) != 0 {
+			return WrapInErrInvalidArg("--agg", errors.New("can only be used along with --summarize-by"))
+		}
+		if config.Every != 0 {
+			return WrapInErrInvalidArg("--every", errors.New("can only be used along with --summarize-by"))
+		}
+		return nil
+	}
+
+	if config.Every < 0 {
+		return WrapInErrInvalidArg("--every", errors.New("can't be negative"))
+	}
+
+	return ParseGroupFlags(&config.GroupFlags, colsMap)
+}
+
+// Summarizer collects the events of a trace gadget and prints them grouped,
+// along with the number of events of each group, instead of each of them. The
+// summary is printed every SummaryConfig.Every, replacing the previous one on
+// the screen, or once when the summarizer is stopped.
+type Summarizer[T any] struct {
+	config       *OutputConfig
+	colsMap      columns.ColumnMap[T]
+	formatter    *textcolumns.TextColumnsFormatter[T]
+	csvFormatter *csvcolumns.CSVColumnsFormatter[T]
+	writer       io.Writer
+
+	mu      sync.Mutex
+	entries []*T
+	stopped bool
+	done    chan struct{}
+}
+
+type summaryEntry[T any] struct {
+	Count int `json:"count"`
+	Event *T  `json:"event"`
+}
+
+func newSummarizer[T any](config *OutputConfig, colsMap columns.ColumnMap[T]) *Summarizer[T] {
+	names := summaryColumns(config, colsMap)
+
+	formatter := textcolumns.NewFormatter(
+		colsMap,
+		textcolumns.WithDefaultColumns(names),
+	)
+	// Widths are adjusted to the content of each summary
+	formatter.SetAutoScale(false)
+
+	separator := csvcolumns.SeparatorComma
+	if config.OutputMode == OutputModeTSV {
+		separator = csvcolumns.SeparatorTab
+	}
+	csvFormatter := csvcolumns.NewFormatter(
+		colsMap,
+		csvcolumns.WithDefaultColumns(names),
+		csvcolumns.WithSeparator(separator),
+	)
+
+	return &Summarizer[T]{
+		config:       config,
+		colsMap:      colsMap,
+		formatter:    formatter,
+		csvFormatter: csvFormatter,
+		writer:       os.Stdout,
+		done:         make(chan struct{}),
+	}
+}
+
+// summaryColumns returns the columns to print in the summary: The ones given
+// by the user or, by default, the columns used to group by followed by the
+// aggregated ones.
+func summaryColumns[T any](config *OutputConfig, colsMap columns.ColumnMap[T]) []string {
+	if len(config.CustomColumns) != 0 {
+		return config.CustomColumns
+	}
+
+	summary := &config.Summary
+	names := append([]string{}, summary.ParsedGroupBy...)
+
+	for _, column := range colsMap.GetOrderedColumns() {
+		name := strings.ToLower(column.Name)
+
+		_, aggregated := summary.ParsedAggregations[name]
+		if !aggregated && (!column.Visible || column.GroupType == columns.GroupTypeNone) {
+			continue
+		}
+
+		found := false
+		for _, n := range names {
+			if n == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Add adds an event to the current summary.
+func (s *Summarizer[T]) Add(entry *T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, entry)
+}
+
+// Start starts printing the summaries periodically, if requested by the user.
+func (s *Summarizer[T]) Start() {
+	if s.config.Summary.Every == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.config.Summary.Every)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.flush(true)
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops printing the summaries periodically and prints the summary of the
+// events received since the last one. It's safe to call it several times, e.g.
+// from different termination paths, only the first one has an effect.
+func (s *Summarizer[T]) Stop() {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	close(s.done)
+	s.mu.Unlock()
+
+	// When printing periodically, don't print an empty summary at the end
+	s.flush(s.config.Summary.Every == 0)
+}
+
+// flush prints the summary of the events received so far and starts a new one.
+func (s *Summarizer[T]) flush(printEmpty bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.entries
+	s.entries = nil
+
+	if len(entries) == 0 && !printEmpty {
+		return
+	}
+
+	s.print(entries)
+}
+
+func (s *Summarizer[T]) print(entries []*T) {
+	groups, err := CountGroups(&s.config.Summary.GroupFlags, s.colsMap, entries)
+	if err != nil {
+		fmt.Fprint(os.Stderr, fmt.Sprint(WrapInErrGenGadgetOutput(err)))
+		return
+	}
+
+	// Show the most frequent groups first. Groups are already sorted by the
+	// columns they were grouped by and then by their values, which keeps
+	// the order of the groups with the same count deterministic.
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+
+	switch s.config.OutputMode {
+	case OutputModeJSON, OutputModeJSONLSchema, OutputModeYAML:
+		summary := make([]summaryEntry[T], 0, len(groups))
+		for _, g := range groups {
+			summary = append(summary, summaryEntry[T]{Count: g.Count, Event: g.Entry})
+		}
+
+		b, err := json.Marshal(summary)
+		if err != nil {
+			fmt.Fprint(os.Stderr, fmt.Sprint(WrapInErrMarshalOutput(err)))
+			return
+		}
+		if s.config.OutputMode != OutputModeYAML {
+			fmt.Fprintln(s.writer, string(b))
+			return
+		}
+
+		b, err = yaml.JSONToYAML(b)
+		if err != nil {
+			fmt.Fprint(os.Stderr, fmt.Sprint(WrapInErrMarshalOutput(err)))
+			return
+		}
+		fmt.Fprint(s.writer, "---\n"+string(b))
+	case OutputModeCSV, OutputModeTSV:
+		separator := string(csvcolumns.SeparatorComma)
+		if s.config.OutputMode == OutputModeTSV {
+			separator = string(csvcolumns.SeparatorTab)
+		}
+
+		fmt.Fprintln(s.writer, "count"+separator+s.csvFormatter.FormatHeader())
+		for _, g := range groups {
+			fmt.Fprintf(s.writer, "%d%s%s\n", g.Count, separator, s.csvFormatter.FormatEntry(g.Entry))
+		}
+	case OutputModeColumns:
+		fallthrough
+	case OutputModeCustomColumns:
+		if s.config.Summary.Every != 0 {
+			RefreshScreen()
+		}
+
+		grouped := make([]*T, 0, len(groups))
+		for _, g := range groups {
+			grouped = append(grouped, g.Entry)
+		}
+		maxWidth := textcolumns.GetTerminalWidth()
+		if maxWidth > 0 {
+			// Leave room for the count column
+			maxWidth -= countColumnWidth + 1
+		}
+		s.formatter.AdjustWidthsToContent(grouped, true, maxWidth, true)
+
+		fmt.Fprintf(s.writer, "%*s %s\n", countColumnWidth, "COUNT", s.formatter.FormatHeader())
+		for _, g := range groups {
+			fmt.Fprintf(s.writer, "%*d %s\n", countColumnWidth, g.Count, s.formatter.FormatEntry(g.Entry))
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type summaryEvent struct {
+	Pod   string `json:"pod" column:"pod"`
+	Comm  string `json:"comm" column:"comm"`
+	Pid   int    `json:"pid" column:"pid"`
+	Bytes int    `json:"bytes" column:"bytes,group:sum"`
+	Lat   int    `json:"lat" column:"lat,hide"`
+}
+
+func newTestSummarizer(t *testing.T, config *OutputConfig) (*Summarizer[summaryEvent], *bytes.Buffer) {
+	parser, err := NewGadgetParser(config, columns.MustCreateColumns[summaryEvent]())
+	if err != nil {
+		t.Fatalf("creating parser: %v", err)
+	}
+
+	summarizer := parser.NewSummarizer()
+	if summarizer == nil {
+		t.Fatalf("expected a summarizer")
+	}
+
+	var b bytes.Buffer
+	summarizer.writer = &b
+	return summarizer, &b
+}
+
+func summaryRows(b *bytes.Buffer) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		rows = append(rows, strings.Fields(line))
+	}
+	return rows
+}
+
+func TestSummarizer(t *testing.T) {
+	config := &OutputConfig{
+		OutputMode: OutputModeColumns,
+		Summary: SummaryConfig{
+			GroupFlags: GroupFlags{GroupBy: "pod,comm"},
+		},
+	}
+	summarizer, b := newTestSummarizer(t, config)
+
+	summarizer.Add(&summaryEvent{Pod: "a", Comm: "cat", Pid: 1, Bytes: 10})
+	summarizer.Add(&summaryEvent{Pod: "b", Comm: "cat", Pid: 2, Bytes: 1})
+	summarizer.Add(&summaryEvent{Pod: "b", Comm: "cat", Pid: 3, Bytes: 2})
+	summarizer.Add(&summaryEvent{Pod: "a", Comm: "ls", Pid: 4, Bytes: 3})
+	summarizer.Stop()
+
+	expected := [][]string{
+		{"COUNT", "POD", "COMM", "BYTES"},
+		{"2", "b", "cat", "3"},
+		{"1", "a", "cat", "10"},
+		{"1", "a", "ls", "3"},
+	}
+	if rows := summaryRows(b); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("unexpected summary:\n%s", b.String())
+	}
+
+	// Only the first call has an effect
+	b.Reset()
+	summarizer.Stop()
+	if b.Len() != 0 {
+		t.Fatalf("unexpected output after stopping twice:\n%s", b.String())
+	}
+}
+
+func TestSummarizerWindows(t *testing.T) {
+	config := &OutputConfig{
+		OutputMode: OutputModeJSON,
+		Summary: SummaryConfig{
+			GroupFlags: GroupFlags{GroupBy: "comm", Aggregations: []string{"lat:max"}},
+			Every:      time.Hour,
+		},
+	}
+	summarizer, b := newTestSummarizer(t, config)
+
+	summarizer.Add(&summaryEvent{Comm: "cat", Lat: 3})
+	summarizer.Add(&summaryEvent{Comm: "cat", Lat: 5})
+	summarizer.flush(true)
+
+	expected := `[{"count":2,"event":{"pod":"","comm":"cat","pid":0,"bytes":0,"lat":5}}]` + "\n"
+	if b.String() != expected {
+		t.Fatalf("unexpected summary:\n%s", b.String())
+	}
+
+	// Each window only contains the events received during it
+	b.Reset()
+	summarizer.Add(&summaryEvent{Comm: "ls", Lat: 1})
+	summarizer.flush(true)
+
+	expected = `[{"count":1,"event":{"pod":"","comm":"ls","pid":0,"bytes":0,"lat":1}}]` + "\n"
+	if b.String() != expected {
+		t.Fatalf("unexpected summary:\n%s", b.String())
+	}
+
+	// Empty windows aren't printed when stopping
+	b.Reset()
+	summarizer.Stop()
+	if b.Len() != 0 {
+		t.Fatalf("unexpected output when stopping:\n%s", b.String())
+	}
+}
+
+func TestSummarizerCSV(t *testing.T) {
+	config := &OutputConfig{
+		OutputMode: OutputModeCSV,
+		Summary: SummaryConfig{
+			GroupFlags: GroupFlags{GroupBy: "comm"},
+		},
+	}
+	summarizer, b := newTestSummarizer(t, config)
+
+	summarizer.Add(&summaryEvent{Comm: "cat", Bytes: 1})
+	summarizer.Add(&summaryEvent{Comm: "cat", Bytes: 2})
+	summarizer.Stop()
+
+	expected := "count,comm,bytes\n2,cat,3\n"
+	if b.String() != expected {
+		t.Fatalf("unexpected summary:\n%s", b.String())
+	}
+}
+
+func TestSummaryColumns(t *testing.T) {
+	colsMap := columns.MustCreateColumns[summaryEvent]().GetColumnMap()
+
+	config := &OutputConfig{
+		Summary: SummaryConfig{
+			GroupFlags: GroupFlags{GroupBy: "comm", Aggregations: []string{"lat:p99"}},
+		},
+	}
+	if err := parseSummaryConfig(&config.Summary, colsMap); err != nil {
+		t.Fatalf("parsing summary config: %v", err)
+	}
+
+	expected := []string{"comm", "bytes", "lat"}
+	if cols := summaryColumns(config, colsMap); !reflect.DeepEqual(cols, expected) {
+		t.Fatalf("expected columns %v, got %v", expected, cols)
+	}
+
+	config.CustomColumns = []string{"pod", "pid"}
+	if cols := summaryColumns(config, colsMap); !reflect.DeepEqual(cols, config.CustomColumns) {
+		t.Fatalf("expected custom columns %v, got %v", config.CustomColumns, cols)
+	}
+}
+
+func TestParseSummaryConfig(t *testing.T) {
+	colsMap := columns.MustCreateColumns[summaryEvent]().GetColumnMap()
+
+	for _, config := range []SummaryConfig{
+		{GroupFlags: GroupFlags{GroupBy: "foo"}},
+		{GroupFlags: GroupFlags{GroupBy: "comm", Aggregations: []string{"comm:sum"}}},
+		{GroupFlags: GroupFlags{Aggregations: []string{"lat:sum"}}},
+		{Every: time.Second},
+		{GroupFlags: GroupFlags{GroupBy: "comm"}, Every: -time.Second},
+		{GroupFlags: GroupFlags{GroupBy: "comm"}, groupBy: "comm"},
+	} {
+		if err := parseSummaryConfig(&config, colsMap); err == nil {
+			t.Errorf("expected error parsing %+v", config)
+		}
+	}
+
+	// --group-by is an alias of --summarize-by
+	config := SummaryConfig{groupBy: "comm", GroupFlags: GroupFlags{Aggregations: []string{"lat:p99"}}}
+	if err := parseSummaryConfig(&config, colsMap); err != nil {
+		t.Fatalf("parsing summary config: %v", err)
+	}
+	if !reflect.DeepEqual(config.ParsedGroupBy, []string{"comm"}) {
+		t.Fatalf("expected to group by comm, got %v", config.ParsedGroupBy)
+	}
+}
@@ -28,6 +28,7 @@ var params utils.CommonFlags
 func NewAdviseCmd() *cobra.Command {
 	cmd := commonadvise.NewCommonAdviseCmd()
 
+	cmd.AddCommand(newCapabilitiesCmd())
 	cmd.AddCommand(newNetworkPolicyCmd())
 	cmd.AddCommand(newSeccompProfileCmd())
 
@@ -0,0 +1,168 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advise
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	"github.com/inspektor-gadget/inspektor-gadget/cmd/kubectl-gadget/utils"
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/capabilities/advisor"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/capabilities/types"
+)
+
+var capabilitiesAdvisorStartCmd = &cobra.Command{
+	Use:          "start",
+	Short:        "Start to monitor the capability checks",
+	RunE:         runCapabilitiesAdvisorStart,
+	SilenceUsage: true,
+}
+
+var capabilitiesAdvisorStopCmd = &cobra.Command{
+	Use:          "stop <trace-id>",
+	Short:        "Stop monitoring and report the securityContext patches",
+	RunE:         runCapabilitiesAdvisorStop,
+	SilenceUsage: true,
+}
+
+var capabilitiesAdvisorListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List existing capabilities traces",
+	RunE:         runCapabilitiesAdvisorList,
+	SilenceUsage: true,
+}
+
+var capabilitiesAuditOnly bool
+
+func newCapabilitiesCmd() *cobra.Command {
+	capabilitiesCmd := &cobra.Command{
+		Use:   "capabilities",
+		Short: "Generate securityContext capabilities based on recorded capability checks",
+	}
+	utils.AddCommonFlags(capabilitiesCmd, &params)
+
+	capabilitiesCmd.AddCommand(capabilitiesAdvisorStartCmd)
+	capabilitiesAdvisorStartCmd.PersistentFlags().BoolVar(&capabilitiesAuditOnly,
+		"audit-only", types.AuditOnlyDefault,
+		"Ignore the capability checks without audit, done by the kernel to probe a capability")
+
+	capabilitiesCmd.AddCommand(capabilitiesAdvisorStopCmd)
+	capabilitiesCmd.AddCommand(capabilitiesAdvisorListCmd)
+
+	return capabilitiesCmd
+}
+
+// runCapabilitiesAdvisorStart starts monitoring of capability checks for the
+// given parameters.
+func runCapabilitiesAdvisorStart(cmd *cobra.Command, args []string) error {
+	config := &utils.TraceConfig{
+		GadgetName:        "advise-capabilities",
+		Operation:         gadgetv1alpha1.OperationStart,
+		TraceOutputMode:   gadgetv1alpha1.TraceOutputModeStatus,
+		TraceInitialState: gadgetv1alpha1.TraceStateStarted,
+		CommonFlags:       &params,
+		Parameters: map[string]string{
+			types.AuditOnlyParam: strconv.FormatBool(capabilitiesAuditOnly),
+		},
+	}
+
+	traceID, err := utils.CreateTrace(config)
+	if err != nil {
+		return commonutils.WrapInErrRunGadget(err)
+	}
+
+	fmt.Printf("%s\n", traceID)
+
+	return nil
+}
+
+// runCapabilitiesAdvisorStop reports an already running trace which ID was
+// given as parameter.
+func runCapabilitiesAdvisorStop(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return commonutils.WrapInErrMissingArgs("<trace-id>")
+	}
+
+	traceID := args[0]
+
+	callback := func(traceOutputMode string, results []string) error {
+		// The pods of a workload can run on several nodes: merge the
+		// patches of all the nodes into one per workload
+		patches := []*advisor.WorkloadPatch{}
+		for _, r := range results {
+			if r == "" {
+				continue
+			}
+			nodePatches := []*advisor.WorkloadPatch{}
+			if err := json.Unmarshal([]byte(r), &nodePatches); err != nil {
+				return commonutils.WrapInErrUnmarshalOutput(err, r)
+			}
+			patches = append(patches, nodePatches...)
+		}
+
+		output, err := advisor.FormatPatches(advisor.MergePatches(patches))
+		if err != nil {
+			return commonutils.WrapInErrMarshalOutput(err)
+		}
+		fmt.Print(output)
+
+		return nil
+	}
+
+	// Maybe there is no trace with the given ID.
+	// But it is better to try to delete something which does not exist than
+	// leaking a resource.
+	defer utils.DeleteTrace(traceID)
+
+	err := utils.SetTraceOperation(traceID, string(gadgetv1alpha1.OperationGenerate))
+	if err != nil {
+		return commonutils.WrapInErrGenGadgetOutput(err)
+	}
+
+	// We stop the trace so its Status.State become Stopped.
+	// Indeed, generate operation does not change value of Status.State.
+	err = utils.SetTraceOperation(traceID, string(gadgetv1alpha1.OperationStop))
+	if err != nil {
+		return commonutils.WrapInErrStopGadget(err)
+	}
+
+	err = utils.PrintTraceOutputFromStatus(traceID, string(gadgetv1alpha1.TraceStateStopped), callback)
+	if err != nil {
+		return commonutils.WrapInErrGetGadgetOutput(err)
+	}
+
+	return nil
+}
+
+// runCapabilitiesAdvisorList lists already running traces which config was
+// given as parameter.
+func runCapabilitiesAdvisorList(cmd *cobra.Command, args []string) error {
+	config := &utils.TraceConfig{
+		GadgetName:  "advise-capabilities",
+		CommonFlags: &params,
+	}
+
+	err := utils.PrintAllTraces(config)
+	if err != nil {
+		return commonutils.WrapInErrListGadgetTraces(err)
+	}
+
+	return nil
+}
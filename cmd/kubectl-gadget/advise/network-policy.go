@@ -16,16 +16,19 @@ package advise
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/kubectl-gadget/utils"
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/networkpolicy/advisor"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/k8sutil"
 )
 
 var networkPolicyMonitorCmd = &cobra.Command{
@@ -40,9 +43,18 @@ var networkPolicyReportCmd = &cobra.Command{
 	RunE:  runNetworkPolicyReport,
 }
 
+var networkPolicyVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify existing network policies against recorded network activity",
+	RunE:  runNetworkPolicyVerify,
+}
+
 var (
-	inputFileName  string
-	outputFileName string
+	policyFileNames  []string
+	inputFileName    string
+	outputFileName   string
+	dnsInputFileName string
+	fqdnPolicyKinds  []string
 )
 
 func newNetworkPolicyCmd() *cobra.Command {
@@ -58,6 +70,16 @@ func newNetworkPolicyCmd() *cobra.Command {
 	networkPolicyCmd.AddCommand(networkPolicyReportCmd)
 	networkPolicyReportCmd.PersistentFlags().StringVarP(&inputFileName, "input", "", "", "File with recorded network activity")
 	networkPolicyReportCmd.PersistentFlags().StringVarP(&outputFileName, "output", "", "-", "File name output")
+	networkPolicyReportCmd.PersistentFlags().StringVarP(&dnsInputFileName, "dns-input", "", "", "File with DNS activity recorded with 'trace dns -o json', used to find the names of external endpoints")
+	networkPolicyReportCmd.PersistentFlags().StringSliceVarP(&fqdnPolicyKinds, "fqdn-policies", "", []string{},
+		fmt.Sprintf("Also generate policies allowing egress traffic to the names found in --dns-input, instead of their addresses in the Kubernetes network policies. Supported kinds: %s, %s (Calico Enterprise and Calico Cloud only)",
+			advisor.FQDNPolicyKindCilium, advisor.FQDNPolicyKindCalico))
+
+	networkPolicyCmd.AddCommand(networkPolicyVerifyCmd)
+	networkPolicyVerifyCmd.PersistentFlags().StringVarP(&inputFileName, "input", "", "", "File with recorded network activity")
+	networkPolicyVerifyCmd.PersistentFlags().StringVarP(&outputFileName, "output", "", "-", "File name output")
+	networkPolicyVerifyCmd.PersistentFlags().StringSliceVarP(&policyFileNames, "policies", "", []string{},
+		"Files with the network policies to verify. If not set, the network policies of the namespaces of the recorded pods are read from the cluster")
 
 	return networkPolicyCmd
 }
@@ -119,12 +141,31 @@ func runNetworkPolicyReport(cmd *cobra.Command, args []string) error {
 		return commonutils.WrapInErrMissingArgs("--input")
 	}
 
+	if len(fqdnPolicyKinds) > 0 && dnsInputFileName == "" {
+		return commonutils.WrapInErrMissingArgs("--dns-input")
+	}
+
 	adv := advisor.NewAdvisor()
 	err := adv.LoadFile(inputFileName)
 	if err != nil {
 		return err
 	}
 
+	if dnsInputFileName != "" {
+		err = adv.LoadDNSFile(dnsInputFileName)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, k := range fqdnPolicyKinds {
+		kind, err := advisor.ParseFQDNPolicyKind(k)
+		if err != nil {
+			return commonutils.WrapInErrInvalidArg("--fqdn-policies", err)
+		}
+		adv.FQDNPolicyKinds = append(adv.FQDNPolicyKinds, kind)
+	}
+
 	adv.GeneratePolicies()
 
 	w, closure, err := newWriter(outputFileName)
@@ -144,3 +185,74 @@ func runNetworkPolicyReport(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func loadClusterPolicies(adv *advisor.NetworkPolicyAdvisor) error {
+	client, err := k8sutil.NewClientsetFromConfigFlags(utils.KubernetesConfigFlags)
+	if err != nil {
+		return commonutils.WrapInErrSetupK8sClient(err)
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing namespaces: %w", err)
+	}
+	adv.NamespaceLabels = map[string]map[string]string{}
+	for _, ns := range namespaces.Items {
+		adv.NamespaceLabels[ns.Name] = ns.Labels
+	}
+
+	for _, ns := range adv.Namespaces() {
+		policies, err := client.NetworkingV1().NetworkPolicies(ns).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("listing network policies in namespace %q: %w", ns, err)
+		}
+		adv.ExistingPolicies = append(adv.ExistingPolicies, policies.Items...)
+	}
+
+	return nil
+}
+
+func runNetworkPolicyVerify(cmd *cobra.Command, args []string) error {
+	if inputFileName == "" {
+		return commonutils.WrapInErrMissingArgs("--input")
+	}
+
+	adv := advisor.NewAdvisor()
+	err := adv.LoadFile(inputFileName)
+	if err != nil {
+		return err
+	}
+
+	if len(policyFileNames) > 0 {
+		for _, f := range policyFileNames {
+			err = adv.LoadPoliciesFile(f)
+			if err != nil {
+				return fmt.Errorf("failed to load policies from %q: %w", f, err)
+			}
+		}
+	} else {
+		err = loadClusterPolicies(adv)
+		if err != nil {
+			return err
+		}
+	}
+
+	report := adv.VerifyPolicies()
+
+	w, closure, err := newWriter(outputFileName)
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", outputFileName, err)
+	}
+	defer closure()
+
+	_, err = w.Write([]byte(report.Format()))
+	if err != nil {
+		return fmt.Errorf("failed to write file %q: %w", outputFileName, err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush file %q: %w", outputFileName, err)
+	}
+
+	return nil
+}
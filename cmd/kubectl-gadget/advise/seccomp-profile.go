@@ -16,10 +16,13 @@ package advise
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/spf13/cobra"
 	seccompprofile "sigs.k8s.io/security-profiles-operator/api/seccompprofile/v1beta1"
 
@@ -27,6 +30,7 @@ import (
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/kubectl-gadget/utils"
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	ociseccomp "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/profile"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -56,6 +60,11 @@ var seccompAdvisorListCmd = &cobra.Command{
 var (
 	outputMode    string
 	profilePrefix string
+	profileDir    string
+	recordDetails bool
+
+	merge       bool
+	baseProfile string
 )
 
 func newSeccompProfileCmd() *cobra.Command {
@@ -66,12 +75,24 @@ func newSeccompProfileCmd() *cobra.Command {
 	seccompAdvisorStartCmd.PersistentFlags().StringVarP(&outputMode,
 		"output-mode", "m",
 		"terminal",
-		"The trace output mode, possibles values are terminal and seccomp-profile.")
+		"The trace output mode, possibles values are terminal, seccomp-profile and file.")
 	seccompAdvisorStartCmd.PersistentFlags().StringVar(&profilePrefix,
 		"profile-prefix", "",
 		"Name prefix of the seccomp profile to be created when using --output-mode=seccomp-profile.\nNamespace can be specified by using namespace/profile-prefix.")
+	seccompAdvisorStartCmd.PersistentFlags().StringVar(&profileDir,
+		"profile-dir", "",
+		"Directory of the nodes, relative to /var/lib/kubelet/seccomp, where the OCI seccomp profiles are written when using --output-mode=file.")
+	seccompAdvisorStartCmd.PersistentFlags().BoolVar(&recordDetails,
+		"record-details", false,
+		"Restrict the arguments of high-risk syscalls to the recorded values and report how often each syscall was called.")
 
 	seccompProfileCmd.AddCommand(seccompAdvisorStopCmd)
+	seccompAdvisorStopCmd.PersistentFlags().BoolVar(&merge,
+		"merge", false,
+		"Merge the profiles of all the containers matched by the trace, e.g. the replicas of a deployment, instead of generating the one of the pod. Only with --output-mode=terminal.")
+	seccompAdvisorStopCmd.PersistentFlags().StringVar(&baseProfile,
+		"base-profile", "",
+		"OCI seccomp profile to list the syscalls not allowed by, compared to the merged profile. Only with --merge.")
 	seccompProfileCmd.AddCommand(seccompAdvisorListCmd)
 
 	return seccompProfileCmd
@@ -83,34 +104,51 @@ func outputModeToTraceOutputMode(outputMode string) (gadgetv1alpha1.TraceOutputM
 		return gadgetv1alpha1.TraceOutputModeStatus, nil
 	case "seccomp-profile":
 		return gadgetv1alpha1.TraceOutputModeExternalResource, nil
+	case "file":
+		return gadgetv1alpha1.TraceOutputModeFile, nil
 	default:
-		return "", fmt.Errorf("%q is not an accepted value for --output-mode, possible values are: terminal (default) and seccomp-profile", outputMode)
+		return "", fmt.Errorf("%q is not an accepted value for --output-mode, possible values are: terminal (default), seccomp-profile and file", outputMode)
 	}
 }
 
 // runSeccompAdvisorStart starts monitoring of syscalls for the given
 // parameters.
 func runSeccompAdvisorStart(cmd *cobra.Command, args []string) error {
-	if params.Podname == "" {
-		return commonutils.WrapInErrMissingArgs("--podname")
-	}
-
 	traceOutputMode, err := outputModeToTraceOutputMode(outputMode)
 	if err != nil {
 		return err
 	}
 
+	// Without a pod, only the merge operation can be used at stop, which
+	// only supports the terminal output mode
+	if params.Podname == "" && traceOutputMode != gadgetv1alpha1.TraceOutputModeStatus {
+		return commonutils.WrapInErrMissingArgs("--podname")
+	}
+
 	if traceOutputMode != gadgetv1alpha1.TraceOutputModeExternalResource && profilePrefix != "" {
-		return errors.New("you can only use --profile-prefix with --output seccomp-profile")
+		return errors.New("you can only use --profile-prefix with --output-mode seccomp-profile")
+	}
+
+	traceOutput := profilePrefix
+	if traceOutputMode == gadgetv1alpha1.TraceOutputModeFile {
+		if profileDir == "" {
+			return commonutils.WrapInErrMissingArgs("--profile-dir")
+		}
+		traceOutput = profileDir
+	} else if profileDir != "" {
+		return errors.New("you can only use --profile-dir with --output-mode file")
 	}
 
 	config := &utils.TraceConfig{
 		GadgetName:        "seccomp",
 		Operation:         gadgetv1alpha1.OperationStart,
 		TraceOutputMode:   traceOutputMode,
-		TraceOutput:       profilePrefix,
+		TraceOutput:       traceOutput,
 		TraceInitialState: gadgetv1alpha1.TraceStateStarted,
 		CommonFlags:       &params,
+		Parameters: map[string]string{
+			"record-details": strconv.FormatBool(recordDetails),
+		},
 	}
 
 	traceID, err := utils.CreateTrace(config)
@@ -177,7 +215,24 @@ func runSeccompAdvisorStop(cmd *cobra.Command, args []string) error {
 
 	traceID := args[0]
 
+	if baseProfile != "" && !merge {
+		return errors.New("you can only use --base-profile with --merge")
+	}
+
+	var base *specs.LinuxSeccomp
+	if baseProfile != "" {
+		var err error
+		base, err = commonadvise.ReadSeccompProfile(baseProfile)
+		if err != nil {
+			return commonutils.WrapInErrInvalidArg("--base-profile", err)
+		}
+	}
+
 	callback := func(traceOutputMode string, results []string) error {
+		if merge {
+			return printMergedProfile(traceOutputMode, results, base)
+		}
+
 		for _, r := range results {
 			if traceOutputMode == string(gadgetv1alpha1.TraceOutputModeExternalResource) {
 				profilesName, err := getSeccompProfilesName(traceID)
@@ -195,9 +250,14 @@ func runSeccompAdvisorStop(cmd *cobra.Command, args []string) error {
 				return nil
 			}
 
-			if r != "" {
-				fmt.Printf("%v\n", r)
+			if r == "" {
+				continue
 			}
+			if traceOutputMode == string(gadgetv1alpha1.TraceOutputModeFile) {
+				fmt.Printf("Successfully wrote seccomp profile on the node: %s\n", r)
+				continue
+			}
+			fmt.Printf("%v\n", r)
 		}
 
 		return nil
@@ -208,7 +268,12 @@ func runSeccompAdvisorStop(cmd *cobra.Command, args []string) error {
 	// leaking a resource.
 	defer utils.DeleteTrace(traceID)
 
-	err := utils.SetTraceOperation(traceID, string(gadgetv1alpha1.OperationGenerate))
+	operation := gadgetv1alpha1.OperationGenerate
+	if merge {
+		operation = gadgetv1alpha1.OperationMerge
+	}
+
+	err := utils.SetTraceOperation(traceID, string(operation))
 	if err != nil {
 		return commonutils.WrapInErrGenGadgetOutput(err)
 	}
@@ -228,6 +293,46 @@ func runSeccompAdvisorStop(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printMergedProfile merges the profiles merged on each node and prints them
+// as a single one, followed by the syscalls base doesn't allow, if given.
+func printMergedProfile(traceOutputMode string, results []string, base *specs.LinuxSeccomp) error {
+	if traceOutputMode != string(gadgetv1alpha1.TraceOutputModeStatus) {
+		return errors.New("--merge can only be used with --output-mode terminal")
+	}
+
+	profiles := []*specs.LinuxSeccomp{}
+	for _, r := range results {
+		// Nodes without any matching container don't output anything
+		if r == "" {
+			continue
+		}
+		profile, err := ociseccomp.Read(strings.NewReader(r))
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+	}
+	if len(profiles) == 0 {
+		return errors.New("no container to merge the seccomp profiles of")
+	}
+
+	merged, err := ociseccomp.Merge(profiles...)
+	if err != nil {
+		return err
+	}
+	output, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return commonutils.WrapInErrMarshalOutput(err)
+	}
+	fmt.Println(string(output))
+
+	if base != nil {
+		fmt.Printf("\nSyscalls not allowed by %s:\n%s", baseProfile, ociseccomp.FormatRules(ociseccomp.Diff(base, merged)))
+	}
+
+	return nil
+}
+
 // runSeccompAdvisorList lists already running traces which config was given as
 // parameter.
 func runSeccompAdvisorList(cmd *cobra.Command, args []string) error {
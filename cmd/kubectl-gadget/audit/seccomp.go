@@ -41,8 +41,8 @@ func newSeccompCmd() *cobra.Command {
 			return commonutils.WrapInErrParserCreate(err)
 		}
 
-		if commonFlags.OutputMode != commonutils.OutputModeJSON {
-			fmt.Println(parser.BuildColumnsHeader())
+		if header := parser.BuildHeader(); header != "" {
+			fmt.Println(header)
 		}
 
 		config := &utils.TraceConfig{
@@ -67,22 +67,7 @@ func newSeccompCmd() *cobra.Command {
 				return ""
 			}
 
-			switch commonFlags.OutputMode {
-			case commonutils.OutputModeJSON:
-				b, err := json.Marshal(e)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error: %s", fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-					return ""
-				}
-
-				return string(b)
-			case commonutils.OutputModeColumns:
-				fallthrough
-			case commonutils.OutputModeCustomColumns:
-				return parser.TransformIntoColumns(&e)
-			}
-
-			return ""
+			return parser.TransformEntry(&e)
 		}
 
 		err = utils.RunTraceAndPrintStream(config, transformEvent)
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/kubectl-gadget/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/k8sutil"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/resources"
 	"github.com/spf13/cobra"
 
@@ -79,6 +81,16 @@ var (
 	wait                bool
 	runtimesConfig      commonutils.RuntimesSocketPathConfig
 	nodeSelector        string
+	metricsConfig       string
+	metricsPort         int32
+)
+
+const (
+	// metricsConfigMapName is the name of the ConfigMap containing the
+	// configuration of the metrics exporter, it's mounted in the gadget pods.
+	metricsConfigMapName = "gadget-metrics"
+	metricsConfigKey     = "config.yaml"
+	metricsConfigDir     = "/etc/inspektor-gadget/metrics"
 )
 
 var supportedHooks = []string{"auto", "crio", "podinformer", "nri", "fanotify"}
@@ -142,6 +154,16 @@ func init() {
 		"node-selector", "",
 		"",
 		"node labels selector for the Inspektor Gadget DaemonSet")
+	deployCmd.PersistentFlags().StringVarP(
+		&metricsConfig,
+		"metrics-config", "",
+		"",
+		"path to a YAML file describing the gadgets to run and the Prometheus metrics to expose from their events")
+	deployCmd.PersistentFlags().Int32VarP(
+		&metricsPort,
+		"metrics-port", "",
+		2223,
+		"port to expose the Prometheus metrics on (only with --metrics-config)")
 	rootCmd.AddCommand(deployCmd)
 }
 
@@ -319,6 +341,74 @@ func createAffinity(client *kubernetes.Clientset) (*v1.Affinity, error) {
 	return affinity, nil
 }
 
+// metricsConfigMap returns the ConfigMap containing the configuration of the
+// metrics exporter read from the given file, after checking that it's valid.
+func metricsConfigMap(path string) (*v1.ConfigMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := metrics.ParseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	// Check the gadgets and the columns used by the metrics
+	if _, err := metrics.New(config); err != nil {
+		return nil, err
+	}
+
+	return &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metricsConfigMapName,
+			Namespace: utils.GadgetNamespace,
+			Labels: map[string]string{
+				"k8s-app": "gadget",
+			},
+		},
+		Data: map[string]string{
+			metricsConfigKey: string(data),
+		},
+	}, nil
+}
+
+// addMetricsExporter mounts the configuration of the metrics exporter in the
+// gadget pods and exposes the port of the metrics endpoint.
+func addMetricsExporter(daemonSet *appsv1.DaemonSet) {
+	podSpec := &daemonSet.Spec.Template.Spec
+	gadgetContainer := &podSpec.Containers[0]
+
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: "metrics-config",
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: metricsConfigMapName,
+				},
+			},
+		},
+	})
+	gadgetContainer.VolumeMounts = append(gadgetContainer.VolumeMounts, v1.VolumeMount{
+		Name:      "metrics-config",
+		MountPath: metricsConfigDir,
+		ReadOnly:  true,
+	})
+
+	gadgetContainer.Ports = append(gadgetContainer.Ports, v1.ContainerPort{
+		Name:          "metrics",
+		ContainerPort: metricsPort,
+		Protocol:      v1.ProtocolTCP,
+	})
+
+	daemonSet.Spec.Template.Annotations["prometheus.io/scrape"] = "true"
+	daemonSet.Spec.Template.Annotations["prometheus.io/port"] = strconv.Itoa(int(metricsPort))
+	daemonSet.Spec.Template.Annotations["prometheus.io/path"] = "/metrics"
+}
+
 func runDeploy(cmd *cobra.Command, args []string) error {
 	found := false
 	for _, supportedHook := range supportedHooks {
@@ -348,6 +438,21 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	objects = append(objects, traceObjects...)
 
+	if metricsConfig != "" {
+		configMap, err := metricsConfigMap(metricsConfig)
+		if err != nil {
+			return commonutils.WrapInErrInvalidArg("--metrics-config", err)
+		}
+
+		// The ConfigMap has to be created before the DaemonSet mounting it
+		for i, object := range objects {
+			if _, ok := object.(*appsv1.DaemonSet); ok {
+				objects = append(objects[:i], append([]runtime.Object{configMap}, objects[i:]...)...)
+				break
+			}
+		}
+	}
+
 	config, err := utils.KubernetesConfigFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to create RESTConfig: %w", err)
@@ -421,9 +526,19 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 					gadgetContainer.Env[i].Value = runtimesConfig.Crio
 				case utils.GadgetEnvironmentDockerSocketpath:
 					gadgetContainer.Env[i].Value = runtimesConfig.Docker
+				case "INSPEKTOR_GADGET_OPTION_METRICS_CONFIG":
+					if metricsConfig != "" {
+						gadgetContainer.Env[i].Value = metricsConfigDir + "/" + metricsConfigKey
+					}
+				case "INSPEKTOR_GADGET_OPTION_METRICS_PORT":
+					gadgetContainer.Env[i].Value = strconv.Itoa(int(metricsPort))
 				}
 			}
 
+			if metricsConfig != "" {
+				addMetricsExporter(daemonSet)
+			}
+
 			if nodeSelector != "" {
 				affinity, err := createAffinity(k8sClient)
 				if err != nil {
@@ -62,6 +62,7 @@ func newCPUCmd() *cobra.Command {
 
 	cmd := commonprofile.NewCPUCmd(runCmd, &cpuFlags)
 
+	commonFlags.AdditionalOutputModes = commonprofile.CPUOutputModes
 	utils.AddCommonFlags(cmd, &commonFlags)
 
 	return cmd
@@ -65,7 +65,7 @@ func (g *ProfileGadget) Run() error {
 		}()
 	}
 
-	if g.commonFlags.OutputMode != commonutils.OutputModeJSON {
+	if g.commonFlags.IsColumnsOutput() {
 		if g.commonFlags.Timeout != 0 {
 			fmt.Printf(g.inProgressMsg + "...")
 		} else {
@@ -75,7 +75,7 @@ func (g *ProfileGadget) Run() error {
 
 	<-c
 
-	if g.commonFlags.OutputMode != commonutils.OutputModeJSON {
+	if g.commonFlags.IsColumnsOutput() {
 		// Trick to have ^C on the same line than above message, so the gadget
 		// output begins on a "clean" line.
 		fmt.Println()
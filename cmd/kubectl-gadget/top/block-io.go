@@ -52,6 +52,7 @@ func newBlockIOCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
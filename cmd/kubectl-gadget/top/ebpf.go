@@ -67,7 +67,11 @@ func newEbpfCmd() *cobra.Command {
 			return commonutils.WrapInErrInvalidArg("--containername / -c",
 				fmt.Errorf("this gadget cannot filter by container name"))
 		}
-		if len(commonFlags.Labels) > 0 {
+		if commonFlags.Image != "" {
+			return commonutils.WrapInErrInvalidArg("--image",
+				fmt.Errorf("this gadget cannot filter by image"))
+		}
+		if commonFlags.LabelsRaw != "" {
 			return commonutils.WrapInErrInvalidArg("--selector / -l",
 				fmt.Errorf("this gadget cannot filter by selector"))
 		}
@@ -78,6 +82,7 @@ func newEbpfCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
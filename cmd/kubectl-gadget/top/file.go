@@ -58,6 +58,7 @@ func newFileCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags.CommonTopFlags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
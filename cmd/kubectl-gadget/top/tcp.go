@@ -64,6 +64,7 @@ func newTCPCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags.CommonTopFlags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
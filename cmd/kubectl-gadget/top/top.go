@@ -65,10 +65,14 @@ func (g *TopGadget[Stats]) Run(args []string) error {
 	}
 	g.CommonTopFlags.ParsedSortBy = sortByColumns
 
+	if err := commonutils.ParseGroupFlags(&g.CommonTopFlags.GroupFlags, g.ColMap); err != nil {
+		return err
+	}
+
 	if g.params == nil {
 		g.params = make(map[string]string)
 	}
-	g.params[top.MaxRowsParam] = strconv.Itoa(g.CommonTopFlags.MaxRows)
+	g.params[top.MaxRowsParam] = strconv.Itoa(g.CommonTopFlags.TracerMaxRows())
 	g.params[top.IntervalParam] = strconv.Itoa(g.CommonTopFlags.OutputInterval)
 	g.params[top.SortByParam] = g.CommonTopFlags.SortBy
 
@@ -58,6 +58,8 @@ func newBindCmd() *cobra.Command {
 	cmd := commontrace.NewBindCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
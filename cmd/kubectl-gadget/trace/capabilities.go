@@ -51,6 +51,8 @@ func newCapabilitiesCmd() *cobra.Command {
 	cmd := commontrace.NewCapabilitiesCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
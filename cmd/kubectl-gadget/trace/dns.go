@@ -25,6 +25,7 @@ import (
 
 func newDNSCmd() *cobra.Command {
 	var commonFlags utils.CommonFlags
+	var flags commontrace.DNSFlags
 
 	runCmd := func(cmd *cobra.Command, args []string) error {
 		parser, err := commonutils.NewGadgetParserWithK8sInfo(&commonFlags.OutputConfig, dnsTypes.GetColumns())
@@ -36,14 +37,19 @@ func newDNSCmd() *cobra.Command {
 			name:        "dns",
 			commonFlags: &commonFlags,
 			parser:      parser,
+			params: map[string]string{
+				"dnstimeout": flags.DNSTimeout.String(),
+			},
 		}
 
 		return dnsGadget.Run()
 	}
 
-	cmd := commontrace.NewDNSCmd(runCmd)
+	cmd := commontrace.NewDNSCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
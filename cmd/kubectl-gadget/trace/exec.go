@@ -44,6 +44,8 @@ func newExecCmd() *cobra.Command {
 	cmd := commontrace.NewExecCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -50,6 +50,8 @@ func newFsSlowerCmd() *cobra.Command {
 
 	cmd := commontrace.NewFsSlowerCmd(runCmd, &flags)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
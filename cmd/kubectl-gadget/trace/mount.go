@@ -43,6 +43,8 @@ func newMountCmd() *cobra.Command {
 
 	cmd := commontrace.NewMountCmd(runCmd)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
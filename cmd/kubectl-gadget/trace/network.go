@@ -43,6 +43,8 @@ func newNetworkCmd() *cobra.Command {
 
 	cmd := commontrace.NewNetworkCmd(runCmd)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -43,6 +43,8 @@ func newOOMKillCmd() *cobra.Command {
 
 	cmd := commontrace.NewOOMKillCmd(runCmd)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
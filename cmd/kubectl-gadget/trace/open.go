@@ -43,6 +43,8 @@ func newOpenCmd() *cobra.Command {
 
 	cmd := commontrace.NewOpenCmd(runCmd)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
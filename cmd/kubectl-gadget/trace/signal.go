@@ -53,6 +53,8 @@ func newSignalCmd() *cobra.Command {
 	cmd := commontrace.NewSignalCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
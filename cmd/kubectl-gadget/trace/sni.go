@@ -42,6 +42,8 @@ func newSNICmd() *cobra.Command {
 
 	cmd := commontrace.NewSNICmd(runCmd)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
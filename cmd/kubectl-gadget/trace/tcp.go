@@ -44,6 +44,8 @@ func newTCPCmd() *cobra.Command {
 	cmd := commontrace.NewTCPCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -47,6 +47,8 @@ func newTcpconnectCmd() *cobra.Command {
 	cmd := commontrace.NewTcpconnectCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
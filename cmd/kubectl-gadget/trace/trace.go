@@ -23,11 +23,21 @@ import (
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/kubectl-gadget/utils"
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 
 	"github.com/spf13/cobra"
 )
 
+const (
+	streamEncodingBinary = "binary"
+	streamEncodingJSON   = "json"
+)
+
+// streamEncoding is the encoding used to receive the events from the nodes.
+var streamEncoding string
+
 // TraceGadget represents a gadget belonging to the trace category.
 type TraceGadget[Event commontrace.TraceEvent] struct {
 	name        string
@@ -48,8 +58,31 @@ func (g *TraceGadget[Event]) Run() error {
 		Parameters:       g.params,
 	}
 
-	if g.commonFlags.OutputMode != commonutils.OutputModeJSON {
-		fmt.Println(g.parser.BuildColumnsHeader())
+	summarizer := g.parser.NewSummarizer()
+
+	if summarizer == nil {
+		if header := g.parser.BuildHeader(); header != "" {
+			fmt.Println(header)
+		}
+	}
+
+	handleEvent := func(e *Event) string {
+		baseEvent := (*e).GetBaseEvent()
+		if baseEvent.Type != eventtypes.NORMAL {
+			commonutils.HandleSpecialEvent(baseEvent, g.commonFlags.Verbose)
+			return ""
+		}
+
+		if !g.parser.Match(e) {
+			return ""
+		}
+
+		if summarizer != nil {
+			summarizer.Add(e)
+			return ""
+		}
+
+		return g.parser.TransformEntry(e)
 	}
 
 	transformEvent := func(line string) string {
@@ -60,31 +93,39 @@ func (g *TraceGadget[Event]) Run() error {
 			return ""
 		}
 
-		baseEvent := e.GetBaseEvent()
-		if baseEvent.Type != eventtypes.NORMAL {
-			commonutils.HandleSpecialEvent(baseEvent, g.commonFlags.Verbose)
-			return ""
+		return handleEvent(&e)
+	}
+
+	transformData := func(data *pb.StreamData) string {
+		if data.Event == nil {
+			return transformEvent(data.Line)
 		}
 
-		switch g.commonFlags.OutputMode {
-		case commonutils.OutputModeJSON:
-			b, err := json.Marshal(e)
-			if err != nil {
-				fmt.Fprint(os.Stderr, fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-				return ""
-			}
-
-			return string(b)
-		case commonutils.OutputModeColumns:
-			fallthrough
-		case commonutils.OutputModeCustomColumns:
-			return g.parser.TransformIntoColumns(&e)
+		var e Event
+		if err := stream.DecodeEvent(data.Event, &e); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: decoding event: %s\n", err)
+			return ""
 		}
 
-		return ""
+		return handleEvent(&e)
 	}
 
-	if err := utils.RunTraceAndPrintStream(config, transformEvent); err != nil {
+	if summarizer != nil {
+		summarizer.Start()
+		utils.AddTerminationHook(summarizer.Stop)
+		defer summarizer.Stop()
+	}
+
+	var err error
+	switch streamEncoding {
+	case streamEncodingBinary:
+		err = utils.RunTraceAndPrintEvents(config, transformData)
+	case streamEncodingJSON:
+		err = utils.RunTraceAndPrintStream(config, transformEvent)
+	default:
+		err = fmt.Errorf("invalid stream encoding %q", streamEncoding)
+	}
+	if err != nil {
 		return commonutils.WrapInErrRunGadget(err)
 	}
 
@@ -94,6 +135,14 @@ func (g *TraceGadget[Event]) Run() error {
 func NewTraceCmd() *cobra.Command {
 	traceCmd := commontrace.NewCommonTraceCmd()
 
+	traceCmd.PersistentFlags().StringVar(
+		&streamEncoding,
+		"stream-encoding",
+		streamEncodingJSON,
+		fmt.Sprintf("Encoding used to receive the events from the nodes [%s, %s]. %q needs nodes running this version of Inspektor Gadget or a newer one",
+			streamEncodingJSON, streamEncodingBinary, streamEncodingBinary),
+	)
+
 	traceCmd.AddCommand(newBindCmd())
 	traceCmd.AddCommand(newCapabilitiesCmd())
 	traceCmd.AddCommand(newDNSCmd())
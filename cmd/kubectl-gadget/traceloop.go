@@ -102,6 +102,10 @@ func runTraceloopStart(cmd *cobra.Command, args []string) error {
 		return commonutils.WrapInErrInvalidArg("--containername / -c", fmt.Errorf("this gadget cannot filter by container name"))
 	}
 
+	if params.Image != "" {
+		return commonutils.WrapInErrInvalidArg("--image", fmt.Errorf("this gadget cannot filter by image"))
+	}
+
 	// At the moment, there could be only one instance of traceloop running at a
 	// given time, so it should cover all existing namespaces.
 	// TODO Make traceloop accept -n option, this would need to care when
@@ -174,8 +178,8 @@ func runTraceloopList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if params.OutputMode != commonutils.OutputModeJSON {
-		fmt.Println(parser.BuildColumnsHeader())
+	if header := parser.BuildHeader(); header != "" {
+		fmt.Println(header)
 	}
 
 	for _, trace := range traces {
@@ -189,18 +193,8 @@ func runTraceloopList(cmd *cobra.Command, args []string) error {
 		for _, info := range infos {
 			info.Node = trace.Spec.Node
 
-			switch params.OutputMode {
-			case commonutils.OutputModeJSON:
-				b, err := json.Marshal(info)
-				if err != nil {
-					return commonutils.WrapInErrMarshalOutput(err)
-				}
-
-				fmt.Println(string(b))
-			case commonutils.OutputModeColumns:
-				fallthrough
-			case commonutils.OutputModeCustomColumns:
-				fmt.Println(parser.TransformIntoColumns(&info))
+			if line := parser.TransformEntry(&info); line != "" {
+				fmt.Println(line)
 			}
 		}
 	}
@@ -227,8 +221,8 @@ func runTraceloopShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if params.OutputMode != commonutils.OutputModeJSON {
-		fmt.Println(parser.BuildColumnsHeader())
+	if header := parser.BuildHeader(); header != "" {
+		fmt.Println(header)
 	}
 
 	var traceID string
@@ -247,19 +241,8 @@ func runTraceloopShow(cmd *cobra.Command, args []string) error {
 				return ""
 			}
 
-			switch params.OutputMode {
-			case commonutils.OutputModeJSON:
-				b, err := json.Marshal(event)
-				if err != nil {
-					fmt.Fprint(os.Stderr, fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-					return ""
-				}
-
-				fmt.Println(string(b))
-			case commonutils.OutputModeColumns:
-				fallthrough
-			case commonutils.OutputModeCustomColumns:
-				fmt.Println(parser.TransformIntoColumns(&event))
+			if line := parser.TransformEntry(&event); line != "" {
+				fmt.Println(line)
 			}
 		}
 
@@ -330,7 +313,7 @@ func runTraceloopShow(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("error creating trace: %w", err)
 		}
 
-		utils.SigHandler(&traceID, params.OutputMode != commonutils.OutputModeJSON)
+		utils.SigHandler(&traceID, params.IsColumnsOutput())
 
 		err = utils.PrintTraceOutputFromStream(traceID, string(gadgetv1alpha1.TraceStateCompleted), &params, transformEvent)
 		if err != nil {
@@ -46,6 +46,12 @@ func ExecPodCapture(client *kubernetes.Clientset, node string, podCmd string) (s
 }
 
 func ExecPod(client *kubernetes.Clientset, node string, podCmd string, cmdStdout io.Writer, cmdStderr io.Writer) error {
+	return execPod(client, node, podCmd, cmdStdout, cmdStderr, true)
+}
+
+// execPod runs podCmd in the gadget pod of the given node. A TTY must not be
+// allocated when the command writes binary data as it would alter it.
+func execPod(client *kubernetes.Clientset, node string, podCmd string, cmdStdout io.Writer, cmdStderr io.Writer, tty bool) error {
 	listOptions := metav1.ListOptions{
 		LabelSelector: "k8s-app=gadget",
 		FieldSelector: "spec.nodeName=" + node + ",status.phase=Running",
@@ -84,7 +90,7 @@ func ExecPod(client *kubernetes.Clientset, node string, podCmd string, cmdStdout
 			Stdin:     false,
 			Stdout:    true,
 			Stderr:    true,
-			TTY:       true,
+			TTY:       tty,
 		}, scheme.ParameterCodec)
 
 	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
@@ -96,7 +102,7 @@ func ExecPod(client *kubernetes.Clientset, node string, podCmd string, cmdStdout
 		Stdin:  nil,
 		Stdout: cmdStdout,
 		Stderr: cmdStderr,
-		Tty:    true,
+		Tty:    tty,
 	})
 	return err
 }
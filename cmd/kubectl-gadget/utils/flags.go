@@ -20,10 +20,13 @@ import (
 	"strings"
 
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection/namefilter"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/k8sutil"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -45,13 +48,18 @@ type CommonFlags struct {
 	commonutils.OutputConfig
 
 	// LabelsRaw allows to filter containers with a label selector in the
-	// following format: key1=value1,key2=value2.
+	// format used by kubectl, e.g. key1=value1,key2 in (v1,v2),!key3.
 	// It's the raw representation as passed by the user.
 	LabelsRaw string
 
-	// Labels is a parsed representation of LabelsRaw
+	// Labels is the parsed representation of the equality requirements of
+	// LabelsRaw
 	Labels map[string]string
 
+	// LabelExpressions is the parsed representation of the other
+	// requirements of LabelsRaw
+	LabelExpressions []metav1.LabelSelectorRequirement
+
 	// Node allows to filter containers by node name
 	Node string
 
@@ -73,6 +81,16 @@ type CommonFlags struct {
 	// Containername allows to filter containers by name
 	Containername string
 
+	// Image allows to filter containers by image
+	Image string
+
+	// Enrichers are the names of the enrichers adding data to the events
+	Enrichers []string
+
+	// FilterByCgroup selects the events of the containers by their cgroup
+	// instead of their mount namespace
+	FilterByCgroup bool
+
 	// Number of seconds that the gadget will run for
 	Timeout int
 }
@@ -85,6 +103,49 @@ func GetNamespace() (string, bool) {
 	return namespace, overridden
 }
 
+// parseLabelSelector parses a label selector as kubectl does. Equality
+// requirements are returned as labels, the other ones as label selector
+// requirements.
+func parseLabelSelector(raw string) (map[string]string, []metav1.LabelSelectorRequirement, error) {
+	selector, err := labels.Parse(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requirements, _ := selector.Requirements()
+
+	matchLabels := make(map[string]string)
+	var expressions []metav1.LabelSelectorRequirement
+
+	for _, r := range requirements {
+		var op metav1.LabelSelectorOperator
+
+		switch r.Operator() {
+		case selection.Equals, selection.DoubleEquals:
+			matchLabels[r.Key()] = r.Values().List()[0]
+			continue
+		case selection.In:
+			op = metav1.LabelSelectorOpIn
+		case selection.NotEquals, selection.NotIn:
+			op = metav1.LabelSelectorOpNotIn
+		case selection.Exists:
+			op = metav1.LabelSelectorOpExists
+		case selection.DoesNotExist:
+			op = metav1.LabelSelectorOpDoesNotExist
+		default:
+			return nil, nil, fmt.Errorf("operator %q is not supported", r.Operator())
+		}
+
+		expressions = append(expressions, metav1.LabelSelectorRequirement{
+			Key:      r.Key(),
+			Operator: op,
+			Values:   r.Values().List(),
+		})
+	}
+
+	return matchLabels, expressions, nil
+}
+
 func AddCommonFlags(command *cobra.Command, params *CommonFlags) {
 	command.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// Namespace
@@ -94,15 +155,22 @@ func AddCommonFlags(command *cobra.Command, params *CommonFlags) {
 
 		// Labels
 		if params.LabelsRaw != "" {
-			params.Labels = make(map[string]string)
-			pairs := strings.Split(params.LabelsRaw, ",")
-			for _, pair := range pairs {
-				kv := strings.Split(pair, "=")
-				if len(kv) != 2 {
-					return commonutils.WrapInErrInvalidArg("--selector / -l",
-						fmt.Errorf("should be a comma-separated list of key-value pairs (key=value[,key=value,...])"))
-				}
-				params.Labels[kv[0]] = kv[1]
+			var err error
+			params.Labels, params.LabelExpressions, err = parseLabelSelector(params.LabelsRaw)
+			if err != nil {
+				return commonutils.WrapInErrInvalidArg("--selector / -l", err)
+			}
+		}
+
+		// Name filters
+		for _, filter := range []struct{ flag, value string }{
+			{"--namespace / -n", params.Namespace},
+			{"--podname / -p", params.Podname},
+			{"--containername / -c", params.Containername},
+			{"--image", params.Image},
+		} {
+			if err := namefilter.Validate(filter.value); err != nil {
+				return commonutils.WrapInErrInvalidArg(filter.flag, err)
 			}
 		}
 
@@ -151,7 +219,7 @@ func AddCommonFlags(command *cobra.Command, params *CommonFlags) {
 		"selector",
 		"l",
 		"",
-		"Labels selector to filter on. Only '=' is supported (e.g. key1=value1,key2=value2).",
+		"Labels selector to filter on. Supports '=', '==', '!=', 'in', 'notin', 'key' and '!key' (e.g. key1=value1,key2 in (v1,v2),!key3).",
 	)
 
 	command.PersistentFlags().StringVar(
@@ -166,7 +234,7 @@ func AddCommonFlags(command *cobra.Command, params *CommonFlags) {
 		"podname",
 		"p",
 		"",
-		"Show only data from pods with that name",
+		"Show only data from pods with that name. Accepts a comma-separated list of names, globs (web-*) and regular expressions (/^web-[0-9]+$/), prefixed by '!' to exclude them",
 	)
 
 	command.PersistentFlags().StringVarP(
@@ -174,7 +242,28 @@ func AddCommonFlags(command *cobra.Command, params *CommonFlags) {
 		"containername",
 		"c",
 		"",
-		"Show only data from containers with that name",
+		"Show only data from containers with that name. Accepts the same patterns as --podname",
+	)
+
+	command.PersistentFlags().StringVar(
+		&params.Image,
+		"image",
+		"",
+		"Show only data from containers running that image, e.g. nginx, nginx:1.23, docker.io/library/nginx:1.23 or sha256:<digest>. Accepts the same patterns as --podname",
+	)
+
+	command.PersistentFlags().StringSliceVar(
+		&params.Enrichers,
+		"enrich",
+		[]string{},
+		"Comma-separated list of enrichers adding data to the events of the trace and audit gadgets: user, process, ancestors or endpoints. Use -o custom-columns=... or -o json to show the data",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&params.FilterByCgroup,
+		"filter-by-cgroup",
+		false,
+		"Select the events of the containers by their cgroup instead of their mount namespace, to include processes that unshare their mount namespace and containers sharing the one of the host. Requires cgroup v2",
 	)
 
 	command.PersistentFlags().BoolVarP(
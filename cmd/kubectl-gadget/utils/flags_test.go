@@ -0,0 +1,54 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	matchLabels, expressions, err := parseLabelSelector("app=web,tier in (frontend,backend),env!=dev,release,!canary")
+	if err != nil {
+		t.Fatalf("Failed to parse selector: %s", err)
+	}
+
+	if !reflect.DeepEqual(matchLabels, map[string]string{"app": "web"}) {
+		t.Fatalf("Unexpected labels %v", matchLabels)
+	}
+
+	expected := map[string]metav1.LabelSelectorRequirement{
+		"tier":    {Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"backend", "frontend"}},
+		"env":     {Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"dev"}},
+		"release": {Key: "release", Operator: metav1.LabelSelectorOpExists, Values: []string{}},
+		"canary":  {Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist, Values: []string{}},
+	}
+	if len(expressions) != len(expected) {
+		t.Fatalf("Unexpected expressions %v", expressions)
+	}
+	for _, e := range expressions {
+		if !reflect.DeepEqual(e, expected[e.Key]) {
+			t.Fatalf("Unexpected expression %v, expected %v", e, expected[e.Key])
+		}
+	}
+
+	for _, selector := range []string{"key>1", "key in (", "=value"} {
+		if _, _, err := parseLabelSelector(selector); err == nil {
+			t.Fatalf("Expected error parsing selector %q", selector)
+		}
+	}
+}
@@ -19,6 +19,9 @@ import (
 	"io"
 	"strings"
 	"sync/atomic"
+
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
 )
 
 type PostProcess struct {
@@ -32,8 +35,11 @@ type postProcessSingle struct {
 	Node             string
 	callback         func(line string, node string)
 	transform        func(line string) string
+	eventCallback    func(data *pb.StreamData, node string)
 	firstLinePrinted *uint64
 	buffer           string // buffer to save incomplete strings
+	frames           []byte // buffer to save incomplete binary messages
+	lastSeq          uint64 // sequence number of the last binary message
 	skipFirstLine    bool
 	verbose          bool
 }
@@ -49,6 +55,11 @@ type PostProcessConfig struct {
 	// It's only called if Callback is nil.
 	Transform func(line string) string
 
+	// Function to be called for each message of a stream using the binary
+	// encoding. When set, the output is parsed as binary messages instead
+	// of lines.
+	EventCallback func(data *pb.StreamData, node string)
+
 	// Streams to print the standard and error outputs.
 	OutStream io.Writer
 	ErrStream io.Writer
@@ -72,6 +83,7 @@ func NewPostProcess(config *PostProcessConfig) *PostProcess {
 			orig:             config.OutStream,
 			callback:         config.Callback,
 			transform:        config.Transform,
+			eventCallback:    config.EventCallback,
 			firstLinePrinted: &p.firstLinePrinted,
 			skipFirstLine:    config.SkipFirstLine,
 			verbose:          config.Verbose,
@@ -88,6 +100,10 @@ func NewPostProcess(config *PostProcessConfig) *PostProcess {
 }
 
 func (post *postProcessSingle) Write(p []byte) (n int, err error) {
+	if post.eventCallback != nil {
+		return post.writeFrames(p)
+	}
+
 	asStr := post.buffer + string(p)
 
 	lines := strings.Split(asStr, "\n")
@@ -123,3 +139,41 @@ func (post *postProcessSingle) Write(p []byte) (n int, err error) {
 
 	return len(p), err
 }
+
+func (post *postProcessSingle) writeFrames(p []byte) (int, error) {
+	post.frames = append(post.frames, p...)
+
+	consumed := 0
+	for consumed < len(post.frames) {
+		data, n, err := stream.ConsumeStreamData(post.frames[consumed:])
+		if err != nil {
+			return 0, fmt.Errorf("parsing stream from node %q: %w", post.Node, err)
+		}
+		if n == 0 {
+			// Wait for the rest of the message
+			break
+		}
+
+		if data.Seq != 0 {
+			post.lastSeq = data.Seq
+		}
+		post.eventCallback(data, post.Node)
+		consumed += n
+	}
+
+	// Keep the incomplete message at the beginning of the buffer
+	post.frames = append(post.frames[:0], post.frames[consumed:]...)
+
+	return len(p), nil
+}
+
+// resumeFlags returns the flags of receive-stream to resume the stream after
+// the last binary message received, dropping the incomplete one.
+func (post *postProcessSingle) resumeFlags() string {
+	post.frames = post.frames[:0]
+
+	if post.lastSeq == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" -resume-after %d", post.lastSeq)
+}
@@ -24,14 +24,17 @@ import (
 	"os/signal"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"text/tabwriter"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -45,6 +48,7 @@ import (
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
 	clientset "github.com/inspektor-gadget/inspektor-gadget/pkg/client/clientset/versioned"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/k8sutil"
 )
 
@@ -344,12 +348,15 @@ func CreateTrace(config *TraceConfig) (string, error) {
 
 	// Keep Filter field empty if it is not really used
 	if config.CommonFlags.Namespace != "" || config.CommonFlags.Podname != "" ||
-		config.CommonFlags.Containername != "" || len(config.CommonFlags.Labels) > 0 {
+		config.CommonFlags.Containername != "" || config.CommonFlags.Image != "" ||
+		len(config.CommonFlags.Labels) > 0 || len(config.CommonFlags.LabelExpressions) > 0 {
 		filter = &gadgetv1alpha1.ContainerFilter{
-			Namespace:     config.CommonFlags.Namespace,
-			Podname:       config.CommonFlags.Podname,
-			ContainerName: config.CommonFlags.Containername,
-			Labels:        config.CommonFlags.Labels,
+			Namespace:        config.CommonFlags.Namespace,
+			Podname:          config.CommonFlags.Podname,
+			ContainerName:    config.CommonFlags.Containername,
+			Labels:           config.CommonFlags.Labels,
+			LabelExpressions: config.CommonFlags.LabelExpressions,
+			Image:            config.CommonFlags.Image,
 		}
 	}
 
@@ -364,13 +371,11 @@ func CreateTrace(config *TraceConfig) (string, error) {
 				GlobalTraceID: traceID,
 				// Add all this information here to be able to find the trace thanks
 				// to them when calling getTraceListFromParameters().
-				"gadgetName": config.GadgetName,
-				"nodeName":   config.CommonFlags.Node,
-				// Kubernetes labels cannot contain ',' but can contain '_'
-				// Kubernetes names cannot contain either, so no need for more complicated escaping
-				"namespace":     strings.Replace(config.CommonFlags.Namespace, ",", "_", -1),
-				"podName":       config.CommonFlags.Podname,
-				"containerName": config.CommonFlags.Containername,
+				"gadgetName":    config.GadgetName,
+				"nodeName":      config.CommonFlags.Node,
+				"namespace":     traceLabelValue(config.CommonFlags.Namespace),
+				"podName":       traceLabelValue(config.CommonFlags.Podname),
+				"containerName": traceLabelValue(config.CommonFlags.Containername),
 				"outputMode":    string(config.TraceOutputMode),
 				// We will not add config.TraceOutput as label because it can contain
 				// "/" which is forbidden in labels.
@@ -383,10 +388,15 @@ func CreateTrace(config *TraceConfig) (string, error) {
 			RunMode:    gadgetv1alpha1.RunModeManual,
 			OutputMode: config.TraceOutputMode,
 			Output:     config.TraceOutput,
+			Enrichers:  config.CommonFlags.Enrichers,
 			Parameters: config.Parameters,
 		},
 	}
 
+	if config.CommonFlags.FilterByCgroup {
+		trace.Spec.FilterMode = gadgetv1alpha1.FilterModeCgroupID
+	}
+
 	for key, value := range config.AdditionalLabels {
 		v, ok := trace.ObjectMeta.Labels[key]
 		if ok {
@@ -708,6 +718,31 @@ func waitForNoOperation(traceID string) (*gadgetv1alpha1.TraceList, error) {
 
 var sigIntReceivedNumber = 0
 
+var (
+	terminationHooksMu sync.Mutex
+	terminationHooks   []func()
+)
+
+// AddTerminationHook registers a function to be called before exiting when
+// the user interrupts the gadget, e.g. to print a summary of the events.
+func AddTerminationHook(hook func()) {
+	terminationHooksMu.Lock()
+	defer terminationHooksMu.Unlock()
+
+	terminationHooks = append(terminationHooks, hook)
+}
+
+func runTerminationHooks() {
+	terminationHooksMu.Lock()
+	hooks := terminationHooks
+	terminationHooks = nil
+	terminationHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
 // SigHandler installs a handler for all signals which cause termination as
 // their default behavior.
 // On reception of this signal, the given trace will be deleted.
@@ -740,6 +775,7 @@ func SigHandler(traceID *string, printTerminationMessage bool) {
 			if printTerminationMessage {
 				fmt.Println("\nTerminating...")
 			}
+			runTerminationHooks()
 			os.Exit(0)
 		} else {
 			os.Exit(1)
@@ -759,7 +795,7 @@ func PrintTraceOutputFromStream(traceID string, expectedState string, params *Co
 		return err
 	}
 
-	return genericStreams(params, traces, nil, transformLine)
+	return genericStreams(params, traces, nil, transformLine, nil)
 }
 
 // PrintTraceOutputFromStatus is used to print trace output using function
@@ -800,6 +836,19 @@ func DeleteTrace(traceID string) error {
 	return nil
 }
 
+// traceLabelValue returns the value of the label used to find the traces
+// created with the given name filter. Kubernetes labels cannot contain ','
+// but can contain '_'. Kubernetes names cannot contain either, so no need for
+// more complicated escaping. Filters with patterns can't be used as label
+// values, so they are not added.
+func traceLabelValue(filter string) string {
+	value := strings.Replace(filter, ",", "_", -1)
+	if len(validation.IsValidLabelValue(value)) != 0 {
+		return ""
+	}
+	return value
+}
+
 // labelsFromFilter creates a string containing labels value from the given
 // labelFilter.
 func labelsFromFilter(filter map[string]string) string {
@@ -826,9 +875,9 @@ func getTraceListFromParameters(config *TraceConfig) ([]gadgetv1alpha1.Trace, er
 	filter := map[string]string{
 		"gadgetName":    config.GadgetName,
 		"nodeName":      config.CommonFlags.Node,
-		"namespace":     strings.Replace(config.CommonFlags.Namespace, ",", "_", -1),
-		"podName":       config.CommonFlags.Podname,
-		"containerName": config.CommonFlags.Containername,
+		"namespace":     traceLabelValue(config.CommonFlags.Namespace),
+		"podName":       traceLabelValue(config.CommonFlags.Podname),
+		"containerName": traceLabelValue(config.CommonFlags.Containername),
 		"outputMode":    string(config.TraceOutputMode),
 	}
 
@@ -917,7 +966,7 @@ func PrintAllTraces(config *TraceConfig) error {
 func RunTraceAndPrintStream(config *TraceConfig, transformLine func(string) string) error {
 	var traceID string
 
-	SigHandler(&traceID, config.CommonFlags.OutputMode != commonutils.OutputModeJSON)
+	SigHandler(&traceID, config.CommonFlags.IsColumnsOutput())
 
 	if config.TraceOutputMode != gadgetv1alpha1.TraceOutputModeStream {
 		return errors.New("TraceOutputMode must be Stream. Otherwise, call RunTraceAndPrintStatusOutput")
@@ -956,7 +1005,48 @@ func RunTraceStreamCallback(config *TraceConfig, callback func(line string, node
 		return err
 	}
 
-	return genericStreams(config.CommonFlags, traces, callback, nil)
+	return genericStreams(config.CommonFlags, traces, callback, nil, nil)
+}
+
+// RunTraceAndPrintEvents is like RunTraceAndPrintStream but receives the
+// events with the binary encoding. transformData is called with each message
+// of the stream, either an event or a line sent by gadgets not supporting
+// the binary encoding, and returns the text to print.
+func RunTraceAndPrintEvents(config *TraceConfig, transformData func(data *pb.StreamData) string) error {
+	var traceID string
+
+	SigHandler(&traceID, config.CommonFlags.IsColumnsOutput())
+
+	if config.TraceOutputMode != gadgetv1alpha1.TraceOutputModeStream {
+		return errors.New("TraceOutputMode must be Stream. Otherwise, call RunTraceAndPrintStatusOutput")
+	}
+
+	traceID, err := CreateTrace(config)
+	if err != nil {
+		return fmt.Errorf("error creating trace: %w", err)
+	}
+
+	defer DeleteTrace(traceID)
+
+	traces, err := waitForTraceState(traceID, string(config.TraceOutputState))
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	eventCallback := func(data *pb.StreamData, node string) {
+		out := transformData(data)
+		if out == "" {
+			return
+		}
+
+		// Events of different nodes are received concurrently
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintln(os.Stdout, out)
+	}
+
+	return genericStreams(config.CommonFlags, traces, nil, nil, eventCallback)
 }
 
 // RunTraceAndPrintStatusOutput creates a trace, prints its output and deletes
@@ -987,11 +1077,17 @@ func RunTraceAndPrintStatusOutput(
 	return PrintTraceOutputFromStatus(traceID, string(config.TraceOutputState), customResultsDisplay)
 }
 
+const (
+	streamReconnectRetries = 3
+	streamReconnectDelay   = time.Second
+)
+
 func genericStreams(
 	params *CommonFlags,
 	results *gadgetv1alpha1.TraceList,
 	callback func(line string, node string),
 	transform func(line string) string,
+	eventCallback func(data *pb.StreamData, node string),
 ) error {
 	completion := make(chan string)
 
@@ -1001,22 +1097,32 @@ func genericStreams(
 	}
 
 	verbose := false
-	// verbose only when not json is used
-	if params.Verbose && params.OutputMode != commonutils.OutputModeJSON {
+	// verbose only when printing columns, not in machine readable formats
+	if params.Verbose && params.IsColumnsOutput() {
 		verbose = true
 	}
 
 	config := &PostProcessConfig{
-		Flows:     len(results.Items),
-		OutStream: os.Stdout,
-		ErrStream: os.Stderr,
-		Callback:  callback,
-		Transform: transform,
-		Verbose:   verbose,
+		Flows:         len(results.Items),
+		OutStream:     os.Stdout,
+		ErrStream:     os.Stderr,
+		Callback:      callback,
+		Transform:     transform,
+		EventCallback: eventCallback,
+		Verbose:       verbose,
 	}
 
 	postProcess := NewPostProcess(config)
 
+	var traceClient *clientset.Clientset
+	if eventCallback != nil {
+		// Used to know if the trace still exists before reconnecting
+		traceClient, err = getTraceClient()
+		if err != nil {
+			return err
+		}
+	}
+
 	streamCount := int32(0)
 	for index, i := range results.Items {
 		if params.Node != "" && i.Spec.Node != params.Node {
@@ -1026,10 +1132,33 @@ func genericStreams(
 		go func(nodeName, namespace, name string, index int) {
 			cmd := fmt.Sprintf("exec gadgettracermanager -call receive-stream -tracerid trace_%s_%s",
 				namespace, name)
+			binary := eventCallback != nil
+			if binary {
+				cmd += " -encoding binary"
+			}
 			postProcess.OutStreams[index].Node = nodeName
-			err := ExecPod(client, nodeName, cmd,
-				postProcess.OutStreams[index], postProcess.ErrStreams[index])
-			if err == nil {
+			err := execPod(client, nodeName, cmd,
+				postProcess.OutStreams[index], postProcess.ErrStreams[index], !binary)
+
+			// Binary streams have sequence numbers, allowing to get the
+			// events sent while reconnecting if the exec is interrupted.
+			deleted := false
+			for retry := 1; binary && err != nil && retry <= streamReconnectRetries; retry++ {
+				// The tracer, and then its stream, is removed with the
+				// trace, e.g. by another instance of the plugin.
+				if deleted = traceDeleted(traceClient, namespace, name); deleted {
+					break
+				}
+
+				fmt.Fprintf(os.Stderr, "Warning: stream on node %q interrupted (%v), reconnecting\n", nodeName, err)
+				time.Sleep(streamReconnectDelay)
+
+				err = execPod(client, nodeName, cmd+postProcess.OutStreams[index].resumeFlags(),
+					postProcess.OutStreams[index], postProcess.ErrStreams[index], false)
+			}
+			if deleted {
+				completion <- fmt.Sprintf("Trace deleted, stopped receiving stream on node %q", nodeName)
+			} else if err == nil {
 				completion <- fmt.Sprintf("Trace completed on node %q", nodeName)
 			} else {
 				completion <- fmt.Sprintf("Error: failed to receive stream on node %q: %v", nodeName, err)
@@ -1059,6 +1188,16 @@ func genericStreams(
 	}
 }
 
+// traceDeleted tells if the trace was deleted or is being deleted. Other
+// errors getting it are ignored, the caller will try to use it anyway.
+func traceDeleted(traceClient *clientset.Clientset, namespace, name string) bool {
+	trace, err := traceClient.GadgetV1alpha1().Traces(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return k8serrors.IsNotFound(err)
+	}
+	return trace.DeletionTimestamp != nil
+}
+
 // DeleteTracesByGadgetName removes all traces with this gadget name
 func DeleteTracesByGadgetName(gadget string) error {
 	traceClient, err := getTraceClient()
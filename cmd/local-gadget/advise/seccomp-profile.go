@@ -19,8 +19,11 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sync"
 	"syscall"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/spf13/cobra"
 
 	commonadvise "github.com/inspektor-gadget/inspektor-gadget/cmd/common/advise"
@@ -35,9 +38,11 @@ const localGadgetSubKey = "local-gadget-key"
 
 func newSeccompProfileCmd() *cobra.Command {
 	var commonFlags utils.CommonFlags
+	var recordDetails bool
+	var outputDir string
 
 	runCmd := func(cmd *cobra.Command, args []string) error {
-		if commonFlags.Containername == "" {
+		if commonFlags.Containername == "" && outputDir == "" {
 			return commonutils.WrapInErrMissingArgs("--containername / -c")
 		}
 
@@ -47,12 +52,19 @@ func newSeccompProfileCmd() *cobra.Command {
 		}
 		defer localGadgetManager.Close()
 
-		tracer, err := seccompAdviseTracer.NewTracer()
+		config := &seccompAdviseTracer.Config{
+			RecordDetails: recordDetails,
+		}
+		tracer, err := seccompAdviseTracer.NewTracer(config)
 		if err != nil {
 			return fmt.Errorf("creating tracer: %w", err)
 		}
 		defer tracer.Close()
 
+		if outputDir != "" {
+			return writeProfiles(localGadgetManager, tracer, &commonFlags, recordDetails, outputDir)
+		}
+
 		var mntns uint64
 		containerNotifier := make(chan error)
 
@@ -108,33 +120,156 @@ func newSeccompProfileCmd() *cobra.Command {
 			}
 		}
 
-		return printProfile(tracer, mntns)
+		return printProfile(tracer, mntns, recordDetails)
 	}
 
 	cmd := commonadvise.NewSeccompProfileCmd(runCmd)
+	cmd.Flags().BoolVar(
+		&recordDetails,
+		"record-details",
+		false,
+		"Restrict the arguments of high-risk syscalls to the recorded values and report how often each syscall was called",
+	)
+	cmd.Flags().StringVar(
+		&outputDir,
+		"output-dir",
+		"",
+		"Write the profile of each container selected by --containername, or of all the containers if not set, in <output-dir>/<namespace>_<pod>_<container>.json",
+	)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
 
 	return cmd
 }
 
-func printProfile(tracer *seccompAdviseTracer.Tracer, mntns uint64) error {
+// writeProfiles writes the profiles of the containers in outputDir when they
+// terminate, or when the user interrupts the command for the ones still
+// running
+func writeProfiles(
+	localGadgetManager *localgadgetmanager.LocalGadgetManager,
+	tracer *seccompAdviseTracer.Tracer,
+	commonFlags *utils.CommonFlags,
+	recordDetails bool,
+	outputDir string,
+) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	running := map[string]*containercollection.Container{}
+
+	write := func(container *containercollection.Container) {
+		// Same names as the File output mode of the seccomp gadget
+		name := fmt.Sprintf("%s_%s_%s.json", container.Namespace, container.Podname, container.Name)
+		path := filepath.Join(outputDir, name)
+		if err := writeProfile(tracer, container.Mntns, recordDetails, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing profile of container %q: %s\n", container.Name, err)
+			return
+		}
+		tracer.Delete(container.Mntns)
+		fmt.Printf("Wrote profile of container %q in %s\n", container.Name, path)
+	}
+
+	containers := localGadgetManager.ContainerCollection.Subscribe(
+		localGadgetSubKey,
+		containercollection.ContainerSelector{
+			Name: commonFlags.Containername,
+		},
+		func(event containercollection.PubSubEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch event.Type {
+			case containercollection.EventTypeAddContainer:
+				running[event.Container.ID] = event.Container
+			case containercollection.EventTypeRemoveContainer:
+				if _, ok := running[event.Container.ID]; ok {
+					delete(running, event.Container.ID)
+					write(event.Container)
+				}
+			}
+		},
+	)
+	defer localGadgetManager.ContainerCollection.Unsubscribe(localGadgetSubKey)
+
+	mu.Lock()
+	for _, container := range containers {
+		running[container.ID] = container
+	}
+	mu.Unlock()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, container := range running {
+		write(container)
+	}
+
+	return nil
+}
+
+// generateProfile generates the profile of the syscalls recorded for a mount
+// namespace. The details are returned if they are recorded.
+func generateProfile(tracer *seccompAdviseTracer.Tracer, mntns uint64, recordDetails bool) (
+	*specs.LinuxSeccomp, *seccompAdviseTracer.SyscallDetails, error,
+) {
 	if mntns == 0 {
-		return fmt.Errorf("impossible to retrieve mount namespace")
+		return nil, nil, fmt.Errorf("impossible to retrieve mount namespace")
 	}
 
 	// Get the list of syscalls from the BPF hash map and generate the seccomp
 	// profile.
 	syscallNames, err := tracer.Peek(mntns)
 	if err != nil {
-		return fmt.Errorf("peeking syscalls for mntns %d: %w", mntns, err)
+		return nil, nil, fmt.Errorf("peeking syscalls for mntns %d: %w", mntns, err)
 	}
-	profile := seccompAdviseTracer.SyscallNamesToLinuxSeccomp(syscallNames)
+
+	var details *seccompAdviseTracer.SyscallDetails
+	if recordDetails {
+		details, err = tracer.PeekDetails(mntns)
+		if err != nil {
+			return nil, nil, fmt.Errorf("peeking syscall details for mntns %d: %w", mntns, err)
+		}
+	}
+
+	return seccompAdviseTracer.SyscallDetailsToLinuxSeccomp(syscallNames, details), details, nil
+}
+
+func writeProfile(tracer *seccompAdviseTracer.Tracer, mntns uint64, recordDetails bool, path string) error {
+	profile, _, err := generateProfile(tracer, mntns, recordDetails)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling seccomp profile: %w", err)
+	}
+
+	return os.WriteFile(path, append(output, '\n'), 0o644)
+}
+
+func printProfile(tracer *seccompAdviseTracer.Tracer, mntns uint64, recordDetails bool) error {
+	profile, details, err := generateProfile(tracer, mntns, recordDetails)
+	if err != nil {
+		return err
+	}
+
 	output, err := json.MarshalIndent(profile, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshalling seccomp profile: %w", err)
 	}
 	fmt.Println(string(output))
 
+	// The report is printed on stderr to keep the profile usable when the
+	// output is redirected
+	if details != nil {
+		fmt.Fprintf(os.Stderr, "\n%s", seccompAdviseTracer.SyscallFrequencyReport(details))
+	}
+
 	return nil
 }
@@ -15,7 +15,6 @@
 package audit
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -51,8 +50,13 @@ func newSeccompCmd() *cobra.Command {
 			return commonutils.WrapInErrParserCreate(err)
 		}
 
-		if commonFlags.OutputMode != commonutils.OutputModeJSON {
-			fmt.Println(parser.BuildColumnsHeader())
+		enricherChain, err := commonFlags.NewEnricherChain()
+		if err != nil {
+			return err
+		}
+
+		if header := parser.BuildHeader(); header != "" {
+			fmt.Println(header)
 		}
 
 		eventCallback := func(event seccompauditTypes.Event) {
@@ -62,26 +66,18 @@ func newSeccompCmd() *cobra.Command {
 				return
 			}
 
-			switch commonFlags.OutputMode {
-			case commonutils.OutputModeJSON:
-				b, err := json.Marshal(event)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error: %s", fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-					return
-				}
-
-				fmt.Println(string(b))
-			case commonutils.OutputModeColumns:
-				fallthrough
-			case commonutils.OutputModeCustomColumns:
-				fmt.Println(parser.TransformIntoColumns(&event))
+			enricherChain.Enrich(&event)
+
+			if line := parser.TransformEntry(&event); line != "" {
+				fmt.Println(line)
 			}
 		}
 
 		// TODO: Improve filtering, see further details in
 		// https://github.com/inspektor-gadget/inspektor-gadget/issues/644.
 		containerSelector := containercollection.ContainerSelector{
-			Name: commonFlags.Containername,
+			Name:  commonFlags.Containername,
+			Image: commonFlags.Image,
 		}
 
 		// Create mount namespace map to filter by containers
@@ -112,6 +108,7 @@ func newSeccompCmd() *cobra.Command {
 	cmd := commonaudit.NewAuditCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
 
 	return cmd
 }
@@ -36,6 +36,7 @@ const localGadgetSubKey = "local-gadget-key"
 func NewListContainersCmd() *cobra.Command {
 	var commonFlags utils.CommonFlags
 	var optionWatch bool
+	var optionHistory bool
 
 	cmd := &cobra.Command{
 		Use:   "list-containers",
@@ -48,10 +49,11 @@ func NewListContainersCmd() *cobra.Command {
 			defer localGadgetManager.Close()
 
 			selector := containercollection.ContainerSelector{
-				Name: commonFlags.Containername,
+				Name:  commonFlags.Containername,
+				Image: commonFlags.Image,
 			}
 
-			if !optionWatch {
+			if !optionWatch && !optionHistory {
 				parser, err := commonutils.NewGadgetParserWithRuntimeInfo(&commonFlags.OutputConfig, containercollection.GetColumns())
 				if err != nil {
 					return commonutils.WrapInErrParserCreate(err)
@@ -74,6 +76,20 @@ func NewListContainersCmd() *cobra.Command {
 			if err != nil {
 				return commonutils.WrapInErrParserCreate(err)
 			}
+
+			if !optionWatch {
+				if header := parser.BuildHeader(); header != "" {
+					fmt.Println(header)
+				}
+				events := localGadgetManager.ContainerCollection.LifecycleEvents(&selector)
+				for i := range events {
+					if err = printPubSubEvent(parser, commonFlags, &events[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
 			containers := localGadgetManager.ContainerCollection.Subscribe(
 				localGadgetSubKey,
 				selector,
@@ -85,8 +101,19 @@ func NewListContainersCmd() *cobra.Command {
 			)
 			defer localGadgetManager.ContainerCollection.Unsubscribe(localGadgetSubKey)
 
-			if commonFlags.OutputMode != commonutils.OutputModeJSON {
-				fmt.Println(parser.BuildColumnsHeader())
+			if header := parser.BuildHeader(); header != "" {
+				fmt.Println(header)
+			}
+			if optionHistory {
+				// The history already contains the creation of the
+				// existing containers
+				events := localGadgetManager.ContainerCollection.LifecycleEvents(&selector)
+				for i := range events {
+					if err = printPubSubEvent(parser, commonFlags, &events[i]); err != nil {
+						return err
+					}
+				}
+				containers = nil
 			}
 			timestamp := time.Now().Format(time.RFC3339)
 			for _, container := range containers {
@@ -113,6 +140,11 @@ func NewListContainersCmd() *cobra.Command {
 		"watch", "w",
 		false,
 		"After listing the containers, watch for new containers")
+	cmd.Flags().BoolVar(
+		&optionHistory,
+		"history",
+		false,
+		"List the creations and removals of containers, with the reason of the removals if known, instead of the current containers")
 
 	utils.AddCommonFlags(cmd, &commonFlags)
 
@@ -120,20 +152,13 @@ func NewListContainersCmd() *cobra.Command {
 }
 
 func printContainers(parser *commonutils.GadgetParser[containercollection.Container], commonFlags utils.CommonFlags, containers []*containercollection.Container) error {
-	switch commonFlags.OutputMode {
-	case commonutils.OutputModeJSON:
-		b, err := json.MarshalIndent(containers, "", "  ")
-		if err != nil {
-			return commonutils.WrapInErrMarshalOutput(err)
-		}
-
-		fmt.Printf("%s\n", b)
-	case commonutils.OutputModeColumns:
-		fallthrough
-	case commonutils.OutputModeCustomColumns:
-		fmt.Println(parser.TransformIntoTable(containers))
+	output, err := parser.TransformEntries(containers)
+	if err != nil {
+		return err
 	}
 
+	fmt.Println(output)
+
 	return nil
 }
 
@@ -145,10 +170,10 @@ func printPubSubEvent(parser *commonutils.GadgetParser[containercollection.PubSu
 			return commonutils.WrapInErrMarshalOutput(err)
 		}
 		fmt.Printf("%s\n", b)
-	case commonutils.OutputModeColumns:
-		fallthrough
-	case commonutils.OutputModeCustomColumns:
-		fmt.Println(parser.TransformIntoColumns(event))
+	default:
+		if line := parser.TransformEntry(event); line != "" {
+			fmt.Println(line)
+		}
 	}
 
 	return nil
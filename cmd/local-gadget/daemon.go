@@ -0,0 +1,160 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	"github.com/inspektor-gadget/inspektor-gadget/cmd/local-gadget/utils"
+	gadgetcollection "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgetservice"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	localgadgetmanager "github.com/inspektor-gadget/inspektor-gadget/pkg/local-gadget-manager"
+)
+
+func newDaemonCmd() *cobra.Command {
+	var commonFlags utils.CommonFlags
+	var group string
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Keep the container collection and the gadgets in a daemon used by the other commands",
+		Long: `Keep the container collection and the gadgets in a daemon serving the gadget
+service on a unix socket. The trace and top commands run their gadget with the
+daemon when its socket exists, so they start faster and can be run by the users
+of the group given with --group.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			socket := commonFlags.DaemonSocket
+			if socket == "" {
+				return commonutils.WrapInErrMissingArgs("--daemon-socket")
+			}
+
+			lis, err := listenDaemonSocket(socket, group)
+			if err != nil {
+				return err
+			}
+			defer os.Remove(socket)
+
+			localGadgetManager, err := localgadgetmanager.NewManager(commonFlags.RuntimeConfigs)
+			if err != nil {
+				lis.Close()
+				return fmt.Errorf("error creating local gadget manager: %w", commonutils.WrapInErrManagerInit(err))
+			}
+			defer localGadgetManager.Close()
+
+			gadgetService := gadgetservice.New(localGadgetManager, gadgetcollection.TraceFactoriesForLocalGadgetDaemon(), "local")
+			defer gadgetService.Close()
+
+			grpcServer := grpc.NewServer()
+			pb.RegisterGadgetServiceServer(grpcServer, gadgetService)
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- grpcServer.Serve(lis)
+			}()
+			defer grpcServer.Stop()
+
+			fmt.Printf("Serving on %s... Hit Ctrl-C to end\n", socket)
+
+			exit := make(chan os.Signal, 1)
+			signal.Notify(exit, syscall.SIGINT, syscall.SIGTERM)
+
+			select {
+			case <-exit:
+				return nil
+			case err := <-errs:
+				return fmt.Errorf("error serving gadget service: %w", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&group,
+		"group",
+		"",
+		"Group whose users can use the daemon. By default, only the user running it can",
+	)
+
+	utils.AddCommonFlags(cmd, &commonFlags)
+
+	return cmd
+}
+
+// listenDaemonSocket listens on the socket of the daemon, accessible by the
+// members of group if set. A socket left by a daemon that didn't exit
+// properly is removed.
+func listenDaemonSocket(socket, group string) (net.Listener, error) {
+	if info, err := os.Stat(socket); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", socket)
+		}
+		if conn, err := net.Dial("unix", socket); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a daemon is already serving on %s", socket)
+		}
+		if err := os.Remove(socket); err != nil {
+			return nil, fmt.Errorf("removing stale socket %s: %w", socket, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	gid := -1
+	if group != "" {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			return nil, commonutils.WrapInErrInvalidArg("--group", err)
+		}
+		gid, err = strconv.Atoi(g.Gid)
+		if err != nil {
+			return nil, commonutils.WrapInErrInvalidArg("--group", err)
+		}
+	}
+
+	// Create the socket accessible only by the user running the daemon, so
+	// nobody else can connect before the permissions are set below
+	oldUmask := syscall.Umask(0o077)
+	lis, err := net.Listen("unix", socket)
+	syscall.Umask(oldUmask)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %q: %w", socket, err)
+	}
+
+	mode := os.FileMode(0o600)
+	if gid != -1 {
+		if err := os.Chown(socket, -1, gid); err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("changing group of %s: %w", socket, err)
+		}
+		mode = 0o660
+	}
+	if err := os.Chmod(socket, mode); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("changing permissions of %s: %w", socket, err)
+	}
+
+	return lis, nil
+}
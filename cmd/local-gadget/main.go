@@ -39,11 +39,13 @@ func main() {
 		advise.NewAdviseCmd(),
 		audit.NewAuditCmd(),
 		containers.NewListContainersCmd(),
+		newDaemonCmd(),
 		interactive.NewInteractiveCmd(),
 		profile.NewProfileCmd(),
 		snapshot.NewSnapshotCmd(),
 		top.NewTopCmd(),
 		trace.NewTraceCmd(),
+		newMetricsCmd(),
 		newTraceloopCmd(),
 		newVersionCmd(),
 	)
@@ -0,0 +1,105 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	"github.com/inspektor-gadget/inspektor-gadget/cmd/local-gadget/utils"
+	localgadgetmanager "github.com/inspektor-gadget/inspektor-gadget/pkg/local-gadget-manager"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics/exporter"
+)
+
+func newMetricsCmd() *cobra.Command {
+	var commonFlags utils.CommonFlags
+	var configPath, address string
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Run gadgets and expose Prometheus metrics from their events",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := metrics.ReadConfig(configPath)
+			if err != nil {
+				return commonutils.WrapInErrInvalidArg("--config", err)
+			}
+
+			metricsExporter, err := exporter.NewExporter(config)
+			if err != nil {
+				return commonutils.WrapInErrInvalidArg("--config", err)
+			}
+
+			localGadgetManager, err := localgadgetmanager.NewManager(commonFlags.RuntimeConfigs)
+			if err != nil {
+				return fmt.Errorf("error creating local gadget manager: %w", commonutils.WrapInErrManagerInit(err))
+			}
+			defer localGadgetManager.Close()
+
+			lis, err := net.Listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("error listening on %q: %w", address, err)
+			}
+
+			if err := metricsExporter.Start(localGadgetManager, "local"); err != nil {
+				lis.Close()
+				return fmt.Errorf("error starting gadgets: %w", err)
+			}
+			defer metricsExporter.Stop()
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- metricsExporter.Serve(lis)
+			}()
+
+			fmt.Printf("Serving metrics on %s/metrics... Hit Ctrl-C to end\n", lis.Addr())
+
+			exit := make(chan os.Signal, 1)
+			signal.Notify(exit, syscall.SIGINT, syscall.SIGTERM)
+
+			select {
+			case <-exit:
+				return nil
+			case err := <-errs:
+				return fmt.Errorf("error serving metrics: %w", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&configPath,
+		"config",
+		"",
+		"Path to the YAML file describing the gadgets to run and the metrics to expose",
+	)
+	cmd.MarkFlagRequired("config")
+
+	cmd.Flags().StringVar(
+		&address,
+		"address",
+		exporter.DefaultAddress,
+		"Address to expose the metrics on",
+	)
+
+	utils.AddCommonFlags(cmd, &commonFlags)
+
+	return cmd
+}
@@ -30,7 +30,7 @@ func newBlockIOCmd() *cobra.Command {
 	var profileFlags ProfileFlags
 
 	runCmd := func(*cobra.Command, []string) error {
-		if profileFlags.Containername != "" || profileFlags.Runtimes != strings.Join(containerutils.AvailableRuntimes, ",") {
+		if profileFlags.Containername != "" || profileFlags.Image != "" || profileFlags.Runtimes != strings.Join(containerutils.DefaultRuntimes, ",") {
 			return fmt.Errorf("block-io gadget doesn't support filtering")
 		}
 
@@ -49,7 +49,8 @@ func newCPUCmd() *cobra.Command {
 		// TODO: Improve filtering, see further details in
 		// https://github.com/inspektor-gadget/inspektor-gadget/issues/644.
 		containerSelector := containercollection.ContainerSelector{
-			Name: profileFlags.Containername,
+			Name:  profileFlags.Containername,
+			Image: profileFlags.Image,
 		}
 
 		// Create mount namespace map to filter by containers
@@ -80,6 +81,8 @@ func newCPUCmd() *cobra.Command {
 	}
 
 	cmd := commonprofile.NewCPUCmd(runCmd, &cpuFlags)
+
+	profileFlags.AdditionalOutputModes = commonprofile.CPUOutputModes
 	AddCommonProfileFlags(cmd, &profileFlags)
 
 	return cmd
@@ -60,7 +60,7 @@ func (g *ProfileGadget) Run() error {
 		}()
 	}
 
-	if g.profileFlags.OutputMode != commonutils.OutputModeJSON {
+	if g.profileFlags.IsColumnsOutput() {
 		if g.profileFlags.Timeout != 0 {
 			fmt.Printf(g.inProgressMsg + "...")
 		} else {
@@ -81,9 +81,11 @@ func (g *ProfileGadget) Run() error {
 		return err
 	}
 
-	// Trick to have ^C on the same line than above message, so the gadget
-	// output begins on a "clean" line.
-	fmt.Println()
+	if g.profileFlags.IsColumnsOutput() {
+		// Trick to have ^C on the same line than above message, so the gadget
+		// output begins on a "clean" line.
+		fmt.Println()
+	}
 
 	err = g.parser.DisplayResultsCallback("", []string{result})
 	if err != nil {
@@ -44,7 +44,8 @@ func (g *SnapshotGadget[Event]) Run() error {
 	// TODO: Improve filtering, see further details in
 	// https://github.com/inspektor-gadget/inspektor-gadget/issues/644.
 	containerSelector := &containercollection.ContainerSelector{
-		Name: g.commonFlags.Containername,
+		Name:  g.commonFlags.Containername,
+		Image: g.commonFlags.Image,
 	}
 
 	allEvents, err := g.runTracer(localGadgetManager, containerSelector)
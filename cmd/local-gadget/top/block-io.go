@@ -49,10 +49,11 @@ func newBlockIOCmd() *cobra.Command {
 				Parser:         parser,
 				ColMap:         cols.ColumnMap,
 			},
+			name:        "biotop",
 			commonFlags: &commonFlags,
 			createAndRunTracer: func(mountNsMap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(*top.Event[types.Stats])) (trace.Tracer, error) {
 				config := &tracer.Config{
-					MaxRows:    flags.MaxRows,
+					MaxRows:    flags.TracerMaxRows(),
 					Interval:   time.Second * time.Duration(flags.OutputInterval),
 					SortBy:     flags.ParsedSortBy,
 					MountnsMap: mountNsMap,
@@ -67,6 +68,7 @@ func newBlockIOCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -55,10 +55,11 @@ func newEbpfCmd() *cobra.Command {
 				Parser:         parser,
 				ColMap:         cols.ColumnMap,
 			},
+			name:        "ebpftop",
 			commonFlags: &commonFlags,
 			createAndRunTracer: func(mountNsMap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(*top.Event[types.Stats])) (trace.Tracer, error) {
 				config := &tracer.Config{
-					MaxRows:  flags.MaxRows,
+					MaxRows:  flags.TracerMaxRows(),
 					Interval: time.Second * time.Duration(flags.OutputInterval),
 					SortBy:   flags.ParsedSortBy,
 				}
@@ -72,6 +73,7 @@ func newEbpfCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
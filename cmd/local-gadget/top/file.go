@@ -15,6 +15,7 @@
 package top
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/cilium/ebpf"
@@ -49,10 +50,14 @@ func newFileCmd() *cobra.Command {
 				Parser:         parser,
 				ColMap:         cols.ColumnMap,
 			},
+			name: "filetop",
+			params: map[string]string{
+				types.AllFilesParam: strconv.FormatBool(flags.ShowAllFiles),
+			},
 			commonFlags: &commonFlags,
 			createAndRunTracer: func(mountNsMap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(*top.Event[types.Stats])) (trace.Tracer, error) {
 				config := &tracer.Config{
-					MaxRows:    flags.MaxRows,
+					MaxRows:    flags.TracerMaxRows(),
 					Interval:   time.Second * time.Duration(flags.OutputInterval),
 					SortBy:     flags.ParsedSortBy,
 					MountnsMap: mountNsMap,
@@ -67,6 +72,7 @@ func newFileCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags.CommonTopFlags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -15,6 +15,7 @@
 package top
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/cilium/ebpf"
@@ -42,14 +43,18 @@ func newTCPCmd() *cobra.Command {
 			return commonutils.WrapInErrParserCreate(err)
 		}
 
+		params := make(map[string]string)
+
 		targetPid := int32(-1)
 		if flags.FilteredPid != 0 {
 			targetPid = int32(flags.FilteredPid)
+			params[types.PidParam] = strconv.FormatUint(uint64(flags.FilteredPid), 10)
 		}
 
 		targetFamily := int32(-1)
 		if flags.Family != 0 {
 			targetFamily = int32(flags.Family)
+			params[types.FamilyParam] = strconv.FormatUint(uint64(flags.Family), 10)
 		}
 
 		gadget := &TopGadget[types.Stats]{
@@ -59,10 +64,12 @@ func newTCPCmd() *cobra.Command {
 				Parser:         parser,
 				ColMap:         cols.ColumnMap,
 			},
+			name:        "tcptop",
+			params:      params,
 			commonFlags: &commonFlags,
 			createAndRunTracer: func(mountNsMap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(*top.Event[types.Stats])) (trace.Tracer, error) {
 				config := &tracer.Config{
-					MaxRows:      flags.MaxRows,
+					MaxRows:      flags.TracerMaxRows(),
 					Interval:     time.Second * time.Duration(flags.OutputInterval),
 					SortBy:       flags.ParsedSortBy,
 					MountnsMap:   mountNsMap,
@@ -79,6 +86,7 @@ func newTCPCmd() *cobra.Command {
 
 	commontop.AddCommonTopFlags(cmd, &flags.CommonTopFlags, cols.ColumnMap, types.SortByDefault)
 	utils.AddCommonFlags(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -29,7 +29,6 @@ import (
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/local-gadget/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/sort"
-	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/trace"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/top"
@@ -42,22 +41,44 @@ type TopGadget[Stats any] struct {
 
 	commonFlags        *utils.CommonFlags
 	createAndRunTracer func(*ebpf.Map, gadgets.DataEnricherByMntNs, func(*top.Event[Stats])) (trace.Tracer, error)
+
+	// name and params are the name and the parameters of the gadget run by
+	// the local-gadget daemon, if any
+	name   string
+	params map[string]string
 }
 
 // Run runs a TopGadget and prints the output after parsing it using the
 // TopParser's methods.
 func (g *TopGadget[Stats]) Run(args []string) error {
+	conn, err := g.commonFlags.DaemonConn()
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		defer conn.Close()
+
+		if err := g.parseFlags(args); err != nil {
+			return err
+		}
+
+		if g.params == nil {
+			g.params = make(map[string]string)
+		}
+		g.params[top.MaxRowsParam] = strconv.Itoa(g.CommonTopFlags.TracerMaxRows())
+		g.params[top.IntervalParam] = strconv.Itoa(g.CommonTopFlags.OutputInterval)
+		g.params[top.SortByParam] = g.CommonTopFlags.SortBy
+
+		return utils.RunWithDaemon(conn, g.commonFlags, g.name, g.params, g.printEvent)
+	}
+
 	localGadgetManager, err := localgadgetmanager.NewManager(g.commonFlags.RuntimeConfigs)
 	if err != nil {
 		return commonutils.WrapInErrManagerInit(err)
 	}
 	defer localGadgetManager.Close()
 
-	// TODO: Improve filtering, see further details in
-	// https://github.com/inspektor-gadget/inspektor-gadget/issues/644.
-	containerSelector := containercollection.ContainerSelector{
-		Name: g.commonFlags.Containername,
-	}
+	containerSelector := g.commonFlags.ContainerSelector()
 
 	// Create mount namespace map to filter by containers
 	mountnsmap, err := localGadgetManager.CreateMountNsMap(containerSelector)
@@ -66,6 +87,25 @@ func (g *TopGadget[Stats]) Run(args []string) error {
 	}
 	defer localGadgetManager.RemoveMountNsMap()
 
+	if err := g.parseFlags(args); err != nil {
+		return err
+	}
+
+	gadgetTracer, err := g.createAndRunTracer(mountnsmap, &localGadgetManager.ContainerCollection, g.printEvent)
+	if err != nil {
+		return commonutils.WrapInErrGadgetTracerCreateAndRun(err)
+	}
+	defer gadgetTracer.Stop()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	return nil
+}
+
+// parseFlags sets the interval and the columns to sort by and group by
+func (g *TopGadget[Stats]) parseFlags(args []string) (err error) {
 	if len(args) == 1 {
 		g.CommonTopFlags.OutputInterval, err = strconv.Atoi(args[0])
 		if err != nil {
@@ -83,23 +123,13 @@ func (g *TopGadget[Stats]) Run(args []string) error {
 	}
 	g.CommonTopFlags.ParsedSortBy = sortByColumns
 
-	// Define a callback to be called each time there is an event.
-	eventCallback := func(event *top.Event[Stats]) {
-		g.PrintHeader()
-		g.PrintStats(event.Stats)
-	}
-
-	gadgetTracer, err := g.createAndRunTracer(mountnsmap, &localGadgetManager.ContainerCollection, eventCallback)
-	if err != nil {
-		return commonutils.WrapInErrGadgetTracerCreateAndRun(err)
-	}
-	defer gadgetTracer.Stop()
-
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
+	return commonutils.ParseGroupFlags(&g.CommonTopFlags.GroupFlags, g.ColMap)
+}
 
-	return nil
+// printEvent is called each time there is an event
+func (g *TopGadget[Stats]) printEvent(event *top.Event[Stats]) {
+	g.PrintHeader()
+	g.PrintStats(event.Stats)
 }
 
 func NewTopCmd() *cobra.Command {
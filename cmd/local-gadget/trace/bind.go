@@ -15,6 +15,9 @@
 package trace
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/cilium/ebpf"
 	"github.com/spf13/cobra"
 
@@ -32,14 +35,25 @@ func newBindCmd() *cobra.Command {
 	var flags commontrace.BindFlags
 
 	runCmd := func(*cobra.Command, []string) error {
+		ports := make([]string, 0, len(flags.ValidatedTargetPorts))
+		for _, port := range flags.ValidatedTargetPorts {
+			ports = append(ports, strconv.FormatUint(uint64(port), 10))
+		}
+
 		parser, err := commonutils.NewGadgetParserWithRuntimeInfo(&commonFlags.OutputConfig, bindTypes.GetColumns())
 		if err != nil {
 			return commonutils.WrapInErrParserCreate(err)
 		}
 
 		bindGadget := &TraceGadget[bindTypes.Event]{
+			name:        "bindsnoop",
 			commonFlags: &commonFlags,
 			parser:      parser,
+			params: map[string]string{
+				"pid":           strconv.FormatUint(uint64(flags.TargetPid), 10),
+				"ports":         strings.Join(ports, ","),
+				"ignore_errors": strconv.FormatBool(flags.IgnoreErrors),
+			},
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(bindTypes.Event)) (trace.Tracer, error) {
 				config := &bindTracer.Config{
 					MountnsMap:   mountnsmap,
@@ -58,6 +72,9 @@ func newBindCmd() *cobra.Command {
 	cmd := commontrace.NewBindCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
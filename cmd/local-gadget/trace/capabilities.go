@@ -15,6 +15,8 @@
 package trace
 
 import (
+	"strconv"
+
 	"github.com/cilium/ebpf"
 	"github.com/spf13/cobra"
 
@@ -41,8 +43,13 @@ func newCapabilitiesCmd() *cobra.Command {
 		}
 
 		capabilitiesGadget := &TraceGadget[capabilitiesTypes.Event]{
+			name:        "capabilities",
 			commonFlags: &commonFlags,
 			parser:      parser,
+			params: map[string]string{
+				capabilitiesTypes.AuditOnlyParam: strconv.FormatBool(flags.AuditOnly),
+				capabilitiesTypes.UniqueParam:    strconv.FormatBool(flags.Unique),
+			},
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(capabilitiesTypes.Event)) (trace.Tracer, error) {
 				config := &capabilitiesTracer.Config{
 					MountnsMap: mountnsmap,
@@ -60,6 +67,9 @@ func newCapabilitiesCmd() *cobra.Command {
 	cmd := commontrace.NewCapabilitiesCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
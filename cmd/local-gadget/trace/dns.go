@@ -15,7 +15,6 @@
 package trace
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -36,6 +35,7 @@ import (
 
 func newDNSCmd() *cobra.Command {
 	var commonFlags utils.CommonFlags
+	var flags commontrace.DNSFlags
 
 	// The DNS gadget works in a different way than most gadgets: It
 	// attaches a new eBPF program to each container when it's
@@ -59,6 +59,8 @@ func newDNSCmd() *cobra.Command {
 			return commonutils.WrapInErrParserCreate(err)
 		}
 
+		summarizer := parser.NewSummarizer()
+
 		eventCallback := func(container *containercollection.Container, event dnsTypes.Event) {
 			baseEvent := event.GetBaseEvent()
 			if baseEvent.Type != eventtypes.NORMAL {
@@ -73,34 +75,35 @@ func newDNSCmd() *cobra.Command {
 				event.Container = container.Name
 			}
 
-			switch commonFlags.OutputMode {
-			case commonutils.OutputModeJSON:
-				b, err := json.Marshal(event)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error: %s", fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-					return
-				}
-
-				fmt.Println(string(b))
-			case commonutils.OutputModeColumns:
-				fallthrough
-			case commonutils.OutputModeCustomColumns:
-				fmt.Println(parser.TransformIntoColumns(&event))
+			if !parser.Match(&event) {
+				return
+			}
+
+			if summarizer != nil {
+				summarizer.Add(&event)
+				return
+			}
+
+			if line := parser.TransformEntry(&event); line != "" {
+				fmt.Println(line)
 			}
 		}
 
-		tracer, err := dnsTracer.NewTracer()
+		tracer, err := dnsTracer.NewTracer(&dnsTracer.Config{
+			DNSTimeout: flags.DNSTimeout,
+		})
 		if err != nil {
 			return commonutils.WrapInErrGadgetTracerCreateAndRun(err)
 		}
 		defer tracer.Close()
 
-		if commonFlags.OutputMode != commonutils.OutputModeJSON {
-			fmt.Println(parser.BuildColumnsHeader())
+		if header := parser.BuildHeader(); summarizer == nil && header != "" {
+			fmt.Println(header)
 		}
 
 		selector := containercollection.ContainerSelector{
-			Name: commonFlags.Containername,
+			Name:  commonFlags.Containername,
+			Image: commonFlags.Image,
 		}
 
 		config := &networktracer.ConnectToContainerCollectionConfig[dnsTypes.Event]{
@@ -116,6 +119,11 @@ func newDNSCmd() *cobra.Command {
 		}
 		defer conn.Close()
 
+		if summarizer != nil {
+			summarizer.Start()
+			defer summarizer.Stop()
+		}
+
 		stop := make(chan os.Signal, 1)
 		signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 		<-stop
@@ -123,9 +131,12 @@ func newDNSCmd() *cobra.Command {
 		return nil
 	}
 
-	cmd := commontrace.NewDNSCmd(runCmd)
+	cmd := commontrace.NewDNSCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -37,6 +37,7 @@ func newExecCmd() *cobra.Command {
 		}
 
 		execGadget := &TraceGadget[execTypes.Event]{
+			name:        "execsnoop",
 			commonFlags: &commonFlags,
 			parser:      parser,
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(execTypes.Event)) (trace.Tracer, error) {
@@ -50,6 +51,9 @@ func newExecCmd() *cobra.Command {
 	cmd := commontrace.NewExecCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -15,6 +15,8 @@
 package trace
 
 import (
+	"strconv"
+
 	"github.com/cilium/ebpf"
 	"github.com/spf13/cobra"
 
@@ -38,8 +40,13 @@ func newFsSlowerCmd() *cobra.Command {
 		}
 
 		fsslowerGadget := &TraceGadget[fsslowerTypes.Event]{
+			name:        "fsslower",
 			commonFlags: &commonFlags,
 			parser:      parser,
+			params: map[string]string{
+				"filesystem": flags.Filesystem,
+				"minlatency": strconv.FormatUint(uint64(flags.MinLatency), 10),
+			},
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(fsslowerTypes.Event)) (trace.Tracer, error) {
 				config := &fsslowerTracer.Config{
 					MountnsMap: mountnsmap,
@@ -56,6 +63,9 @@ func newFsSlowerCmd() *cobra.Command {
 	cmd := commontrace.NewFsSlowerCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
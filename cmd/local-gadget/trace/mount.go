@@ -37,6 +37,7 @@ func newMountCmd() *cobra.Command {
 		}
 
 		mountGadget := &TraceGadget[mountTypes.Event]{
+			name:        "mountsnoop",
 			commonFlags: &commonFlags,
 			parser:      parser,
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(mountTypes.Event)) (trace.Tracer, error) {
@@ -50,6 +51,9 @@ func newMountCmd() *cobra.Command {
 	cmd := commontrace.NewMountCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -40,6 +40,7 @@ func newOOMKillCmd() *cobra.Command {
 		}
 
 		oomkillGadget := &TraceGadget[oomkillTypes.Event]{
+			name:        "oomkill",
 			commonFlags: &commonFlags,
 			parser:      parser,
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(oomkillTypes.Event)) (trace.Tracer, error) {
@@ -53,6 +54,9 @@ func newOOMKillCmd() *cobra.Command {
 	cmd := commontrace.NewOOMKillCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -37,6 +37,7 @@ func newOpenCmd() *cobra.Command {
 		}
 
 		openGadget := &TraceGadget[openTypes.Event]{
+			name:        "opensnoop",
 			commonFlags: &commonFlags,
 			parser:      parser,
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(openTypes.Event)) (trace.Tracer, error) {
@@ -50,6 +51,9 @@ func newOpenCmd() *cobra.Command {
 	cmd := commontrace.NewOpenCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -15,6 +15,8 @@
 package trace
 
 import (
+	"strconv"
+
 	"github.com/cilium/ebpf"
 	"github.com/spf13/cobra"
 
@@ -41,8 +43,15 @@ func newSignalCmd() *cobra.Command {
 		}
 
 		signalGadget := &TraceGadget[signalTypes.Event]{
+			name:        "sigsnoop",
 			commonFlags: &commonFlags,
 			parser:      parser,
+			params: map[string]string{
+				"signal":    flags.Sig,
+				"pid":       strconv.FormatUint(flags.Pid, 10),
+				"failed":    strconv.FormatBool(flags.Failed),
+				"kill-only": strconv.FormatBool(flags.KillOnly),
+			},
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(signalTypes.Event)) (trace.Tracer, error) {
 				return signalTracer.NewTracer(&signalTracer.Config{
 					MountnsMap:   mountnsmap,
@@ -60,6 +69,9 @@ func newSignalCmd() *cobra.Command {
 	cmd := commontrace.NewSignalCmd(runCmd, &flags)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
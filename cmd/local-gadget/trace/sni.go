@@ -15,7 +15,6 @@
 package trace
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -59,6 +58,8 @@ func newSNICmd() *cobra.Command {
 			return commonutils.WrapInErrParserCreate(err)
 		}
 
+		summarizer := parser.NewSummarizer()
+
 		eventCallback := func(container *containercollection.Container, event sniTypes.Event) {
 			baseEvent := event.GetBaseEvent()
 			if baseEvent.Type != eventtypes.NORMAL {
@@ -73,19 +74,17 @@ func newSNICmd() *cobra.Command {
 				event.Container = container.Name
 			}
 
-			switch commonFlags.OutputMode {
-			case commonutils.OutputModeJSON:
-				b, err := json.Marshal(event)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error: %s", fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-					return
-				}
-
-				fmt.Println(string(b))
-			case commonutils.OutputModeColumns:
-				fallthrough
-			case commonutils.OutputModeCustomColumns:
-				fmt.Println(parser.TransformIntoColumns(&event))
+			if !parser.Match(&event) {
+				return
+			}
+
+			if summarizer != nil {
+				summarizer.Add(&event)
+				return
+			}
+
+			if line := parser.TransformEntry(&event); line != "" {
+				fmt.Println(line)
 			}
 		}
 
@@ -95,12 +94,13 @@ func newSNICmd() *cobra.Command {
 		}
 		defer tracer.Close()
 
-		if commonFlags.OutputMode != commonutils.OutputModeJSON {
-			fmt.Println(parser.BuildColumnsHeader())
+		if header := parser.BuildHeader(); summarizer == nil && header != "" {
+			fmt.Println(header)
 		}
 
 		selector := containercollection.ContainerSelector{
-			Name: commonFlags.Containername,
+			Name:  commonFlags.Containername,
+			Image: commonFlags.Image,
 		}
 
 		config := &networktracer.ConnectToContainerCollectionConfig[sniTypes.Event]{
@@ -116,6 +116,11 @@ func newSNICmd() *cobra.Command {
 		}
 		defer conn.Close()
 
+		if summarizer != nil {
+			summarizer.Start()
+			defer summarizer.Stop()
+		}
+
 		stop := make(chan os.Signal, 1)
 		signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 		<-stop
@@ -126,6 +131,9 @@ func newSNICmd() *cobra.Command {
 	cmd := commontrace.NewSNICmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
@@ -37,6 +37,7 @@ func newTCPCmd() *cobra.Command {
 		}
 
 		tcpGadget := &TraceGadget[tcpTypes.Event]{
+			name:        "tcptracer",
 			commonFlags: &commonFlags,
 			parser:      parser,
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(tcpTypes.Event)) (trace.Tracer, error) {
@@ -50,6 +51,9 @@ func newTCPCmd() *cobra.Command {
 	cmd := commontrace.NewTCPCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
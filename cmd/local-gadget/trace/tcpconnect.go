@@ -40,6 +40,7 @@ func newTcpconnectCmd() *cobra.Command {
 		}
 
 		tcpconnectGadget := &TraceGadget[tcpconnectTypes.Event]{
+			name:        "tcpconnect",
 			commonFlags: &commonFlags,
 			parser:      parser,
 			createAndRunTracer: func(mountnsmap *ebpf.Map, enricher gadgets.DataEnricherByMntNs, eventCallback func(tcpconnectTypes.Event)) (trace.Tracer, error) {
@@ -53,6 +54,9 @@ func newTcpconnectCmd() *cobra.Command {
 	cmd := commontrace.NewTcpconnectCmd(runCmd)
 
 	utils.AddCommonFlags(cmd, &commonFlags)
+	utils.AddEnrichFlag(cmd, &commonFlags)
+	commonutils.AddFilterFlag(cmd, &commonFlags.OutputConfig)
+	commonutils.AddSummaryFlags(cmd, &commonFlags.OutputConfig)
 
 	return cmd
 }
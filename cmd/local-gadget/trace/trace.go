@@ -15,7 +15,6 @@
 package trace
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -27,7 +26,6 @@ import (
 	commontrace "github.com/inspektor-gadget/inspektor-gadget/cmd/common/trace"
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
 	"github.com/inspektor-gadget/inspektor-gadget/cmd/local-gadget/utils"
-	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/trace"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
 	localgadgetmanager "github.com/inspektor-gadget/inspektor-gadget/pkg/local-gadget-manager"
@@ -39,22 +37,37 @@ type TraceGadget[Event commontrace.TraceEvent] struct {
 	commonFlags        *utils.CommonFlags
 	parser             commontrace.TraceParser[Event]
 	createAndRunTracer func(*ebpf.Map, gadgets.DataEnricherByMntNs, func(Event)) (trace.Tracer, error)
+
+	// name and params are the name and the parameters of the gadget run by
+	// the local-gadget daemon, if any
+	name   string
+	params map[string]string
 }
 
 // Run runs a TraceGadget and prints the output after parsing it using the
 // TraceParser's methods.
 func (g *TraceGadget[Event]) Run() error {
+	conn, err := g.commonFlags.DaemonConn()
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		defer conn.Close()
+
+		return g.run(func(eventCallback func(Event)) error {
+			return utils.RunWithDaemon(conn, g.commonFlags, g.name, g.params, func(event *Event) {
+				eventCallback(*event)
+			})
+		})
+	}
+
 	localGadgetManager, err := localgadgetmanager.NewManager(g.commonFlags.RuntimeConfigs)
 	if err != nil {
 		return commonutils.WrapInErrManagerInit(err)
 	}
 	defer localGadgetManager.Close()
 
-	// TODO: Improve filtering, see further details in
-	// https://github.com/inspektor-gadget/inspektor-gadget/issues/644.
-	containerSelector := containercollection.ContainerSelector{
-		Name: g.commonFlags.Containername,
-	}
+	containerSelector := g.commonFlags.ContainerSelector()
 
 	// Create mount namespace map to filter by containers
 	mountnsmap, err := localGadgetManager.CreateMountNsMap(containerSelector)
@@ -63,8 +76,37 @@ func (g *TraceGadget[Event]) Run() error {
 	}
 	defer localGadgetManager.RemoveMountNsMap()
 
-	if g.commonFlags.OutputMode != commonutils.OutputModeJSON {
-		fmt.Println(g.parser.BuildColumnsHeader())
+	return g.run(func(eventCallback func(Event)) error {
+		gadgetTracer, err := g.createAndRunTracer(mountnsmap, &localGadgetManager.ContainerCollection, eventCallback)
+		if err != nil {
+			return commonutils.WrapInErrGadgetTracerCreateAndRun(err)
+		}
+		defer gadgetTracer.Stop()
+
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+		<-stop
+
+		return nil
+	})
+}
+
+// run prints the events given by the tracer started by startAndWait, which
+// returns when the gadget should stop.
+func (g *TraceGadget[Event]) run(startAndWait func(eventCallback func(Event)) error) error {
+	// The events are enriched here, also when they come from the daemon, as
+	// the enrichers only read the /proc of the host
+	enricherChain, err := g.commonFlags.NewEnricherChain()
+	if err != nil {
+		return err
+	}
+
+	summarizer := g.parser.NewSummarizer()
+
+	if summarizer == nil {
+		if header := g.parser.BuildHeader(); header != "" {
+			fmt.Println(header)
+		}
 	}
 
 	// Define a callback to be called each time there is an event.
@@ -75,33 +117,28 @@ func (g *TraceGadget[Event]) Run() error {
 			return
 		}
 
-		switch g.commonFlags.OutputMode {
-		case commonutils.OutputModeJSON:
-			b, err := json.Marshal(event)
-			if err != nil {
-				fmt.Fprint(os.Stderr, fmt.Sprint(commonutils.WrapInErrMarshalOutput(err)))
-				return
-			}
-
-			fmt.Println(string(b))
-		case commonutils.OutputModeColumns:
-			fallthrough
-		case commonutils.OutputModeCustomColumns:
-			fmt.Println(g.parser.TransformIntoColumns(&event))
+		enricherChain.Enrich(&event)
+
+		if !g.parser.Match(&event) {
+			return
 		}
-	}
 
-	gadgetTracer, err := g.createAndRunTracer(mountnsmap, &localGadgetManager.ContainerCollection, eventCallback)
-	if err != nil {
-		return commonutils.WrapInErrGadgetTracerCreateAndRun(err)
+		if summarizer != nil {
+			summarizer.Add(&event)
+			return
+		}
+
+		if line := g.parser.TransformEntry(&event); line != "" {
+			fmt.Println(line)
+		}
 	}
-	defer gadgetTracer.Stop()
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
+	if summarizer != nil {
+		summarizer.Start()
+		defer summarizer.Stop()
+	}
 
-	return nil
+	return startAndWait(eventCallback)
 }
 
 func NewTraceCmd() *cobra.Command {
@@ -15,7 +15,6 @@
 package main
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -79,7 +78,7 @@ func newTraceloopCmd() *cobra.Command {
 				return err
 			}
 
-			if commonFlags.OutputMode != commonutils.OutputModeJSON {
+			if commonFlags.IsColumnsOutput() {
 				fmt.Println("Tracing syscalls... Hit Ctrl-C to end")
 			}
 
@@ -91,8 +90,8 @@ func newTraceloopCmd() *cobra.Command {
 			// Just to avoid mixing Ctrl^C and data.
 			fmt.Println()
 
-			if commonFlags.OutputMode != commonutils.OutputModeJSON {
-				fmt.Println(parser.BuildColumnsHeader())
+			if header := parser.BuildHeader(); header != "" {
+				fmt.Println(header)
 			}
 
 			for _, container := range containers {
@@ -102,20 +101,9 @@ func newTraceloopCmd() *cobra.Command {
 				}
 
 				for _, event := range events {
-					var line string
-
-					if commonFlags.OutputMode == commonutils.OutputModeJSON {
-						b, err := json.Marshal(event)
-						if err != nil {
-							return commonutils.WrapInErrMarshalOutput(err)
-						}
-
-						line = string(b)
-					} else {
-						line = parser.TransformIntoColumns(event)
+					if line := parser.TransformEntry(event); line != "" {
+						fmt.Println(line)
 					}
-
-					fmt.Println(line)
 				}
 			}
 
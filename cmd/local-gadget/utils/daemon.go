@@ -0,0 +1,150 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
+)
+
+// DefaultDaemonSocket is the socket where "local-gadget daemon" serves the
+// gadget service by default
+const DefaultDaemonSocket = "/run/local-gadget.socket"
+
+// DaemonConn returns a connection to the local-gadget daemon, or nil if its
+// socket doesn't exist: The gadgets are run in-process then.
+func (f *CommonFlags) DaemonConn() (*grpc.ClientConn, error) {
+	if f.DaemonSocket == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(f.DaemonSocket)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return nil, nil
+	}
+
+	// The daemon uses the container runtimes it was started with, so don't
+	// silently ignore the ones given by the user
+	if f.runtimesFlag != "" {
+		return nil, commonutils.WrapInErrInvalidArg(f.runtimesFlag,
+			fmt.Errorf("can't be used with the local-gadget daemon on %s, which uses its own container runtimes. Use --daemon-socket=\"\" to run the gadget in-process", f.DaemonSocket))
+	}
+
+	log.Infof("Running the gadget with the local-gadget daemon on %s", f.DaemonSocket)
+
+	conn, err := grpc.Dial("unix://"+f.DaemonSocket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to the local-gadget daemon on %s: %w", f.DaemonSocket, err)
+	}
+	return conn, nil
+}
+
+// RunWithDaemon runs a gadget with the local-gadget daemon on the containers
+// selected by the flags and calls callback with its events, until the user
+// interrupts it.
+func RunWithDaemon[Event any](conn *grpc.ClientConn, flags *CommonFlags, gadget string,
+	params map[string]string, callback func(*Event),
+) error {
+	selector, err := selectorToProto(flags.ContainerSelector())
+	if err != nil {
+		return err
+	}
+
+	client := pb.NewGadgetServiceClient(conn)
+
+	// Handle the signals before starting the gadget, so it's always stopped
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	run, err := client.StartGadget(context.Background(), &pb.StartGadgetRequest{
+		Gadget:     gadget,
+		Selector:   selector,
+		Parameters: params,
+		OutputMode: "Stream",
+	})
+	if err != nil {
+		return fmt.Errorf("starting gadget %q with the local-gadget daemon: %w", gadget, err)
+	}
+	defer func() {
+		if _, err := client.StopGadget(context.Background(), &pb.GadgetRunID{Id: run.Id}); err != nil {
+			log.Warnf("Failed to stop gadget %q: %s", run.Id, err)
+		}
+	}()
+
+	events, err := client.StreamGadget(ctx, &pb.StreamGadgetRequest{
+		Id:       run.Id,
+		Encoding: pb.StreamEncoding_BINARY,
+	})
+	if err != nil {
+		return fmt.Errorf("streaming gadget %q: %w", gadget, err)
+	}
+
+	for {
+		data, err := events.Recv()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("gadget %q stopped by the local-gadget daemon", gadget)
+		}
+		if err != nil {
+			return fmt.Errorf("streaming gadget %q: %w", gadget, err)
+		}
+
+		// Events not supported by the binary encoding are sent as JSON
+		var event Event
+		if data.Event != nil {
+			err = stream.DecodeEvent(data.Event, &event)
+		} else {
+			err = json.Unmarshal([]byte(data.Line), &event)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: decoding event: %s\n", err)
+			continue
+		}
+
+		callback(&event)
+	}
+}
+
+// selectorToProto converts a container selector to the one of the gadget
+// service, which doesn't support label expressions.
+func selectorToProto(selector containercollection.ContainerSelector) (*pb.ContainerSelector, error) {
+	if len(selector.LabelExpressions) != 0 {
+		return nil, errors.New("label expressions aren't supported by the local-gadget daemon")
+	}
+
+	return &pb.ContainerSelector{
+		Namespace:     selector.Namespace,
+		Podname:       selector.Podname,
+		Labels:        selector.Labels,
+		ContainerName: selector.Name,
+		Image:         selector.Image,
+	}, nil
+}
@@ -19,8 +19,11 @@ import (
 	"strings"
 
 	commonutils "github.com/inspektor-gadget/inspektor-gadget/cmd/common/utils"
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
 	containerutils "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils"
 	runtimeclient "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/runtime-client"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/enrichers"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -37,17 +40,45 @@ type CommonFlags struct {
 	// Containername allows to filter containers by name.
 	Containername string
 
+	// Image allows to filter containers by image.
+	Image string
+
 	// The name of the container runtimes to be used separated by comma.
 	Runtimes string
 
 	// RuntimeConfigs contains the list of the container runtimes to be used
 	// with their specific socket path.
 	RuntimeConfigs []*containerutils.RuntimeConfig
+
+	// DaemonSocket is the socket of the local-gadget daemon. The gadgets
+	// are run by the daemon when it exists.
+	DaemonSocket string
+
+	// Enrichers are the names of the enrichers adding data to the events
+	Enrichers []string
+
+	// runtimesFlag is the name of the flag configuring the container
+	// runtimes set by the user, if any. They can't be used with the daemon.
+	runtimesFlag string
 }
 
 func AddCommonFlags(command *cobra.Command, commonFlags *CommonFlags) {
 	command.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// Runtimes Configuration
+		for _, name := range []string{
+			"runtimes",
+			"docker-socketpath",
+			"containerd-socketpath",
+			"crio-socketpath",
+			"podman-socketpath",
+			"cri-socketpath",
+		} {
+			if cmd.Flags().Changed(name) {
+				commonFlags.runtimesFlag = "--" + name
+				break
+			}
+		}
+
 		parts := strings.Split(commonFlags.Runtimes, ",")
 
 	partsLoop:
@@ -62,6 +93,14 @@ func AddCommonFlags(command *cobra.Command, commonFlags *CommonFlags) {
 				socketPath = commonFlags.RuntimesSocketPathConfig.Containerd
 			case runtimeclient.CrioName:
 				socketPath = commonFlags.RuntimesSocketPathConfig.Crio
+			case runtimeclient.PodmanName:
+				socketPath = commonFlags.RuntimesSocketPathConfig.Podman
+			case runtimeclient.CRIName:
+				socketPath = commonFlags.RuntimesSocketPathConfig.CRI
+				if socketPath == "" {
+					return commonutils.WrapInErrInvalidArg("--cri-socketpath",
+						fmt.Errorf("the socket path is required by the %q runtime", runtimeclient.CRIName))
+				}
 			default:
 				return commonutils.WrapInErrInvalidArg("--runtime / -r",
 					fmt.Errorf("runtime %q is not supported", p))
@@ -81,6 +120,14 @@ func AddCommonFlags(command *cobra.Command, commonFlags *CommonFlags) {
 			})
 		}
 
+		// Container name and image
+		if err := containercollection.ValidateNameFilter(commonFlags.Containername); err != nil {
+			return commonutils.WrapInErrInvalidArg("--containername / -c", err)
+		}
+		if err := containercollection.ValidateNameFilter(commonFlags.Image); err != nil {
+			return commonutils.WrapInErrInvalidArg("--image", err)
+		}
+
 		// Output Mode
 		if err := commonFlags.ParseOutputConfig(); err != nil {
 			return err
@@ -100,14 +147,60 @@ func AddCommonFlags(command *cobra.Command, commonFlags *CommonFlags) {
 		"containername",
 		"c",
 		"",
-		"Show only data from containers with that name",
+		"Show only data from containers with that name. Accepts a comma-separated list of names, globs (web-*) and regular expressions (/^web-[0-9]+$/), prefixed by '!' to exclude them",
+	)
+
+	command.PersistentFlags().StringVar(
+		&commonFlags.Image,
+		"image",
+		"",
+		"Show only data from containers running that image, e.g. nginx, nginx:1.23, docker.io/library/nginx:1.23 or sha256:<digest>. Accepts the same patterns as --containername",
 	)
 
 	command.PersistentFlags().StringVarP(
 		&commonFlags.Runtimes,
 		"runtimes", "r",
-		strings.Join(containerutils.AvailableRuntimes, ","),
+		strings.Join(containerutils.DefaultRuntimes, ","),
 		fmt.Sprintf("Container runtimes to be used separated by comma. Supported values are: %s",
 			strings.Join(containerutils.AvailableRuntimes, ", ")),
 	)
+
+	command.PersistentFlags().StringVar(
+		&commonFlags.DaemonSocket,
+		"daemon-socket",
+		DefaultDaemonSocket,
+		"Socket of the local-gadget daemon. When it exists, the gadgets are run by the daemon, with its container runtimes. Set it to an empty string to always run them in-process",
+	)
+}
+
+// ContainerSelector returns the selector of the containers given by the flags
+func (f *CommonFlags) ContainerSelector() containercollection.ContainerSelector {
+	// TODO: Improve filtering, see further details in
+	// https://github.com/inspektor-gadget/inspektor-gadget/issues/644.
+	return containercollection.ContainerSelector{
+		Name:  f.Containername,
+		Image: f.Image,
+	}
+}
+
+// AddEnrichFlag adds the --enrich flag to the gadgets whose events can be
+// enriched, i.e. the trace and audit ones.
+func AddEnrichFlag(command *cobra.Command, commonFlags *CommonFlags) {
+	command.PersistentFlags().StringSliceVar(
+		&commonFlags.Enrichers,
+		"enrich",
+		[]string{},
+		"Comma-separated list of enrichers adding data to the events: user, process or ancestors. Use -o custom-columns=... or -o json to show the data",
+	)
+}
+
+// NewEnricherChain returns the chain of the enrichers given by --enrich. The
+// endpoints enricher isn't available as there is no Kubernetes API to get the
+// pods and services from.
+func (f *CommonFlags) NewEnricherChain() (*gadgets.EnricherChain, error) {
+	chain, err := enrichers.NewRegistry().NewChain(f.Enrichers)
+	if err != nil {
+		return nil, commonutils.WrapInErrInvalidArg("--enrich", err)
+	}
+	return chain, nil
 }
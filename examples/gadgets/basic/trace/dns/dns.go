@@ -19,6 +19,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/cilium/ebpf/rlimit"
 
@@ -43,10 +44,15 @@ func main() {
 		}
 		fmt.Printf("A new %q dns %s about %s was observed\n",
 			event.QType, qr, event.DNSName)
+		if event.Latency != 0 {
+			fmt.Printf("  it took %s and returned %v\n", event.Latency, event.Addresses)
+		}
 	}
 
-	// Create tracer. In this case no parameters are passed.
-	tracer, err := tracer.NewTracer()
+	// Create tracer. Queries without response are reported after 5 seconds.
+	tracer, err := tracer.NewTracer(&tracer.Config{
+		DNSTimeout: 5 * time.Second,
+	})
 	if err != nil {
 		fmt.Printf("error creating tracer: %s\n", err)
 		return
@@ -162,9 +162,16 @@ else
   fi
 fi
 
+METRICS_FLAGS=""
+if [ -n "$INSPEKTOR_GADGET_OPTION_METRICS_CONFIG" ] ; then
+  echo "Metrics config: $INSPEKTOR_GADGET_OPTION_METRICS_CONFIG"
+  METRICS_FLAGS="-metrics-config=$INSPEKTOR_GADGET_OPTION_METRICS_CONFIG -metrics-address=:$INSPEKTOR_GADGET_OPTION_METRICS_PORT"
+fi
+
 echo "Starting the Gadget Tracer Manager..."
 # change directory before running gadgettracermanager
 cd /
 rm -f /run/gadgettracermanager.socket
 exec /bin/gadgettracermanager -serve -hook-mode=$GADGET_TRACER_MANAGER_HOOK_MODE \
-    -controller -fallback-podinformer=$INSPEKTOR_GADGET_OPTION_FALLBACK_POD_INFORMER
+    -controller -fallback-podinformer=$INSPEKTOR_GADGET_OPTION_FALLBACK_POD_INFORMER \
+    $METRICS_FLAGS
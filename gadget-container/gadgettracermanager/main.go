@@ -36,8 +36,13 @@ import (
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
+	gadgetcollection "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgetservice"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager"
 	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	gadgetstream "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics/exporter"
 )
 
 var (
@@ -51,11 +56,16 @@ var (
 	method              string
 	label               string
 	tracerid            string
+	encoding            string
+	resumeAfter         uint64
 	containerID         string
 	namespace           string
 	podname             string
 	containername       string
+	image               string
 	containerPid        uint
+	metricsConfig       string
+	metricsAddress      string
 )
 
 var clientTimeout = 2 * time.Second
@@ -67,18 +77,24 @@ func init() {
 	flag.BoolVar(&serve, "serve", false, "Start server")
 	flag.BoolVar(&controller, "controller", false, "Enable the controller for custom resources")
 
-	flag.StringVar(&method, "call", "", "Call a method (add-tracer, remove-tracer, receive-stream, add-container, remove-container)")
+	flag.StringVar(&method, "call", "", "Call a method (add-tracer, remove-tracer, receive-stream, add-container, remove-container, container-history, list-gadgets)")
 	flag.StringVar(&label, "label", "", "key=value,key=value labels to use in add-tracer")
 	flag.StringVar(&tracerid, "tracerid", "", "tracerid to use in receive-stream")
+	flag.StringVar(&encoding, "encoding", "json", "encoding of the events printed by receive-stream: json (one line per event) or binary (size-prefixed StreamData messages)")
+	flag.Uint64Var(&resumeAfter, "resume-after", 0, "sequence number of the last event received, to resume a receive-stream")
 	flag.StringVar(&containerID, "containerid", "", "container id to use in add-container or remove-container")
-	flag.StringVar(&namespace, "namespace", "", "namespace to use in add-container")
-	flag.StringVar(&podname, "podname", "", "podname to use in add-container")
-	flag.StringVar(&containername, "containername", "", "container name to use in add-container")
+	flag.StringVar(&namespace, "namespace", "", "namespace to use in add-container or container-history")
+	flag.StringVar(&podname, "podname", "", "podname to use in add-container or container-history")
+	flag.StringVar(&containername, "containername", "", "container name to use in add-container or container-history")
+	flag.StringVar(&image, "image", "", "image to use in container-history")
 	flag.UintVar(&containerPid, "containerpid", 0, "container PID to use in add-container")
 
 	flag.BoolVar(&dump, "dump", false, "Dump state for debugging")
 	flag.BoolVar(&liveness, "liveness", false, "Execute as client and perform liveness probe")
 	flag.BoolVar(&fallbackPodInformer, "fallback-podinformer", true, "Use pod informer as a fallback for main hook")
+
+	flag.StringVar(&metricsConfig, "metrics-config", "", "Run the gadgets described in this file and expose their metrics (only with -serve)")
+	flag.StringVar(&metricsAddress, "metrics-address", exporter.DefaultAddress, "Address to expose the metrics on")
 }
 
 func main() {
@@ -135,21 +151,47 @@ func main() {
 		// break
 
 	case "receive-stream":
+		var streamEncoding pb.StreamEncoding
+		switch encoding {
+		case "json":
+			streamEncoding = pb.StreamEncoding_JSON
+		case "binary":
+			streamEncoding = pb.StreamEncoding_BINARY
+		default:
+			log.Fatalf("invalid encoding %q: must be json or binary", encoding)
+		}
+
 		stream, err := client.ReceiveStream(context.Background(), &pb.TracerID{
-			Id: tracerid,
+			Id:          tracerid,
+			Encoding:    streamEncoding,
+			ResumeAfter: resumeAfter,
 		})
 		if err != nil {
 			log.Fatalf("%v", err)
 		}
+
+		var buf []byte
 		for {
-			line, err := stream.Recv()
+			data, err := stream.Recv()
 			if errors.Is(err, io.EOF) {
 				break
 			}
 			if err != nil {
 				log.Fatalf("%v.ReceiveStream(_) = _, %v", client, err)
 			}
-			fmt.Println(line.Line)
+
+			if streamEncoding == pb.StreamEncoding_JSON {
+				fmt.Println(data.Line)
+				continue
+			}
+
+			buf, err = gadgetstream.AppendStreamData(buf[:0], data)
+			if err != nil {
+				log.Fatalf("encoding stream data: %v", err)
+			}
+			if _, err := os.Stdout.Write(buf); err != nil {
+				log.Fatalf("writing stream data: %v", err)
+			}
 		}
 
 		os.Exit(0)
@@ -178,6 +220,35 @@ func main() {
 		}
 		os.Exit(0)
 
+	case "container-history":
+		history, err := client.GetContainerHistory(ctx, &pb.ContainerHistoryRequest{
+			Namespace: namespace,
+			Podname:   podname,
+			Name:      containername,
+			Image:     image,
+		})
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		for _, e := range history.Events {
+			fmt.Printf("%s %s %s %s/%s/%s %s\n", e.Timestamp, e.Type, e.Id,
+				e.Namespace, e.Podname, e.Name, e.Reason)
+		}
+		os.Exit(0)
+
+	case "list-gadgets":
+		list, err := pb.NewGadgetServiceClient(conn).ListGadgets(ctx, &pb.ListGadgetsRequest{})
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		for _, g := range list.Gadgets {
+			fmt.Printf("%s %s\n", g.Name, strings.Join(g.OutputModes, ","))
+			for _, p := range g.Parameters {
+				fmt.Printf("  %s (default %q): %s\n", p.Name, p.DefaultValue, p.Doc)
+			}
+		}
+		os.Exit(0)
+
 	default:
 		fmt.Printf("invalid method %q\n", method)
 		flag.PrintDefaults()
@@ -244,6 +315,9 @@ func main() {
 
 		pb.RegisterGadgetTracerManagerServer(grpcServer, tracerManager)
 
+		gadgetService := gadgetservice.New(tracerManager, gadgetcollection.TraceFactories(), node)
+		pb.RegisterGadgetServiceServer(grpcServer, gadgetService)
+
 		healthserver := health.NewServer()
 		healthpb.RegisterHealthServer(grpcServer, healthserver)
 
@@ -254,10 +328,53 @@ func main() {
 			go startController(node, tracerManager)
 		}
 
+		var metricsExporter *exporter.Exporter
+		if metricsConfig != "" {
+			metricsExporter, err = startMetricsExporter(node, tracerManager)
+			if err != nil {
+				log.Fatalf("failed to start metrics exporter: %v", err)
+			}
+		}
+
 		exitSignal := make(chan os.Signal, 1)
 		signal.Notify(exitSignal, syscall.SIGINT, syscall.SIGTERM)
 		<-exitSignal
 
+		if metricsExporter != nil {
+			metricsExporter.Stop()
+		}
+		gadgetService.Close()
 		tracerManager.Close()
 	}
 }
+
+func startMetricsExporter(node string, tracerManager *gadgettracermanager.GadgetTracerManager) (*exporter.Exporter, error) {
+	config, err := metrics.ReadConfig(metricsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsExporter, err := exporter.NewExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	lis, err := net.Listen("tcp", metricsAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := metricsExporter.Start(tracerManager, node); err != nil {
+		lis.Close()
+		return nil, err
+	}
+
+	log.Printf("Serving metrics on %s/metrics", metricsAddress)
+	go func() {
+		if err := metricsExporter.Serve(lis); err != nil {
+			log.Errorf("serving metrics: %v", err)
+		}
+	}()
+
+	return metricsExporter, nil
+}
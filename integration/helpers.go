@@ -24,9 +24,54 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
 
+// NormalizeImageName returns the short name of an image, as the runtimes
+// report the same image in different ways, e.g. "busybox",
+// "docker.io/library/busybox:latest" or "busybox@sha256:...".
+func NormalizeImageName(image string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "library/")
+	return strings.TrimSuffix(image, ":latest")
+}
+
+// normalizeImage normalizes the image fields of the entries, if any. The
+// digest depends on the image pulled by the cluster and is cleared. The image
+// of containers is reduced to its short name, so the tests listing containers
+// verify it with each runtime, and the one of events is cleared.
+func normalizeImage[T any](entry *T) {
+	switch e := any(entry).(type) {
+	case *containercollection.Container:
+		normalizeContainerImage(e)
+		return
+	case *containercollection.PubSubEvent:
+		if e.Container != nil {
+			normalizeContainerImage(e.Container)
+		}
+		return
+	}
+
+	v := reflect.ValueOf(entry).Elem()
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for _, name := range []string{"Image", "ImageDigest"} {
+		if f := v.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.CanSet() {
+			f.SetString("")
+		}
+	}
+}
+
+func normalizeContainerImage(c *containercollection.Container) {
+	c.Image = NormalizeImageName(c.Image)
+	c.ImageDigest = ""
+}
+
 func parseMultiJSONOutput[T any](output string, normalize func(*T)) ([]*T, error) {
 	ret := []*T{}
 
@@ -39,6 +84,7 @@ func parseMultiJSONOutput[T any](output string, normalize func(*T)) ([]*T, error
 		// To be able to use reflect.DeepEqual and cmp.Diff, we need to
 		// "normalize" the output so that it only includes non-default values
 		// for the fields we are able to verify.
+		normalizeImage(&entry)
 		if normalize != nil {
 			normalize(&entry)
 		}
@@ -60,6 +106,7 @@ func parseJSONArrayOutput[T any](output string, normalize func(*T)) ([]*T, error
 		// To be able to use reflect.DeepEqual and cmp.Diff, we need to
 		// "normalize" the output so that it only includes non-default values
 		// for the fields we are able to verify.
+		normalizeImage(entry)
 		if normalize != nil {
 			normalize(entry)
 		}
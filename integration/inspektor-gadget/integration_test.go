@@ -373,6 +373,7 @@ func TestBindsnoop(t *testing.T) {
 
 			normalize := func(e *bindTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.MountNsID = 0
 			}
@@ -488,6 +489,7 @@ func TestCapabilities(t *testing.T) {
 
 			normalize := func(e *capabilitiesTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.UID = 0
 				e.MountNsID = 0
@@ -568,7 +570,15 @@ func TestDns(t *testing.T) {
 
 			normalize := func(e *dnsTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.ID = "0000"
+				e.SrcIP = ""
+				e.DstIP = ""
+				e.SrcPort = 0
+				e.DstPort = 0
+				e.Latency = 0
+				e.NumAnswers = 0
+				e.Addresses = nil
 			}
 
 			return ExpectEntriesToMatch(output, normalize, expectedEntries...)
@@ -677,6 +687,7 @@ func TestExecsnoop(t *testing.T) {
 
 			normalize := func(e *execTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.Ppid = 0
 				e.UID = 0
@@ -766,6 +777,7 @@ func TestFsslower(t *testing.T) {
 
 			normalize := func(e *fsslowerType.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.MountNsID = 0
 				e.Pid = 0
 				e.Bytes = 0
@@ -809,6 +821,7 @@ func TestMountsnoop(t *testing.T) {
 
 			normalize := func(e *mountTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.Tid = 0
 				e.MountNsID = 0
@@ -969,6 +982,7 @@ func TestOomkill(t *testing.T) {
 
 			normalize := func(e *oomkillTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.KilledPid = 0
 				e.Pages = 0
 				e.TriggeredPid = 0
@@ -1036,6 +1050,7 @@ func TestOpensnoop(t *testing.T) {
 
 			normalize := func(e *openTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.MountNsID = 0
 				e.Pid = 0
 				e.UID = 0
@@ -1093,6 +1108,7 @@ func TestNetworkGraph(t *testing.T) {
 
 			normalize := func(e *networkTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.PodHostIP = ""
 			}
 
@@ -1218,6 +1234,7 @@ func TestSigsnoop(t *testing.T) {
 
 			normalize := func(e *signalTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.TargetPid = 0
 				e.Retval = 0
@@ -1259,6 +1276,7 @@ func TestSnisnoop(t *testing.T) {
 
 			normalize := func(e *sniTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 			}
 
 			return ExpectAllToMatch(output, normalize, expectedEntry)
@@ -1357,6 +1375,7 @@ func TestTcpconnect(t *testing.T) {
 
 			normalize := func(e *tcpconnectTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.MountNsID = 0
 			}
@@ -1408,6 +1427,7 @@ func TestTcptracer(t *testing.T) {
 
 			normalize := func(e *tcpTypes.Event) {
 				e.Node = ""
+				e.Timestamp = 0
 				e.Pid = 0
 				e.Sport = 0
 				e.MountNsID = 0
@@ -35,6 +35,7 @@ func TestListContainers(t *testing.T) {
 				Podname:   "test-pod",
 				Runtime:   *containerRuntime,
 				Namespace: ns,
+				Image:     "busybox",
 			}
 
 			normalize := func(c *containercollection.Container) {
@@ -93,6 +94,7 @@ func TestFilterByContainerName(t *testing.T) {
 				Podname:   cn,
 				Runtime:   *containerRuntime,
 				Namespace: ns,
+				Image:     "busybox",
 			}
 
 			normalize := func(c *containercollection.Container) {
@@ -148,6 +150,7 @@ func TestWatchCreatedContainers(t *testing.T) {
 					Podname:   cn,
 					Runtime:   *containerRuntime,
 					Namespace: ns,
+					Image:     "busybox",
 				},
 			}
 
@@ -206,6 +209,7 @@ func TestWatchDeletedContainers(t *testing.T) {
 					Podname:   cn,
 					Runtime:   *containerRuntime,
 					Namespace: ns,
+					Image:     "busybox",
 				},
 			}
 
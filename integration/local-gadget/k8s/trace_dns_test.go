@@ -87,6 +87,15 @@ func TestTraceDns(t *testing.T) {
 			}
 
 			normalize := func(e *dnsTypes.Event) {
+				e.Timestamp = 0
+				e.SrcIP = ""
+				e.DstIP = ""
+				e.SrcPort = 0
+				e.DstPort = 0
+				e.Latency = 0
+				e.NumAnswers = 0
+				e.Addresses = nil
+
 				// TODO: Handle it once we support getting K8s container name for docker
 				// Issue: https://github.com/inspektor-gadget/inspektor-gadget/issues/737
 				if *containerRuntime == ContainerRuntimeDocker {
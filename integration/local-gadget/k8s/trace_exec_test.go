@@ -55,6 +55,8 @@ func TestTraceExec(t *testing.T) {
 			}
 
 			normalize := func(e *execTypes.Event) {
+				e.Timestamp = 0
+
 				// TODO: Handle it once we support getting K8s container name for docker
 				// Issue: https://github.com/inspektor-gadget/inspektor-gadget/issues/737
 				if *containerRuntime == ContainerRuntimeDocker {
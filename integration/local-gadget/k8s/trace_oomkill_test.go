@@ -38,6 +38,8 @@ func TestTraceOOMKill(t *testing.T) {
 			expectedEntry.Container = "test-pod-container"
 
 			normalize := func(e *oomkillTypes.Event) {
+				e.Timestamp = 0
+
 				// TODO: Handle it once we support getting K8s container name for docker
 				// Issue: https://github.com/inspektor-gadget/inspektor-gadget/issues/737
 				if *containerRuntime == ContainerRuntimeDocker {
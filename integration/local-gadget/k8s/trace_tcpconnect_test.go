@@ -52,6 +52,8 @@ func TestTraceTcpconnect(t *testing.T) {
 			}
 
 			normalize := func(e *tcpconnectTypes.Event) {
+				e.Timestamp = 0
+
 				// TODO: Handle it once we support getting K8s container name for docker
 				// Issue: https://github.com/inspektor-gadget/inspektor-gadget/issues/737
 				if *containerRuntime == ContainerRuntimeDocker {
@@ -35,6 +35,7 @@ func TestFilterByContainerName(t *testing.T) {
 				Name:      cn,
 				Namespace: "default",
 				Runtime:   "docker",
+				Image:     "busybox",
 			}
 
 			normalize := func(c *containercollection.Container) {
@@ -86,6 +87,7 @@ func TestWatchContainers(t *testing.T) {
 						Podname:   cn,
 						Runtime:   "docker",
 						Namespace: "default",
+						Image:     "busybox",
 					},
 				},
 				{
@@ -95,6 +97,7 @@ func TestWatchContainers(t *testing.T) {
 						Podname:   cn,
 						Runtime:   "docker",
 						Namespace: "default",
+						Image:     "busybox",
 					},
 				},
 			}
@@ -38,6 +38,9 @@ const (
 	// OperationDelete indicates we want to delete a resource which is owned by a
 	// trace. At the moment, this is only used by traceloop.
 	OperationDelete Operation = "delete"
+	// OperationMerge indicates to merge the outputs generated for several
+	// containers, e.g. seccomp profiles
+	OperationMerge Operation = "merge"
 )
 
 // RunMode defines running mode for the Trace
@@ -54,7 +57,7 @@ const (
 )
 
 // TraceOutputMode defines output mode for the Trace
-// +kubebuilder:validation:Enum=Status;Stream;File;ExternalResource
+// +kubebuilder:validation:Enum=Status;Stream;File;ExternalResource;OTLP
 type TraceOutputMode string
 
 const (
@@ -66,21 +69,53 @@ const (
 	TraceOutputModeFile TraceOutputMode = "File"
 	// TraceOutputModeExternalResource indicates to create an external resource, as a seccomp profile
 	TraceOutputModeExternalResource TraceOutputMode = "ExternalResource"
+	// TraceOutputModeOTLP indicates to export events as OpenTelemetry logs to an OTLP collector
+	TraceOutputModeOTLP TraceOutputMode = "OTLP"
+)
+
+// FilterMode defines how the gadget selects the events of the containers
+// matching the filter of the Trace
+// +kubebuilder:validation:Enum=MountNamespace;CgroupID
+type FilterMode string
+
+const (
+	// FilterModeMountNamespace selects the events of the processes in the
+	// mount namespaces of the containers
+	FilterModeMountNamespace FilterMode = "MountNamespace"
+	// FilterModeCgroupID selects the events of the processes in the
+	// cgroups of the containers. It works for processes that unshare their
+	// mount namespace and for containers sharing the one of the host.
+	FilterModeCgroupID FilterMode = "CgroupID"
 )
 
 // ContainerFilter filters events based on different criteria
+//
+// Namespace, Podname, ContainerName and Image are comma-separated lists of
+// names, glob patterns such as "web-*" or regular expressions between slashes
+// such as "/^web-[0-9]+$/". Items prefixed by "!" exclude what they match,
+// e.g. "*,!ingress-controller-*".
 type ContainerFilter struct {
-	// Namespace selects events from this pod namespace
+	// Namespace selects events from these pod namespaces
 	Namespace string `json:"namespace,omitempty"`
 
-	// Podname selects events from this pod name
+	// Podname selects events from these pod names
 	Podname string `json:"podname,omitempty"`
 
 	// Labels selects events from pods with these labels
 	Labels map[string]string `json:"labels,omitempty"`
 
-	// ContainerName selects events from containers with this name
+	// LabelExpressions selects events from pods whose labels match all
+	// these requirements, e.g. with the In, NotIn, Exists or DoesNotExist
+	// operators
+	LabelExpressions []metav1.LabelSelectorRequirement `json:"labelExpressions,omitempty"`
+
+	// ContainerName selects events from containers with these names
 	ContainerName string `json:"containerName,omitempty"`
+
+	// Image selects events from containers running these images. Images
+	// can be given with or without registry and tag, e.g. "nginx" or
+	// "docker.io/library/nginx:1.23", or by digest, e.g. "sha256:5f3d..."
+	Image string `json:"image,omitempty"`
 }
 
 // TraceSpec defines the desired state of Trace
@@ -103,7 +138,12 @@ type TraceSpec struct {
 	// pod name, labels or container name
 	Filter *ContainerFilter `json:"filter,omitempty"`
 
-	// OutputMode is "Status", "Stream", "File" or "ExternalResource"
+	// FilterMode is "MountNamespace" (default) to select the events of
+	// the containers by their mount namespace or "CgroupID" to select
+	// them by their cgroup
+	FilterMode FilterMode `json:"filterMode,omitempty"`
+
+	// OutputMode is "Status", "Stream", "File", "ExternalResource" or "OTLP"
 	OutputMode TraceOutputMode `json:"outputMode,omitempty"`
 
 	// Output allows a gadget to output the results in the specified
@@ -114,8 +154,15 @@ type TraceSpec struct {
 	//   resource (such as
 	//   seccompprofiles.security-profiles-operator.x-k8s.io for the
 	//   seccomp gadget)
+	// * With OutputMode=OTLP, Output specifies the endpoint of the OTLP
+	//   collector (such as grpc://otel-collector:4317 or
+	//   http://otel-collector:4318)
 	Output string `json:"output,omitempty"`
 
+	// Enrichers are the names of the enrichers adding data to the events
+	// of the gadget: "user", "process", "ancestors" or "endpoints"
+	Enrichers []string `json:"enrichers,omitempty"`
+
 	// TODO: Ideally it should be a map[string]interface{} but it's not
 	// supported: https://github.com/kubernetes-sigs/controller-tools/issues/636
 
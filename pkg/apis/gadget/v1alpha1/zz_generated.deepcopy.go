@@ -20,6 +20,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -33,6 +34,13 @@ func (in *ContainerFilter) DeepCopyInto(out *ContainerFilter) {
 			(*out)[key] = val
 		}
 	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFilter.
@@ -112,6 +120,11 @@ func (in *TraceSpec) DeepCopyInto(out *TraceSpec) {
 		*out = new(ContainerFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Enrichers != nil {
+		in, out := &in.Enrichers, &out.Enrichers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make(map[string]string, len(*in))
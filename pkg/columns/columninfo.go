@@ -35,6 +35,8 @@ const (
 	MaxCharsBool   = 5  // false
 )
 
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
 type subField struct {
 	index int
 	isPtr bool
@@ -55,6 +57,7 @@ type Column[T any] struct {
 	Description  string                // Description can hold a short description of the field that can be used to aid the user
 	Order        int                   // Order defines the default order in which columns are shown
 	Tags         []string              // Tags can be used to dynamically include or exclude columns
+	Unit         string                // Unit of the values of the column, e.g. "ns" or "bytes"; informational only
 
 	offset        uintptr
 	fieldIndex    int          // used for the main struct
@@ -160,15 +163,14 @@ func (ci *Column[T]) parseTagInfo(tagInfo []string) error {
 			if paramsLen == 1 {
 				return fmt.Errorf("missing group value for field %q", ci.Name)
 			}
-			switch params[1] {
-			case "sum":
-				if !ci.columnType.ConvertibleTo(reflect.TypeOf(int(0))) {
-					return fmt.Errorf("cannot use sum on field %q of kind %q", ci.Name, ci.kind.String())
-				}
-				ci.GroupType = GroupTypeSum
-			default:
+			groupType, err := ParseGroupType(params[1])
+			if err != nil {
 				return fmt.Errorf("invalid group value %q for field %q", params[1], ci.Name)
 			}
+			if err := ci.CheckGroupType(groupType); err != nil {
+				return err
+			}
+			ci.GroupType = groupType
 		case "hide":
 			if paramsLen != 1 {
 				return fmt.Errorf("parameter hide on field %q must not have a value", ci.Name)
@@ -202,6 +204,11 @@ func (ci *Column[T]) parseTagInfo(tagInfo []string) error {
 				return fmt.Errorf("negative precision value %q for field %q", params[1], ci.Name)
 			}
 			ci.Precision = w
+		case "unit":
+			if paramsLen == 1 || params[1] == "" {
+				return fmt.Errorf("missing unit value for field %q", ci.Name)
+			}
+			ci.Unit = params[1]
 		case "width":
 			ci.Width, err = ci.getWidth(params)
 			if err != nil {
@@ -217,6 +224,16 @@ func (ci *Column[T]) parseTagInfo(tagInfo []string) error {
 			if err != nil {
 				return err
 			}
+		case "stringer":
+			if paramsLen != 1 {
+				return fmt.Errorf("parameter stringer on field %q must not have a value", ci.Name)
+			}
+			if !ci.columnType.Implements(stringerType) {
+				return fmt.Errorf("parameter stringer on field %q is only valid for types implementing fmt.Stringer", ci.Name)
+			}
+			ci.Extractor = func(entry *T) string {
+				return ci.getRawField(reflect.ValueOf(entry)).Interface().(fmt.Stringer).String()
+			}
 		case "template":
 			ci.useTemplate = true
 			if paramsLen < 2 || params[1] == "" {
@@ -331,6 +348,24 @@ func (ci *Column[T]) IsVirtual() bool {
 	return ci.fieldIndex == virtualIndex
 }
 
+// CheckGroupType returns an error if the column can't be aggregated using the given group type. Numbers can be
+// aggregated using any of them, while strings can only be counted.
+func (ci *Column[T]) CheckGroupType(groupType GroupType) error {
+	if groupType == GroupTypeNone {
+		return nil
+	}
+	if ci.IsVirtual() {
+		return fmt.Errorf("cannot use %s on virtual field %q", groupType, ci.Name)
+	}
+	if groupType.IsCount() && ci.kind == reflect.String {
+		return nil
+	}
+	if !ci.columnType.ConvertibleTo(reflect.TypeOf(int(0))) {
+		return fmt.Errorf("cannot use %s on field %q of kind %q", groupType, ci.Name, ci.kind.String())
+	}
+	return nil
+}
+
 // HasCustomExtractor returns true, if the column has a user defined extractor set
 func (ci *Column[T]) HasCustomExtractor() bool {
 	return ci.Extractor != nil
@@ -15,6 +15,7 @@
 package columns
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -151,6 +152,27 @@ func TestColumnsGroup(t *testing.T) {
 	expectColumnValue(t, expectColumn(t, cols, "float32"), "GroupType", GroupTypeSum)
 	expectColumnValue(t, expectColumn(t, cols, "float64"), "GroupType", GroupTypeSum)
 
+	type testSuccess2 struct {
+		FieldCount    uint64  `column:"count,group:count"`
+		FieldMin      int64   `column:"min,group:min"`
+		FieldMax      int64   `column:"max,group:max"`
+		FieldAvg      float64 `column:"avg,group:avg"`
+		FieldP50      uint32  `column:"p50,group:p50"`
+		FieldP95      uint32  `column:"p95,group:p95"`
+		FieldP99      uint32  `column:"p99,group:p99"`
+		FieldDistinct string  `column:"distinct,group:distinct"`
+	}
+
+	cols2 := expectColumnsSuccess[testSuccess2](t)
+	expectColumnValue(t, expectColumn(t, cols2, "count"), "GroupType", GroupTypeCount)
+	expectColumnValue(t, expectColumn(t, cols2, "min"), "GroupType", GroupTypeMin)
+	expectColumnValue(t, expectColumn(t, cols2, "max"), "GroupType", GroupTypeMax)
+	expectColumnValue(t, expectColumn(t, cols2, "avg"), "GroupType", GroupTypeAvg)
+	expectColumnValue(t, expectColumn(t, cols2, "p50"), "GroupType", GroupTypeP50)
+	expectColumnValue(t, expectColumn(t, cols2, "p95"), "GroupType", GroupTypeP95)
+	expectColumnValue(t, expectColumn(t, cols2, "p99"), "GroupType", GroupTypeP99)
+	expectColumnValue(t, expectColumn(t, cols2, "distinct"), "GroupType", GroupTypeDistinct)
+
 	expectColumnsFail[struct {
 		Field int64 `column:"fail,group"`
 	}](t, "missing parameter")
@@ -166,6 +188,9 @@ func TestColumnsGroup(t *testing.T) {
 	expectColumnsFail[struct {
 		Field string `column:"fail,group:sum"`
 	}](t, "wrong type")
+	expectColumnsFail[struct {
+		Field string `column:"fail,group:p99"`
+	}](t, "wrong type for percentile")
 }
 
 func TestColumnsHide(t *testing.T) {
@@ -242,6 +267,54 @@ func TestColumnsPrecision(t *testing.T) {
 	}](t, "invalid field")
 }
 
+type testStringer int64
+
+func (ts testStringer) String() string {
+	return fmt.Sprintf("value-%d", ts)
+}
+
+func TestColumnsStringer(t *testing.T) {
+	type testSuccess1 struct {
+		Field testStringer `column:"field,stringer"`
+	}
+
+	cols := expectColumnsSuccess[testSuccess1](t)
+	col := expectColumn(t, cols, "field")
+	if col.Kind() != reflect.Int64 {
+		t.Errorf("Expected stringer column to keep kind %q, got %q", reflect.Int64, col.Kind())
+	}
+
+	if !col.HasCustomExtractor() {
+		t.Fatalf("Expected stringer column to have an extractor")
+	}
+	if val := col.Get(&testSuccess1{Field: 42}).String(); val != "value-42" {
+		t.Errorf("Expected stringer column to return %q, got %q", "value-42", val)
+	}
+
+	expectColumnsFail[struct {
+		Field int64 `column:"fail,stringer"`
+	}](t, "type without String()")
+	expectColumnsFail[struct {
+		Field testStringer `column:"fail,stringer:foo"`
+	}](t, "invalid parameter")
+}
+
+func TestColumnsUnit(t *testing.T) {
+	type testSuccess1 struct {
+		Field uint64 `column:"field,unit:ns"`
+	}
+
+	cols := expectColumnsSuccess[testSuccess1](t)
+	expectColumnValue(t, expectColumn(t, cols, "field"), "Unit", "ns")
+
+	expectColumnsFail[struct {
+		Field uint64 `column:"fail,unit"`
+	}](t, "missing unit")
+	expectColumnsFail[struct {
+		Field uint64 `column:"fail,unit:"`
+	}](t, "empty unit")
+}
+
 func TestColumnsWidth(t *testing.T) {
 	type testSuccess1 struct {
 		FieldWidth     int64 `column:"int,width:4"`
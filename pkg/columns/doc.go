@@ -55,9 +55,12 @@ The parameter could be used for specific options, passing nil will use the defau
 	| align     | left,right             | defines the alignment of the column (whitespace before or after the value)                                           |
 	| ellipsis  | none,left,right,middle | defines how situations of content exceeding the given space should be handled, eg: where to place the ellipsis ("…") |
 	| fixed     | none                   | defines that this column will have a fixed width, even when auto-scaling is enabled                                  |
-	| group     | sum                    | defines what should happen with the field whenever entries are grouped (see grouping)                                |
+	| group     | sum,count,min,max,avg, | defines what should happen with the field whenever entries are grouped (see grouping)                                |
+	|           | p50,p95,p99,distinct   |                                                                                                                      |
 	| hide      | none                   | specifies that this column is not to be considered by default (see custom columns)                                   |
 	| precision | int                    | specifies the precision of floats (number of decimals)                                                               |
+	| stringer  | none                   | uses the String() method of the field's type to print its value; sorting and filtering still use the raw value       |
+	| unit      | string                 | unit of the values (e.g. ns, bytes); it isn't used when printing columns, but it's exported along with the schema    |
 	| width     | int                    | defines the space allocated for the column                                                                           |
 
 # Virtual Columns or Custom Extractors
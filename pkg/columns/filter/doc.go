@@ -36,13 +36,28 @@ Additional rule options for integers, floats and strings are `>`, `>=`, `<` and
 
 	filter.FilterEntries(columnMap, events, []string{"pid:>=55"})
 
+# Expressions
+
+More complex filters can be written as an expression combining comparisons with "and", "or" and "not" (or "&&", "||"
+and "!"), using parentheses to group them:
+
+	filter.GetFilterFromExpression(columnMap, `pod~"^api" and (dport==443 or dport==8443) and not comm in ("curl","wget")`)
+
+The comparison operators are `==` (or `=`), `!=`, `<`, `<=`, `>`, `>=`, `~` and `!~`, the last two taking a regular
+expression. `column in (value1, value2, ...)` matches any of the given values and can be negated with `not in`.
+Values can be written without quotes unless they contain spaces, operators, parentheses or commas. Double quoted values
+support Go escape sequences, single quoted values are taken literally, which comes in handy for regular expressions.
+
+Keywords and column names are case-insensitive. The expression is checked against the columns when creating the filter,
+so unknown columns or values that can't be compared with the kind of their column are reported as errors at that time.
+
 # Optimizing / Streaming
 
 If you have to filter a stream of incoming events, you can use
 
 	myFilter := filter.GetFilterFromString(columnMap, filter)
 
-to get a filter with a .Match(entry) function that you can use to match against entries.
+or GetFilterFromExpression() to get a filter with a .Match(entry) function that you can use to match against entries.
 
 # Filter examples
 
@@ -87,3 +87,38 @@ func ExampleGetFilterFromString() {
 	// {Alice 32 Security}
 	// {Bob 26 Security}
 }
+
+func ExampleGetFilterFromExpression() {
+	type Employee struct {
+		Name       string `column:"name" columnTags:"sensitive"`
+		Age        int    `column:"age" columnTags:"sensitive"`
+		Department string `column:"department"`
+	}
+
+	Employees := []*Employee{
+		{"Alice", 32, "Security"},
+		{"Bob", 26, "Security"},
+		{"Eve", 99, "Security also"},
+	}
+
+	employeeColumns := columns.MustCreateColumns[Employee]()
+
+	// Get columnMap
+	cmap := employeeColumns.GetColumnMap()
+
+	// Create a new filter that matches employees from the "Security" department older than 30, or named Eve
+	employeeFilter, err := filter.GetFilterFromExpression(cmap, `(department=="Security" and age>30) or name in ("Eve")`)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, e := range Employees {
+		if employeeFilter.Match(e) {
+			fmt.Println(*e)
+		}
+	}
+
+	// Output:
+	// {Alice 32 Security}
+	// {Eve 99 Security also}
+}
@@ -0,0 +1,550 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// isKeyword returns true if the token is the given keyword. Keywords are
+// case-insensitive and can be used as values if quoted.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// operators are sorted so the longest ones are tried first
+var operators = []string{"==", "!=", ">=", "<=", "!~", "&&", "||", "=", ">", "<", "~", "!"}
+
+// isWordRune returns whether r can be part of an unquoted word, i.e. a column
+// name or a value like 443, 10.0.0.1 or ^api.
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()",'=!<>~&|`, r)
+}
+
+func tokenize(expr string) ([]token, error) {
+	tokens := []token{}
+
+	for pos := 0; pos < len(expr); {
+		c := expr[pos]
+
+		switch {
+		case unicode.IsSpace(rune(c)):
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			pos++
+		case c == '"':
+			// Double quoted strings support the same escape sequences as Go
+			prefix, err := strconv.QuotedPrefix(expr[pos:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d", pos)
+			}
+			value, _ := strconv.Unquote(prefix)
+			tokens = append(tokens, token{kind: tokenString, text: value, pos: pos})
+			pos += len(prefix)
+		case c == '\'':
+			// Single quoted strings are taken literally, that's handy for
+			// regular expressions
+			end := strings.IndexByte(expr[pos+1:], '\'')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string at position %d", pos)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: expr[pos+1 : pos+1+end], pos: pos})
+			pos += end + 2
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(expr[pos:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+					pos += len(op)
+					found = true
+					break
+				}
+			}
+			if found {
+				continue
+			}
+
+			start := pos
+			for pos < len(expr) {
+				r, size := utf8.DecodeRuneInString(expr[pos:])
+				if !isWordRune(r) {
+					break
+				}
+				pos += size
+			}
+			if pos == start {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: expr[start:pos], pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+// node is an element of the abstract syntax tree of an expression
+type node interface {
+	String() string
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) String() string {
+	return fmt.Sprintf("(%s and %s)", n.left, n.right)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) String() string {
+	return fmt.Sprintf("(%s or %s)", n.left, n.right)
+}
+
+type notNode struct {
+	expr node
+}
+
+func (n *notNode) String() string {
+	return fmt.Sprintf("not %s", n.expr)
+}
+
+// comparisonNode compares a column with a single value
+type comparisonNode struct {
+	column   string
+	operator string
+	value    string
+}
+
+func (n *comparisonNode) String() string {
+	return fmt.Sprintf("%s%s%s", n.column, n.operator, strconv.Quote(n.value))
+}
+
+// inNode matches if the column is equal to any of the values
+type inNode struct {
+	column string
+	values []string
+}
+
+func (n *inNode) String() string {
+	values := make([]string, 0, len(n.values))
+	for _, v := range n.values {
+		values = append(values, strconv.Quote(v))
+	}
+	return fmt.Sprintf("%s in (%s)", n.column, strings.Join(values, ","))
+}
+
+// parser is a recursive descent parser for the following grammar:
+//
+//	expression = and { ( "or" | "||" ) and }
+//	and        = not { ( "and" | "&&" ) not }
+//	not        = ( "not" | "!" ) not | primary
+//	primary    = "(" expression ")" | column operator value | column [ "not" ] "in" "(" value { "," value } ")"
+//	operator   = "==" | "=" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "!~"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected %s", t)
+	}
+	return fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func parseExpression(expr string) (node, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, unexpected(t)
+	}
+
+	return n, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isKeyword("or") || p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isKeyword("and") || p.isOperator("&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.peek().isKeyword("not") || p.isOperator("!") {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{expr: expr}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	if t.kind == tokenLParen {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, unexpected(t)
+		}
+		return n, nil
+	}
+
+	if t.kind != tokenWord {
+		return nil, unexpected(t)
+	}
+	column := t.text
+
+	// column [not] in (values...)
+	negate := false
+	if p.peek().isKeyword("not") {
+		p.next()
+		negate = true
+		if !p.peek().isKeyword("in") {
+			return nil, unexpected(p.peek())
+		}
+	}
+	if p.peek().isKeyword("in") {
+		p.next()
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		var n node = &inNode{column: column, values: values}
+		if negate {
+			n = &notNode{expr: n}
+		}
+		return n, nil
+	}
+
+	if !p.isOperator("==", "=", "!=", "<", "<=", ">", ">=", "~", "!~") {
+		return nil, unexpected(p.peek())
+	}
+	operator := p.next().text
+	if operator == "=" {
+		operator = "=="
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return &comparisonNode{column: column, operator: operator, value: value}, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return "", unexpected(t)
+	}
+	return t.text, nil
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if t := p.next(); t.kind != tokenLParen {
+		return nil, unexpected(t)
+	}
+
+	values := []string{}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		t := p.next()
+		if t.kind == tokenRParen {
+			return values, nil
+		}
+		if t.kind != tokenComma {
+			return nil, unexpected(t)
+		}
+	}
+}
+
+// compileNode type checks the node against the columns and turns it into a
+// function matching entries.
+func compileNode[T any](cols columns.ColumnMap[T], n node) (func(*T) bool, error) {
+	switch n := n.(type) {
+	case *andNode:
+		left, right, err := compileNodes(cols, n.left, n.right)
+		if err != nil {
+			return nil, err
+		}
+		return func(entry *T) bool {
+			return left(entry) && right(entry)
+		}, nil
+	case *orNode:
+		left, right, err := compileNodes(cols, n.left, n.right)
+		if err != nil {
+			return nil, err
+		}
+		return func(entry *T) bool {
+			return left(entry) || right(entry)
+		}, nil
+	case *notNode:
+		expr, err := compileNode(cols, n.expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(entry *T) bool {
+			return !expr(entry)
+		}, nil
+	case *comparisonNode:
+		fs, err := newComparison(cols, n.column, n.operator, n.value)
+		if err != nil {
+			return nil, err
+		}
+		return fs.compareFunc, nil
+	case *inNode:
+		return compileIn(cols, n)
+	default:
+		return nil, fmt.Errorf("unknown node %T", n)
+	}
+}
+
+func compileNodes[T any](cols columns.ColumnMap[T], left, right node) (func(*T) bool, func(*T) bool, error) {
+	l, err := compileNode(cols, left)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := compileNode(cols, right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+// newComparison returns a FilterSpec comparing the column with the value
+// using the given operator
+func newComparison[T any](cols columns.ColumnMap[T], columnName, operator, value string) (*FilterSpec[T], error) {
+	column, ok := cols.GetColumn(columnName)
+	if !ok {
+		return nil, fmt.Errorf("column %q not found", columnName)
+	}
+
+	fs := &FilterSpec[T]{
+		cols:   cols,
+		column: column,
+		value:  value,
+	}
+
+	switch operator {
+	case "==":
+		fs.comparisonType = comparisonTypeMatch
+	case "!=":
+		fs.comparisonType = comparisonTypeMatch
+		fs.negate = true
+	case "<":
+		fs.comparisonType = comparisonTypeLt
+	case "<=":
+		fs.comparisonType = comparisonTypeLte
+	case ">":
+		fs.comparisonType = comparisonTypeGt
+	case ">=":
+		fs.comparisonType = comparisonTypeGte
+	case "~":
+		fs.comparisonType = comparisonTypeRegex
+	case "!~":
+		fs.comparisonType = comparisonTypeRegex
+		fs.negate = true
+	default:
+		return nil, fmt.Errorf("unknown operator %q", operator)
+	}
+
+	if err := fs.compile(); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+func compileIn[T any](cols columns.ColumnMap[T], n *inNode) (func(*T) bool, error) {
+	column, ok := cols.GetColumn(n.column)
+	if !ok {
+		return nil, fmt.Errorf("column %q not found", n.column)
+	}
+
+	// Use a set for string fields, they are the most common case
+	if column.Kind() == reflect.String && !column.IsVirtual() {
+		values := make(map[string]struct{}, len(n.values))
+		for _, v := range n.values {
+			values[v] = struct{}{}
+		}
+		offset := column.GetOffset()
+		return func(entry *T) bool {
+			_, ok := values[columns.GetField[string](entry, offset)]
+			return ok
+		}, nil
+	}
+
+	matchers := make([]func(*T) bool, 0, len(n.values))
+	for _, v := range n.values {
+		fs, err := newComparison(cols, n.column, "==", v)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, fs.compareFunc)
+	}
+	return func(entry *T) bool {
+		for _, match := range matchers {
+			if match(entry) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// GetFilterFromExpression prepares a filter from an expression like
+//
+//	pod~"^api" and (dport==443 or dport==8443) and not comm in ("curl","wget")
+//
+// that has a Match() function that can be called on entries of type *T. The
+// expression is checked against the kinds of the columns, so errors like
+// comparing a number with a string are reported here and not when matching.
+func GetFilterFromExpression[T any](cols columns.ColumnMap[T], expression string) (*FilterSpec[T], error) {
+	n, err := parseExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing filter expression: %w", err)
+	}
+
+	match, err := compileNode(cols, n)
+	if err != nil {
+		return nil, fmt.Errorf("could not apply filter expression: %w", err)
+	}
+
+	return &FilterSpec[T]{
+		value:       expression,
+		compareFunc: match,
+		cols:        cols,
+	}, nil
+}
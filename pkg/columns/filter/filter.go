@@ -79,6 +79,12 @@ func getValueFromFilterSpec[T any](fs *FilterSpec[T], column *columns.Column[T])
 		value = reflect.ValueOf(number).Convert(column.Type())
 	case reflect.String:
 		value = reflect.ValueOf(fs.value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(fs.value)
+		if err != nil {
+			return value, fmt.Errorf("tried to compare %q to bool column %q", fs.value, column.Name)
+		}
+		value = reflect.ValueOf(b)
 	default:
 		return reflect.Value{}, fmt.Errorf("tried to match %q on unsupported column %q", fs.value, column.Name)
 	}
@@ -119,11 +125,6 @@ func GetFilterFromString[T any](cols columns.ColumnMap[T], filter string) (*Filt
 		fs.comparisonType = comparisonTypeRegex
 		filterRule = strings.TrimPrefix(filterRule, "~")
 		fs.value = filterRule
-		re, err := regexp.Compile(fs.value)
-		if err != nil {
-			return nil, fmt.Errorf("could not compile regular expression %q: %w", fs.value, err)
-		}
-		fs.regex = re
 	} else if strings.HasPrefix(filterRule, ">=") {
 		fs.comparisonType = comparisonTypeGte
 		filterRule = strings.TrimPrefix(filterRule, ">=")
@@ -142,35 +143,50 @@ func GetFilterFromString[T any](cols columns.ColumnMap[T], filter string) (*Filt
 		fs.value = filterRule
 	}
 
-	if fs.comparisonType == comparisonTypeRegex && column.Kind() != reflect.String {
-		return nil, fmt.Errorf("tried to apply regular expression on non-string column %q", fs.column.Name)
+	if err := fs.compile(); err != nil {
+		return nil, err
 	}
 
-	// We precalculate value to be of a comparable type to column.kind when comparisonType is not comparisonTypeRegex
-	var value reflect.Value
-	var err error
-	switch fs.comparisonType {
-	case comparisonTypeMatch,
-		comparisonTypeGt,
-		comparisonTypeGte,
-		comparisonTypeLt,
-		comparisonTypeLte:
-		value, err = getValueFromFilterSpec(fs, column)
+	return fs, nil
+}
+
+// compile verifies that the comparison can be done on the column and
+// prepares the function used by Match()
+func (fs *FilterSpec[T]) compile() error {
+	if fs.comparisonType == comparisonTypeRegex {
+		if fs.column.Kind() != reflect.String {
+			return fmt.Errorf("tried to apply regular expression on non-string column %q", fs.column.Name)
+		}
+		re, err := regexp.Compile(fs.value)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("could not compile regular expression %q: %w", fs.value, err)
 		}
+		fs.regex = re
 	}
 
-	if value.IsValid() {
+	if fs.column.Kind() == reflect.Bool && fs.comparisonType != comparisonTypeMatch {
+		return fmt.Errorf("tried to compare bool column %q using an order", fs.column.Name)
+	}
+
+	// We precalculate value to be of a comparable type to column.kind when comparisonType is not comparisonTypeRegex
+	if fs.comparisonType != comparisonTypeRegex {
+		value, err := getValueFromFilterSpec(fs, fs.column)
+		if err != nil {
+			return err
+		}
 		fs.refValue = value.Interface()
 	}
 
 	fs.compareFunc = fs.getComparisonFunc()
 
-	return fs, nil
+	return nil
 }
 
 func (fs *FilterSpec[T]) getComparisonFunc() func(*T) bool {
+	if fs.column.IsVirtual() {
+		return fs.getVirtualComparisonFunc()
+	}
+
 	offset := fs.column.GetOffset()
 
 	switch fs.column.Kind() {
@@ -207,8 +223,9 @@ func (fs *FilterSpec[T]) getComparisonFunc() func(*T) bool {
 		return getComparisonFuncForComparisonType[float64, T](fs.comparisonType, fs.negate, offset, fs.refValue)
 	case reflect.Bool:
 		if fs.comparisonType == comparisonTypeMatch {
+			ref := reflect.ValueOf(fs.refValue).Bool()
 			return func(entry *T) bool {
-				return columns.GetField[bool](entry, offset) == fs.refValue.(bool)
+				return columns.GetField[bool](entry, offset) == ref != fs.negate
 			}
 		}
 		fallthrough
@@ -219,27 +236,73 @@ func (fs *FilterSpec[T]) getComparisonFunc() func(*T) bool {
 	}
 }
 
+// getVirtualComparisonFunc compares the string returned by the extractor of a
+// virtual column, as there isn't any field to read it from.
+func (fs *FilterSpec[T]) getVirtualComparisonFunc() func(*T) bool {
+	get := func(entry *T) string {
+		return fs.column.Get(entry).String()
+	}
+
+	if fs.comparisonType == comparisonTypeRegex {
+		return func(entry *T) bool {
+			return fs.regex.MatchString(get(entry)) != fs.negate
+		}
+	}
+
+	refValue := reflect.ValueOf(fs.refValue).String()
+	switch fs.comparisonType {
+	case comparisonTypeMatch:
+		return func(entry *T) bool {
+			return get(entry) == refValue != fs.negate
+		}
+	case comparisonTypeGt:
+		return func(entry *T) bool {
+			return get(entry) > refValue != fs.negate
+		}
+	case comparisonTypeGte:
+		return func(entry *T) bool {
+			return get(entry) >= refValue != fs.negate
+		}
+	case comparisonTypeLt:
+		return func(entry *T) bool {
+			return get(entry) < refValue != fs.negate
+		}
+	case comparisonTypeLte:
+		return func(entry *T) bool {
+			return get(entry) <= refValue != fs.negate
+		}
+	default:
+		return func(a *T) bool {
+			return false
+		}
+	}
+}
+
 func getComparisonFuncForComparisonType[OT constraints.Ordered, T any](ct comparisonType, negate bool, offset uintptr, refValue any) func(a *T) bool {
+	// refValue has the type of the column, that could be a named type like
+	// time.Duration, get it as the underlying type only once
+	ref := reflect.ValueOf(refValue).Convert(reflect.TypeOf(*new(OT))).Interface().(OT)
+
 	switch ct {
 	case comparisonTypeMatch:
 		return func(a *T) bool {
-			return columns.GetField[OT](a, offset) == refValue.(OT) != negate
+			return columns.GetField[OT](a, offset) == ref != negate
 		}
 	case comparisonTypeGt:
 		return func(a *T) bool {
-			return columns.GetField[OT](a, offset) > refValue.(OT) != negate
+			return columns.GetField[OT](a, offset) > ref != negate
 		}
 	case comparisonTypeGte:
 		return func(a *T) bool {
-			return columns.GetField[OT](a, offset) >= refValue.(OT) != negate
+			return columns.GetField[OT](a, offset) >= ref != negate
 		}
 	case comparisonTypeLt:
 		return func(a *T) bool {
-			return columns.GetField[OT](a, offset) < refValue.(OT) != negate
+			return columns.GetField[OT](a, offset) < ref != negate
 		}
 	case comparisonTypeLte:
 		return func(a *T) bool {
-			return columns.GetField[OT](a, offset) <= refValue.(OT) != negate
+			return columns.GetField[OT](a, offset) <= ref != negate
 		}
 	default:
 		return func(a *T) bool {
@@ -16,6 +16,7 @@ package filter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
 )
@@ -297,3 +298,118 @@ func TestFilters(t *testing.T) {
 		}
 	})
 }
+
+func TestExpressions(t *testing.T) {
+	type testData struct {
+		Pod         string        `column:"pod"`
+		Comm        string        `column:"comm"`
+		Dport       uint16        `column:"dport"`
+		Latency     time.Duration `column:"latency"`
+		Float64     float64       `column:"float64"`
+		Bool        bool          `column:"bool"`
+		Virtual     string
+		Unsupported []string `column:"unsupported"`
+	}
+
+	entries := []*testData{
+		{Pod: "api-1", Comm: "curl", Dport: 443, Latency: time.Millisecond, Float64: 1.5, Bool: true, Virtual: "a"},
+		{Pod: "api-2", Comm: "nginx", Dport: 8443, Latency: 2 * time.Millisecond, Float64: 2.5, Virtual: "b"},
+		{Pod: "api-3", Comm: "wget", Dport: 8443, Latency: 3 * time.Millisecond, Float64: 3.5, Virtual: "c"},
+		{Pod: "web", Comm: "nginx", Dport: 80, Latency: 4 * time.Millisecond, Float64: 4.5, Bool: true, Virtual: "d"},
+		{Pod: "my api", Comm: "and", Dport: 443, Latency: 5 * time.Millisecond, Float64: 5.5, Virtual: "e"},
+	}
+
+	cols, err := columns.NewColumns[testData]()
+	if err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
+	cols.MustAddColumn(columns.Column[testData]{
+		Name: "virtual",
+		Extractor: func(e *testData) string {
+			return e.Virtual
+		},
+	})
+	cmap := cols.GetColumnMap()
+
+	expressionTests := []struct {
+		expression    string
+		expectedCount int
+		expectError   bool
+		description   string
+	}{
+		{expression: `pod~"^api" and (dport==443 or dport==8443) and not comm in ("curl","wget")`, expectedCount: 1, description: "example"},
+		{expression: `pod=="api-1"`, expectedCount: 1, description: "exact match on quoted string"},
+		{expression: `pod=api-1`, expectedCount: 1, description: "exact match on unquoted string"},
+		{expression: `pod!=api-1`, expectedCount: 4, description: "negated match"},
+		{expression: `pod=="my api"`, expectedCount: 1, description: "string with spaces"},
+		{expression: `comm=="and"`, expectedCount: 1, description: "quoted keyword"},
+		{expression: `comm==and`, expectedCount: 1, description: "unquoted keyword as value"},
+		{expression: `pod~'^api-\d$'`, expectedCount: 3, description: "regular expression in single quotes"},
+		{expression: `pod!~api`, expectedCount: 1, description: "negated regular expression"},
+		{expression: `pod~"("`, expectError: true, description: "invalid regular expression"},
+		{expression: `dport>443`, expectedCount: 2, description: "gt on uint"},
+		{expression: `dport>=443 && dport<8443`, expectedCount: 2, description: "range with operators"},
+		{expression: `dport<=80 || comm==curl`, expectedCount: 2, description: "or with operators"},
+		{expression: `!(dport==443)`, expectedCount: 3, description: "not with operator"},
+		{expression: `not not dport==443`, expectedCount: 2, description: "double negation"},
+		{expression: `dport in (80, 443)`, expectedCount: 3, description: "in on uint"},
+		{expression: `comm not in (nginx)`, expectedCount: 3, description: "not in on string"},
+		{expression: `latency>=3000000`, expectedCount: 3, description: "named type"},
+		{expression: `float64<2.5`, expectedCount: 1, description: "lt on float"},
+		{expression: `bool==true`, expectedCount: 2, description: "match on bool"},
+		{expression: `bool!=true`, expectedCount: 3, description: "negated match on bool"},
+		{expression: `bool>false`, expectError: true, description: "order on bool"},
+		{expression: `virtual in (a,b) or virtual>d`, expectedCount: 3, description: "virtual column"},
+		{expression: `POD==web AND Comm==nginx`, expectedCount: 1, description: "case-insensitive columns and keywords"},
+		{expression: `a==b or c==d and e==f`, expectError: true, description: "unknown columns"},
+		{expression: `dport==https`, expectError: true, description: "string on uint column"},
+		{expression: `dport~443`, expectError: true, description: "regular expression on uint column"},
+		{expression: `dport in (443, https)`, expectError: true, description: "string in list on uint column"},
+		{expression: `unsupported==1`, expectError: true, description: "unsupported column"},
+		{expression: ``, expectError: true, description: "empty expression"},
+		{expression: `pod==`, expectError: true, description: "missing value"},
+		{expression: `(pod==web`, expectError: true, description: "missing parenthesis"},
+		{expression: `pod==web)`, expectError: true, description: "extra parenthesis"},
+		{expression: `pod==web comm==nginx`, expectError: true, description: "missing operator"},
+		{expression: `dport in ()`, expectError: true, description: "empty list"},
+		{expression: `pod=="web`, expectError: true, description: "unterminated string"},
+	}
+
+	for _, test := range expressionTests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			fs, err := GetFilterFromExpression(cmap, test.expression)
+			if err != nil {
+				if !test.expectError {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if test.expectError {
+				t.Fatalf("Expected error")
+			}
+
+			count := 0
+			for _, entry := range entries {
+				if fs.Match(entry) {
+					count++
+				}
+			}
+			if count != test.expectedCount {
+				t.Errorf("Expected %d entries, got %d", test.expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	n, err := parseExpression(`a==1 or b==2 and not c in (x, "y z")`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `(a=="1" or (b=="2" and not c in ("x","y z")))`
+	if n.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, n.String())
+	}
+}
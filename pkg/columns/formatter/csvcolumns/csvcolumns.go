@@ -0,0 +1,146 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package csvcolumns helps to output structs (and events of structs) as comma-separated values (CSV), or using any other
+separator like tabs (TSV), using metadata from a `Columns` instance. Unlike textcolumns, values aren't padded nor
+abbreviated, so the output can be read by other tools.
+
+	cf := csvcolumns.NewFormatter(columnMap, csvcolumns.WithSeparator('\t'))
+	fmt.Println(cf.FormatHeader())
+	fmt.Println(cf.FormatEntry(&event))
+
+Values are obtained using the extractors of the columns, if any. Fields containing the separator, quotes or newlines
+are quoted as described in RFC 4180.
+*/
+package csvcolumns
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type CSVColumnsFormatter[T any] struct {
+	options     *Options
+	showColumns []*columns.Column[T]
+}
+
+// NewFormatter returns a CSVColumnsFormatter that will turn entries of type T into rows of separated values
+func NewFormatter[T any](cols columns.ColumnMap[T], options ...Option) *CSVColumnsFormatter[T] {
+	opts := DefaultOptions()
+	for _, o := range options {
+		o(opts)
+	}
+
+	var showColumns []*columns.Column[T]
+	if opts.DefaultColumns != nil {
+		for _, name := range opts.DefaultColumns {
+			if column, ok := cols.GetColumn(strings.ToLower(name)); ok {
+				showColumns = append(showColumns, column)
+			}
+		}
+	} else {
+		for _, column := range cols.GetOrderedColumns() {
+			if column.Visible {
+				showColumns = append(showColumns, column)
+			}
+		}
+	}
+
+	return &CSVColumnsFormatter[T]{
+		options:     opts,
+		showColumns: showColumns,
+	}
+}
+
+// FormatHeader returns the names of the columns as a row
+func (cf *CSVColumnsFormatter[T]) FormatHeader() string {
+	names := make([]string, 0, len(cf.showColumns))
+	for _, column := range cf.showColumns {
+		names = append(names, column.Name)
+	}
+	return cf.formatRow(names)
+}
+
+// FormatEntry returns the values of the entry as a row; nil entries are formatted as an empty string
+func (cf *CSVColumnsFormatter[T]) FormatEntry(entry *T) string {
+	if entry == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(cf.showColumns))
+	for _, column := range cf.showColumns {
+		values = append(values, formatValue(column.Get(entry)))
+	}
+	return cf.formatRow(values)
+}
+
+// FormatTable returns the header and a row for each of the entries, skipping nil entries
+func (cf *CSVColumnsFormatter[T]) FormatTable(entries []*T) string {
+	rows := make([]string, 0, len(entries)+1)
+	rows = append(rows, cf.FormatHeader())
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		rows = append(rows, cf.FormatEntry(entry))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (cf *CSVColumnsFormatter[T]) formatRow(fields []string) string {
+	var sb strings.Builder
+
+	w := csv.NewWriter(&sb)
+	w.Comma = cf.options.Separator
+	// Writing to a strings.Builder can't fail, errors can only be caused by an
+	// invalid separator
+	if err := w.Write(fields); err != nil {
+		return ""
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func formatValue(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Int,
+		reflect.Int8,
+		reflect.Int16,
+		reflect.Int32,
+		reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint,
+		reflect.Uint8,
+		reflect.Uint16,
+		reflect.Uint32,
+		reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	}
+	return fmt.Sprint(value.Interface())
+}
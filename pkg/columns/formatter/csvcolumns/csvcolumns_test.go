@@ -0,0 +1,76 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csvcolumns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type testStruct struct {
+	Name     string  `column:"name,width:10"`
+	Age      uint    `column:"age,width:4,align:right,fixed"`
+	Size     float32 `column:"size,width:6,precision:2,align:right"`
+	Balance  int     `column:"balance,width:8,align:right"`
+	CanDance bool    `column:"canDance,width:8,hide"`
+}
+
+var testEntries = []*testStruct{
+	{"Alice", 32, 1.74, 1000, true},
+	{"Bob, Jr.", 26, 1.73, -200, true},
+	{"Eve \"E\"", 99, 5.12, 1000000, false},
+	nil,
+}
+
+func TestCSVColumnsFormatter(t *testing.T) {
+	cols := columns.MustCreateColumns[testStruct]()
+	cols.MustSetExtractor("balance", func(entry *testStruct) string {
+		if entry.Balance < 0 {
+			return "negative"
+		}
+		return "positive"
+	})
+	colMap := cols.GetColumnMap()
+
+	t.Run("CSV", func(t *testing.T) {
+		formatter := NewFormatter(colMap)
+
+		expected := strings.Join([]string{
+			"name,age,size,balance",
+			"Alice,32,1.74,positive",
+			"\"Bob, Jr.\",26,1.73,negative",
+			"\"Eve \"\"E\"\"\",99,5.12,positive",
+		}, "\n")
+		if out := formatter.FormatTable(testEntries); out != expected {
+			t.Errorf("got\n%s\nexpected\n%s", out, expected)
+		}
+		if out := formatter.FormatEntry(nil); out != "" {
+			t.Errorf("got %q for nil entry, expected an empty string", out)
+		}
+	})
+
+	t.Run("TSVWithCustomColumns", func(t *testing.T) {
+		formatter := NewFormatter(colMap, WithSeparator(SeparatorTab), WithDefaultColumns([]string{"canDance", "Name", "invalid"}))
+
+		if out := formatter.FormatHeader(); out != "canDance\tname" {
+			t.Errorf("got header %q", out)
+		}
+		if out := formatter.FormatEntry(testEntries[1]); out != "true\tBob, Jr." {
+			t.Errorf("got entry %q", out)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package jsoncolumns helps to output structs (and events of structs) as JSON objects, one per line, using metadata from
+a `Columns` instance. The objects contain the values of the columns keyed by their names, in the order of the columns,
+and can be preceded by a schema describing them, so other tools can ingest them without guessing the types:
+
+	jf := jsoncolumns.NewFormatter(columnMap)
+	fmt.Println(jf.FormatSchema())
+	line, err := jf.FormatEntry(&event)
+	...
+	fmt.Println(line)
+
+will print something like
+
+	{"schema":[{"name":"node","kind":"string"},{"name":"pid","kind":"uint32"},{"name":"latency","kind":"uint64","unit":"ns"}]}
+	{"node":"minikube","pid":1234,"latency":4200}
+
+Values are obtained using the extractors of the columns, if any. The kind of those columns is "string".
+*/
+package jsoncolumns
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+// ColumnSchema describes a column in the schema
+type ColumnSchema struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Unit        string `json:"unit,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Schema describes the columns of the objects printed by the formatter
+type Schema struct {
+	Columns []ColumnSchema `json:"schema"`
+}
+
+type JSONColumnsFormatter[T any] struct {
+	options     *Options
+	showColumns []*columns.Column[T]
+}
+
+// NewFormatter returns a JSONColumnsFormatter that will turn entries of type T into JSON objects
+func NewFormatter[T any](cols columns.ColumnMap[T], options ...Option) *JSONColumnsFormatter[T] {
+	opts := DefaultOptions()
+	for _, o := range options {
+		o(opts)
+	}
+
+	var showColumns []*columns.Column[T]
+	if opts.DefaultColumns != nil {
+		for _, name := range opts.DefaultColumns {
+			if column, ok := cols.GetColumn(strings.ToLower(name)); ok {
+				showColumns = append(showColumns, column)
+			}
+		}
+	} else {
+		// Unlike in tables, hidden columns are included: There is no need to
+		// save space
+		showColumns = cols.GetOrderedColumns()
+	}
+
+	return &JSONColumnsFormatter[T]{
+		options:     opts,
+		showColumns: showColumns,
+	}
+}
+
+// GetSchema returns the schema of the objects printed by the formatter
+func (jf *JSONColumnsFormatter[T]) GetSchema() *Schema {
+	schema := &Schema{
+		Columns: make([]ColumnSchema, 0, len(jf.showColumns)),
+	}
+	for _, column := range jf.showColumns {
+		kind := column.Kind()
+		if column.HasCustomExtractor() {
+			kind = reflect.String
+		}
+		schema.Columns = append(schema.Columns, ColumnSchema{
+			Name:        column.Name,
+			Kind:        kind.String(),
+			Unit:        column.Unit,
+			Description: column.Description,
+		})
+	}
+	return schema
+}
+
+// FormatSchema returns the schema of the objects printed by the formatter as a single line of JSON
+func (jf *JSONColumnsFormatter[T]) FormatSchema() string {
+	// It can't fail, the schema only contains strings
+	b, _ := json.Marshal(jf.GetSchema())
+	return string(b)
+}
+
+// FormatEntry returns the values of the entry as a single line of JSON; nil entries are formatted as an empty string
+func (jf *JSONColumnsFormatter[T]) FormatEntry(entry *T) (string, error) {
+	if entry == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range jf.showColumns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(column.Name)
+		if err != nil {
+			return "", err
+		}
+		value, err := json.Marshal(column.Get(entry).Interface())
+		if err != nil {
+			return "", err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.String(), nil
+}
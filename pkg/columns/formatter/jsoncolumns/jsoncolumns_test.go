@@ -0,0 +1,80 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsoncolumns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type testStruct struct {
+	Name    string        `column:"name"`
+	Pid     uint32        `column:"pid"`
+	Latency time.Duration `column:"latency,unit:ns,hide" columnDesc:"Time to complete the operation"`
+	Ratio   float64       `column:"ratio"`
+	Ok      bool          `column:"ok"`
+}
+
+func TestJSONColumnsFormatter(t *testing.T) {
+	cols := columns.MustCreateColumns[testStruct]()
+	cols.MustSetExtractor("ok", func(entry *testStruct) string {
+		if entry.Ok {
+			return "yes"
+		}
+		return "no"
+	})
+	colMap := cols.GetColumnMap()
+
+	entry := &testStruct{Name: "cat \"1\"", Pid: 42, Latency: 1500, Ratio: 0.5, Ok: true}
+
+	t.Run("AllColumns", func(t *testing.T) {
+		formatter := NewFormatter(colMap)
+
+		expectedSchema := `{"schema":[{"name":"name","kind":"string"},{"name":"pid","kind":"uint32"},` +
+			`{"name":"latency","kind":"int64","unit":"ns","description":"Time to complete the operation"},` +
+			`{"name":"ratio","kind":"float64"},{"name":"ok","kind":"string"}]}`
+		if schema := formatter.FormatSchema(); schema != expectedSchema {
+			t.Errorf("got schema\n%s\nexpected\n%s", schema, expectedSchema)
+		}
+
+		expected := `{"name":"cat \"1\"","pid":42,"latency":1500,"ratio":0.5,"ok":"yes"}`
+		out, err := formatter.FormatEntry(entry)
+		if err != nil {
+			t.Fatalf("formatting entry: %v", err)
+		}
+		if out != expected {
+			t.Errorf("got\n%s\nexpected\n%s", out, expected)
+		}
+	})
+
+	t.Run("CustomColumns", func(t *testing.T) {
+		formatter := NewFormatter(colMap, WithDefaultColumns([]string{"PID", "name"}))
+
+		expectedSchema := `{"schema":[{"name":"pid","kind":"uint32"},{"name":"name","kind":"string"}]}`
+		if schema := formatter.FormatSchema(); schema != expectedSchema {
+			t.Errorf("got schema\n%s\nexpected\n%s", schema, expectedSchema)
+		}
+
+		out, err := formatter.FormatEntry(entry)
+		if err != nil {
+			t.Fatalf("formatting entry: %v", err)
+		}
+		if expected := `{"pid":42,"name":"cat \"1\""}`; out != expected {
+			t.Errorf("got\n%s\nexpected\n%s", out, expected)
+		}
+	})
+}
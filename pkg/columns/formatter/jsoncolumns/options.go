@@ -0,0 +1,34 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsoncolumns
+
+type Option func(*Options)
+
+type Options struct {
+	DefaultColumns []string // defines which columns to show by default; will be set to all columns, even hidden ones, if nil
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		DefaultColumns: nil,
+	}
+}
+
+// WithDefaultColumns sets the columns that should be displayed by default
+func WithDefaultColumns(columns []string) Option {
+	return func(opts *Options) {
+		opts.DefaultColumns = columns
+	}
+}
@@ -28,7 +28,12 @@ import (
 )
 
 func (tf *TextColumnsFormatter[T]) setFormatter(column *Column[T]) {
-	switch column.col.Kind() {
+	kind := column.col.Kind()
+	if column.col.HasCustomExtractor() {
+		// Extractors always return strings, regardless of the kind of the underlying field
+		kind = reflect.String
+	}
+	switch kind {
 	case reflect.Int,
 		reflect.Int8,
 		reflect.Int16,
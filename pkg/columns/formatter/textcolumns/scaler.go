@@ -250,7 +250,7 @@ func (tf *TextColumnsFormatter[T]) AdjustWidthsToContent(entries []*T, considerH
 			field := column.col.GetRef(entryValue)
 
 			flen := 0
-			switch column.col.Kind() {
+			switch field.Kind() {
 			case reflect.Int,
 				reflect.Int8,
 				reflect.Int16,
@@ -0,0 +1,207 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+)
+
+type number interface {
+	int64 | uint64 | float64
+}
+
+// ParseAggregations parses aggregations in the format "column:type", like "lat:p99", and returns them in a map
+// that can be passed to GroupEntriesWithAggregations()
+func ParseAggregations[T any](cols columns.ColumnMap[T], aggregations []string) (map[string]columns.GroupType, error) {
+	parsed := make(map[string]columns.GroupType, len(aggregations))
+
+	for _, aggregation := range aggregations {
+		info := strings.SplitN(aggregation, ":", 2)
+		if len(info) != 2 {
+			return nil, fmt.Errorf("invalid aggregation %q: expected column:type", aggregation)
+		}
+
+		columnName := strings.ToLower(info[0])
+		column, ok := cols.GetColumn(columnName)
+		if !ok {
+			return nil, fmt.Errorf("invalid aggregation %q: column %q not found", aggregation, info[0])
+		}
+
+		groupType, err := columns.ParseGroupType(info[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregation %q: %w", aggregation, err)
+		}
+
+		if err := column.CheckGroupType(groupType); err != nil {
+			return nil, fmt.Errorf("invalid aggregation %q: %w", aggregation, err)
+		}
+
+		parsed[columnName] = groupType
+	}
+
+	return parsed, nil
+}
+
+// aggregate sets the field of the column in dst to the aggregation of the values of that field in entries. counts
+// holds the number of original entries each of them stands for, nil means one each; it's only used by count and avg.
+func aggregate[T any](column *columns.Column[T], groupType columns.GroupType, dst *T, entries []reflect.Value, counts []int) {
+	field := column.GetRaw(dst)
+	if !field.CanSet() {
+		return
+	}
+
+	switch groupType {
+	case columns.GroupTypeCount:
+		total := len(entries)
+		if counts != nil {
+			total = 0
+			for _, c := range counts {
+				total += c
+			}
+		}
+		setCount(field, total)
+		return
+	case columns.GroupTypeDistinct:
+		distinct := make(map[string]struct{})
+		for _, entry := range entries {
+			distinct[getStringFromValue(column.GetRaw(entry.Interface().(*T)))] = struct{}{}
+		}
+		setCount(field, len(distinct))
+		return
+	}
+
+	switch column.Kind() {
+	case reflect.Int,
+		reflect.Int8,
+		reflect.Int16,
+		reflect.Int32,
+		reflect.Int64:
+		values := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			values = append(values, column.GetRaw(entry.Interface().(*T)).Int())
+		}
+		field.SetInt(aggregateNumbers(values, counts, groupType))
+	case reflect.Uint,
+		reflect.Uint8,
+		reflect.Uint16,
+		reflect.Uint32,
+		reflect.Uint64:
+		values := make([]uint64, 0, len(entries))
+		for _, entry := range entries {
+			values = append(values, column.GetRaw(entry.Interface().(*T)).Uint())
+		}
+		field.SetUint(aggregateNumbers(values, counts, groupType))
+	case reflect.Float32,
+		reflect.Float64:
+		values := make([]float64, 0, len(entries))
+		for _, entry := range entries {
+			values = append(values, column.GetRaw(entry.Interface().(*T)).Float())
+		}
+		field.SetFloat(aggregateNumbers(values, counts, groupType))
+	}
+}
+
+func aggregateNumbers[N number](values []N, counts []int, groupType columns.GroupType) N {
+	switch groupType {
+	case columns.GroupTypeSum:
+		return sum(values)
+	case columns.GroupTypeMin:
+		min := values[0]
+		for _, v := range values[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min
+	case columns.GroupTypeMax:
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	case columns.GroupTypeAvg:
+		if counts == nil {
+			return sum(values) / N(len(values))
+		}
+		// Each value is the average of counts[i] entries
+		var s, total N
+		for i, v := range values {
+			s += v * N(counts[i])
+			total += N(counts[i])
+		}
+		return s / total
+	case columns.GroupTypeP50:
+		return percentile(values, 50)
+	case columns.GroupTypeP95:
+		return percentile(values, 95)
+	case columns.GroupTypeP99:
+		return percentile(values, 99)
+	}
+	return values[0]
+}
+
+func sum[N number](values []N) N {
+	var s N
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
+
+// percentile returns the p-th percentile of the values using the nearest-rank method, so the result is always one
+// of the values
+func percentile[N number](values []N, p int) N {
+	sorted := append([]N{}, values...)
+	slices.Sort(sorted)
+
+	// rank = ceil(p / 100 * n)
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// setCount sets a field to a number of entries. Strings are set to its decimal representation.
+func setCount(field reflect.Value, count int) {
+	switch field.Kind() {
+	case reflect.Int,
+		reflect.Int8,
+		reflect.Int16,
+		reflect.Int32,
+		reflect.Int64:
+		field.SetInt(int64(count))
+	case reflect.Uint,
+		reflect.Uint8,
+		reflect.Uint16,
+		reflect.Uint32,
+		reflect.Uint64:
+		field.SetUint(uint64(count))
+	case reflect.Float32,
+		reflect.Float64:
+		field.SetFloat(float64(count))
+	case reflect.String:
+		field.SetString(strconv.Itoa(count))
+	}
+}
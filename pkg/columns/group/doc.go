@@ -15,7 +15,35 @@
 /*
 Package group can group the entries of an array by one or more columns. This will reduce the number of entries to
 the number of distinct values for the columns you group by. By default, the values of the first entry belonging to a
-group will be used, however, you can specify the `group` attribute to add up the values of a given
-field.
+group will be used, however, you can specify the `group` attribute to aggregate the values of a given field:
+
+	| Value    | Result                                                             |
+	|----------|--------------------------------------------------------------------|
+	| sum      | sum of the values                                                  |
+	| count    | number of entries in the group                                     |
+	| min      | lowest value                                                       |
+	| max      | highest value                                                      |
+	| avg      | mean of the values (truncated for integers)                        |
+	| p50      | median of the values                                               |
+	| p95, p99 | 95th and 99th percentile of the values (nearest-rank method)       |
+	| distinct | number of distinct values                                          |
+
+count and distinct can also be used on string fields, that will be set to the decimal representation of the
+number. The attribute can be overridden for specific columns using GroupEntriesWithAggregations():
+
+	aggregations, err := group.ParseAggregations(columnMap, []string{"latency:p99"})
+	...
+	grouped, err := group.GroupEntriesWithAggregations(columnMap, entries, []string{"pod"}, aggregations)
+
+When grouping by several columns, the entries are grouped by each of them in order, aggregating the result of the
+previous step. count and avg take into account the number of entries aggregated in the previous steps, but
+percentiles and distinct can't be computed that way, so they make it return an error. Use GroupEntriesByColumns() instead to group the entries having the same values in all the columns, like
+"GROUP BY pod, comm" would do in SQL. It also returns the number of entries in each group:
+
+	groups, err := group.GroupEntriesByColumns(columnMap, entries, []string{"pod", "comm"}, nil)
+	...
+	for _, g := range groups {
+		fmt.Println(g.Entry.Pod, g.Entry.Comm, g.Count)
+	}
 */
 package group
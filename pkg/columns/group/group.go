@@ -20,6 +20,8 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/sort"
 )
@@ -49,12 +51,29 @@ func getStringFromValue(value reflect.Value) string {
 // GroupEntries will group the given entries using the column names given in groupBy and return a new
 // array with the results; if groupBy contains an empty string, all given entries will be grouped
 func GroupEntries[T any](columns columns.ColumnMap[T], entries []*T, groupBy []string) ([]*T, error) {
+	return GroupEntriesWithAggregations(columns, entries, groupBy, nil)
+}
+
+// GroupEntriesWithAggregations works like GroupEntries, but the columns in aggregations are aggregated using the given
+// group type instead of the one defined by their group attribute. The keys of aggregations are lowercase column
+// names, see ParseAggregations(). Grouping by several columns fails if a column uses a percentile or distinct, since
+// they can't be computed from the result of the previous step.
+func GroupEntriesWithAggregations[T any](columns columns.ColumnMap[T], entries []*T, groupBy []string, aggregations map[string]columns.GroupType) ([]*T, error) {
 	if entries == nil {
 		return nil, nil
 	}
 
+	if len(groupBy) > 1 {
+		if err := checkReaggregation(columns, aggregations); err != nil {
+			return nil, err
+		}
+	}
+
 	newEntries := entries
 
+	// weights holds the number of original entries each of newEntries stands for, nil means one each
+	var weights map[*T]int
+
 	for _, groupName := range groupBy {
 		groupName = strings.ToLower(groupName)
 
@@ -75,7 +94,7 @@ func GroupEntries[T any](columns columns.ColumnMap[T], entries []*T, groupBy []s
 			groupMap[""] = allValues
 
 			outEntries := make([]*T, 0, len(groupMap))
-			flattenValues(columns, &outEntries, groupMap)
+			flattenValues(columns, &outEntries, groupMap, aggregations, weights)
 
 			// We may exit now, since grouping more fields makes no sense after this
 			return outEntries, nil
@@ -109,7 +128,7 @@ func GroupEntries[T any](columns columns.ColumnMap[T], entries []*T, groupBy []s
 		}
 
 		outEntries := make([]*T, 0, len(groupMap))
-		flattenValues(columns, &outEntries, groupMap)
+		weights = flattenValues(columns, &outEntries, groupMap, aggregations, weights)
 
 		// Sort by groupName to get a deterministic result
 		sort.SortEntries(columns, outEntries, []string{groupName})
@@ -120,46 +139,134 @@ func GroupEntries[T any](columns columns.ColumnMap[T], entries []*T, groupBy []s
 	return newEntries, nil
 }
 
-func flattenValues[T any](cols columns.ColumnMap[T], outEntries *[]*T, groupMap map[string][]reflect.Value) {
-	entriesVal := reflect.ValueOf(outEntries).Elem()
+// checkReaggregation verifies that the aggregated values of all the columns keep their meaning when they are
+// aggregated again
+func checkReaggregation[T any](cols columns.ColumnMap[T], aggregations map[string]columns.GroupType) error {
+	for name, column := range cols.GetColumnMap() {
+		groupType := column.GroupType
+		if aggregation, ok := aggregations[name]; ok {
+			groupType = aggregation
+		}
+		switch groupType {
+		case columns.GroupTypeP50, columns.GroupTypeP95, columns.GroupTypeP99, columns.GroupTypeDistinct:
+			return fmt.Errorf("column %q can't be aggregated with %q when grouping by several columns, use GroupEntriesByColumns()", name, groupType)
+		}
+	}
+	return nil
+}
+
+// Group is a group of entries having the same values in the columns they were grouped by
+type Group[T any] struct {
+	// Entry holds the values of the group, aggregated like in GroupEntriesWithAggregations()
+	Entry *T
+
+	// Count is the number of entries in the group
+	Count int
+}
+
+// GroupEntriesByColumns groups the entries having the same values in all the columns given in groupBy, unlike
+// GroupEntries, which groups them by each of the columns in turn. The columns are aggregated like in
+// GroupEntriesWithAggregations() and the groups are sorted by the groupBy columns, then by the values they were grouped
+// by.
+func GroupEntriesByColumns[T any](cols columns.ColumnMap[T], entries []*T, groupBy []string, aggregations map[string]columns.GroupType) ([]Group[T], error) {
+	groupColumns := make([]*columns.Column[T], 0, len(groupBy))
+	for _, groupName := range groupBy {
+		column, ok := cols.GetColumn(strings.ToLower(groupName))
+		if !ok {
+			return nil, fmt.Errorf("could not group by %q: column not found", groupName)
+		}
+		groupColumns = append(groupColumns, column)
+	}
+
+	groupMap := make(map[string][]reflect.Value)
+
+	var key strings.Builder
+	for _, entry := range entries {
+		if entry == nil {
+			// Skip nil entries
+			continue
+		}
+
+		entryVal := reflect.ValueOf(entry)
+
+		key.Reset()
+		for _, column := range groupColumns {
+			key.WriteString(getStringFromValue(column.GetRef(entryVal.Elem())))
+			// Use a separator that can't be confused with the values
+			key.WriteByte(0)
+		}
+
+		groupMap[key.String()] = append(groupMap[key.String()], entryVal)
+	}
+
+	// Go through the groups in the order of their keys, so the ones the sort below can't tell apart (e.g. because a
+	// column isn't sortable) always keep the same order
+	keys := make([]string, 0, len(groupMap))
+	for k := range groupMap {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	outEntries := make([]*T, 0, len(groupMap))
+	counts := make(map[*T]int, len(groupMap))
+	for _, k := range keys {
+		entry, count := flattenGroup(cols, groupMap[k], aggregations, nil)
+		outEntries = append(outEntries, entry)
+		counts[entry] = count
+	}
+
+	// Sort by the groupBy columns to get a deterministic result, the sort is stable so the keys are the last
+	// tie-breaker
+	sort.SortEntries(cols, outEntries, groupBy)
+
+	groups := make([]Group[T], 0, len(outEntries))
+	for _, entry := range outEntries {
+		groups = append(groups, Group[T]{Entry: entry, Count: counts[entry]})
+	}
 
+	return groups, nil
+}
+
+// flattenValues appends the aggregated entry of each group to outEntries and returns the number of original entries
+// each of them stands for
+func flattenValues[T any](cols columns.ColumnMap[T], outEntries *[]*T, groupMap map[string][]reflect.Value, aggregations map[string]columns.GroupType, weights map[*T]int) map[*T]int {
+	newWeights := make(map[*T]int, len(groupMap))
 	for _, v := range groupMap {
-		// Use first entry as base
-		entry := reflect.New(v[0].Elem().Type())
-		entry.Elem().Set(v[0].Elem())
-		for i := 1; i < len(v); i++ {
-			curEntry := v[i]
-			for _, column := range cols.GetColumnMap() {
-				switch column.GroupType {
-				case columns.GroupTypeNone:
-					continue
-				case columns.GroupTypeSum:
-					switch column.Kind() {
-					case reflect.Int,
-						reflect.Int8,
-						reflect.Int16,
-						reflect.Int32,
-						reflect.Int64:
-						cur := column.GetRef(entry).Int() + column.GetRef(curEntry).Int()
-						column.GetRef(entry).SetInt(cur)
-					case reflect.Uint,
-						reflect.Uint8,
-						reflect.Uint16,
-						reflect.Uint32,
-						reflect.Uint64:
-						cur := column.GetRef(entry).Uint() + column.GetRef(curEntry).Uint()
-						column.GetRef(entry).SetUint(cur)
-					case reflect.Float32,
-						reflect.Float64:
-						cur := column.GetRef(entry).Float() + column.GetRef(curEntry).Float()
-						column.GetRef(entry).SetFloat(cur)
-					}
-				}
-			}
+		entry, weight := flattenGroup(cols, v, aggregations, weights)
+		*outEntries = append(*outEntries, entry)
+		newWeights[entry] = weight
+	}
+	return newWeights
+}
+
+// flattenGroup returns a new entry with the values of the first entry of the group and its columns aggregated, along
+// with the number of original entries it stands for. weights holds that number for each of the values when they are
+// the result of a previous aggregation, nil means one each.
+func flattenGroup[T any](cols columns.ColumnMap[T], values []reflect.Value, aggregations map[string]columns.GroupType, weights map[*T]int) (*T, int) {
+	var counts []int
+	total := len(values)
+	if weights != nil {
+		counts = make([]int, 0, len(values))
+		total = 0
+		for _, v := range values {
+			counts = append(counts, weights[v.Interface().(*T)])
+			total += weights[v.Interface().(*T)]
 		}
+	}
 
-		entriesVal = reflect.Append(entriesVal, entry)
+	// Use first entry as base
+	entry := reflect.New(values[0].Elem().Type())
+	entry.Elem().Set(values[0].Elem())
+	for name, column := range cols.GetColumnMap() {
+		groupType := column.GroupType
+		if aggregation, ok := aggregations[name]; ok {
+			groupType = aggregation
+		}
+		if groupType == columns.GroupTypeNone {
+			continue
+		}
+		aggregate(column, groupType, entry.Interface().(*T), values, counts)
 	}
 
-	reflect.ValueOf(outEntries).Elem().Set(entriesVal)
+	return entry.Interface().(*T), total
 }
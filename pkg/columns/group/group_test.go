@@ -15,6 +15,7 @@
 package group
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -169,3 +170,174 @@ func TestGroupSum(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupAggregations(t *testing.T) {
+	type testStruct struct {
+		Name     string  `column:"name"`
+		Count    int     `column:"count,group:count"`
+		Min      int64   `column:"min,group:min"`
+		Max      uint32  `column:"max,group:max"`
+		Avg      float64 `column:"avg,group:avg"`
+		Latency  uint64  `column:"lat,group:p50"`
+		Comm     string  `column:"comm,group:distinct"`
+		Override int     `column:"override,group:sum"`
+	}
+
+	entries := []*testStruct{}
+	for i := 1; i <= 100; i++ {
+		entries = append(entries, &testStruct{
+			Name:     "a",
+			Min:      int64(i),
+			Max:      uint32(i),
+			Avg:      float64(i),
+			Latency:  uint64(101 - i),
+			Comm:     fmt.Sprintf("comm%d", i%3),
+			Override: i,
+		})
+	}
+	entries = append(entries, &testStruct{Name: "b", Min: -1, Max: 5, Avg: 2.5, Latency: 7, Comm: "x", Override: 3}, nil)
+
+	cols, err := columns.NewColumns[testStruct]()
+	if err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+	cmap := cols.GetColumnMap()
+
+	result, err := GroupEntries(cmap, entries, []string{"name"})
+	if err != nil {
+		t.Fatalf("While grouping: %v", err)
+	}
+
+	expected := []*testStruct{
+		{Name: "a", Count: 100, Min: 1, Max: 100, Avg: 50.5, Latency: 50, Comm: "3", Override: 5050},
+		{Name: "b", Count: 1, Min: -1, Max: 5, Avg: 2.5, Latency: 7, Comm: "1", Override: 3},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		for _, entry := range result {
+			t.Logf("%+v", entry)
+		}
+		t.Fatalf("Unexpected result")
+	}
+
+	aggregations, err := ParseAggregations(cmap, []string{"LAT:p99", "override:max"})
+	if err != nil {
+		t.Fatalf("Parsing aggregations: %v", err)
+	}
+
+	result, err = GroupEntriesWithAggregations(cmap, entries, []string{"name"}, aggregations)
+	if err != nil {
+		t.Fatalf("While grouping: %v", err)
+	}
+	if result[0].Latency != 99 || result[0].Override != 100 {
+		t.Fatalf("Unexpected result with aggregations: %+v", result[0])
+	}
+	if result[1].Latency != 7 || result[1].Override != 3 {
+		t.Fatalf("Unexpected result with aggregations: %+v", result[1])
+	}
+
+	// Percentiles of percentiles are meaningless
+	if _, err := GroupEntriesWithAggregations(cmap, entries, []string{"name", "comm"}, aggregations); err == nil {
+		t.Fatalf("Expected error grouping by several columns with p99")
+	}
+
+	for _, aggregation := range []string{"lat", "foo:p99", "lat:p42", "name:sum", "name:avg"} {
+		if _, err := ParseAggregations(cmap, []string{aggregation}); err == nil {
+			t.Errorf("Expected error parsing aggregation %q", aggregation)
+		}
+	}
+}
+
+func TestGroupSeveralColumnsAvg(t *testing.T) {
+	type testStruct struct {
+		Pod     string `column:"pod"`
+		Comm    string `column:"comm"`
+		Count   int    `column:"count,group:count"`
+		Latency uint64 `column:"lat,group:avg"`
+	}
+
+	entries := []*testStruct{
+		{Pod: "a", Comm: "cat", Latency: 10},
+		{Pod: "a", Comm: "cat", Latency: 20},
+		{Pod: "a", Comm: "cat", Latency: 30},
+		{Pod: "a", Comm: "ls", Latency: 100},
+		{Pod: "b", Comm: "ls", Latency: 5},
+		{Pod: "c", Comm: "cat", Latency: 10},
+	}
+
+	cols, err := columns.NewColumns[testStruct]()
+	if err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+	cmap := cols.GetColumnMap()
+
+	// Grouping by pod first gives a (4 entries, avg 40), b and c (1 entry each), the average of cat must still be
+	// computed from its 5 entries and not from the averages of a and c
+	result, err := GroupEntries(cmap, entries, []string{"pod", "comm"})
+	if err != nil {
+		t.Fatalf("While grouping: %v", err)
+	}
+
+	expected := []*testStruct{
+		{Pod: "a", Comm: "cat", Count: 5, Latency: 34},
+		{Pod: "b", Comm: "ls", Count: 1, Latency: 5},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		for _, entry := range result {
+			t.Logf("%+v", entry)
+		}
+		t.Fatalf("Unexpected result")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []uint64{15, 20, 35, 40, 50}
+
+	for p, expected := range map[int]uint64{5: 15, 30: 20, 40: 20, 50: 35, 99: 50, 100: 50} {
+		if v := percentile(values, p); v != expected {
+			t.Errorf("percentile(%v, %d) = %d, expected %d", values, p, v, expected)
+		}
+	}
+}
+
+func TestGroupByColumns(t *testing.T) {
+	type testStruct struct {
+		Pod   string `column:"pod"`
+		Comm  string `column:"comm"`
+		Bytes int    `column:"bytes,group:sum"`
+	}
+
+	entries := []*testStruct{
+		{Pod: "b", Comm: "cat", Bytes: 1},
+		{Pod: "a", Comm: "cat", Bytes: 2},
+		{Pod: "a", Comm: "ls", Bytes: 3},
+		nil,
+		{Pod: "a", Comm: "cat", Bytes: 4},
+	}
+
+	cols, err := columns.NewColumns[testStruct]()
+	if err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+	cmap := cols.GetColumnMap()
+
+	result, err := GroupEntriesByColumns(cmap, entries, []string{"pod", "Comm"}, nil)
+	if err != nil {
+		t.Fatalf("While grouping: %v", err)
+	}
+
+	expected := []Group[testStruct]{
+		{Entry: &testStruct{Pod: "a", Comm: "cat", Bytes: 6}, Count: 2},
+		{Entry: &testStruct{Pod: "a", Comm: "ls", Bytes: 3}, Count: 1},
+		{Entry: &testStruct{Pod: "b", Comm: "cat", Bytes: 1}, Count: 1},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		for _, g := range result {
+			t.Logf("%+v (%d)", g.Entry, g.Count)
+		}
+		t.Fatalf("Unexpected result")
+	}
+
+	if _, err := GroupEntriesByColumns(cmap, entries, []string{"foo"}, nil); err == nil {
+		t.Fatalf("Expected error grouping by unknown column")
+	}
+}
@@ -14,6 +14,8 @@
 
 package columns
 
+import "fmt"
+
 // Alignment defines whether text should be aligned to the left or right inside a column
 type Alignment int
 
@@ -26,10 +28,64 @@ const (
 type GroupType int
 
 const (
-	GroupTypeNone GroupType = iota // GroupTypeNone uses the first occurrence of a value in a group to represent its group
-	GroupTypeSum                   // GroupTypeSum adds values of this column up for its group
+	GroupTypeNone     GroupType = iota // GroupTypeNone uses the first occurrence of a value in a group to represent its group
+	GroupTypeSum                       // GroupTypeSum adds values of this column up for its group
+	GroupTypeCount                     // GroupTypeCount uses the number of entries of its group
+	GroupTypeMin                       // GroupTypeMin uses the lowest value of this column in its group
+	GroupTypeMax                       // GroupTypeMax uses the highest value of this column in its group
+	GroupTypeAvg                       // GroupTypeAvg uses the mean of the values of this column in its group
+	GroupTypeP50                       // GroupTypeP50 uses the median of the values of this column in its group
+	GroupTypeP95                       // GroupTypeP95 uses the 95th percentile of the values of this column in its group
+	GroupTypeP99                       // GroupTypeP99 uses the 99th percentile of the values of this column in its group
+	GroupTypeDistinct                  // GroupTypeDistinct uses the number of distinct values of this column in its group
 )
 
+var groupTypeNames = map[GroupType]string{
+	GroupTypeNone:     "none",
+	GroupTypeSum:      "sum",
+	GroupTypeCount:    "count",
+	GroupTypeMin:      "min",
+	GroupTypeMax:      "max",
+	GroupTypeAvg:      "avg",
+	GroupTypeP50:      "p50",
+	GroupTypeP95:      "p95",
+	GroupTypeP99:      "p99",
+	GroupTypeDistinct: "distinct",
+}
+
+// GroupTypeNames returns the names of all the group types that can be used in
+// the group tag, sorted by their value
+func GroupTypeNames() []string {
+	names := make([]string, 0, len(groupTypeNames))
+	for gt := GroupTypeNone; int(gt) < len(groupTypeNames); gt++ {
+		names = append(names, groupTypeNames[gt])
+	}
+	return names
+}
+
+func (gt GroupType) String() string {
+	if name, ok := groupTypeNames[gt]; ok {
+		return name
+	}
+	return fmt.Sprintf("GroupType(%d)", int(gt))
+}
+
+// ParseGroupType returns the group type with the given name, like "sum" or "p99"
+func ParseGroupType(name string) (GroupType, error) {
+	for gt, gtName := range groupTypeNames {
+		if gtName == name {
+			return gt, nil
+		}
+	}
+	return GroupTypeNone, fmt.Errorf("invalid group type %q", name)
+}
+
+// IsCount returns true if the group type results in a number of entries instead
+// of being computed from the values of the column
+func (gt GroupType) IsCount() bool {
+	return gt == GroupTypeCount || gt == GroupTypeDistinct
+}
+
 // Order defines the sorting order of columns
 type Order bool
 
@@ -51,6 +51,9 @@ type ContainerCollection struct {
 	// gather initial containers and then call the enrichers
 	initialContainers []*Container
 
+	// history keeps the removed containers and the lifecycle events
+	history containerHistory
+
 	// nodeName is used by the Enrich() function
 	nodeName string
 
@@ -98,6 +101,7 @@ initialContainersLoop:
 		}
 
 		cc.containers.Store(container.ID, container)
+		cc.history.record(EventTypeAddContainer, container, "")
 		if cc.pubsub != nil {
 			cc.pubsub.Publish(EventTypeAddContainer, container)
 		}
@@ -122,15 +126,24 @@ func (cc *ContainerCollection) GetContainer(id string) *Container {
 // RemoveContainer removes a container from the collection, but only after
 // notifying all the subscribers.
 func (cc *ContainerCollection) RemoveContainer(id string) {
+	cc.RemoveContainerWithReason(id, "")
+}
+
+// RemoveContainerWithReason removes a container from the collection like
+// RemoveContainer, telling why it was removed, e.g. its exit status.
+func (cc *ContainerCollection) RemoveContainerWithReason(id string, reason string) {
 	v, loaded := cc.containers.Load(id)
 	if !loaded {
 		return
 	}
 
 	if cc.pubsub != nil {
-		cc.pubsub.Publish(EventTypeRemoveContainer, v.(*Container))
+		cc.pubsub.publish(EventTypeRemoveContainer, v.(*Container), reason)
 	}
 
+	// Keep the container to enrich the events received after its removal
+	cc.history.record(EventTypeRemoveContainer, v.(*Container), reason)
+
 	// Remove the container from the collection after publishing the event as
 	// subscribers might need to use the different collection's lookups during
 	// the notification handler, and they expect the container to still be
@@ -153,6 +166,7 @@ func (cc *ContainerCollection) AddContainer(container *Container) {
 	if loaded {
 		return
 	}
+	cc.history.record(EventTypeAddContainer, container, "")
 	if cc.pubsub != nil {
 		cc.pubsub.Publish(EventTypeAddContainer, container)
 	}
@@ -325,10 +339,16 @@ func (cc *ContainerCollection) EnrichByMntNs(event *eventtypes.CommonData, mount
 	event.Node = cc.nodeName
 
 	container := cc.LookupContainerByMntns(mountnsid)
+	if container == nil {
+		// The event can arrive after the removal of its container
+		container = cc.LookupRemovedContainerByMntns(mountnsid)
+	}
 	if container != nil {
 		event.Container = container.Name
 		event.Pod = container.Podname
 		event.Namespace = container.Namespace
+		event.Image = container.Image
+		event.ImageDigest = container.ImageDigest
 	}
 }
 
@@ -336,6 +356,9 @@ func (cc *ContainerCollection) EnrichByNetNs(event *eventtypes.CommonData, netns
 	event.Node = cc.nodeName
 
 	containers := cc.LookupContainersByNetns(netnsid)
+	if len(containers) == 0 {
+		containers = cc.LookupRemovedContainersByNetns(netnsid)
+	}
 	if len(containers) == 0 || containers[0].HostNetwork {
 		return
 	}
@@ -343,6 +366,8 @@ func (cc *ContainerCollection) EnrichByNetNs(event *eventtypes.CommonData, netns
 		event.Container = containers[0].Name
 		event.Pod = containers[0].Podname
 		event.Namespace = containers[0].Namespace
+		event.Image = containers[0].Image
+		event.ImageDigest = containers[0].ImageDigest
 		return
 	}
 	if containers[0].Podname != "" && containers[0].Namespace != "" {
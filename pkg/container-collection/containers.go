@@ -17,6 +17,7 @@ package containercollection
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	ocispec "github.com/opencontainers/runtime-spec/specs-go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,14 +65,43 @@ type Container struct {
 	Labels    map[string]string `json:"labels,omitempty"`
 	PodUID    string            `json:"podUID,omitempty"`
 
+	// Image metadata
+	Image       string `json:"image,omitempty" column:"image,template:image" columnTags:"runtime"`
+	ImageDigest string `json:"imageDigest,omitempty" column:"imageDigest,template:imageDigest" columnTags:"runtime"`
+
+	// StartedAt is the time when the container started, if known
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// RestartCount is the number of times Kubernetes restarted the
+	// container
+	RestartCount int `json:"restartCount,omitempty"`
+
+	// CPU limit in millicores and memory limit in bytes, or 0 if the
+	// container has no limit or it's unknown
+	CPULimit    int64 `json:"cpuLimit,omitempty"`
+	MemoryLimit int64 `json:"memoryLimit,omitempty"`
+
 	ownerReference *metav1.OwnerReference
 }
 
+// ContainerSelector selects containers. Namespace, Podname, Name and Image
+// are comma-separated lists of names, glob patterns (e.g. "nginx-*") or
+// regular expressions between slashes (e.g. "/^web-[0-9]+$/"). Items
+// prefixed by "!" exclude the names they match. Labels must all be equal and
+// LabelExpressions use the set-based requirements of Kubernetes selectors.
+// Empty fields match all containers.
+//
+// Image items match the image name as given by the runtime (e.g.
+// "docker.io/library/nginx:1.23"), its short form without the default
+// registry (e.g. "nginx:1.23"), both of them without tag (e.g. "nginx"), and
+// the image digest (e.g. "sha256:5f3d...").
 type ContainerSelector struct {
-	Namespace string
-	Podname   string
-	Labels    map[string]string
-	Name      string
+	Namespace        string
+	Podname          string
+	Labels           map[string]string
+	LabelExpressions []metav1.LabelSelectorRequirement
+	Name             string
+	Image            string
 }
 
 // GetOwnerReference returns the owner reference information of the
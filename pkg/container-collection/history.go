@@ -0,0 +1,232 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containercollection
+
+import (
+	"sync"
+	"time"
+)
+
+// Default limits of the history of containers. They can be changed with
+// WithContainerHistory().
+const (
+	// DefaultTombstonesSize is the number of removed containers kept to
+	// enrich the events received after the removal of their container.
+	DefaultTombstonesSize = 256
+
+	// DefaultTombstonesTTL is how long removed containers are kept.
+	DefaultTombstonesTTL = 2 * time.Minute
+
+	// DefaultLifecycleEventsSize is the number of container creations and
+	// removals kept in the lifecycle history.
+	DefaultLifecycleEventsSize = 1024
+)
+
+// tombstone is a container removed from the collection
+type tombstone struct {
+	container *Container
+	removedAt time.Time
+}
+
+// containerHistory keeps the recently removed containers and the lifecycle
+// events of the containers. Its zero value uses the default limits.
+type containerHistory struct {
+	mu sync.RWMutex
+
+	tombstonesSize int
+	tombstonesTTL  time.Duration
+	lifecycleSize  int
+
+	// tombstones contains the removed containers, the oldest first. They
+	// are indexed by mount and network namespace in tombstonesByMntns and
+	// tombstonesByNetns, also the oldest first, as events are enriched
+	// with them.
+	tombstones        []*tombstone
+	tombstonesByMntns map[uint64][]*tombstone
+	tombstonesByNetns map[uint64][]*tombstone
+
+	// lifecycle is a ring buffer with the last lifecycle events, the
+	// next one being written at lifecycleNext
+	lifecycle     []PubSubEvent
+	lifecycleNext int
+
+	// now can be replaced for testing
+	now func() time.Time
+}
+
+func (h *containerHistory) limits() (tombstonesSize int, tombstonesTTL time.Duration, lifecycleSize int) {
+	tombstonesSize, tombstonesTTL, lifecycleSize = h.tombstonesSize, h.tombstonesTTL, h.lifecycleSize
+	if tombstonesSize == 0 {
+		tombstonesSize = DefaultTombstonesSize
+	}
+	if tombstonesTTL == 0 {
+		tombstonesTTL = DefaultTombstonesTTL
+	}
+	if lifecycleSize == 0 {
+		lifecycleSize = DefaultLifecycleEventsSize
+	}
+	return
+}
+
+func (h *containerHistory) timeNow() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+// record adds a lifecycle event to the history and keeps the removed
+// containers as tombstones.
+func (h *containerHistory) record(eventType EventType, container *Container, reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tombstonesSize, _, lifecycleSize := h.limits()
+	now := h.timeNow()
+
+	if lifecycleSize > 0 {
+		event := PubSubEvent{
+			Timestamp: now.Format(time.RFC3339),
+			Type:      eventType,
+			Container: container,
+			Reason:    reason,
+		}
+		if len(h.lifecycle) < lifecycleSize {
+			h.lifecycle = append(h.lifecycle, event)
+		} else {
+			h.lifecycle[h.lifecycleNext] = event
+		}
+		h.lifecycleNext = (h.lifecycleNext + 1) % lifecycleSize
+	}
+
+	if eventType != EventTypeRemoveContainer || tombstonesSize <= 0 {
+		return
+	}
+
+	// Expired tombstones are only removed here, lookups skip them
+	_, ttl, _ := h.limits()
+	for len(h.tombstones) > 0 &&
+		(len(h.tombstones) >= tombstonesSize || now.Sub(h.tombstones[0].removedAt) > ttl) {
+		h.removeOldestTombstone()
+	}
+
+	t := &tombstone{
+		container: container,
+		removedAt: now,
+	}
+	if h.tombstonesByMntns == nil {
+		h.tombstonesByMntns = make(map[uint64][]*tombstone)
+		h.tombstonesByNetns = make(map[uint64][]*tombstone)
+	}
+	h.tombstones = append(h.tombstones, t)
+	h.tombstonesByMntns[container.Mntns] = append(h.tombstonesByMntns[container.Mntns], t)
+	h.tombstonesByNetns[container.Netns] = append(h.tombstonesByNetns[container.Netns], t)
+}
+
+// removeOldestTombstone removes the oldest tombstone, which is also the
+// oldest one of its namespaces. It must be called with h.mu held.
+func (h *containerHistory) removeOldestTombstone() {
+	t := h.tombstones[0]
+	h.tombstones[0] = nil
+	h.tombstones = h.tombstones[1:]
+
+	removeFromIndex(h.tombstonesByMntns, t.container.Mntns)
+	removeFromIndex(h.tombstonesByNetns, t.container.Netns)
+}
+
+func removeFromIndex(index map[uint64][]*tombstone, key uint64) {
+	if len(index[key]) <= 1 {
+		delete(index, key)
+		return
+	}
+	index[key] = index[key][1:]
+}
+
+// lookupTombstonesByMntns returns the containers removed recently that ran
+// in the given mount namespace, the most recently removed first.
+func (h *containerHistory) lookupTombstonesByMntns(mntnsid uint64) []*Container {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.liveContainers(h.tombstonesByMntns[mntnsid])
+}
+
+// lookupTombstonesByNetns returns the containers removed recently that ran
+// in the given network namespace, the most recently removed first.
+func (h *containerHistory) lookupTombstonesByNetns(netnsid uint64) []*Container {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.liveContainers(h.tombstonesByNetns[netnsid])
+}
+
+// liveContainers returns the containers of the tombstones that didn't
+// expire, the most recently removed first. It must be called with h.mu held.
+func (h *containerHistory) liveContainers(tombstones []*tombstone) (containers []*Container) {
+	_, ttl, _ := h.limits()
+	now := h.timeNow()
+
+	for i := len(tombstones) - 1; i >= 0; i-- {
+		if now.Sub(tombstones[i].removedAt) > ttl {
+			// The older ones expired too
+			break
+		}
+		containers = append(containers, tombstones[i].container)
+	}
+	return containers
+}
+
+// events returns the lifecycle events, the oldest first.
+func (h *containerHistory) events() []PubSubEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, _, lifecycleSize := h.limits()
+	if len(h.lifecycle) < lifecycleSize {
+		return append([]PubSubEvent{}, h.lifecycle...)
+	}
+
+	return append(append([]PubSubEvent{}, h.lifecycle[h.lifecycleNext:]...), h.lifecycle[:h.lifecycleNext]...)
+}
+
+// LookupRemovedContainerByMntns returns the most recently removed container
+// with the given mount namespace inode id if it was removed recently enough
+// to still be kept by the collection, or nil.
+func (cc *ContainerCollection) LookupRemovedContainerByMntns(mntnsid uint64) *Container {
+	containers := cc.history.lookupTombstonesByMntns(mntnsid)
+	if len(containers) == 0 {
+		return nil
+	}
+	return containers[0]
+}
+
+// LookupRemovedContainersByNetns returns the recently removed containers
+// that ran in the given network namespace, the most recently removed first.
+func (cc *ContainerCollection) LookupRemovedContainersByNetns(netnsid uint64) []*Container {
+	return cc.history.lookupTombstonesByNetns(netnsid)
+}
+
+// LifecycleEvents returns the creations and removals of the containers
+// matching the selector kept in the history of the collection, the oldest
+// first.
+func (cc *ContainerCollection) LifecycleEvents(selector *ContainerSelector) []PubSubEvent {
+	events := []PubSubEvent{}
+	for _, event := range cc.history.events() {
+		if ContainerSelectorMatches(selector, event.Container) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
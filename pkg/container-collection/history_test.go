@@ -0,0 +1,155 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containercollection
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+func newTestCollection(t *testing.T, options ...ContainerCollectionOption) (*ContainerCollection, *time.Time) {
+	t.Helper()
+
+	cc := &ContainerCollection{}
+	if err := cc.Initialize(options...); err != nil {
+		t.Fatalf("Failed to initialize container collection: %s", err)
+	}
+
+	now := time.Unix(1665000000, 0)
+	cc.history.now = func() time.Time { return now }
+
+	return cc, &now
+}
+
+func TestEnrichRemovedContainer(t *testing.T) {
+	cc, now := newTestCollection(t, WithNodeName("node1"))
+
+	cc.AddContainer(&Container{
+		ID:        "abc",
+		Mntns:     55,
+		Netns:     66,
+		Namespace: "default",
+		Podname:   "job",
+		Name:      "worker",
+	})
+	cc.RemoveContainerWithReason("abc", "Completed (exit code 0)")
+
+	if cc.GetContainer("abc") != nil {
+		t.Fatalf("Container not removed")
+	}
+
+	var event eventtypes.CommonData
+	cc.EnrichByMntNs(&event, 55)
+	if event.Container != "worker" || event.Pod != "job" || event.Namespace != "default" {
+		t.Fatalf("Event not enriched by mount namespace: %+v", event)
+	}
+
+	event = eventtypes.CommonData{}
+	cc.EnrichByNetNs(&event, 66)
+	if event.Container != "worker" || event.Pod != "job" {
+		t.Fatalf("Event not enriched by network namespace: %+v", event)
+	}
+
+	// Removed containers are only kept for a while
+	*now = now.Add(DefaultTombstonesTTL + time.Second)
+
+	event = eventtypes.CommonData{}
+	cc.EnrichByMntNs(&event, 55)
+	if event.Container != "" {
+		t.Fatalf("Event enriched with expired container: %+v", event)
+	}
+}
+
+func TestTombstonesSize(t *testing.T) {
+	cc, _ := newTestCollection(t, WithContainerHistory(2, time.Hour, 0))
+
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("container%d", i)
+		cc.AddContainer(&Container{ID: id, Mntns: uint64(i)})
+		cc.RemoveContainer(id)
+	}
+
+	if cc.LookupRemovedContainerByMntns(1) != nil {
+		t.Fatalf("Oldest removed container not dropped")
+	}
+	for _, mntns := range []uint64{2, 3} {
+		if cc.LookupRemovedContainerByMntns(mntns) == nil {
+			t.Fatalf("Removed container with mntns %d not found", mntns)
+		}
+	}
+}
+
+func TestTombstonesByNetns(t *testing.T) {
+	cc, _ := newTestCollection(t, WithContainerHistory(2, time.Hour, 0))
+
+	// Containers of the same pod share the network namespace
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("container%d", i)
+		cc.AddContainer(&Container{ID: id, Mntns: uint64(i), Netns: 42})
+		cc.RemoveContainer(id)
+	}
+
+	containers := cc.LookupRemovedContainersByNetns(42)
+	if len(containers) != 2 || containers[0].ID != "container3" || containers[1].ID != "container2" {
+		t.Fatalf("Unexpected removed containers: %+v", containers)
+	}
+	if len(cc.history.tombstonesByMntns) != 2 || len(cc.history.tombstonesByNetns[42]) != 2 {
+		t.Fatalf("Dropped tombstone still indexed")
+	}
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	cc, _ := newTestCollection(t, WithContainerHistory(0, 0, 3))
+
+	cc.AddContainer(&Container{ID: "id1", Namespace: "ns1", Name: "c1"})
+	cc.AddContainer(&Container{ID: "id2", Namespace: "ns2", Name: "c2"})
+	cc.RemoveContainerWithReason("id1", "OOMKilled (exit code 137)")
+	cc.AddContainer(&Container{ID: "id3", Namespace: "ns1", Name: "c3"})
+
+	// The first event was dropped from the history
+	events := cc.LifecycleEvents(&ContainerSelector{})
+	if len(events) != 3 {
+		t.Fatalf("Got %d events, expected 3", len(events))
+	}
+
+	events = cc.LifecycleEvents(&ContainerSelector{Namespace: "ns1"})
+	if len(events) != 2 {
+		t.Fatalf("Got %d events, expected 2: %+v", len(events), events)
+	}
+	if events[0].Type != EventTypeRemoveContainer || events[0].Container.ID != "id1" ||
+		events[0].Reason != "OOMKilled (exit code 137)" {
+		t.Fatalf("Unexpected event %+v", events[0])
+	}
+	if events[1].Type != EventTypeAddContainer || events[1].Container.ID != "id3" {
+		t.Fatalf("Unexpected event %+v", events[1])
+	}
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	cc, _ := newTestCollection(t, WithContainerHistory(-1, 0, -1))
+
+	cc.AddContainer(&Container{ID: "id1", Mntns: 1})
+	cc.RemoveContainer("id1")
+
+	if cc.LookupRemovedContainerByMntns(1) != nil {
+		t.Fatalf("Removed container kept")
+	}
+	if events := cc.LifecycleEvents(&ContainerSelector{}); len(events) != 0 {
+		t.Fatalf("Unexpected events %+v", events)
+	}
+}
@@ -17,6 +17,7 @@ package containercollection
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -58,9 +59,14 @@ func NewK8sClient(nodeName string) (*K8sClient, error) {
 	// Get a runtime client to talk to the container runtime handling pods in
 	// this node.
 	list := strings.SplitN(node.Status.NodeInfo.ContainerRuntimeVersion, "://", 2)
+	runtimeName := list[0]
+	if !isKnownRuntime(runtimeName) && os.Getenv("INSPEKTOR_GADGET_CRI_SOCKETPATH") != "" {
+		// Use the generic CRI client for the other runtimes
+		runtimeName = runtimeclient.CRIName
+	}
 	runtimeClient, err := containerutils.NewContainerRuntimeClient(
 		&containerutils.RuntimeConfig{
-			Name: list[0],
+			Name: runtimeName,
 		})
 	if err != nil {
 		return nil, err
@@ -74,21 +80,46 @@ func NewK8sClient(nodeName string) (*K8sClient, error) {
 	}, nil
 }
 
+func isKnownRuntime(name string) bool {
+	for _, r := range containerutils.AvailableRuntimes {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (k *K8sClient) Close() {
 	k.runtimeClient.Close()
 }
 
-// GetNonRunningContainers returns the list of containers IDs that are not running.
-func (k *K8sClient) GetNonRunningContainers(pod *v1.Pod) []string {
-	ret := []string{}
+// GetNonRunningContainers returns the IDs of the containers that are not
+// running, with the reason of their termination if known.
+func (k *K8sClient) GetNonRunningContainers(pod *v1.Pod) map[string]string {
+	ret := make(map[string]string)
 
 	containerStatuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
 	containerStatuses = append(containerStatuses, pod.Status.ContainerStatuses...)
 
 	for _, s := range containerStatuses {
-		if s.ContainerID != "" && s.State.Running == nil {
-			ret = append(ret, s.ContainerID)
+		if s.ContainerID == "" || s.State.Running != nil {
+			continue
+		}
+
+		idParts := strings.SplitN(s.ContainerID, "//", 2)
+		if len(idParts) != 2 {
+			continue
+		}
+
+		reason := ""
+		if t := s.State.Terminated; t != nil {
+			reason = t.Reason
+			if reason == "" {
+				reason = "Terminated"
+			}
+			reason = fmt.Sprintf("%s (exit code %d)", reason, t.ExitCode)
 		}
+		ret[idParts[1]] = reason
 	}
 
 	return ret
@@ -125,19 +156,52 @@ func (k *K8sClient) PodToContainers(pod *v1.Pod) []Container {
 		}
 
 		containerDef := Container{
-			ID:        idParts[1],
-			Namespace: pod.GetNamespace(),
-			Podname:   pod.GetName(),
-			Name:      s.Name,
-			Labels:    labels,
-			Pid:       uint32(containerData.Pid),
+			ID:           idParts[1],
+			Namespace:    pod.GetNamespace(),
+			Podname:      pod.GetName(),
+			Name:         s.Name,
+			Labels:       labels,
+			Pid:          uint32(containerData.Pid),
+			Image:        s.Image,
+			ImageDigest:  runtimeclient.ParseImageDigest(s.ImageID),
+			RestartCount: int(s.RestartCount),
+			CPULimit:     containerData.CPULimit,
+			MemoryLimit:  containerData.MemoryLimit,
+		}
+		if startedAt := s.State.Running.StartedAt; !startedAt.IsZero() {
+			containerDef.StartedAt = &startedAt.Time
 		}
+		enrichContainerWithPodSpec(&containerDef, pod)
 		containers = append(containers, containerDef)
 	}
 
 	return containers
 }
 
+// enrichContainerWithPodSpec sets the image, if not known yet, and the
+// resource limits of the container from its specification in the pod.
+func enrichContainerWithPodSpec(container *Container, pod *v1.Pod) {
+	specs := append([]v1.Container{}, pod.Spec.InitContainers...)
+	specs = append(specs, pod.Spec.Containers...)
+
+	for _, spec := range specs {
+		if spec.Name != container.Name {
+			continue
+		}
+
+		if container.Image == "" {
+			container.Image = spec.Image
+		}
+		if cpu := spec.Resources.Limits.Cpu(); !cpu.IsZero() {
+			container.CPULimit = cpu.MilliValue()
+		}
+		if memory := spec.Resources.Limits.Memory(); !memory.IsZero() {
+			container.MemoryLimit = memory.Value()
+		}
+		return
+	}
+}
+
 // ListContainers return a list of the current containers that are
 // running in the node.
 func (k *K8sClient) ListContainers() (arr []Container, err error) {
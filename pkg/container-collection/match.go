@@ -1,4 +1,4 @@
-// Copyright 2019-2021 The Inspektor Gadget authors
+// Copyright 2019-2022 The Inspektor Gadget authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,21 +15,134 @@
 package containercollection
 
 import (
+	"fmt"
 	"strings"
 
-	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection/namefilter"
 )
 
+func nameMatches(filter string, name string) bool {
+	if filter == "" {
+		return true
+	}
+
+	patterns, err := namefilter.Compile(filter)
+	if err != nil {
+		// Invalid filters don't match anything. They are rejected when
+		// validating the selector.
+		return false
+	}
+
+	return patterns.Matches(name)
+}
+
+// imageNames returns the names an image filter is matched against: the image
+// as given by the runtime, its short form without the default registry, both
+// without tag and digest, and the image digest.
+func imageNames(image, digest string) []string {
+	var names []string
+	add := func(name string) {
+		if name == "" {
+			return
+		}
+		for _, n := range names {
+			if n == name {
+				return
+			}
+		}
+		names = append(names, name)
+	}
+
+	for _, name := range []string{image, shortImageName(image)} {
+		add(name)
+		add(imageRepository(name))
+	}
+	add(digest)
+
+	return names
+}
+
+// shortImageName removes the default registry and namespace of Docker Hub
+// from an image name, e.g. "docker.io/library/nginx:1.23" is "nginx:1.23".
+func shortImageName(image string) string {
+	image = strings.TrimPrefix(image, "docker.io/")
+	return strings.TrimPrefix(image, "library/")
+}
+
+// imageRepository removes the tag and the digest of an image name, e.g.
+// "nginx:1.23" is "nginx". The port of the registry isn't removed.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+func imageMatches(filter string, c *Container) bool {
+	if filter == "" {
+		return true
+	}
+
+	patterns, err := namefilter.Compile(filter)
+	if err != nil {
+		return false
+	}
+
+	return patterns.MatchesAny(imageNames(c.Image, c.ImageDigest))
+}
+
+func labelExpressionsSelector(expressions []metav1.LabelSelectorRequirement) (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchExpressions: expressions,
+	})
+}
+
+// ValidateNameFilter checks the syntax of a filter on namespaces, pod names
+// or container names of a ContainerSelector.
+func ValidateNameFilter(filter string) error {
+	return namefilter.Validate(filter)
+}
+
+// ValidateContainerSelector checks that the name filters and the label
+// expressions of a container selector are valid.
+func ValidateContainerSelector(s *ContainerSelector) error {
+	for _, filter := range []struct{ name, value string }{
+		{"namespace", s.Namespace},
+		{"pod name", s.Podname},
+		{"container name", s.Name},
+		{"image", s.Image},
+	} {
+		if err := ValidateNameFilter(filter.value); err != nil {
+			return fmt.Errorf("invalid %s filter: %w", filter.name, err)
+		}
+	}
+
+	if _, err := labelExpressionsSelector(s.LabelExpressions); err != nil {
+		return fmt.Errorf("invalid label expressions: %w", err)
+	}
+
+	return nil
+}
+
 // ContainerSelectorMatches tells if a container matches the criteria in a
 // container selector.
 func ContainerSelectorMatches(s *ContainerSelector, c *Container) bool {
-	if s.Namespace != "" && !slices.Contains(strings.Split(s.Namespace, ","), c.Namespace) {
+	if !nameMatches(s.Namespace, c.Namespace) {
 		return false
 	}
-	if s.Podname != "" && s.Podname != c.Podname {
+	if !nameMatches(s.Podname, c.Podname) {
 		return false
 	}
-	if s.Name != "" && s.Name != c.Name {
+	if !nameMatches(s.Name, c.Name) {
+		return false
+	}
+	if !imageMatches(s.Image, c) {
 		return false
 	}
 	for sk, sv := range s.Labels {
@@ -37,6 +150,12 @@ func ContainerSelectorMatches(s *ContainerSelector, c *Container) bool {
 			return false
 		}
 	}
+	if len(s.LabelExpressions) > 0 {
+		selector, err := labelExpressionsSelector(s.LabelExpressions)
+		if err != nil || !selector.Matches(labels.Set(c.Labels)) {
+			return false
+		}
+	}
 
 	return true
 }
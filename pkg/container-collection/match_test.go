@@ -124,6 +124,208 @@ func TestSelector(t *testing.T) {
 				Name:      "this-container",
 			},
 		},
+		{
+			description: "Excluded namespace",
+			match:       false,
+			selector: &ContainerSelector{
+				Namespace: "!kube-system",
+			},
+			container: &Container{
+				Namespace: "kube-system",
+				Podname:   "this-pod",
+				Name:      "this-container",
+			},
+		},
+		{
+			description: "Namespace not excluded",
+			match:       true,
+			selector: &ContainerSelector{
+				Namespace: "!kube-system,!kube-public",
+			},
+			container: &Container{
+				Namespace: "this-namespace",
+				Podname:   "this-pod",
+				Name:      "this-container",
+			},
+		},
+		{
+			description: "Glob on pod name with exclusion",
+			match:       false,
+			selector: &ContainerSelector{
+				Namespace: "prod",
+				Podname:   "*,!ingress-controller-*",
+			},
+			container: &Container{
+				Namespace: "prod",
+				Podname:   "ingress-controller-7f8d9",
+				Name:      "controller",
+			},
+		},
+		{
+			description: "Glob on pod name with exclusion not matching",
+			match:       true,
+			selector: &ContainerSelector{
+				Namespace: "prod",
+				Podname:   "*,!ingress-controller-*",
+			},
+			container: &Container{
+				Namespace: "prod",
+				Podname:   "web-7f8d9",
+				Name:      "nginx",
+			},
+		},
+		{
+			description: "Regex on container name",
+			match:       true,
+			selector: &ContainerSelector{
+				Name: "/^(nginx|envoy)$/",
+			},
+			container: &Container{
+				Namespace: "this-namespace",
+				Podname:   "this-pod",
+				Name:      "envoy",
+			},
+		},
+		{
+			description: "Regex with comma on container name without match",
+			match:       false,
+			selector: &ContainerSelector{
+				Name: "/^web-[0-9]{1,3}$/",
+			},
+			container: &Container{
+				Namespace: "this-namespace",
+				Podname:   "this-pod",
+				Name:      "web-1234",
+			},
+		},
+		{
+			description: "Label expressions matching",
+			match:       true,
+			selector: &ContainerSelector{
+				LabelExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend", "backend"}},
+					{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"dev"}},
+					{Key: "app", Operator: metav1.LabelSelectorOpExists},
+					{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+			container: &Container{
+				Namespace: "this-namespace",
+				Podname:   "this-pod",
+				Name:      "this-container",
+				Labels: map[string]string{
+					"tier": "backend",
+					"env":  "prod",
+					"app":  "web",
+				},
+			},
+		},
+		{
+			description: "Label expression with a label that must not exist",
+			match:       false,
+			selector: &ContainerSelector{
+				LabelExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+			container: &Container{
+				Namespace: "this-namespace",
+				Podname:   "this-pod",
+				Name:      "this-container",
+				Labels: map[string]string{
+					"canary": "true",
+				},
+			},
+		},
+		{
+			description: "Image matches without registry and tag",
+			match:       true,
+			selector: &ContainerSelector{
+				Image: "nginx",
+			},
+			container: &Container{
+				Name:  "this-container",
+				Image: "docker.io/library/nginx:1.23",
+			},
+		},
+		{
+			description: "Image matches with tag",
+			match:       true,
+			selector: &ContainerSelector{
+				Image: "nginx:1.23",
+			},
+			container: &Container{
+				Name:  "this-container",
+				Image: "docker.io/library/nginx:1.23",
+			},
+		},
+		{
+			description: "Image does not match other tag",
+			match:       false,
+			selector: &ContainerSelector{
+				Image: "nginx:1.22",
+			},
+			container: &Container{
+				Name:  "this-container",
+				Image: "docker.io/library/nginx:1.23",
+			},
+		},
+		{
+			description: "Image matches glob on registry",
+			match:       true,
+			selector: &ContainerSelector{
+				Image: "ghcr.io/myorg/*",
+			},
+			container: &Container{
+				Name:  "this-container",
+				Image: "ghcr.io/myorg/app:v2",
+			},
+		},
+		{
+			description: "Image matches digest",
+			match:       true,
+			selector: &ContainerSelector{
+				Image: "sha256:5f3d2a*",
+			},
+			container: &Container{
+				Name:        "this-container",
+				Image:       "docker.io/library/nginx:1.23",
+				ImageDigest: "sha256:5f3d2a8e1b",
+			},
+		},
+		{
+			description: "Image excluded",
+			match:       false,
+			selector: &ContainerSelector{
+				Image: "!busybox",
+			},
+			container: &Container{
+				Name:  "this-container",
+				Image: "busybox:latest",
+			},
+		},
+		{
+			description: "Unknown image",
+			match:       false,
+			selector: &ContainerSelector{
+				Image: "nginx",
+			},
+			container: &Container{
+				Name: "this-container",
+			},
+		},
+		{
+			description: "Invalid filter",
+			match:       false,
+			selector: &ContainerSelector{
+				Podname: "/[/",
+			},
+			container: &Container{
+				Namespace: "this-namespace",
+				Podname:   "this-pod",
+				Name:      "this-container",
+			},
+		},
 	}
 
 	for i, entry := range table {
@@ -135,6 +337,74 @@ func TestSelector(t *testing.T) {
 	}
 }
 
+func TestValidateSelector(t *testing.T) {
+	table := []struct {
+		description string
+		valid       bool
+		selector    *ContainerSelector
+	}{
+		{
+			description: "Selector without filter",
+			valid:       true,
+			selector:    &ContainerSelector{},
+		},
+		{
+			description: "Valid patterns",
+			valid:       true,
+			selector: &ContainerSelector{
+				Namespace: "prod,!kube-*",
+				Podname:   "/^web-[0-9]{1,3}$/,!/canary/",
+				Name:      "nginx",
+			},
+		},
+		{
+			description: "Invalid regular expression",
+			valid:       false,
+			selector: &ContainerSelector{
+				Podname: "/web-(/",
+			},
+		},
+		{
+			description: "Invalid glob pattern",
+			valid:       false,
+			selector: &ContainerSelector{
+				Name: "web-[",
+			},
+		},
+		{
+			description: "Empty item",
+			valid:       false,
+			selector: &ContainerSelector{
+				Namespace: "ns1,,ns2",
+			},
+		},
+		{
+			description: "Invalid image pattern",
+			valid:       false,
+			selector: &ContainerSelector{
+				Image: "nginx:[",
+			},
+		},
+		{
+			description: "Invalid label expression",
+			valid:       false,
+			selector: &ContainerSelector{
+				LabelExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpIn},
+				},
+			},
+		},
+	}
+
+	for i, entry := range table {
+		err := ValidateContainerSelector(entry.selector)
+		if entry.valid != (err == nil) {
+			t.Fatalf("Failed test %q (index %d): error %v, expected valid %v",
+				entry.description, i, err, entry.valid)
+		}
+	}
+}
+
 func TestContainerResolver(t *testing.T) {
 	opts := []ContainerCollectionOption{}
 
@@ -0,0 +1,205 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package namefilter implements the filters on namespaces, pod names,
+// container names and images of container selectors. It has no dependencies
+// on the container collection so clients can validate the filters.
+package namefilter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// namePattern matches a name (namespace, pod or container name) against one
+// of the items of a name filter
+type namePattern struct {
+	negated bool
+	exact   string
+	glob    string
+	regex   *regexp.Regexp
+}
+
+func (p *namePattern) matches(name string) bool {
+	switch {
+	case p.regex != nil:
+		return p.regex.MatchString(name)
+	case p.glob != "":
+		// The pattern was validated when compiled
+		ok, _ := path.Match(p.glob, name)
+		return ok
+	default:
+		return p.exact == name
+	}
+}
+
+// Filter is a compiled name filter
+type Filter []*namePattern
+
+// maxCachedFilters is the maximum number of compiled filters kept in
+// patternsCache. Selectors live as long as their tracers and there are
+// usually few of them, but the filters come from the users.
+const maxCachedFilters = 1024
+
+// patternsCache contains the name filters already compiled, to avoid
+// compiling them each time a container is checked. It's emptied when it
+// reaches maxCachedFilters entries.
+var patternsCache = struct {
+	sync.Mutex
+	filters map[string]Filter
+}{
+	filters: make(map[string]Filter),
+}
+
+// splitNameFilter splits a name filter on the commas that aren't part of a
+// regular expression.
+func splitNameFilter(filter string) []string {
+	var items []string
+	inRegex := false
+	start := 0
+
+	for i, c := range filter {
+		switch {
+		case c == '/' && (i == start || (i == start+1 && filter[start] == '!')):
+			inRegex = true
+		case c == '/' && inRegex:
+			inRegex = false
+		case c == ',' && !inRegex:
+			items = append(items, filter[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(items, filter[start:])
+}
+
+// Compile compiles a name filter: a comma-separated list of names, glob
+// patterns or regular expressions between slashes, each of them negated if
+// prefixed with "!".
+func Compile(filter string) (Filter, error) {
+	patternsCache.Lock()
+	cached, ok := patternsCache.filters[filter]
+	patternsCache.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var patterns Filter
+	for _, item := range splitNameFilter(filter) {
+		p := &namePattern{}
+
+		if strings.HasPrefix(item, "!") {
+			p.negated = true
+			item = item[1:]
+		}
+
+		switch {
+		case len(item) >= 2 && strings.HasPrefix(item, "/") && strings.HasSuffix(item, "/"):
+			regex, err := regexp.Compile(item[1 : len(item)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %w", item, err)
+			}
+			p.regex = regex
+		case strings.ContainsAny(item, "*?["):
+			if _, err := path.Match(item, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", item, err)
+			}
+			p.glob = item
+		case item == "":
+			return nil, fmt.Errorf("empty item in %q", filter)
+		default:
+			p.exact = item
+		}
+
+		patterns = append(patterns, p)
+	}
+
+	patternsCache.Lock()
+	if len(patternsCache.filters) >= maxCachedFilters {
+		patternsCache.filters = make(map[string]Filter)
+	}
+	patternsCache.filters[filter] = patterns
+	patternsCache.Unlock()
+
+	return patterns, nil
+}
+
+// Matches tells if a name matches at least one of the patterns that aren't
+// negated, if any, and none of the negated ones.
+func (patterns Filter) Matches(name string) bool {
+	hasIncluded := false
+	included := false
+	for _, p := range patterns {
+		if p.negated {
+			if p.matches(name) {
+				return false
+			}
+			continue
+		}
+
+		hasIncluded = true
+		if !included && p.matches(name) {
+			included = true
+		}
+	}
+
+	return !hasIncluded || included
+}
+
+// MatchesAny tells if at least one of the names matches at least one of the
+// patterns that aren't negated, if any, and none of the names matches the
+// negated ones.
+func (patterns Filter) MatchesAny(names []string) bool {
+	hasIncluded := false
+	included := false
+	for _, p := range patterns {
+		if !p.negated {
+			hasIncluded = true
+		}
+		for _, name := range names {
+			if !p.matches(name) {
+				continue
+			}
+			if p.negated {
+				return false
+			}
+			included = true
+		}
+	}
+
+	return !hasIncluded || included
+}
+
+// IsExact tells if a name filter only matches a single name, i.e. it's a valid
+// filter without patterns, regular expressions, negations or several items.
+func IsExact(filter string) bool {
+	patterns, err := Compile(filter)
+	if err != nil || len(patterns) != 1 {
+		return false
+	}
+	return !patterns[0].negated && patterns[0].exact != ""
+}
+
+// Validate checks the syntax of a name filter. An empty filter is valid.
+func Validate(filter string) error {
+	if filter == "" {
+		return nil
+	}
+
+	_, err := Compile(filter)
+	return err
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namefilter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsExact(t *testing.T) {
+	for filter, expected := range map[string]bool{
+		"mypod":       true,
+		"":            false,
+		"mypod-*":     false,
+		"/^mypod$/":   false,
+		"!mypod":      false,
+		"mypod,other": false,
+		"[":           false,
+	} {
+		if IsExact(filter) != expected {
+			t.Errorf("IsExact(%q) = %t, expected %t", filter, !expected, expected)
+		}
+	}
+}
+
+func TestPatternsCacheLimit(t *testing.T) {
+	for i := 0; i < 2*maxCachedFilters; i++ {
+		if _, err := Compile(fmt.Sprintf("pod-%d-*", i)); err != nil {
+			t.Fatalf("compiling filter: %v", err)
+		}
+	}
+
+	patternsCache.Lock()
+	defer patternsCache.Unlock()
+	if len(patternsCache.filters) > maxCachedFilters {
+		t.Fatalf("expected at most %d cached filters, got %d", maxCachedFilters, len(patternsCache.filters))
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
@@ -58,6 +59,14 @@ func enrichContainerWithContainerData(containerData *runtimeclient.ContainerData
 	container.Podname = containerData.PodName
 	container.PodUID = containerData.PodUID
 
+	// Image
+	if containerData.Image != "" {
+		container.Image = containerData.Image
+	}
+	if containerData.ImageDigest != "" {
+		container.ImageDigest = containerData.ImageDigest
+	}
+
 	// Notice we are temporarily using the runtime container name as the
 	// Kubernetes container name because the Container struct doesn't have that
 	// field, and we don't support filtering by runtime container name yet.
@@ -73,6 +82,19 @@ func enrichContainerWithContainerData(containerData *runtimeclient.ContainerData
 	}
 }
 
+func enrichContainerWithContainerDetailsData(containerDetails *runtimeclient.ContainerDetailsData, container *Container) {
+	enrichContainerWithContainerData(&containerDetails.ContainerData, container)
+
+	container.Pid = uint32(containerDetails.Pid)
+	if !containerDetails.StartedAt.IsZero() {
+		startedAt := containerDetails.StartedAt
+		container.StartedAt = &startedAt
+	}
+	container.RestartCount = containerDetails.RestartCount
+	container.CPULimit = containerDetails.CPULimit
+	container.MemoryLimit = containerDetails.MemoryLimit
+}
+
 func containerRuntimeEnricher(
 	runtimeName string,
 	runtimeClient runtimeclient.ContainerRuntimeClient,
@@ -181,8 +203,7 @@ func WithContainerRuntimeEnrichment(runtime *containerutils.RuntimeConfig) Conta
 			}
 
 			var c Container
-			c.Pid = uint32(containerDetails.Pid)
-			enrichContainerWithContainerData(&containerDetails.ContainerData, &c)
+			enrichContainerWithContainerDetailsData(containerDetails, &c)
 			cc.initialContainers = append(cc.initialContainers, &c)
 		}
 
@@ -242,7 +263,7 @@ func withPodInformer(nodeName string, fallbackMode bool) ContainerCollectionOpti
 					}
 					if containerIDs, ok := containerIDsByKey[d]; ok {
 						for containerID := range containerIDs {
-							cc.RemoveContainer(containerID)
+							cc.RemoveContainerWithReason(containerID, "pod deleted")
 						}
 					}
 				case c, ok := <-podInformer.CreatedChan():
@@ -258,13 +279,14 @@ func withPodInformer(nodeName string, fallbackMode bool) ContainerCollectionOpti
 
 					// first: remove containers that are not running anymore
 					nonrunning := k8sClient.GetNonRunningContainers(c)
-					for _, id := range nonrunning {
+					for id, reason := range nonrunning {
 						// container had not been added, no need to remove it
 						if _, ok := containerIDs[id]; !ok {
 							continue
 						}
 
-						cc.RemoveContainer(id)
+						cc.RemoveContainerWithReason(id, reason)
+						delete(containerIDs, id)
 					}
 
 					// second: add containers that are in running state
@@ -454,7 +476,8 @@ func WithKubernetesEnrichment(nodeName string, kubeconfig *rest.Config) Containe
 			podUID := ""
 			containerName := ""
 			labels := make(map[string]string)
-			for _, pod := range pods.Items {
+			var containerPod *v1.Pod
+			for i, pod := range pods.Items {
 				uid := string(pod.ObjectMeta.UID)
 				// check if this container is associated to this pod
 				uidWithUnderscores := strings.ReplaceAll(uid, "-", "_")
@@ -482,6 +505,7 @@ func WithKubernetesEnrichment(nodeName string, kubeconfig *rest.Config) Containe
 						pattern := fmt.Sprintf("pods/%s/containers/%s/", uid, c.Name)
 						if strings.Contains(m.Source, pattern) {
 							containerName = c.Name
+							containerPod = &pods.Items[i]
 							break
 						}
 					}
@@ -493,6 +517,9 @@ func WithKubernetesEnrichment(nodeName string, kubeconfig *rest.Config) Containe
 			container.PodUID = podUID
 			container.Name = containerName
 			container.Labels = labels
+			if containerPod != nil {
+				enrichContainerWithPodSpec(container, containerPod)
+			}
 
 			// drop pause containers
 			if container.Podname != "" && containerName == "" {
@@ -521,7 +548,7 @@ func WithRuncFanotify() ContainerCollectionOption {
 				}
 				cc.AddContainer(container)
 			case runcfanotify.EventTypeRemoveContainer:
-				cc.RemoveContainer(notif.ContainerID)
+				cc.RemoveContainerWithReason(notif.ContainerID, "container process terminated")
 			}
 		})
 		if err != nil {
@@ -638,6 +665,13 @@ func WithOCIConfigEnrichment() ContainerCollectionOption {
 			if podUID := resolver.PodUID(container.OciConfig.Annotations); podUID != "" {
 				container.PodUID = podUID
 			}
+			if image := resolver.ImageName(container.OciConfig.Annotations); image != "" {
+				container.Image = image
+			}
+			if digest := resolver.ImageDigest(container.OciConfig.Annotations); digest != "" {
+				container.ImageDigest = digest
+			}
+			enrichContainerWithOCIResources(container)
 
 			return true
 		})
@@ -645,6 +679,43 @@ func WithOCIConfigEnrichment() ContainerCollectionOption {
 	}
 }
 
+// enrichContainerWithOCIResources sets the CPU and memory limits of the
+// container from the resources of its OCI config
+func enrichContainerWithOCIResources(container *Container) {
+	if container.OciConfig.Linux == nil || container.OciConfig.Linux.Resources == nil {
+		return
+	}
+	resources := container.OciConfig.Linux.Resources
+
+	if cpu := resources.CPU; cpu != nil && cpu.Quota != nil {
+		var period int64
+		if cpu.Period != nil {
+			period = int64(*cpu.Period)
+		}
+		container.CPULimit = runtimeclient.CPUQuotaToMillicores(*cpu.Quota, period)
+	}
+	if memory := resources.Memory; memory != nil && memory.Limit != nil && *memory.Limit > 0 {
+		container.MemoryLimit = *memory.Limit
+	}
+}
+
+// WithContainerHistory sets how many removed containers are kept, and for
+// how long, to enrich the events received after the removal of their
+// container, and how many container creations and removals are kept in the
+// lifecycle history. Zero values keep the defaults and negative sizes
+// disable them.
+func WithContainerHistory(tombstonesSize int, tombstonesTTL time.Duration, lifecycleEventsSize int) ContainerCollectionOption {
+	return func(cc *ContainerCollection) error {
+		cc.history.mu.Lock()
+		defer cc.history.mu.Unlock()
+
+		cc.history.tombstonesSize = tombstonesSize
+		cc.history.tombstonesTTL = tombstonesTTL
+		cc.history.lifecycleSize = lifecycleEventsSize
+		return nil
+	}
+}
+
 func WithNodeName(nodeName string) ContainerCollectionOption {
 	return func(cc *ContainerCollection) error {
 		cc.nodeName = nodeName
@@ -63,6 +63,9 @@ type PubSubEvent struct {
 	Timestamp string     `json:"timestamp,omitempty" column:"timestamp,maxWidth:30" columnTags:"runtime"`
 	Type      EventType  `json:"event" column:"event,maxWidth:10" columnTags:"runtime"`
 	Container *Container `json:"container"`
+
+	// Reason tells why a container was removed, if known
+	Reason string `json:"reason,omitempty" column:"reason,maxWidth:40" columnTags:"runtime"`
 }
 
 // GadgetPubSub provides a synchronous publish subscribe mechanism for gadgets
@@ -105,6 +108,10 @@ func (g *GadgetPubSub) Unsubscribe(key interface{}) {
 }
 
 func (g *GadgetPubSub) Publish(eventType EventType, container *Container) {
+	g.publish(eventType, container, "")
+}
+
+func (g *GadgetPubSub) publish(eventType EventType, container *Container, reason string) {
 	// Make a copy so we don't keep the lock while actually publishing
 	g.mu.RLock()
 	copiedSubs := []FuncNotify{}
@@ -121,6 +128,7 @@ func (g *GadgetPubSub) Publish(eventType EventType, container *Container) {
 				Timestamp: time.Now().Format(time.RFC3339),
 				Type:      eventType,
 				Container: container,
+				Reason:    reason,
 			}
 			callback(event)
 			wg.Done()
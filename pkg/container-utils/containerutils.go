@@ -27,8 +27,10 @@ import (
 	ocispec "github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/containerd"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/cri"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/crio"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/docker"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/podman"
 	runtimeclient "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/runtime-client"
 )
 
@@ -36,6 +38,17 @@ var AvailableRuntimes = []string{
 	runtimeclient.DockerName,
 	runtimeclient.ContainerdName,
 	runtimeclient.CrioName,
+	runtimeclient.PodmanName,
+	runtimeclient.CRIName,
+}
+
+// DefaultRuntimes are the runtimes used when none is specified. The generic
+// CRI client isn't part of them as it needs a socket path, neither is Podman
+// as its API service usually isn't running.
+var DefaultRuntimes = []string{
+	runtimeclient.DockerName,
+	runtimeclient.ContainerdName,
+	runtimeclient.CrioName,
 }
 
 type RuntimeConfig struct {
@@ -63,6 +76,18 @@ func NewContainerRuntimeClient(runtime *RuntimeConfig) (runtimeclient.ContainerR
 			socketPath = envsp
 		}
 		return crio.NewCrioClient(socketPath)
+	case runtimeclient.PodmanName:
+		socketPath := runtime.SocketPath
+		if envsp := os.Getenv("INSPEKTOR_GADGET_PODMAN_SOCKETPATH"); envsp != "" && socketPath == "" {
+			socketPath = envsp
+		}
+		return podman.NewPodmanClient(socketPath)
+	case runtimeclient.CRIName:
+		socketPath := runtime.SocketPath
+		if envsp := os.Getenv("INSPEKTOR_GADGET_CRI_SOCKETPATH"); envsp != "" && socketPath == "" {
+			socketPath = envsp
+		}
+		return cri.NewGenericCRIClient(socketPath)
 	default:
 		return nil, fmt.Errorf("unknown container runtime: %s (available %s)",
 			runtime, strings.Join(AvailableRuntimes, ", "))
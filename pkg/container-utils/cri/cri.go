@@ -30,6 +30,10 @@ import (
 	pb "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
+// containerAnnotationRestartCount is the annotation set by the kubelet with
+// the number of restarts of a container
+const containerAnnotationRestartCount = "io.kubernetes.container.restartCount"
+
 // CRIClient implements the ContainerRuntimeClient interface using the CRI
 // plugin interface to communicate with the different container runtimes.
 type CRIClient struct {
@@ -147,12 +151,20 @@ func parseContainerDetailsData(runtimeName string, containerStatus *pb.Container
 	// Create container details structure to be filled.
 	containerDetailsData := &runtimeclient.ContainerDetailsData{
 		ContainerData: runtimeclient.ContainerData{
-			ID:      containerStatus.Id,
-			Name:    strings.TrimPrefix(containerStatus.GetMetadata().Name, "/"),
-			State:   containerStatusStateToRuntimeClientState(containerStatus.GetState()),
-			Runtime: runtimeName,
+			ID:          containerStatus.Id,
+			Name:        strings.TrimPrefix(containerStatus.GetMetadata().Name, "/"),
+			State:       containerStatusStateToRuntimeClientState(containerStatus.GetState()),
+			Runtime:     runtimeName,
+			Image:       containerStatus.GetImage().GetImage(),
+			ImageDigest: runtimeclient.ParseImageDigest(containerStatus.ImageRef),
 		},
 	}
+	if containerStatus.StartedAt > 0 {
+		containerDetailsData.StartedAt = time.Unix(0, containerStatus.StartedAt)
+	}
+	if restartCount, ok := containerStatus.Annotations[containerAnnotationRestartCount]; ok {
+		containerDetailsData.RestartCount, _ = strconv.Atoi(restartCount)
+	}
 
 	// Fill K8S information.
 	runtimeclient.EnrichWithK8sMetadata(&containerDetailsData.ContainerData, containerStatus.Labels)
@@ -183,6 +195,15 @@ func parseExtraInfo(extraInfo map[string]string,
 		} `json:"mounts,omitempty"`
 		Linux *struct {
 			CgroupsPath string `json:"cgroupsPath,omitempty"`
+			Resources   *struct {
+				CPU *struct {
+					Quota  int64 `json:"quota,omitempty"`
+					Period int64 `json:"period,omitempty"`
+				} `json:"cpu,omitempty"`
+				Memory *struct {
+					Limit int64 `json:"limit,omitempty"`
+				} `json:"memory,omitempty"`
+			} `json:"resources,omitempty"`
 		} `json:"linux,omitempty" platform:"linux"`
 	}
 	type InfoContent struct {
@@ -248,6 +269,16 @@ func parseExtraInfo(extraInfo map[string]string,
 	if runtimeSpec != nil {
 		if runtimeSpec.Linux != nil {
 			containerDetailsData.CgroupsPath = runtimeSpec.Linux.CgroupsPath
+
+			if resources := runtimeSpec.Linux.Resources; resources != nil {
+				if resources.CPU != nil {
+					containerDetailsData.CPULimit = runtimeclient.CPUQuotaToMillicores(
+						resources.CPU.Quota, resources.CPU.Period)
+				}
+				if resources.Memory != nil && resources.Memory.Limit > 0 {
+					containerDetailsData.MemoryLimit = resources.Memory.Limit
+				}
+			}
 		}
 		if len(runtimeSpec.Mounts) > 0 {
 			containerDetailsData.Mounts = make([]runtimeclient.ContainerMountData, len(runtimeSpec.Mounts))
@@ -282,10 +313,12 @@ func containerStatusStateToRuntimeClientState(containerStatusState pb.ContainerS
 
 func CRIContainerToContainerData(runtimeName string, container *pb.Container) *runtimeclient.ContainerData {
 	containerData := &runtimeclient.ContainerData{
-		ID:      container.Id,
-		Name:    strings.TrimPrefix(container.GetMetadata().Name, "/"),
-		State:   containerStatusStateToRuntimeClientState(container.GetState()),
-		Runtime: runtimeName,
+		ID:          container.Id,
+		Name:        strings.TrimPrefix(container.GetMetadata().Name, "/"),
+		State:       containerStatusStateToRuntimeClientState(container.GetState()),
+		Runtime:     runtimeName,
+		Image:       container.GetImage().GetImage(),
+		ImageDigest: runtimeclient.ParseImageDigest(container.ImageRef),
 	}
 
 	// Fill K8S information.
@@ -0,0 +1,60 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	runtimeclient "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/runtime-client"
+)
+
+const (
+	DefaultTimeout = 2 * time.Second
+)
+
+// GenericCRIClient is a CRIClient for the runtimes implementing the CRI that
+// don't have a specific client. They are only identified by their socket
+// path.
+type GenericCRIClient struct {
+	CRIClient
+}
+
+func NewGenericCRIClient(socketPath string) (runtimeclient.ContainerRuntimeClient, error) {
+	if socketPath == "" {
+		return nil, errors.New("the socket path of the CRI runtime must be set")
+	}
+
+	criClient, err := NewCRIClient(runtimeclient.CRIName, socketPath, DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenericCRIClient{
+		CRIClient: criClient,
+	}, nil
+}
+
+// GetContainerDetails accepts container IDs prefixed by any runtime name, like
+// the ones of the statuses of Kubernetes pods, as the name of the runtime
+// isn't known.
+func (c *GenericCRIClient) GetContainerDetails(containerID string) (*runtimeclient.ContainerDetailsData, error) {
+	if _, id, ok := strings.Cut(containerID, "://"); ok {
+		containerID = id
+	}
+
+	return c.CRIClient.GetContainerDetails(containerID)
+}
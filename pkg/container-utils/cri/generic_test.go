@@ -0,0 +1,183 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	runtimeclient "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/runtime-client"
+)
+
+// fakeRuntimeService implements the parts of the CRI runtime service used by
+// the client
+type fakeRuntimeService struct {
+	pb.UnimplementedRuntimeServiceServer
+
+	containers []*pb.Container
+}
+
+func (s *fakeRuntimeService) ListContainers(_ context.Context, req *pb.ListContainersRequest) (*pb.ListContainersResponse, error) {
+	res := &pb.ListContainersResponse{}
+	for _, c := range s.containers {
+		if id := req.GetFilter().GetId(); id != "" && id != c.Id {
+			continue
+		}
+		res.Containers = append(res.Containers, c)
+	}
+	return res, nil
+}
+
+func (s *fakeRuntimeService) ContainerStatus(_ context.Context, req *pb.ContainerStatusRequest) (*pb.ContainerStatusResponse, error) {
+	for _, c := range s.containers {
+		if c.Id != req.ContainerId {
+			continue
+		}
+		return &pb.ContainerStatusResponse{
+			Status: &pb.ContainerStatus{
+				Id:        c.Id,
+				Metadata:  c.Metadata,
+				State:     c.State,
+				Labels:    c.Labels,
+				Image:     c.Image,
+				ImageRef:  "docker.io/library/nginx@sha256:5f3d2a8e1b7c6d5e4f3a2b1c0d9e8f7a",
+				StartedAt: 1665000000000000000,
+				Annotations: map[string]string{
+					"io.kubernetes.container.restartCount": "3",
+				},
+			},
+			Info: map[string]string{
+				"info": `{"pid":1234,"runtimeSpec":{"linux":{"cgroupsPath":"/kubepods/pod1/abc",` +
+					`"resources":{"cpu":{"quota":25000,"period":100000},"memory":{"limit":268435456}}}}}`,
+			},
+		}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "container %q not found", req.ContainerId)
+}
+
+func newFakeCRIRuntime(t *testing.T, service *fakeRuntimeService) string {
+	t.Helper()
+
+	socketPath := filepath.Join(t.TempDir(), "cri.sock")
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s: %s", socketPath, err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterRuntimeServiceServer(server, service)
+	go server.Serve(l)
+	t.Cleanup(server.Stop)
+
+	return socketPath
+}
+
+func TestGenericCRIClient(t *testing.T) {
+	socketPath := newFakeCRIRuntime(t, &fakeRuntimeService{
+		containers: []*pb.Container{
+			{
+				Id:       "abc",
+				Metadata: &pb.ContainerMetadata{Name: "web"},
+				State:    pb.ContainerState_CONTAINER_RUNNING,
+				Image:    &pb.ImageSpec{Image: "docker.io/library/nginx:1.23"},
+				ImageRef: "sha256:5f3d2a8e1b7c6d5e4f3a2b1c0d9e8f7a",
+				Labels: map[string]string{
+					"io.kubernetes.pod.name":      "mypod",
+					"io.kubernetes.pod.namespace": "myns",
+					"io.kubernetes.pod.uid":       "uid1",
+				},
+			},
+			{
+				Id:       "def",
+				Metadata: &pb.ContainerMetadata{Name: "job"},
+				State:    pb.ContainerState_CONTAINER_EXITED,
+			},
+		},
+	})
+
+	client, err := NewGenericCRIClient(socketPath)
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+	defer client.Close()
+
+	containers, err := client.GetContainers()
+	if err != nil {
+		t.Fatalf("Failed to get containers: %s", err)
+	}
+	if len(containers) != 2 || containers[1].State != runtimeclient.StateExited {
+		t.Fatalf("Unexpected containers %+v", containers)
+	}
+
+	web := runtimeclient.ContainerData{
+		ID:           "abc",
+		Name:         "web",
+		State:        runtimeclient.StateRunning,
+		Runtime:      runtimeclient.CRIName,
+		PodName:      "mypod",
+		PodNamespace: "myns",
+		PodUID:       "uid1",
+		Image:        "docker.io/library/nginx:1.23",
+		ImageDigest:  "sha256:5f3d2a8e1b7c6d5e4f3a2b1c0d9e8f7a",
+	}
+
+	container, err := client.GetContainer("abc")
+	if err != nil {
+		t.Fatalf("Failed to get container: %s", err)
+	}
+	if !cmp.Equal(*container, web) {
+		t.Fatalf("Unexpected container:\n%s", cmp.Diff(web, *container))
+	}
+
+	// The runtime prefix of the IDs of Kubernetes pod statuses is ignored
+	details, err := client.GetContainerDetails("some-runtime://abc")
+	if err != nil {
+		t.Fatalf("Failed to get container details: %s", err)
+	}
+	expected := &runtimeclient.ContainerDetailsData{
+		ContainerData: web,
+		Pid:           1234,
+		CgroupsPath:   "/kubepods/pod1/abc",
+		StartedAt:     time.Unix(1665000000, 0),
+		RestartCount:  3,
+		CPULimit:      250,
+		MemoryLimit:   268435456,
+	}
+	if !cmp.Equal(details, expected) {
+		t.Fatalf("Unexpected details:\n%s", cmp.Diff(expected, details))
+	}
+
+	if _, err := client.GetContainer("unknown"); err == nil {
+		t.Fatalf("Expected error for unknown container")
+	}
+	if _, err := client.GetContainerDetails("unknown"); err == nil {
+		t.Fatalf("Expected error for unknown container")
+	}
+}
+
+func TestGenericCRIClientWithoutSocketPath(t *testing.T) {
+	if _, err := NewGenericCRIClient(""); err == nil {
+		t.Fatalf("Expected error without socket path")
+	}
+}
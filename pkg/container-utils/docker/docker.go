@@ -160,13 +160,26 @@ func (c *DockerClient) GetContainerDetails(containerID string) (*runtimeclient.C
 
 	containerDetailsData := runtimeclient.ContainerDetailsData{
 		ContainerData: runtimeclient.ContainerData{
-			ID:      containerJSON.ID,
-			Name:    strings.TrimPrefix(containerJSON.Name, "/"),
-			State:   containerStatusStateToRuntimeClientState(containerJSON.State.Status),
-			Runtime: runtimeclient.DockerName,
+			ID:          containerJSON.ID,
+			Name:        strings.TrimPrefix(containerJSON.Name, "/"),
+			State:       containerStatusStateToRuntimeClientState(containerJSON.State.Status),
+			Runtime:     runtimeclient.DockerName,
+			Image:       containerJSON.Config.Image,
+			ImageDigest: runtimeclient.ParseImageDigest(containerJSON.Image),
 		},
-		Pid:         containerJSON.State.Pid,
-		CgroupsPath: string(containerJSON.HostConfig.Cgroup),
+		Pid:          containerJSON.State.Pid,
+		CgroupsPath:  string(containerJSON.HostConfig.Cgroup),
+		RestartCount: containerJSON.RestartCount,
+		MemoryLimit:  containerJSON.HostConfig.Memory,
+	}
+	if startedAt, err := time.Parse(time.RFC3339Nano, containerJSON.State.StartedAt); err == nil {
+		containerDetailsData.StartedAt = startedAt
+	}
+	if nanoCPUs := containerJSON.HostConfig.NanoCPUs; nanoCPUs > 0 {
+		containerDetailsData.CPULimit = nanoCPUs / 1000000
+	} else {
+		containerDetailsData.CPULimit = runtimeclient.CPUQuotaToMillicores(
+			containerJSON.HostConfig.CPUQuota, containerJSON.HostConfig.CPUPeriod)
 	}
 	if len(containerJSON.Mounts) > 0 {
 		containerDetailsData.Mounts = make([]runtimeclient.ContainerMountData, len(containerJSON.Mounts))
@@ -232,10 +245,12 @@ func containerStatusStateToRuntimeClientState(containerState string) (runtimeCli
 
 func DockerContainerToContainerData(container *dockertypes.Container) *runtimeclient.ContainerData {
 	containerData := &runtimeclient.ContainerData{
-		ID:      container.ID,
-		Name:    strings.TrimPrefix(container.Names[0], "/"),
-		State:   containerStatusStateToRuntimeClientState(container.State),
-		Runtime: runtimeclient.DockerName,
+		ID:          container.ID,
+		Name:        strings.TrimPrefix(container.Names[0], "/"),
+		State:       containerStatusStateToRuntimeClientState(container.State),
+		Runtime:     runtimeclient.DockerName,
+		Image:       container.Image,
+		ImageDigest: runtimeclient.ParseImageDigest(container.ImageID),
 	}
 
 	// Fill K8S information.
@@ -26,6 +26,9 @@ const (
 	containerdPodUIDAnnotation        = "io.kubernetes.cri.sandbox-uid"
 	containerdContainerNameAnnotation = "io.kubernetes.cri.container-name"
 	containerdContainerTypeAnnotation = "io.kubernetes.cri.container-type"
+
+	// Image name annotation, not set by older versions of containerd
+	containerdImageNameAnnotation = "io.kubernetes.cri.image-name"
 )
 
 type containerdResolver struct{}
@@ -50,6 +53,16 @@ func (containerdResolver) PodNamespace(annotations map[string]string) string {
 	return annotations[containerdPodNamespaceAnnotation]
 }
 
+func (containerdResolver) ImageName(annotations map[string]string) string {
+	return annotations[containerdImageNameAnnotation]
+}
+
+// ImageDigest returns an empty string because containerd doesn't set the
+// digest of the image in the annotations.
+func (containerdResolver) ImageDigest(annotations map[string]string) string {
+	return ""
+}
+
 func (containerdResolver) Runtime() string {
 	return "containerd"
 }
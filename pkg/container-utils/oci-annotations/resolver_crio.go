@@ -14,6 +14,8 @@
 
 package ociannotations
 
+import "strings"
+
 const (
 	// cri-o container annotations to get container information
 	// https://github.com/containers/podman/blob/main/pkg/annotations/annotations.go
@@ -24,6 +26,8 @@ const (
 	crioPodUIDAnnotation           = "io.kubernetes.pod.uid"
 	crioContainerNameAnnotation    = "io.kubernetes.container.name"
 	crioContainerTypeAnnotation    = "io.kubernetes.cri-o.ContainerType"
+	crioImageNameAnnotation        = "io.kubernetes.cri-o.ImageName"
+	crioImageRefAnnotation         = "io.kubernetes.cri-o.ImageRef"
 )
 
 type crioResolver struct{}
@@ -48,6 +52,20 @@ func (crioResolver) PodNamespace(annotations map[string]string) string {
 	return annotations[crioPodNamespaceAnnotation]
 }
 
+func (crioResolver) ImageName(annotations map[string]string) string {
+	return annotations[crioImageNameAnnotation]
+}
+
+// ImageDigest returns the ID of the image, which CRI-O gives without
+// algorithm.
+func (crioResolver) ImageDigest(annotations map[string]string) string {
+	imageRef := annotations[crioImageRefAnnotation]
+	if imageRef == "" || strings.Contains(imageRef, ":") {
+		return imageRef
+	}
+	return "sha256:" + imageRef
+}
+
 func (crioResolver) Runtime() string {
 	return "cri-o"
 }
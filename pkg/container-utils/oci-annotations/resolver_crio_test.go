@@ -23,6 +23,8 @@ func Test_crioResolver(t *testing.T) {
 		crioPodUIDAnnotation:        "test-pod-uid",
 		crioContainerNameAnnotation: "test-container-name",
 		crioContainerTypeAnnotation: "test-container-type",
+		crioImageNameAnnotation:     "test-image-name",
+		crioImageRefAnnotation:      "5f3d2a",
 	}
 
 	resolver := crioResolver{}
@@ -38,4 +40,6 @@ func Test_crioResolver(t *testing.T) {
 	assert(resolver.PodUID(annotations), "test-pod-uid")
 	assert(resolver.ContainerName(annotations), "test-container-name")
 	assert(resolver.ContainerType(annotations), "test-container-type")
+	assert(resolver.ImageName(annotations), "test-image-name")
+	assert(resolver.ImageDigest(annotations), "sha256:5f3d2a")
 }
@@ -32,6 +32,10 @@ type Resolver interface {
 	PodUID(annotations map[string]string) string
 	// PodNamespace returns the namespace of the pod to which container belongs
 	PodNamespace(annotations map[string]string) string
+	// ImageName returns the name of the image of the container
+	ImageName(annotations map[string]string) string
+	// ImageDigest returns the digest of the image of the container, if known
+	ImageDigest(annotations map[string]string) string
 	// Runtime returns runtime in which the container is running
 	Runtime() string
 }
@@ -0,0 +1,320 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	runtimeclient "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/runtime-client"
+)
+
+const (
+	DefaultTimeout = 2 * time.Second
+
+	// apiPrefix is the prefix of the libpod REST API endpoints. Podman
+	// accepts requests with older versions than its own.
+	apiPrefix = "http://d/v3.0.0/libpod"
+)
+
+// PodmanClient implements the ContainerRuntimeClient interface using the
+// libpod REST API served by "podman system service" on a Unix socket.
+type PodmanClient struct {
+	client     *http.Client
+	socketPath string
+}
+
+func NewPodmanClient(socketPath string) (runtimeclient.ContainerRuntimeClient, error) {
+	if socketPath == "" {
+		socketPath = runtimeclient.PodmanDefaultSocketPath
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				d := net.Dialer{Timeout: DefaultTimeout}
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+		Timeout: DefaultTimeout,
+	}
+
+	return &PodmanClient{
+		client:     client,
+		socketPath: socketPath,
+	}, nil
+}
+
+// podmanContainer contains the fields used of the containers listed by
+// /containers/json.
+type podmanContainer struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	State   string            `json:"State"`
+	Labels  map[string]string `json:"Labels"`
+	Image   string            `json:"Image"`
+	ImageID string            `json:"ImageID"`
+	IsInfra bool              `json:"IsInfra"`
+}
+
+// podmanContainerInspect contains the fields used of the details of a
+// container returned by /containers/{id}/json.
+type podmanContainerInspect struct {
+	ID           string `json:"Id"`
+	Name         string `json:"Name"`
+	Image        string `json:"Image"`
+	ImageName    string `json:"ImageName"`
+	RestartCount int    `json:"RestartCount"`
+	State        *struct {
+		Status     string    `json:"Status"`
+		Pid        int       `json:"Pid"`
+		CgroupPath string    `json:"CgroupPath"`
+		StartedAt  time.Time `json:"StartedAt"`
+	} `json:"State"`
+	Config *struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig *struct {
+		Memory    int64 `json:"Memory"`
+		NanoCpus  int64 `json:"NanoCpus"`
+		CPUQuota  int64 `json:"CpuQuota"`
+		CPUPeriod int64 `json:"CpuPeriod"`
+	} `json:"HostConfig"`
+	Mounts []struct {
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+	IsInfra bool `json:"IsInfra"`
+}
+
+// podmanError is the body of the responses of the API in case of error
+type podmanError struct {
+	Cause   string `json:"cause"`
+	Message string `json:"message"`
+}
+
+func (c *PodmanClient) get(path string, query url.Values, out any) error {
+	u := apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	resp, err := c.client.Get(u)
+	if err != nil {
+		return fmt.Errorf("requesting %s from %s: %w", path, c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response of %s: %w", path, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var perr podmanError
+		if err := json.Unmarshal(body, &perr); err == nil && perr.Message != "" {
+			return fmt.Errorf("requesting %s: %s", path, perr.Message)
+		}
+		return fmt.Errorf("requesting %s: %s", path, resp.Status)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decoding response of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func listContainers(c *PodmanClient, containerID string) ([]podmanContainer, error) {
+	query := url.Values{}
+	// We need to request for all containers (also non-running) because
+	// when we are enriching a container that is being created, it is not
+	// in "running" state yet.
+	query.Set("all", "true")
+	if containerID != "" {
+		filters, _ := json.Marshal(map[string][]string{"id": {containerID}})
+		query.Set("filters", string(filters))
+	}
+
+	var containers []podmanContainer
+	if err := c.get("/containers/json", query, &containers); err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	// Drop the infra containers of pods, like the pause containers of
+	// the other runtimes.
+	noInfraContainers := []podmanContainer{}
+	for _, c := range containers {
+		if c.IsInfra {
+			continue
+		}
+		noInfraContainers = append(noInfraContainers, c)
+	}
+	if containerID != "" && len(containers) != 0 && len(noInfraContainers) == 0 {
+		return nil, runtimeclient.ErrPauseContainer
+	}
+
+	return noInfraContainers, nil
+}
+
+func (c *PodmanClient) GetContainers() ([]*runtimeclient.ContainerData, error) {
+	containers, err := listContainers(c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*runtimeclient.ContainerData, len(containers))
+
+	for i, container := range containers {
+		ret[i] = podmanContainerToContainerData(&container)
+	}
+
+	return ret, nil
+}
+
+func (c *PodmanClient) GetContainer(containerID string) (*runtimeclient.ContainerData, error) {
+	containers, err := listContainers(c, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("container %q not found", containerID)
+	}
+	if len(containers) > 1 {
+		log.Warnf("PodmanClient: multiple containers (%d) with ID %q. Taking the first one: %+v",
+			len(containers), containerID, containers)
+	}
+
+	return podmanContainerToContainerData(&containers[0]), nil
+}
+
+func (c *PodmanClient) GetContainerDetails(containerID string) (*runtimeclient.ContainerDetailsData, error) {
+	containerID, err := runtimeclient.ParseContainerID(runtimeclient.PodmanName, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var container podmanContainerInspect
+	if err := c.get("/containers/"+url.PathEscape(containerID)+"/json", nil, &container); err != nil {
+		return nil, err
+	}
+
+	if container.IsInfra {
+		return nil, runtimeclient.ErrPauseContainer
+	}
+	if container.State == nil {
+		return nil, errors.New("container state is nil")
+	}
+	if container.State.Pid == 0 {
+		return nil, errors.New("got zero pid")
+	}
+
+	containerDetailsData := runtimeclient.ContainerDetailsData{
+		ContainerData: runtimeclient.ContainerData{
+			ID:          container.ID,
+			Name:        container.Name,
+			State:       containerStatusStateToRuntimeClientState(container.State.Status),
+			Runtime:     runtimeclient.PodmanName,
+			Image:       container.ImageName,
+			ImageDigest: imageDigest(container.Image),
+		},
+		Pid:          container.State.Pid,
+		CgroupsPath:  container.State.CgroupPath,
+		StartedAt:    container.State.StartedAt,
+		RestartCount: container.RestartCount,
+	}
+	if hostConfig := container.HostConfig; hostConfig != nil {
+		containerDetailsData.MemoryLimit = hostConfig.Memory
+		if hostConfig.NanoCpus > 0 {
+			containerDetailsData.CPULimit = hostConfig.NanoCpus / 1000000
+		} else {
+			containerDetailsData.CPULimit = runtimeclient.CPUQuotaToMillicores(hostConfig.CPUQuota, hostConfig.CPUPeriod)
+		}
+	}
+	if len(container.Mounts) > 0 {
+		containerDetailsData.Mounts = make([]runtimeclient.ContainerMountData, len(container.Mounts))
+		for i, containerMount := range container.Mounts {
+			containerDetailsData.Mounts[i] = runtimeclient.ContainerMountData{
+				Destination: containerMount.Destination,
+				Source:      containerMount.Source,
+			}
+		}
+	}
+
+	// Fill K8S information.
+	if container.Config != nil {
+		runtimeclient.EnrichWithK8sMetadata(&containerDetailsData.ContainerData, container.Config.Labels)
+	}
+
+	return &containerDetailsData, nil
+}
+
+func (c *PodmanClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// Convert the state from container status to state of runtime client.
+func containerStatusStateToRuntimeClientState(containerState string) (runtimeClientState string) {
+	switch containerState {
+	case "created", "configured", "initialized":
+		runtimeClientState = runtimeclient.StateCreated
+	case "running":
+		runtimeClientState = runtimeclient.StateRunning
+	case "exited", "stopped":
+		runtimeClientState = runtimeclient.StateExited
+	default:
+		runtimeClientState = runtimeclient.StateUnknown
+	}
+	return
+}
+
+// imageDigest returns the digest of an image from its ID, which podman gives
+// without algorithm.
+func imageDigest(imageID string) string {
+	if imageID != "" && !strings.Contains(imageID, ":") {
+		imageID = "sha256:" + imageID
+	}
+	return runtimeclient.ParseImageDigest(imageID)
+}
+
+func podmanContainerToContainerData(container *podmanContainer) *runtimeclient.ContainerData {
+	containerData := &runtimeclient.ContainerData{
+		ID:          container.ID,
+		State:       containerStatusStateToRuntimeClientState(container.State),
+		Runtime:     runtimeclient.PodmanName,
+		Image:       container.Image,
+		ImageDigest: imageDigest(container.ImageID),
+	}
+	if len(container.Names) > 0 {
+		containerData.Name = container.Names[0]
+	}
+
+	// Fill K8S information.
+	runtimeclient.EnrichWithK8sMetadata(containerData, container.Labels)
+
+	return containerData
+}
@@ -0,0 +1,234 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	runtimeclient "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/runtime-client"
+)
+
+const (
+	webID   = "2b1e4e5b6e4fa7f8c5e5bba1c5a0f8b2a0d0e6b6e2c3a1f9d8e7c6b5a4f3e2d1"
+	infraID = "9f8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c5b4a39281706f5e4d3c2b1a0"
+	imageID = "5f3d2a8e1b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e"
+)
+
+var fakeContainers = []map[string]any{
+	{
+		"Id":      webID,
+		"Names":   []string{"web"},
+		"State":   "running",
+		"Labels":  map[string]string{"io.kubernetes.pod.name": "mypod", "io.kubernetes.pod.namespace": "myns"},
+		"Image":   "docker.io/library/nginx:1.23",
+		"ImageID": imageID,
+	},
+	{
+		"Id":      infraID,
+		"Names":   []string{"mypod-infra"},
+		"State":   "running",
+		"IsInfra": true,
+	},
+}
+
+// newFakePodman starts a server implementing the endpoints of the libpod
+// API used by the client on a Unix socket.
+func newFakePodman(t *testing.T) string {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3.0.0/libpod/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("all") != "true" {
+			t.Errorf("Non-running containers not requested")
+		}
+
+		containers := fakeContainers
+		if f := r.URL.Query().Get("filters"); f != "" {
+			var filters map[string][]string
+			if err := json.Unmarshal([]byte(f), &filters); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			containers = nil
+			for _, c := range fakeContainers {
+				if len(filters["id"]) == 1 && strings.HasPrefix(c["Id"].(string), filters["id"][0]) {
+					containers = append(containers, c)
+				}
+			}
+		}
+
+		json.NewEncoder(w).Encode(containers)
+	})
+	mux.HandleFunc("/v3.0.0/libpod/containers/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v3.0.0/libpod/containers/"), "/json")
+		if id != webID {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{
+				"cause":    "no such container",
+				"message":  "no container with name or ID \"" + id + "\" found: no such container",
+				"response": 404,
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"Id":           webID,
+			"Name":         "web",
+			"Image":        imageID,
+			"ImageName":    "docker.io/library/nginx:1.23",
+			"RestartCount": 2,
+			"State": map[string]any{
+				"Status":     "running",
+				"Pid":        4242,
+				"CgroupPath": "/machine.slice/libpod-" + webID + ".scope",
+				"StartedAt":  "2022-10-05T20:00:00Z",
+			},
+			"HostConfig": map[string]any{
+				"Memory":    134217728,
+				"NanoCpus":  0,
+				"CpuQuota":  50000,
+				"CpuPeriod": 100000,
+			},
+			"Config": map[string]any{
+				"Labels": map[string]string{"io.kubernetes.pod.name": "mypod"},
+			},
+			"Mounts": []map[string]any{
+				{"Source": "/srv/www", "Destination": "/usr/share/nginx/html"},
+			},
+		})
+	})
+
+	socketPath := filepath.Join(t.TempDir(), "podman.sock")
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s: %s", socketPath, err)
+	}
+
+	server := httptest.NewUnstartedServer(mux)
+	server.Listener = l
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return socketPath
+}
+
+func newTestClient(t *testing.T) runtimeclient.ContainerRuntimeClient {
+	t.Helper()
+
+	client, err := NewPodmanClient(newFakePodman(t))
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestGetContainers(t *testing.T) {
+	client := newTestClient(t)
+
+	containers, err := client.GetContainers()
+	if err != nil {
+		t.Fatalf("Failed to get containers: %s", err)
+	}
+
+	expected := []*runtimeclient.ContainerData{
+		{
+			ID:           webID,
+			Name:         "web",
+			State:        runtimeclient.StateRunning,
+			Runtime:      runtimeclient.PodmanName,
+			PodName:      "mypod",
+			PodNamespace: "myns",
+			Image:        "docker.io/library/nginx:1.23",
+			ImageDigest:  "sha256:" + imageID,
+		},
+	}
+	if !cmp.Equal(containers, expected) {
+		t.Fatalf("Unexpected containers:\n%s", cmp.Diff(expected, containers))
+	}
+}
+
+func TestGetContainer(t *testing.T) {
+	client := newTestClient(t)
+
+	container, err := client.GetContainer(webID)
+	if err != nil {
+		t.Fatalf("Failed to get container: %s", err)
+	}
+	if container.ID != webID || container.Name != "web" {
+		t.Fatalf("Unexpected container %+v", container)
+	}
+
+	if _, err := client.GetContainer(infraID); !errors.Is(err, runtimeclient.ErrPauseContainer) {
+		t.Fatalf("Expected ErrPauseContainer for infra container, got %v", err)
+	}
+
+	if _, err := client.GetContainer("unknown"); err == nil {
+		t.Fatalf("Expected error for unknown container")
+	}
+}
+
+func TestGetContainerDetails(t *testing.T) {
+	client := newTestClient(t)
+
+	details, err := client.GetContainerDetails("podman://" + webID)
+	if err != nil {
+		t.Fatalf("Failed to get container details: %s", err)
+	}
+
+	expected := &runtimeclient.ContainerDetailsData{
+		ContainerData: runtimeclient.ContainerData{
+			ID:          webID,
+			Name:        "web",
+			State:       runtimeclient.StateRunning,
+			Runtime:     runtimeclient.PodmanName,
+			PodName:     "mypod",
+			Image:       "docker.io/library/nginx:1.23",
+			ImageDigest: "sha256:" + imageID,
+		},
+		Pid:          4242,
+		CgroupsPath:  "/machine.slice/libpod-" + webID + ".scope",
+		StartedAt:    time.Date(2022, 10, 5, 20, 0, 0, 0, time.UTC),
+		RestartCount: 2,
+		CPULimit:     500,
+		MemoryLimit:  134217728,
+		Mounts: []runtimeclient.ContainerMountData{
+			{Source: "/srv/www", Destination: "/usr/share/nginx/html"},
+		},
+	}
+	if !cmp.Equal(details, expected) {
+		t.Fatalf("Unexpected details:\n%s", cmp.Diff(expected, details))
+	}
+
+	_, err = client.GetContainerDetails("unknown")
+	if err == nil || !strings.Contains(err.Error(), "no such container") {
+		t.Fatalf("Expected error of the API for unknown container, got %v", err)
+	}
+
+	if _, err := client.GetContainerDetails("docker://" + webID); err == nil {
+		t.Fatalf("Expected error for ID of another runtime")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 const (
@@ -30,6 +31,13 @@ const (
 
 	DockerName              = "docker"
 	DockerDefaultSocketPath = "/run/docker.sock"
+
+	PodmanName              = "podman"
+	PodmanDefaultSocketPath = "/run/podman/podman.sock"
+
+	// CRIName is the name of the generic client for the runtimes
+	// implementing the CRI. It has no default socket path.
+	CRIName = "cri"
 )
 
 var ErrPauseContainer = errors.New("it is a pause container")
@@ -61,6 +69,16 @@ type ContainerData struct {
 
 	// Namespace of the pod running the container.
 	PodNamespace string
+
+	// Image is the name of the image of the container as given by the
+	// user, e.g. "docker.io/library/nginx:1.23".
+	Image string
+
+	// ImageDigest is the digest of the image of the container, e.g.
+	// "sha256:5f3d...". Runtimes that don't know the digest of the
+	// manifest of the image give the digest of its configuration, i.e. the
+	// image ID.
+	ImageDigest string
 }
 
 // ContainerDetailsData contains container extra information returned from the
@@ -78,6 +96,21 @@ type ContainerDetailsData struct {
 
 	// List of mounts in the container.
 	Mounts []ContainerMountData
+
+	// StartedAt is the time when the container started, or the zero time
+	// if it isn't known.
+	StartedAt time.Time
+
+	// RestartCount is the number of times the container was restarted.
+	RestartCount int
+
+	// CPULimit is the CPU limit of the container in millicores, or 0 if it
+	// has no limit.
+	CPULimit int64
+
+	// MemoryLimit is the memory limit of the container in bytes, or 0 if
+	// it has no limit.
+	MemoryLimit int64
 }
 
 // ContainerMountData contains mount information in ContainerData.
@@ -143,6 +176,35 @@ func ParseContainerID(expectedRuntime, containerID string) (string, error) {
 	return split[0], nil
 }
 
+// ParseImageDigest returns the digest part of an image reference, e.g.
+// "sha256:5f3d..." for "docker.io/library/nginx@sha256:5f3d...". Digests
+// without repository are returned as is.
+func ParseImageDigest(imageRef string) string {
+	if i := strings.LastIndex(imageRef, "@"); i >= 0 {
+		return imageRef[i+1:]
+	}
+	if _, hex, ok := strings.Cut(imageRef, ":"); ok && len(hex) >= 32 && strings.Trim(hex, "0123456789abcdef") == "" {
+		return imageRef
+	}
+	return ""
+}
+
+// defaultCPUPeriod is the default CFS period of the kernel in microseconds
+const defaultCPUPeriod = 100000
+
+// CPUQuotaToMillicores converts a CFS quota and period in microseconds to
+// millicores. A zero period is the default one. It returns 0 if there is no
+// quota.
+func CPUQuotaToMillicores(quota, period int64) int64 {
+	if quota <= 0 {
+		return 0
+	}
+	if period <= 0 {
+		period = defaultCPUPeriod
+	}
+	return quota * 1000 / period
+}
+
 func EnrichWithK8sMetadata(container *ContainerData, labels map[string]string) {
 	if podName, ok := labels[containerLabelK8sPodName]; ok {
 		container.PodName = podName
@@ -30,8 +30,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/otlp"
 )
 
 const (
@@ -176,6 +178,29 @@ func (r *TraceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 		return ctrl.Result{}, nil
 	}
+	selector := gadgets.ContainerSelectorFromContainerFilter(trace.Spec.Filter)
+	if err := containercollection.ValidateContainerSelector(selector); err != nil {
+		setTraceOpError(ctx, r.Client, req.NamespacedName.String(),
+			trace, fmt.Sprintf("Invalid filter: %s", err))
+
+		return ctrl.Result{}, nil
+	}
+	if trace.Spec.OutputMode == gadgetv1alpha1.TraceOutputModeOTLP {
+		if err := otlp.ValidateEndpoint(trace.Spec.Output); err != nil {
+			setTraceOpError(ctx, r.Client, req.NamespacedName.String(),
+				trace, fmt.Sprintf("Invalid OTLP endpoint: %s", err))
+
+			return ctrl.Result{}, nil
+		}
+	}
+	if r.TracerManager != nil {
+		if err := r.TracerManager.ValidateEnrichers(trace.Spec.Enrichers); err != nil {
+			setTraceOpError(ctx, r.Client, req.NamespacedName.String(),
+				trace, fmt.Sprintf("Invalid enrichers: %s", err))
+
+			return ctrl.Result{}, nil
+		}
+	}
 
 	// The Trace is not being deleted and specs are valid, we can register our finalizer
 	beforeFinalizer := trace.DeepCopy()
@@ -189,12 +214,33 @@ func (r *TraceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	if r.TracerManager != nil {
 		err = r.TracerManager.AddTracer(
 			gadgets.TraceNameFromNamespacedName(req.NamespacedName),
-			*gadgets.ContainerSelectorFromContainerFilter(trace.Spec.Filter),
+			*selector,
 		)
 		if err != nil && !errors.Is(err, os.ErrExist) {
 			log.Errorf("Failed to add tracer BPF map: %s", err)
 			return ctrl.Result{}, err
 		}
+
+		err = r.TracerManager.SetTracerEnrichers(
+			gadgets.TraceNameFromNamespacedName(req.NamespacedName),
+			trace.Spec.Enrichers,
+		)
+		if err != nil {
+			log.Errorf("Failed to set enrichers: %s", err)
+			return ctrl.Result{}, err
+		}
+
+		if trace.Spec.OutputMode == gadgetv1alpha1.TraceOutputModeOTLP {
+			err = r.TracerManager.AddOTLPExporter(
+				gadgets.TraceNameFromNamespacedName(req.NamespacedName),
+				trace.Spec.Gadget,
+				trace.Spec.Output,
+			)
+			if err != nil && !errors.Is(err, os.ErrExist) {
+				log.Errorf("Failed to add OTLP exporter: %s", err)
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	// Lookup annotations
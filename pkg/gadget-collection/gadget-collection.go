@@ -16,6 +16,7 @@ package gadgetcollection
 
 import (
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	advisecapabilities "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/advise/capabilities"
 	seccomp "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/advise/seccomp"
 	auditseccomp "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/audit/seccomp"
 	biolatency "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/profile/block-io"
@@ -44,30 +45,31 @@ import (
 
 func TraceFactories() map[string]gadgets.TraceFactory {
 	return map[string]gadgets.TraceFactory{
-		"audit-seccomp":     auditseccomp.NewFactory(),
-		"bindsnoop":         bindsnoop.NewFactory(),
-		"biolatency":        biolatency.NewFactory(),
-		"biotop":            biotop.NewFactory(),
-		"capabilities":      capabilities.NewFactory(),
-		"dns":               dns.NewFactory(),
-		"ebpftop":           ebpftop.NewFactory(),
-		"execsnoop":         execsnoop.NewFactory(),
-		"filetop":           filetop.NewFactory(),
-		"fsslower":          fsslower.NewFactory(),
-		"opensnoop":         opensnoop.NewFactory(),
-		"mountsnoop":        mountsnoop.NewFactory(),
-		"network-graph":     networkgraph.NewFactory(),
-		"oomkill":           oomkill.NewFactory(),
-		"process-collector": processcollector.NewFactory(),
-		"profile":           profile.NewFactory(),
-		"seccomp":           seccomp.NewFactory(),
-		"sigsnoop":          sigsnoop.NewFactory(),
-		"snisnoop":          snisnoop.NewFactory(),
-		"socket-collector":  socketcollector.NewFactory(),
-		"tcpconnect":        tcpconnect.NewFactory(),
-		"tcptop":            tcptop.NewFactory(),
-		"tcptracer":         tcptracer.NewFactory(),
-		"traceloop":         traceloop.NewFactory(),
+		"advise-capabilities": advisecapabilities.NewFactory(),
+		"audit-seccomp":       auditseccomp.NewFactory(),
+		"bindsnoop":           bindsnoop.NewFactory(),
+		"biolatency":          biolatency.NewFactory(),
+		"biotop":              biotop.NewFactory(),
+		"capabilities":        capabilities.NewFactory(),
+		"dns":                 dns.NewFactory(),
+		"ebpftop":             ebpftop.NewFactory(),
+		"execsnoop":           execsnoop.NewFactory(),
+		"filetop":             filetop.NewFactory(),
+		"fsslower":            fsslower.NewFactory(),
+		"opensnoop":           opensnoop.NewFactory(),
+		"mountsnoop":          mountsnoop.NewFactory(),
+		"network-graph":       networkgraph.NewFactory(),
+		"oomkill":             oomkill.NewFactory(),
+		"process-collector":   processcollector.NewFactory(),
+		"profile":             profile.NewFactory(),
+		"seccomp":             seccomp.NewFactory(),
+		"sigsnoop":            sigsnoop.NewFactory(),
+		"snisnoop":            snisnoop.NewFactory(),
+		"socket-collector":    socketcollector.NewFactory(),
+		"tcpconnect":          tcpconnect.NewFactory(),
+		"tcptop":              tcptop.NewFactory(),
+		"tcptracer":           tcptracer.NewFactory(),
+		"traceloop":           traceloop.NewFactory(),
 	}
 }
 
@@ -84,3 +86,24 @@ func TraceFactoriesForLocalGadget() map[string]gadgets.TraceFactory {
 		"traceloop":         traceloop.NewFactory(),
 	}
 }
+
+// TraceFactoriesForLocalGadgetDaemon returns the factories of the gadgets
+// that the trace and top commands of local-gadget run with its daemon.
+func TraceFactoriesForLocalGadgetDaemon() map[string]gadgets.TraceFactory {
+	return map[string]gadgets.TraceFactory{
+		"bindsnoop":    bindsnoop.NewFactory(),
+		"biotop":       biotop.NewFactory(),
+		"capabilities": capabilities.NewFactory(),
+		"ebpftop":      ebpftop.NewFactory(),
+		"execsnoop":    execsnoop.NewFactory(),
+		"filetop":      filetop.NewFactory(),
+		"fsslower":     fsslower.NewFactory(),
+		"mountsnoop":   mountsnoop.NewFactory(),
+		"oomkill":      oomkill.NewFactory(),
+		"opensnoop":    opensnoop.NewFactory(),
+		"sigsnoop":     sigsnoop.NewFactory(),
+		"tcpconnect":   tcpconnect.NewFactory(),
+		"tcptop":       tcptop.NewFactory(),
+		"tcptracer":    tcptracer.NewFactory(),
+	}
+}
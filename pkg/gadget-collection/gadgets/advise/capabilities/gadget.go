@@ -0,0 +1,283 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets/trace"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/capabilities/advisor"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/capabilities/tracer"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/capabilities/types"
+	standardtracer "github.com/inspektor-gadget/inspektor-gadget/pkg/standardgadgets/trace/capabilities"
+)
+
+type Trace struct {
+	helpers gadgets.GadgetHelpers
+
+	started  bool
+	tracer   trace.Tracer
+	recorder *advisor.Recorder
+
+	// owners are the owner references of the pods of the traced
+	// containers, by namespace/pod. They are fetched when the containers
+	// are created so they are still known after the pods are deleted.
+	mu     sync.Mutex
+	owners map[string]*metav1.OwnerReference
+}
+
+type TraceFactory struct {
+	gadgets.BaseFactory
+}
+
+func NewFactory() gadgets.TraceFactory {
+	return &TraceFactory{
+		BaseFactory: gadgets.BaseFactory{DeleteTrace: deleteTrace},
+	}
+}
+
+func (f *TraceFactory) Description() string {
+	return `The advise-capabilities gadget records the capabilities checked by each
+container in order to generate the minimal securityContext.capabilities of
+their workloads.
+
+The gadget.kinvolk.io/operation=generate annotation writes in
+Trace.Status.Output, as a JSON array, one strategic merge patch per workload
+owning the traced pods, as found with their owner references, along with the
+capabilities recorded for each container. Each patch drops all the
+capabilities of the containers and only adds the ones they were granted while
+the trace was running, including the containers that terminated in the
+meantime. Denied capabilities are not added as the containers already run
+without them. The checks done by runc while setting up the containers are
+ignored. The patches of the different nodes are merged by kubectl-gadget.
+
+With the audit-only parameter, the checks done without audit are ignored: the
+kernel does them to probe a capability before falling back to another code
+path, so they don't mean the capability is required.
+`
+}
+
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name:    types.AuditOnlyParam,
+			Doc:     "Ignore the capability checks without audit.",
+			Default: strconv.FormatBool(types.AuditOnlyDefault),
+		},
+	}
+}
+
+func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
+	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
+		gadgetv1alpha1.TraceOutputModeStatus: {},
+	}
+}
+
+func deleteTrace(name string, t interface{}) {
+	trace := t.(*Trace)
+	if trace.started {
+		trace.helpers.Unsubscribe(genPubSubKey(name))
+	}
+	if trace.tracer != nil {
+		trace.tracer.Stop()
+	}
+}
+
+func (f *TraceFactory) Operations() map[gadgetv1alpha1.Operation]gadgets.TraceOperation {
+	n := func() interface{} {
+		return &Trace{
+			helpers: f.Helpers,
+		}
+	}
+
+	return map[gadgetv1alpha1.Operation]gadgets.TraceOperation{
+		gadgetv1alpha1.OperationStart: {
+			Doc: "Start recording capability checks",
+			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+				f.LookupOrCreate(name, n).(*Trace).Start(trace)
+			},
+			Order: 1,
+		},
+		gadgetv1alpha1.OperationGenerate: {
+			Doc: `Generate the securityContext.capabilities patches of the workloads of the
+containers recorded since the trace started.`,
+			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+				f.LookupOrCreate(name, n).(*Trace).Generate(trace)
+			},
+			Order: 2,
+		},
+		gadgetv1alpha1.OperationStop: {
+			Doc: "Stop recording capability checks",
+			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+				f.LookupOrCreate(name, n).(*Trace).Stop(trace)
+			},
+			Order: 3,
+		},
+	}
+}
+
+type pubSubKey string
+
+func genPubSubKey(name string) pubSubKey {
+	return pubSubKey(fmt.Sprintf("gadget/advise-capabilities/%s", name))
+}
+
+// addOwnerReference stores the owner reference of the pod of a container
+func (t *Trace) addOwnerReference(c *containercollection.Container) {
+	ownerRef, err := c.GetOwnerReference()
+	// Owner reference doesn't make any sense for local-gadget, then
+	// do not print any warning message if this is not running in
+	// the cluster.
+	if err != nil && !errors.Is(err, rest.ErrNotInCluster) {
+		log.Warnf("Failed to get owner reference of %s/%s/%s: %s",
+			c.Namespace, c.Podname, c.Name, err)
+	}
+	if ownerRef == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.owners[c.Namespace+"/"+c.Podname] = ownerRef
+	t.mu.Unlock()
+}
+
+func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
+	trace.Status.Output = ""
+	if t.started {
+		trace.Status.State = gadgetv1alpha1.TraceStateStarted
+		return
+	}
+
+	auditOnly := types.AuditOnlyDefault
+	if val, ok := trace.Spec.Parameters[types.AuditOnlyParam]; ok {
+		var err error
+		auditOnly, err = strconv.ParseBool(val)
+		if err != nil {
+			trace.Status.OperationError = fmt.Sprintf("%q is not valid for %q", val, types.AuditOnlyParam)
+			return
+		}
+	}
+
+	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
+
+	t.recorder = advisor.NewRecorder(auditOnly)
+	t.mu.Lock()
+	t.owners = map[string]*metav1.OwnerReference{}
+	t.mu.Unlock()
+
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
+	if err != nil {
+		trace.Status.OperationError = err.Error()
+		return
+	}
+	// Unique is not used: it would drop the checks of a capability
+	// after the first one, even if its verdict is different.
+	config := &tracer.Config{
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
+		AuditOnly:   auditOnly,
+	}
+
+	t.tracer, err = tracer.NewTracer(config, t.helpers, t.recorder.Record)
+	if err != nil {
+		trace.Status.OperationWarning = fmt.Sprint("failed to create core tracer. Falling back to standard one")
+
+		// fallback to standard tracer
+		log.Infof("Gadget %s: falling back to standard tracer. CO-RE tracer failed: %s",
+			trace.Spec.Gadget, err)
+
+		t.tracer, err = standardtracer.NewTracer(config, t.recorder.Record)
+		if err != nil {
+			trace.Status.OperationError = fmt.Sprintf("failed to create tracer: %s", err)
+			return
+		}
+	}
+
+	// Fetch the owner references of the containers when they are
+	// created, the pods might not exist anymore when the patches are
+	// generated.
+	containers := t.helpers.Subscribe(
+		genPubSubKey(trace.ObjectMeta.Namespace+"/"+trace.ObjectMeta.Name),
+		*gadgets.ContainerSelectorFromContainerFilter(trace.Spec.Filter),
+		func(event containercollection.PubSubEvent) {
+			if event.Type == containercollection.EventTypeAddContainer {
+				t.addOwnerReference(event.Container)
+			}
+		},
+	)
+	for _, container := range containers {
+		t.addOwnerReference(container)
+	}
+
+	t.started = true
+
+	trace.Status.State = gadgetv1alpha1.TraceStateStarted
+}
+
+func (t *Trace) Generate(trace *gadgetv1alpha1.Trace) {
+	if !t.started {
+		trace.Status.OperationError = "Not started"
+		return
+	}
+	if trace.Spec.OutputMode != gadgetv1alpha1.TraceOutputModeStatus {
+		trace.Status.OperationError = fmt.Sprintf("OutputMode not supported: %s", trace.Spec.OutputMode)
+		return
+	}
+
+	containers := t.recorder.Containers(nil)
+	if len(containers) == 0 {
+		trace.Status.OperationWarning = "No capability check recorded"
+		trace.Status.Output = ""
+		return
+	}
+
+	t.mu.Lock()
+	patches := advisor.GeneratePatches(containers, t.owners)
+	t.mu.Unlock()
+
+	// The patches are merged with the ones of the other nodes by the client
+	output, err := json.Marshal(patches)
+	if err != nil {
+		trace.Status.OperationError = fmt.Sprintf("Failed to marshal patches: %s", err)
+		return
+	}
+	trace.Status.Output = string(output)
+}
+
+func (t *Trace) Stop(trace *gadgetv1alpha1.Trace) {
+	if !t.started {
+		trace.Status.OperationError = "Not started"
+		return
+	}
+
+	t.helpers.Unsubscribe(genPubSubKey(trace.ObjectMeta.Namespace + "/" + trace.ObjectMeta.Name))
+
+	t.tracer.Stop()
+	t.tracer = nil
+	t.started = false
+
+	trace.Status.State = gadgetv1alpha1.TraceStateStopped
+}
@@ -19,11 +19,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
@@ -35,7 +38,9 @@ import (
 
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
 	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection/namefilter"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	ociseccomp "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/profile"
 	seccomptracer "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/tracer"
 )
 
@@ -45,6 +50,15 @@ type Trace struct {
 
 	started bool
 
+	// recordDetails is set when the syscall details are recorded for
+	// this trace
+	recordDetails bool
+
+	// terminated are the profiles of the containers terminated since the
+	// trace started, by namespace/pod/container, for the merge operation
+	mu         sync.Mutex
+	terminated map[string]*specs.LinuxSeccomp
+
 	// policyGenerated is used to know if there was a policy generated
 	// at pod termination so that the Generate() operation does not have
 	// to notify that it did not find a pod that matches the filter.
@@ -59,8 +73,19 @@ type TraceSingleton struct {
 	mu     sync.Mutex
 	tracer *seccomptracer.Tracer
 	users  int
+
+	// recordDetails is the RecordDetails configuration of tracer. It can
+	// only be set by the first trace starting it.
+	recordDetails bool
 }
 
+const (
+	recordDetailsParam   = "record-details"
+	recordDetailsDefault = false
+
+	baseProfileParam = "base-profile"
+)
+
 var traceSingleton TraceSingleton
 
 func NewFactory() gadgets.TraceFactory {
@@ -79,15 +104,28 @@ The seccomp policies can be generated in two ways:
    exclusion of other fields because there can be only one SeccompProfile
    written in the Trace.Status.Output or in the SeccompProfile resource named
    by Trace.Spec.Output. The on-demand generation supports the outputMode
-   Status and ExternalResource.
+   Status, ExternalResource and File.
 2. automatically when containers matching the Trace.Spec.Filter terminate. In
    this case, all filters are supported. The at-termination generation supports
-   the outputMode ExternalResource and Stream.
+   the outputMode ExternalResource, Stream and File.
 
 The seccomp policies can be written in the Status field of the Trace custom
-resource, or in SeccompProfiles custom resources managed by the [Kubernetes
+resource, in SeccompProfiles custom resources managed by the [Kubernetes
 Security Profiles
-Operator](https://github.com/kubernetes-sigs/security-profiles-operator).
+Operator](https://github.com/kubernetes-sigs/security-profiles-operator), or as
+OCI seccomp profiles on the nodes with the outputMode File. The profiles are
+written in the directory given by Trace.Spec.Output, relative to the kubelet
+seccomp directory /var/lib/kubelet/seccomp, and named
+<namespace>_<pod>_<container>.json, so pods can use them with a Localhost
+seccomp profile <directory>/<namespace>_<pod>_<container>.json. The on-demand
+generation writes the path of the profile in Trace.Status.Output.
+
+The gadget.kinvolk.io/operation=merge annotation merges the policies of all the
+containers matching Trace.Spec.Filter, the running ones and the ones which
+terminated since the trace started, e.g. the replicas of a deployment. The
+merged policy is written in Trace.Status.Output. If the base-profile parameter
+is set to an existing OCI seccomp profile, the syscalls it doesn't allow are
+listed after the merged policy.
 
 SeccompProfiles will have the following annotations:
 
@@ -107,6 +145,18 @@ SeccompProfiles will have the following annotations:
   pod that was traced
 * seccomp.gadget.kinvolk.io/ownerReference-UID: the ownerReference's UID of the
   pod that was traced
+* seccomp.gadget.kinvolk.io/syscall-counts: how many times each syscall was
+  called, in JSON, when the record-details parameter is set
+* seccomp.gadget.kinvolk.io/syscall-counts-incomplete: the comma-separated
+  syscalls whose count is a lower bound, as the map of the counts was full
+
+With the record-details parameter, the gadget also records the argument values
+of high-risk syscalls (clone flags, socket family and type, ioctl request,
+personality and prctl option) and the policies only allow the combinations
+of values seen in a call. In the Status output mode, a report of how many
+times each syscall was called is written after the policy. As the syscalls
+are recorded by a single tracer for all the traces of a node, the parameter
+is only effective on the first trace started.
 
 SeccompProfiles will have the same labels as the Trace custom resource that
 generated them. They don't have meaning for the seccomp gadget. They are
@@ -114,11 +164,26 @@ merely copied for convenience.
 `
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name:    recordDetailsParam,
+			Doc:     "Record how often each syscall is called and the arguments of high-risk syscalls.",
+			Default: strconv.FormatBool(recordDetailsDefault),
+		},
+		{
+			Name: baseProfileParam,
+			Doc:  "OCI seccomp profile to compare the profile merged by the merge operation with.",
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStatus:           {},
 		gadgetv1alpha1.TraceOutputModeStream:           {},
 		gadgetv1alpha1.TraceOutputModeExternalResource: {},
+		gadgetv1alpha1.TraceOutputModeFile:             {},
 	}
 }
 
@@ -163,12 +228,20 @@ namespace and pod name should be specified at the exclusion of other fields.`,
 			},
 			Order: 2,
 		},
+		gadgetv1alpha1.OperationMerge: {
+			Doc: `Merge the seccomp profiles of the containers matching Trace.Spec.Filter,
+running or terminated since the trace started.`,
+			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+				f.LookupOrCreate(name, n).(*Trace).Merge(trace)
+			},
+			Order: 3,
+		},
 		gadgetv1alpha1.OperationStop: {
 			Doc: "Stop recording syscalls",
 			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
 				f.LookupOrCreate(name, n).(*Trace).Stop(trace)
 			},
-			Order: 3,
+			Order: 4,
 		},
 	}
 }
@@ -306,7 +379,7 @@ func getSeccompProfileNsName(
 
 // generateSeccompPolicy generates a seccomp policy which is ready to be
 // created.
-func generateSeccompPolicy(client client.Client, trace *gadgetv1alpha1.Trace, syscallNames []string, podname, containername, fullPodName string, ownerReference *metav1.OwnerReference) (*seccompprofile.SeccompProfile, error) {
+func generateSeccompPolicy(client client.Client, trace *gadgetv1alpha1.Trace, syscallNames []string, details *seccomptracer.SyscallDetails, podname, containername, fullPodName string, ownerReference *metav1.OwnerReference) (*seccompprofile.SeccompProfile, error) {
 	profileName, err := getSeccompProfileNsName(
 		client,
 		trace.ObjectMeta.Namespace,
@@ -317,7 +390,7 @@ func generateSeccompPolicy(client client.Client, trace *gadgetv1alpha1.Trace, sy
 		return nil, fmt.Errorf("failed to get the profile name: %w", err)
 	}
 
-	r := syscallNamesToSeccompPolicy(profileName, syscallNames)
+	r := syscallNamesToSeccompPolicy(profileName, syscallNames, details)
 	seccompProfileAddLabelsAndAnnotations(r, trace, fullPodName, containername, ownerReference)
 
 	return r, nil
@@ -346,6 +419,12 @@ func (t *Trace) containerTerminated(trace *gadgetv1alpha1.Trace, event container
 		return
 	}
 
+	details, err := t.peekDetails(event.Container.Mntns)
+	if err != nil {
+		log.Errorf("peeking syscall details for mntns %d: %s", event.Container.Mntns, err)
+		return
+	}
+
 	// The container has terminated. Cleanup the BPF hash map
 	traceSingleton.tracer.Delete(event.Container.Mntns)
 
@@ -354,7 +433,24 @@ func (t *Trace) containerTerminated(trace *gadgetv1alpha1.Trace, event container
 	// This field was fetched when the container was created
 	ownerReference := getContainerOwnerReference(event.Container)
 
-	r, err := generateSeccompPolicy(t.client, trace, syscallNames, event.Container.Podname,
+	policy := seccomptracer.SyscallDetailsToLinuxSeccomp(syscallNames, details)
+	t.mu.Lock()
+	t.terminated[fmt.Sprintf("%s/%s", namespacedName, event.Container.Name)] = policy
+	t.mu.Unlock()
+
+	if trace.Spec.OutputMode == gadgetv1alpha1.TraceOutputModeFile {
+		path, err := writeLinuxSeccomp(trace.Spec.Output, event.Container.Namespace,
+			event.Container.Podname, event.Container.Name, policy)
+		if err != nil {
+			log.Errorf("Trace %s: writing seccomp profile for pod %s: %s", traceName, namespacedName, err)
+			return
+		}
+		log.Infof("Trace %s: wrote seccomp profile for pod %s in %s", traceName, namespacedName, path)
+		t.policyGenerated = true
+		return
+	}
+
+	r, err := generateSeccompPolicy(t.client, trace, syscallNames, details, event.Container.Podname,
 		event.Container.Name, namespacedName, ownerReference)
 	if err != nil {
 		log.Errorf("Trace %s: %v", traceName, err)
@@ -385,6 +481,57 @@ func (t *Trace) containerTerminated(trace *gadgetv1alpha1.Trace, event container
 	}
 }
 
+// kubeletSeccompRoot is the directory of the nodes where the kubelet looks up
+// the Localhost seccomp profiles. The File output mode only writes there.
+const kubeletSeccompRoot = "/var/lib/kubelet/seccomp"
+
+// profileDir returns the directory of the host given by a Trace.Spec.Output,
+// which must be relative to kubeletSeccompRoot and can't leave it
+func profileDir(output string) (string, error) {
+	if output == "" {
+		return "", errors.New("missing output directory")
+	}
+	dir := filepath.Clean(output)
+	if filepath.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+		return "", fmt.Errorf("output directory %q must be relative to %s", output, kubeletSeccompRoot)
+	}
+	return filepath.Join(kubeletSeccompRoot, dir), nil
+}
+
+// writeLinuxSeccomp writes the OCI seccomp profile of a container in the
+// directory given by output and returns the path of the file on the host
+func writeLinuxSeccomp(output, namespace, podname, containername string, policy *specs.LinuxSeccomp) (string, error) {
+	dir, err := profileDir(output)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling seccomp profile: %w", err)
+	}
+
+	hostDir := filepath.Join(os.Getenv("HOST_ROOT"), dir)
+	if err := os.MkdirAll(hostDir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s_%s_%s.json", namespace, podname, containername)
+	if err := os.WriteFile(filepath.Join(hostDir, name), append(b, '\n'), 0o644); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, name), nil
+}
+
+// peekDetails returns the syscall details of a mount namespace if they are
+// recorded for this trace, nil otherwise
+func (t *Trace) peekDetails(mntns uint64) (*seccomptracer.SyscallDetails, error) {
+	if !t.recordDetails {
+		return nil, nil
+	}
+	return traceSingleton.tracer.PeekDetails(mntns)
+}
+
 func getContainerOwnerReference(c *containercollection.Container) *metav1.OwnerReference {
 	ownerRef, err := c.GetOwnerReference()
 	// Owner reference doesn't make any sense for local-gadget, then
@@ -406,17 +553,44 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 		return
 	}
 
+	recordDetails := recordDetailsDefault
+	if param, ok := trace.Spec.Parameters[recordDetailsParam]; ok {
+		var err error
+		recordDetails, err = strconv.ParseBool(param)
+		if err != nil {
+			trace.Status.OperationError = fmt.Sprintf("%q is not valid for %q", param, recordDetailsParam)
+			return
+		}
+	}
+
+	if trace.Spec.OutputMode == gadgetv1alpha1.TraceOutputModeFile {
+		if _, err := profileDir(trace.Spec.Output); err != nil {
+			trace.Status.OperationError = err.Error()
+			return
+		}
+	}
+
 	traceSingleton.mu.Lock()
 	defer traceSingleton.mu.Unlock()
 	if traceSingleton.tracer == nil {
 		var err error
-		traceSingleton.tracer, err = seccomptracer.NewTracer()
+		config := &seccomptracer.Config{
+			RecordDetails: recordDetails,
+		}
+		traceSingleton.tracer, err = seccomptracer.NewTracer(config)
 		if err != nil {
 			trace.Status.OperationError = fmt.Sprintf("Failed to start seccomp tracer: %s", err)
 			return
 		}
+		traceSingleton.recordDetails = recordDetails
+	} else if recordDetails && !traceSingleton.recordDetails {
+		trace.Status.OperationWarning = "Syscall details are not recorded: the seccomp tracer was started without them by another trace"
 	}
 
+	t.mu.Lock()
+	t.terminated = map[string]*specs.LinuxSeccomp{}
+	t.mu.Unlock()
+
 	// 'trace' is owned by the controller and could be modified
 	// outside of the gadget control. Make a copy for the callback.
 	traceCopy := trace.DeepCopy()
@@ -446,6 +620,7 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 
 	traceSingleton.users++
 	t.started = true
+	t.recordDetails = recordDetails && traceSingleton.recordDetails
 	t.policyGenerated = false
 
 	trace.Status.State = gadgetv1alpha1.TraceStateStarted
@@ -465,10 +640,23 @@ func (t *Trace) Generate(trace *gadgetv1alpha1.Trace) {
 		trace.Status.OperationError = "Missing pod"
 		return
 	}
-	if len(trace.Spec.Filter.Labels) != 0 {
+	if len(trace.Spec.Filter.Labels) != 0 || len(trace.Spec.Filter.LabelExpressions) != 0 {
 		trace.Status.OperationError = "Seccomp gadget does not support filtering by labels"
 		return
 	}
+	// The profile is generated for a single pod, patterns would be looked up
+	// as names
+	for _, name := range []string{trace.Spec.Filter.Namespace, trace.Spec.Filter.Podname} {
+		if !namefilter.IsExact(name) {
+			trace.Status.OperationError = fmt.Sprintf("Seccomp gadget needs a pod name, not a pattern: %q", name)
+			return
+		}
+	}
+	if trace.Spec.Filter.ContainerName != "" && !namefilter.IsExact(trace.Spec.Filter.ContainerName) {
+		trace.Status.OperationError = fmt.Sprintf("Seccomp gadget needs a container name, not a pattern: %q",
+			trace.Spec.Filter.ContainerName)
+		return
+	}
 
 	var mntns uint64
 	var containerName string
@@ -538,9 +726,15 @@ func (t *Trace) Generate(trace *gadgetv1alpha1.Trace) {
 		return
 	}
 
+	details, err := t.peekDetails(mntns)
+	if err != nil {
+		trace.Status.OperationError = fmt.Sprintf("peeking syscall details for mntns %d: %s", mntns, err)
+		return
+	}
+
 	switch trace.Spec.OutputMode {
 	case gadgetv1alpha1.TraceOutputModeStatus:
-		policy := seccomptracer.SyscallNamesToLinuxSeccomp(syscallNames)
+		policy := seccomptracer.SyscallDetailsToLinuxSeccomp(syscallNames, details)
 		output, err := json.MarshalIndent(policy, "", "  ")
 		if err != nil {
 			trace.Status.OperationError = fmt.Sprintf("Failed to marshal seccomp policy: %s", err)
@@ -548,12 +742,15 @@ func (t *Trace) Generate(trace *gadgetv1alpha1.Trace) {
 		}
 
 		trace.Status.Output = string(output)
+		if details != nil {
+			trace.Status.Output += "\n\n" + seccomptracer.SyscallFrequencyReport(details)
+		}
 	case gadgetv1alpha1.TraceOutputModeExternalResource:
 		podName := fmt.Sprintf("%s/%s", trace.Spec.Filter.Namespace, trace.Spec.Filter.Podname)
 
 		ownerReference := t.helpers.LookupOwnerReferenceByMntns(mntns)
 
-		r, err := generateSeccompPolicy(t.client, trace, syscallNames, trace.Spec.Filter.Podname, containerName, podName, ownerReference)
+		r, err := generateSeccompPolicy(t.client, trace, syscallNames, details, trace.Spec.Filter.Podname, containerName, podName, ownerReference)
 		if err != nil {
 			trace.Status.OperationError = err.Error()
 			return
@@ -565,12 +762,97 @@ func (t *Trace) Generate(trace *gadgetv1alpha1.Trace) {
 			return
 		}
 	case gadgetv1alpha1.TraceOutputModeFile:
-		fallthrough
+		policy := seccomptracer.SyscallDetailsToLinuxSeccomp(syscallNames, details)
+		path, err := writeLinuxSeccomp(trace.Spec.Output, trace.Spec.Filter.Namespace,
+			trace.Spec.Filter.Podname, containerName, policy)
+		if err != nil {
+			trace.Status.OperationError = fmt.Sprintf("Failed to write seccomp profile: %s", err)
+			return
+		}
+		trace.Status.Output = path
 	default:
 		trace.Status.OperationError = fmt.Sprintf("OutputMode not supported: %s", trace.Spec.OutputMode)
 	}
 }
 
+func (t *Trace) Merge(trace *gadgetv1alpha1.Trace) {
+	if traceSingleton.tracer == nil {
+		log.Errorf("Seccomp tracer is nil")
+		return
+	}
+
+	if !t.started {
+		trace.Status.OperationError = "Not started"
+		return
+	}
+	if trace.Spec.OutputMode != gadgetv1alpha1.TraceOutputModeStatus {
+		trace.Status.OperationError = fmt.Sprintf("OutputMode not supported: %s", trace.Spec.OutputMode)
+		return
+	}
+
+	var base *specs.LinuxSeccomp
+	if param, ok := trace.Spec.Parameters[baseProfileParam]; ok {
+		var err error
+		base, err = ociseccomp.Read(strings.NewReader(param))
+		if err != nil {
+			trace.Status.OperationError = fmt.Sprintf("Invalid %q: %s", baseProfileParam, err)
+			return
+		}
+	}
+
+	policies := []*specs.LinuxSeccomp{}
+
+	containers := t.helpers.GetContainersBySelector(gadgets.ContainerSelectorFromContainerFilter(trace.Spec.Filter))
+	for _, c := range containers {
+		syscallNames, err := traceSingleton.tracer.Peek(c.Mntns)
+		if err != nil {
+			// The container didn't do any syscall since the tracer started
+			log.Debugf("peeking syscalls for mntns %d: %s", c.Mntns, err)
+			continue
+		}
+		details, err := t.peekDetails(c.Mntns)
+		if err != nil {
+			trace.Status.OperationError = fmt.Sprintf("peeking syscall details for mntns %d: %s", c.Mntns, err)
+			return
+		}
+		policies = append(policies, seccomptracer.SyscallDetailsToLinuxSeccomp(syscallNames, details))
+	}
+
+	t.mu.Lock()
+	keys := []string{}
+	for key := range t.terminated {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		policies = append(policies, t.terminated[key])
+	}
+	t.mu.Unlock()
+
+	if len(policies) == 0 {
+		trace.Status.OperationWarning = "No container to merge the seccomp profiles of"
+		return
+	}
+
+	merged, err := ociseccomp.Merge(policies...)
+	if err != nil {
+		trace.Status.OperationError = fmt.Sprintf("Failed to merge seccomp profiles: %s", err)
+		return
+	}
+	output, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		trace.Status.OperationError = fmt.Sprintf("Failed to marshal seccomp policy: %s", err)
+		return
+	}
+
+	trace.Status.Output = string(output)
+	if base != nil {
+		missing := ociseccomp.Diff(base, merged)
+		trace.Status.Output += fmt.Sprintf("\n\nSyscalls not allowed by %s:\n%s",
+			baseProfileParam, ociseccomp.FormatRules(missing))
+	}
+}
+
 func (t *Trace) Stop(trace *gadgetv1alpha1.Trace) {
 	if !t.started {
 		trace.Status.OperationError = "Not started"
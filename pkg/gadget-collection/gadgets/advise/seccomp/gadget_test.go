@@ -15,10 +15,14 @@
 package seccomp
 
 import (
+	"reflect"
 	"testing"
 
+	commonseccomp "github.com/containers/common/pkg/seccomp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	seccompprofile "sigs.k8s.io/security-profiles-operator/api/seccompprofile/v1beta1"
+
+	seccomptracer "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/tracer"
 )
 
 func TestGetSeccompProfileNextName(t *testing.T) {
@@ -215,3 +219,86 @@ func TestGetSeccompProfileNextName(t *testing.T) {
 			nextName, expectedNextName)
 	}
 }
+
+func TestSyscallNamesToSeccompPolicy(t *testing.T) {
+	profileName := &SeccompProfileNsName{namespace: "default", name: "mypod"}
+	syscallNames := []string{"ioctl", "read", "socket", "write"}
+
+	// Without details, all the syscalls are allowed with any argument
+	r := syscallNamesToSeccompPolicy(profileName, syscallNames, nil)
+	if len(r.Spec.Syscalls) != 1 || !reflect.DeepEqual(r.Spec.Syscalls[0].Names, syscallNames) {
+		t.Fatalf("Unexpected syscalls: %+v", r.Spec.Syscalls)
+	}
+	if _, ok := r.ObjectMeta.Annotations["seccomp.gadget.kinvolk.io/syscall-counts"]; ok {
+		t.Fatalf("Unexpected syscall counts annotation")
+	}
+
+	arg := func(index uint, value uint64) seccomptracer.SyscallArg {
+		return seccomptracer.SyscallArg{Index: index, Value: value}
+	}
+	details := &seccomptracer.SyscallDetails{
+		Counts:           map[string]uint64{"ioctl": 2, "read": 10, "socket": 3, "write": 5},
+		IncompleteCounts: map[string]bool{"read": true},
+		Args: map[string][][]seccomptracer.SyscallArg{
+			"ioctl": {{arg(1, 0x5401)}},
+			// socket(AF_UNIX, SOCK_STREAM) and socket(AF_INET, SOCK_DGRAM)
+			// don't allow socket(AF_INET, SOCK_STREAM)
+			"socket": {{arg(0, 1), arg(1, 1)}, {arg(0, 2), arg(1, 2)}},
+		},
+	}
+	r = syscallNamesToSeccompPolicy(profileName, syscallNames, details)
+
+	eq := func(index uint, value uint64) *seccompprofile.Arg {
+		return &seccompprofile.Arg{Index: index, Value: value, Op: commonseccomp.OpEqualTo}
+	}
+	expected := []*seccompprofile.Syscall{
+		{Names: []string{"read", "write"}, Action: commonseccomp.ActAllow, Args: []*seccompprofile.Arg{}},
+		{Names: []string{"ioctl"}, Action: commonseccomp.ActAllow, Args: []*seccompprofile.Arg{eq(1, 0x5401)}},
+		{Names: []string{"socket"}, Action: commonseccomp.ActAllow, Args: []*seccompprofile.Arg{eq(0, 1), eq(1, 1)}},
+		{Names: []string{"socket"}, Action: commonseccomp.ActAllow, Args: []*seccompprofile.Arg{eq(0, 2), eq(1, 2)}},
+	}
+	if !reflect.DeepEqual(r.Spec.Syscalls, expected) {
+		t.Fatalf("Unexpected syscalls: %+v", r.Spec.Syscalls)
+	}
+
+	counts := r.ObjectMeta.Annotations["seccomp.gadget.kinvolk.io/syscall-counts"]
+	if counts != `{"ioctl":2,"read":10,"socket":3,"write":5}` {
+		t.Fatalf("Unexpected syscall counts annotation: %q", counts)
+	}
+
+	incomplete := r.ObjectMeta.Annotations["seccomp.gadget.kinvolk.io/syscall-counts-incomplete"]
+	if incomplete != "read" {
+		t.Fatalf("Unexpected syscall counts incomplete annotation: %q", incomplete)
+	}
+
+	report := seccomptracer.SyscallFrequencyReport(details)
+	expectedReport := "SYSCALL  COUNT\nread     10+\nwrite    5\nsocket   3\nioctl    2\n\n" +
+		"+: Some calls weren't counted because the map of the counts was full\n"
+	if report != expectedReport {
+		t.Fatalf("Unexpected report:\n%s", report)
+	}
+}
+
+func TestProfileDir(t *testing.T) {
+	valid := map[string]string{
+		"profiles":        "/var/lib/kubelet/seccomp/profiles",
+		"profiles/myapp/": "/var/lib/kubelet/seccomp/profiles/myapp",
+		"a/../profiles":   "/var/lib/kubelet/seccomp/profiles",
+		".":               "/var/lib/kubelet/seccomp",
+	}
+	for output, expected := range valid {
+		dir, err := profileDir(output)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %s", output, err)
+		}
+		if dir != expected {
+			t.Fatalf("Unexpected directory for %q: %q", output, dir)
+		}
+	}
+
+	for _, output := range []string{"", "/etc", "..", "../../etc", "profiles/../../etc"} {
+		if _, err := profileDir(output); err == nil {
+			t.Fatalf("Expected an error for %q", output)
+		}
+	}
+}
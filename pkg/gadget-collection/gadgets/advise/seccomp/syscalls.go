@@ -18,20 +18,55 @@
 package seccomp
 
 import (
+	"encoding/json"
+	"sort"
+	"strings"
+
 	commonseccomp "github.com/containers/common/pkg/seccomp"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/tracer"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	seccompprofile "sigs.k8s.io/security-profiles-operator/api/seccompprofile/v1beta1"
 )
 
-func syscallNamesToSeccompPolicy(profileName *SeccompProfileNsName, syscallNames []string) *seccompprofile.SeccompProfile {
+// syscallNamesToSeccompPolicy generates a policy allowing the given syscalls.
+// If details are given, the syscalls whose argument values were recorded are
+// only allowed with those values and the number of calls to each syscall is
+// added in the seccomp.gadget.kinvolk.io/syscall-counts annotation. The
+// syscalls whose count is a lower bound are listed in the
+// seccomp.gadget.kinvolk.io/syscall-counts-incomplete one.
+func syscallNamesToSeccompPolicy(profileName *SeccompProfileNsName, syscallNames []string, details *tracer.SyscallDetails) *seccompprofile.SeccompProfile {
+	names := []string{}
+	restricted := []*seccompprofile.Syscall{}
+	for _, name := range syscallNames {
+		if details == nil || len(details.Args[name]) == 0 {
+			names = append(names, name)
+			continue
+		}
+		for _, rule := range tracer.SyscallArgRules(details.Args[name]) {
+			args := []*seccompprofile.Arg{}
+			for _, arg := range rule {
+				args = append(args, &seccompprofile.Arg{
+					Index: arg.Index,
+					Value: arg.Value,
+					Op:    commonseccomp.OpEqualTo,
+				})
+			}
+			restricted = append(restricted, &seccompprofile.Syscall{
+				Names:  []string{name},
+				Action: commonseccomp.ActAllow,
+				Args:   args,
+			})
+		}
+	}
+
 	syscalls := []*seccompprofile.Syscall{
 		{
-			Names:  syscallNames,
+			Names:  names,
 			Action: commonseccomp.ActAllow,
 			Args:   []*seccompprofile.Arg{},
 		},
 	}
+	syscalls = append(syscalls, restricted...)
 
 	ret := seccompprofile.SeccompProfile{
 		ObjectMeta: metav1.ObjectMeta{
@@ -58,5 +93,21 @@ func syscallNamesToSeccompPolicy(profileName *SeccompProfileNsName, syscallNames
 		ret.Spec.Architectures = append(ret.Spec.Architectures, arch)
 	}
 
+	if details != nil {
+		counts, err := json.Marshal(details.Counts)
+		if err == nil {
+			ret.ObjectMeta.Annotations["seccomp.gadget.kinvolk.io/syscall-counts"] = string(counts)
+		}
+
+		if len(details.IncompleteCounts) != 0 {
+			incomplete := make([]string, 0, len(details.IncompleteCounts))
+			for name := range details.IncompleteCounts {
+				incomplete = append(incomplete, name)
+			}
+			sort.Strings(incomplete)
+			ret.ObjectMeta.Annotations["seccomp.gadget.kinvolk.io/syscall-counts-incomplete"] = strings.Join(incomplete, ",")
+		}
+	}
+
 	return &ret
 }
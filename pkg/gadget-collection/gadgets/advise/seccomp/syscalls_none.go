@@ -18,10 +18,11 @@
 package seccomp
 
 import (
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/advise/seccomp/tracer"
 	seccompprofile "sigs.k8s.io/security-profiles-operator/api/seccompprofile/v1beta1"
 )
 
-func syscallNamesToSeccompPolicy(profileName *SeccompProfileNsName, syscallNames []string) *seccompprofile.SeccompProfile {
+func syscallNamesToSeccompPolicy(profileName *SeccompProfileNsName, syscallNames []string, details *tracer.SyscallDetails) *seccompprofile.SeccompProfile {
 	panic("Not implemented")
 	return nil
 }
@@ -21,7 +21,6 @@ import (
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
 	auditseccomptracer "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/audit/seccomp/tracer"
 	types "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/audit/seccomp/types"
-	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
 
 type Trace struct {
@@ -57,6 +56,7 @@ one of those two conditions:
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -100,22 +100,20 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	eventCallback := func(event types.Event) {
 		event.Node = trace.Spec.Node
 
-		t.helpers.PublishEvent(
-			traceName,
-			eventtypes.EventString(event),
-		)
+		t.helpers.PublishTypedEvent(traceName, event)
 	}
 
 	var err error
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 
 	config := &auditseccomptracer.Config{
 		MountnsMap:    mountNsMap,
+		CgroupIDMap:   cgroupIDMap,
 		ContainersMap: t.helpers.ContainersMap(),
 	}
 	t.tracer, err = auditseccomptracer.NewTracer(config, eventCallback)
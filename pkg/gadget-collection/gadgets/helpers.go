@@ -15,8 +15,12 @@
 package gadgets
 
 import (
+	"fmt"
+
+	"github.com/cilium/ebpf"
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
 	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -36,10 +40,38 @@ func ContainerSelectorFromContainerFilter(f *gadgetv1alpha1.ContainerFilter) *co
 	for k, v := range f.Labels {
 		labels[k] = v
 	}
+	var expressions []metav1.LabelSelectorRequirement
+	for _, r := range f.LabelExpressions {
+		expressions = append(expressions, *r.DeepCopy())
+	}
 	return &containercollection.ContainerSelector{
-		Namespace: f.Namespace,
-		Podname:   f.Podname,
-		Labels:    labels,
-		Name:      f.ContainerName,
+		Namespace:        f.Namespace,
+		Podname:          f.Podname,
+		Labels:           labels,
+		LabelExpressions: expressions,
+		Name:             f.ContainerName,
+		Image:            f.Image,
+	}
+}
+
+// TracerFilterMaps returns the map used by the tracer of a trace to select the
+// events of its containers according to filterMode: The mount namespace map
+// or the cgroup ID map. The other one is nil.
+func TracerFilterMaps(helpers GadgetHelpers, traceName string, filterMode gadgetv1alpha1.FilterMode) (mountNsMap, cgroupIDMap *ebpf.Map, err error) {
+	switch filterMode {
+	case "", gadgetv1alpha1.FilterModeMountNamespace:
+		mountNsMap, err = helpers.TracerMountNsMap(traceName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find tracer's mount ns map: %w", err)
+		}
+	case gadgetv1alpha1.FilterModeCgroupID:
+		cgroupIDMap, err = helpers.TracerCgroupIDMap(traceName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find tracer's cgroup ID map: %w", err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown filter mode %q", filterMode)
 	}
+
+	return mountNsMap, cgroupIDMap, nil
 }
@@ -61,6 +61,22 @@ type TraceFactoryWithDocumentation interface {
 	Description() string
 }
 
+// TraceParameter describes a parameter that users can give to a gadget in the
+// Parameters field of the trace.
+type TraceParameter struct {
+	Name string
+	Doc  string
+
+	// Default is the value used when the parameter isn't given. It's empty
+	// when the parameter has no default value.
+	Default string
+}
+
+type TraceFactoryWithParameters interface {
+	// Parameters returns the parameters supported by the gadget
+	Parameters() []TraceParameter
+}
+
 // TraceOperation packages an operation on a gadget that users can call via the
 // annotation gadget.kinvolk.io/operation.
 type TraceOperation struct {
@@ -84,7 +100,12 @@ type GadgetHelpers interface {
 	gadgets.DataEnricherByNetNs
 
 	PublishEvent(tracerID string, line string) error
+	// PublishTypedEvent publishes an event without encoding it. It's
+	// encoded, as JSON or with the binary encoding of the streams, only
+	// when needed. The event must not be modified afterwards.
+	PublishTypedEvent(tracerID string, event any) error
 	TracerMountNsMap(tracerID string) (*ebpf.Map, error)
+	TracerCgroupIDMap(tracerID string) (*ebpf.Map, error)
 	ContainersMap() *ebpf.Map
 }
 
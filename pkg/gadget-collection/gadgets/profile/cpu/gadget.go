@@ -48,6 +48,19 @@ func (f *TraceFactory) Description() string {
 	return `Analyze CPU performance by sampling stack traces`
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name: types.ProfileUserParam,
+			Doc:  "Show only the user space stacks. The value is ignored.",
+		},
+		{
+			Name: types.ProfileKernelParam,
+			Doc:  "Show only the kernel stacks. The value is ignored.",
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStatus: {},
@@ -92,9 +105,9 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 
@@ -102,6 +115,7 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	_, kernelStackOnly := trace.Spec.Parameters[types.ProfileKernelParam]
 	config := &tracer.Config{
 		MountnsMap:      mountNsMap,
+		CgroupIDMap:     cgroupIDMap,
 		UserStackOnly:   userStackOnly,
 		KernelStackOnly: kernelStackOnly,
 	}
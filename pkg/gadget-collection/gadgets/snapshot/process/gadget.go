@@ -66,13 +66,14 @@ func (f *TraceFactory) Operations() map[gadgetv1alpha1.Operation]gadgets.TraceOp
 
 func (t *Trace) Collect(trace *gadgetv1alpha1.Trace) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 	config := &tracer.Config{
-		MountnsMap: mountNsMap,
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
 	}
 	events, err := tracer.RunCollector(config, t.helpers)
 	if err != nil {
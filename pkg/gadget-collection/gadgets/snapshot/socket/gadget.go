@@ -42,6 +42,16 @@ func (f *TraceFactory) Description() string {
 	return `The socket-collector gadget gathers information about TCP and UDP sockets.`
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name:    "protocol",
+			Doc:     "Show only the sockets using this protocol (all, tcp or udp).",
+			Default: "all",
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStatus: {},
@@ -63,6 +63,29 @@ The following parameters are supported:
 		top.SortByParam, strings.Join(validCols, ","), strings.Join(types.SortByDefault, ","))
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	cols := types.GetColumns()
+	validCols, _ := sort.FilterSortableColumns(cols.ColumnMap, cols.GetColumnNames())
+
+	return []gadgets.TraceParameter{
+		{
+			Name:    top.IntervalParam,
+			Doc:     "Output interval, in seconds.",
+			Default: strconv.Itoa(top.IntervalDefault),
+		},
+		{
+			Name:    top.MaxRowsParam,
+			Doc:     "Maximum rows to print.",
+			Default: strconv.Itoa(top.MaxRowsDefault),
+		},
+		{
+			Name:    top.SortByParam,
+			Doc:     fmt.Sprintf("The field to sort the results by (%s).", strings.Join(validCols, ",")),
+			Default: strings.Join(types.SortByDefault, ","),
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
@@ -144,16 +167,17 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 		}
 	}
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 	config := &biotoptracer.Config{
-		MaxRows:    maxRows,
-		Interval:   time.Second * time.Duration(intervalSeconds),
-		SortBy:     sortBy,
-		MountnsMap: mountNsMap,
+		MaxRows:     maxRows,
+		Interval:    time.Second * time.Duration(intervalSeconds),
+		SortBy:      sortBy,
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
 	}
 
 	eventCallback := func(ev *top.Event[types.Stats]) {
@@ -67,6 +67,29 @@ The following parameters are supported:
 		top.SortByParam, strings.Join(validCols, ","), strings.Join(types.SortByDefault, ","))
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	cols := types.GetColumns()
+	validCols, _ := sort.FilterSortableColumns(cols.ColumnMap, cols.GetColumnNames())
+
+	return []gadgets.TraceParameter{
+		{
+			Name:    top.IntervalParam,
+			Doc:     "Output interval, in seconds.",
+			Default: strconv.Itoa(top.IntervalDefault),
+		},
+		{
+			Name:    top.MaxRowsParam,
+			Doc:     "Maximum rows to print.",
+			Default: strconv.Itoa(top.MaxRowsDefault),
+		},
+		{
+			Name:    top.SortByParam,
+			Doc:     fmt.Sprintf("The field to sort the results by (%s).", strings.Join(validCols, ",")),
+			Default: strings.Join(types.SortByDefault, ","),
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
@@ -65,6 +65,34 @@ The following parameters are supported:
 		types.AllFilesParam, types.AllFilesDefault)
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	cols := types.GetColumns()
+	validCols, _ := sort.FilterSortableColumns(cols.ColumnMap, cols.GetColumnNames())
+
+	return []gadgets.TraceParameter{
+		{
+			Name:    top.IntervalParam,
+			Doc:     "Output interval, in seconds.",
+			Default: strconv.Itoa(top.IntervalDefault),
+		},
+		{
+			Name:    top.MaxRowsParam,
+			Doc:     "Maximum rows to print.",
+			Default: strconv.Itoa(top.MaxRowsDefault),
+		},
+		{
+			Name:    top.SortByParam,
+			Doc:     fmt.Sprintf("The field to sort the results by (%s).", strings.Join(validCols, ",")),
+			Default: strings.Join(types.SortByDefault, ","),
+		},
+		{
+			Name:    types.AllFilesParam,
+			Doc:     "Show all files, not only regular files.",
+			Default: strconv.FormatBool(types.AllFilesDefault),
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
@@ -155,18 +183,19 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 		}
 	}
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 
 	config := &filetoptracer.Config{
-		AllFiles:   allFiles,
-		MaxRows:    maxRows,
-		Interval:   time.Second * time.Duration(intervalSeconds),
-		SortBy:     sortBy,
-		MountnsMap: mountNsMap,
+		AllFiles:    allFiles,
+		MaxRows:     maxRows,
+		Interval:    time.Second * time.Duration(intervalSeconds),
+		SortBy:      sortBy,
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
 	}
 
 	eventCallback := func(ev *top.Event[types.Stats]) {
@@ -15,7 +15,6 @@
 package bindsnoop
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -53,9 +52,28 @@ func (f *TraceFactory) Description() string {
 	return `bindsnoop traces the kernel functions performing socket binding.`
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name: "pid",
+			Doc:  "Only trace the bindings of this PID (default to all).",
+		},
+		{
+			Name: "ports",
+			Doc:  "Comma-separated list of ports to trace (default to all).",
+		},
+		{
+			Name:    "ignore_errors",
+			Doc:     "Don't show the failed bindings.",
+			Default: "false",
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -98,12 +116,7 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
 
 	eventCallback := func(event types.Event) {
-		r, err := json.Marshal(event)
-		if err != nil {
-			log.Warnf("Gadget %s: error marshalling event: %s", trace.Spec.Gadget, err)
-			return
-		}
-		t.helpers.PublishEvent(traceName, string(r))
+		t.helpers.PublishTypedEvent(traceName, event)
 	}
 
 	params := trace.Spec.Parameters
@@ -147,13 +160,14 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 
 	var err error
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 	config := &tracer.Config{
 		MountnsMap:   mountNsMap,
+		CgroupIDMap:  cgroupIDMap,
 		TargetPid:    targetPid,
 		TargetPorts:  targetPorts,
 		IgnoreErrors: ignoreErrors,
@@ -15,7 +15,6 @@
 package capabilities
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -52,9 +51,25 @@ func (f *TraceFactory) Description() string {
 	return `capabilities traces security capability checks"`
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name:    types.AuditOnlyParam,
+			Doc:     "Only show audit checks.",
+			Default: strconv.FormatBool(types.AuditOnlyDefault),
+		},
+		{
+			Name:    types.UniqueParam,
+			Doc:     "Only show a capability once on the same container.",
+			Default: strconv.FormatBool(types.UniqueDefault),
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -120,25 +135,21 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
 
 	eventCallback := func(event types.Event) {
-		r, err := json.Marshal(event)
-		if err != nil {
-			log.Warnf("Gadget %s: error marshalling event: %s", trace.Spec.Gadget, err)
-			return
-		}
-		t.helpers.PublishEvent(traceName, string(r))
+		t.helpers.PublishTypedEvent(traceName, event)
 	}
 
 	var err error
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 	config := &tracer.Config{
-		MountnsMap: mountNsMap,
-		AuditOnly:  auditOnly,
-		Unique:     unique,
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
+		AuditOnly:   auditOnly,
+		Unique:      unique,
 	}
 
 	t.tracer, err = tracer.NewTracer(config, t.helpers, eventCallback)
@@ -16,6 +16,7 @@ package dns
 
 import (
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -25,7 +26,6 @@ import (
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
 	dnsTracer "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/tracer"
 	dnsTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
-	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
 
 type Trace struct {
@@ -49,12 +49,28 @@ func NewFactory() gadgets.TraceFactory {
 }
 
 func (f *TraceFactory) Description() string {
-	return `The dns gadget traces DNS requests.`
+	t := `The dns gadget traces DNS requests.
+
+The following parameters are supported:
+- dnstimeout: Time after which a query without response is reported as timed out. (default %s)`
+
+	return fmt.Sprintf(t, dnsTypes.DNSTimeoutDefault)
+}
+
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name:    "dnstimeout",
+			Doc:     "Time after which a query without response is reported as timed out.",
+			Default: dnsTypes.DNSTimeoutDefault.String(),
+		},
+	}
 }
 
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -95,10 +111,7 @@ func (f *TraceFactory) Operations() map[gadgetv1alpha1.Operation]gadgets.TraceOp
 
 func (t *Trace) publishEvent(trace *gadgetv1alpha1.Trace, event *dnsTypes.Event) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
-	t.helpers.PublishEvent(
-		traceName,
-		eventtypes.EventString(event),
-	)
+	t.helpers.PublishTypedEvent(traceName, *event)
 }
 
 func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
@@ -107,8 +120,20 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 		return
 	}
 
+	dnsTimeout := dnsTypes.DNSTimeoutDefault
+	if val, ok := trace.Spec.Parameters["dnstimeout"]; ok {
+		var err error
+		dnsTimeout, err = time.ParseDuration(val)
+		if err != nil || dnsTimeout <= 0 {
+			trace.Status.OperationError = fmt.Sprintf("%q is not valid for dnstimeout", val)
+			return
+		}
+	}
+
 	var err error
-	t.tracer, err = dnsTracer.NewTracer()
+	t.tracer, err = dnsTracer.NewTracer(&dnsTracer.Config{
+		DNSTimeout: dnsTimeout,
+	})
 	if err != nil {
 		trace.Status.OperationError = fmt.Sprintf("Failed to start dns tracer: %s", err)
 		return
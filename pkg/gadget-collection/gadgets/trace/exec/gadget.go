@@ -15,7 +15,6 @@
 package execsnoop
 
 import (
-	"encoding/json"
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
@@ -54,6 +53,7 @@ func (f *TraceFactory) Description() string {
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -96,23 +96,19 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
 
 	eventCallback := func(event types.Event) {
-		r, err := json.Marshal(event)
-		if err != nil {
-			log.Warnf("Gadget %s: error marshalling event: %s", trace.Spec.Gadget, err)
-			return
-		}
-		t.helpers.PublishEvent(traceName, string(r))
+		t.helpers.PublishTypedEvent(traceName, event)
 	}
 
 	var err error
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 	config := &tracer.Config{
-		MountnsMap: mountNsMap,
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
 	}
 	t.tracer, err = tracer.NewTracer(config, t.helpers, eventCallback)
 	if err != nil {
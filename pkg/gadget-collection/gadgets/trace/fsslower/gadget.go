@@ -15,7 +15,6 @@
 package fsslower
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -58,9 +57,24 @@ The following parameters are supported:
 	return fmt.Sprintf(t, strings.Join(validFilesystems, ", "), types.MinLatencyDefault)
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name: "filesystem",
+			Doc:  fmt.Sprintf("Which filesystem to trace [%s]", strings.Join(validFilesystems, ", ")),
+		},
+		{
+			Name:    "minlatency",
+			Doc:     "Min latency to trace, in ms.",
+			Default: strconv.FormatUint(uint64(types.MinLatencyDefault), 10),
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -103,12 +117,7 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
 
 	eventCallback := func(event types.Event) {
-		r, err := json.Marshal(event)
-		if err != nil {
-			fmt.Printf("error marshalling event: %s\n", err)
-			return
-		}
-		t.helpers.PublishEvent(traceName, string(r))
+		t.helpers.PublishTypedEvent(traceName, event)
 	}
 
 	var err error
@@ -138,16 +147,17 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 		minLatency = uint(minLatencyParsed)
 	}
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 
 	config := &tracer.Config{
-		MountnsMap: mountNsMap,
-		Filesystem: filesystem,
-		MinLatency: minLatency,
+		MountnsMap:  mountNsMap,
+		CgroupIDMap: cgroupIDMap,
+		Filesystem:  filesystem,
+		MinLatency:  minLatency,
 	}
 	t.tracer, err = tracer.NewTracer(config, t.helpers, eventCallback)
 	if err != nil {
@@ -61,6 +61,7 @@ func (f *TraceFactory) Description() string {
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -100,10 +101,7 @@ func (t *Trace) publishEvent(
 	event *netTypes.Event,
 ) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
-	t.helpers.PublishEvent(
-		traceName,
-		eventtypes.EventString(event),
-	)
+	t.helpers.PublishTypedEvent(traceName, *event)
 }
 
 func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
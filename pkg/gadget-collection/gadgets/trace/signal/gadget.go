@@ -15,7 +15,6 @@
 package sigsnoop
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -26,8 +25,6 @@ import (
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/signal/types"
 
 	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type Trace struct {
@@ -58,9 +55,33 @@ The following parameters are supported:
 `
 }
 
+func (f *TraceFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{
+		{
+			Name:    "failed",
+			Doc:     "Trace only failed signal sending.",
+			Default: "false",
+		},
+		{
+			Name: "signal",
+			Doc:  "Which particular signal to trace (default to all).",
+		},
+		{
+			Name: "pid",
+			Doc:  "Which particular pid to trace (default to all).",
+		},
+		{
+			Name:    "kill-only",
+			Doc:     "Trace only signals sent by the kill syscall.",
+			Default: "false",
+		},
+	}
+}
+
 func (f *TraceFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
 	return map[gadgetv1alpha1.TraceOutputMode]struct{}{
 		gadgetv1alpha1.TraceOutputModeStream: {},
+		gadgetv1alpha1.TraceOutputModeOTLP:   {},
 	}
 }
 
@@ -103,12 +124,7 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 	traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
 
 	eventCallback := func(event types.Event) {
-		r, err := json.Marshal(event)
-		if err != nil {
-			log.Warnf("Gadget %s: error marshalling event: %s", trace.Spec.Gadget, err)
-			return
-		}
-		t.helpers.PublishEvent(traceName, string(r))
+		t.helpers.PublishTypedEvent(traceName, event)
 	}
 
 	params := trace.Spec.Parameters
@@ -153,13 +169,14 @@ func (t *Trace) Start(trace *gadgetv1alpha1.Trace) {
 
 	var err error
 
-	mountNsMap, err := t.helpers.TracerMountNsMap(traceName)
+	mountNsMap, cgroupIDMap, err := gadgets.TracerFilterMaps(t.helpers, traceName, trace.Spec.FilterMode)
 	if err != nil {
-		trace.Status.OperationError = fmt.Sprintf("failed to find tracer's mount ns map: %s", err)
+		trace.Status.OperationError = err.Error()
 		return
 	}
 	config := &tracer.Config{
 		MountnsMap:   mountNsMap,
+		CgroupIDMap:  cgroupIDMap,
 		TargetPid:    targetPid,
 		TargetSignal: targetSignal,
 		FailedOnly:   failedOnly,
@@ -0,0 +1,351 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package advisor records the capabilities used by containers and generates
+// the minimal securityContext.capabilities of their workloads.
+package advisor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/capabilities/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// ContainerCapabilities are the capabilities checked by a container
+type ContainerCapabilities struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+
+	// Required are the capabilities the container was granted. They must
+	// be kept to preserve its behaviour.
+	Required map[string]struct{} `json:"required"`
+
+	// SetID are the required capabilities checked by set*id syscalls,
+	// i.e. with InsetID set
+	SetID map[string]struct{} `json:"setID"`
+
+	// Denied are the capabilities the container was refused. As the
+	// container already runs without them, they are not added to the
+	// securityContext.
+	Denied map[string]struct{} `json:"denied"`
+}
+
+// Recorder records the capabilities checked by each container from the
+// events of the capabilities tracer. Containers are identified by namespace,
+// pod and name so that the checks done before a restart are kept.
+type Recorder struct {
+	mu         sync.Mutex
+	containers map[string]*ContainerCapabilities
+
+	// auditOnly ignores the checks without audit: the kernel does them to
+	// probe a capability before falling back to another code path, so
+	// they don't mean that the capability is required.
+	auditOnly bool
+}
+
+func NewRecorder(auditOnly bool) *Recorder {
+	return &Recorder{
+		containers: map[string]*ContainerCapabilities{},
+		auditOnly:  auditOnly,
+	}
+}
+
+func containerKey(namespace, pod, container string) string {
+	return namespace + "/" + pod + "/" + container
+}
+
+// Record adds the capability checked in the event to its container
+func (r *Recorder) Record(event types.Event) {
+	if event.Type != eventtypes.NORMAL || event.Container == "" {
+		return
+	}
+	// runc sets up the container with its own capabilities before
+	// executing the entrypoint, as the seccomp gadget ignores its syscalls
+	if strings.HasPrefix(event.Comm, "runc") {
+		return
+	}
+	if r.auditOnly && event.Audit == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := containerKey(event.Namespace, event.Pod, event.Container)
+	c, ok := r.containers[key]
+	if !ok {
+		c = &ContainerCapabilities{
+			Namespace: event.Namespace,
+			Pod:       event.Pod,
+			Container: event.Container,
+			Required:  map[string]struct{}{},
+			SetID:     map[string]struct{}{},
+			Denied:    map[string]struct{}{},
+		}
+		r.containers[key] = c
+	}
+
+	if event.Verdict != "Allow" {
+		c.Denied[event.CapName] = struct{}{}
+		return
+	}
+	c.Required[event.CapName] = struct{}{}
+	if event.InsetID != nil && *event.InsetID {
+		c.SetID[event.CapName] = struct{}{}
+	}
+}
+
+// Containers returns a copy of the capabilities recorded for the containers
+// accepted by the filter, sorted by namespace, pod and container.
+func (r *Recorder) Containers(filter func(c *ContainerCapabilities) bool) []*ContainerCapabilities {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := []*ContainerCapabilities{}
+	for _, c := range r.containers {
+		if filter != nil && !filter(c) {
+			continue
+		}
+		cp := &ContainerCapabilities{
+			Namespace: c.Namespace,
+			Pod:       c.Pod,
+			Container: c.Container,
+			Required:  map[string]struct{}{},
+			SetID:     map[string]struct{}{},
+			Denied:    map[string]struct{}{},
+		}
+		for k := range c.Required {
+			cp.Required[k] = struct{}{}
+		}
+		for k := range c.SetID {
+			cp.SetID[k] = struct{}{}
+		}
+		for k := range c.Denied {
+			// Checks allowed at another time are required
+			if _, ok := c.Required[k]; !ok {
+				cp.Denied[k] = struct{}{}
+			}
+		}
+		ret = append(ret, cp)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return containerKey(ret[i].Namespace, ret[i].Pod, ret[i].Container) <
+			containerKey(ret[j].Namespace, ret[j].Pod, ret[j].Container)
+	})
+	return ret
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WorkloadPatch is a strategic merge patch setting the capabilities of the
+// containers of a workload
+type WorkloadPatch struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+
+	// Containers are the capabilities recorded for the containers of all
+	// the pods of the workload
+	Containers []*ContainerCapabilities `json:"containers"`
+
+	Patch map[string]interface{} `json:"patch"`
+}
+
+/* podSpecPath returns the path of the pod template spec in a workload of the
+ * given kind. Pods without owner are patched directly.
+ */
+func podSpecPath(kind string) []string {
+	switch kind {
+	case "Pod":
+		return []string{"spec"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	default:
+		// Deployment, ReplicaSet, StatefulSet, DaemonSet, Job and
+		// ReplicationController
+		return []string{"spec", "template", "spec"}
+	}
+}
+
+/* GeneratePatches groups the containers by the workload owning their pod and
+ * generates for each workload a patch dropping all the capabilities of its
+ * containers but the required ones. owners gives the owner reference of a
+ * pod by namespace/pod, if any.
+ */
+func GeneratePatches(containers []*ContainerCapabilities, owners map[string]*metav1.OwnerReference) []*WorkloadPatch {
+	patches := map[string]*WorkloadPatch{}
+	for _, c := range containers {
+		p := &WorkloadPatch{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  c.Namespace,
+			Name:       c.Pod,
+		}
+		if owner := owners[c.Namespace+"/"+c.Pod]; owner != nil {
+			p.APIVersion = owner.APIVersion
+			p.Kind = owner.Kind
+			p.Name = owner.Name
+		}
+
+		key := p.Namespace + "/" + p.Kind + "/" + p.Name
+		if existing, ok := patches[key]; ok {
+			p = existing
+		} else {
+			patches[key] = p
+		}
+		p.Containers = append(p.Containers, c)
+	}
+
+	ret := make([]*WorkloadPatch, 0, len(patches))
+	for _, p := range patches {
+		p.Patch = p.buildPatch()
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		ki := ret[i].Namespace + "/" + ret[i].Kind + "/" + ret[i].Name
+		kj := ret[j].Namespace + "/" + ret[j].Kind + "/" + ret[j].Name
+		return ki < kj
+	})
+	return ret
+}
+
+/* MergePatches merges the patches generated for the same workloads, e.g. on
+ * the different nodes running their pods, into a patch per workload adding
+ * the capabilities required by any of its containers with the same name.
+ */
+func MergePatches(patches []*WorkloadPatch) []*WorkloadPatch {
+	containers := []*ContainerCapabilities{}
+	owners := map[string]*metav1.OwnerReference{}
+	for _, p := range patches {
+		for _, c := range p.Containers {
+			containers = append(containers, c)
+			if p.Kind != "Pod" {
+				owners[c.Namespace+"/"+c.Pod] = &metav1.OwnerReference{
+					APIVersion: p.APIVersion,
+					Kind:       p.Kind,
+					Name:       p.Name,
+				}
+			}
+		}
+	}
+	sort.SliceStable(containers, func(i, j int) bool {
+		return containerKey(containers[i].Namespace, containers[i].Pod, containers[i].Container) <
+			containerKey(containers[j].Namespace, containers[j].Pod, containers[j].Container)
+	})
+
+	return GeneratePatches(containers, owners)
+}
+
+func (p *WorkloadPatch) buildPatch() map[string]interface{} {
+	// Union of the capabilities of the replicas by container name
+	required := map[string]map[string]struct{}{}
+	for _, c := range p.Containers {
+		if _, ok := required[c.Container]; !ok {
+			required[c.Container] = map[string]struct{}{}
+		}
+		for capName := range c.Required {
+			required[c.Container][capName] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	containers := []interface{}{}
+	for _, name := range names {
+		capabilities := map[string]interface{}{
+			"drop": []string{"ALL"},
+		}
+		if add := sortedKeys(required[name]); len(add) > 0 {
+			capabilities["add"] = add
+		}
+		containers = append(containers, map[string]interface{}{
+			"name": name,
+			"securityContext": map[string]interface{}{
+				"capabilities": capabilities,
+			},
+		})
+	}
+
+	var patch interface{} = map[string]interface{}{"containers": containers}
+	path := podSpecPath(p.Kind)
+	for i := len(path) - 1; i >= 0; i-- {
+		patch = map[string]interface{}{path[i]: patch}
+	}
+	return patch.(map[string]interface{})
+}
+
+/* FormatPatches writes the patches as YAML documents. Each one starts with
+ * comments giving the workload to patch and the capabilities recorded for
+ * its containers.
+ */
+func FormatPatches(patches []*WorkloadPatch) (string, error) {
+	var out strings.Builder
+	for i, p := range patches {
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		if p.Kind == "Pod" {
+			// The securityContext of a running pod can't be updated
+			fmt.Fprintf(&out, "# pod %s/%s has no owner: merge into its manifest and recreate it\n",
+				p.Namespace, p.Name)
+		} else {
+			fmt.Fprintf(&out, "# kubectl patch %s %s -n %s --type strategic --patch-file <file>\n",
+				strings.ToLower(p.Kind), p.Name, p.Namespace)
+		}
+		for _, c := range p.Containers {
+			fmt.Fprintf(&out, "# %s/%s: required: %s", c.Pod, c.Container, formatCaps(c.Required))
+			if len(c.SetID) > 0 {
+				fmt.Fprintf(&out, ", by set*id syscalls: %s", formatCaps(c.SetID))
+			}
+			if len(c.Denied) > 0 {
+				fmt.Fprintf(&out, ", denied: %s", formatCaps(c.Denied))
+			}
+			out.WriteString("\n")
+		}
+
+		yamlOutput, err := k8syaml.Marshal(p.Patch)
+		if err != nil {
+			return "", fmt.Errorf("marshaling patch of %s %s/%s: %w", p.Kind, p.Namespace, p.Name, err)
+		}
+		out.Write(yamlOutput)
+	}
+	return out.String(), nil
+}
+
+func formatCaps(caps map[string]struct{}) string {
+	if len(caps) == 0 {
+		return "none"
+	}
+	return strings.Join(sortedKeys(caps), ",")
+}
@@ -0,0 +1,240 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisor
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/capabilities/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+func capEvent(pod, container, capName string, audit int, verdict string, insetID bool) types.Event {
+	return types.Event{
+		Event: eventtypes.Event{
+			Type: eventtypes.NORMAL,
+			CommonData: eventtypes.CommonData{
+				Namespace: "default",
+				Pod:       pod,
+				Container: container,
+			},
+		},
+		CapName: capName,
+		Audit:   audit,
+		Verdict: verdict,
+		InsetID: &insetID,
+	}
+}
+
+func TestGeneratePatches(t *testing.T) {
+	r := NewRecorder(true)
+	for _, e := range []types.Event{
+		// Replicas of the nginx deployment
+		capEvent("nginx-1", "nginx", "NET_BIND_SERVICE", 1, "Allow", false),
+		capEvent("nginx-1", "nginx", "SETUID", 1, "Allow", true),
+		capEvent("nginx-2", "nginx", "CHOWN", 1, "Allow", false),
+		// Probe without audit
+		capEvent("nginx-2", "nginx", "DAC_OVERRIDE", 0, "Allow", false),
+		capEvent("nginx-2", "sidecar", "SYS_ADMIN", 1, "Deny", false),
+		// Denied once but allowed later
+		capEvent("nginx-2", "sidecar", "KILL", 1, "Deny", false),
+		capEvent("nginx-2", "sidecar", "KILL", 1, "Allow", false),
+		// Pod without owner
+		capEvent("debug", "shell", "SYS_PTRACE", 1, "Allow", false),
+		// Host process
+		capEvent("", "", "SYS_ADMIN", 1, "Allow", false),
+	} {
+		r.Record(e)
+	}
+
+	owner := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "nginx"}
+	owners := map[string]*metav1.OwnerReference{
+		"default/nginx-1": owner,
+		"default/nginx-2": owner,
+	}
+
+	patches := GeneratePatches(r.Containers(nil), owners)
+	output, err := FormatPatches(patches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# kubectl patch deployment nginx -n default --type strategic --patch-file <file>
+# nginx-1/nginx: required: NET_BIND_SERVICE,SETUID, by set*id syscalls: SETUID
+# nginx-2/nginx: required: CHOWN
+# nginx-2/sidecar: required: KILL, denied: SYS_ADMIN
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        securityContext:
+          capabilities:
+            add:
+            - CHOWN
+            - NET_BIND_SERVICE
+            - SETUID
+            drop:
+            - ALL
+      - name: sidecar
+        securityContext:
+          capabilities:
+            add:
+            - KILL
+            drop:
+            - ALL
+---
+# pod default/debug has no owner: merge into its manifest and recreate it
+# debug/shell: required: SYS_PTRACE
+spec:
+  containers:
+  - name: shell
+    securityContext:
+      capabilities:
+        add:
+        - SYS_PTRACE
+        drop:
+        - ALL
+`
+	if output != expected {
+		t.Fatalf("Unexpected patches:\n%s\nExpected:\n%s", output, expected)
+	}
+
+	filtered := r.Containers(func(c *ContainerCapabilities) bool { return c.Pod == "debug" })
+	if len(filtered) != 1 || filtered[0].Container != "shell" {
+		t.Fatalf("Unexpected filtered containers: %+v", filtered)
+	}
+}
+
+func TestPodSpecPath(t *testing.T) {
+	c := &ContainerCapabilities{Namespace: "default", Pod: "backup-1", Container: "backup", Required: map[string]struct{}{}}
+	owners := map[string]*metav1.OwnerReference{
+		"default/backup-1": {APIVersion: "batch/v1", Kind: "CronJob", Name: "backup"},
+	}
+
+	patches := GeneratePatches([]*ContainerCapabilities{c}, owners)
+	output, err := FormatPatches(patches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No capability is required: only drop them all
+	expected := `# kubectl patch cronjob backup -n default --type strategic --patch-file <file>
+# backup-1/backup: required: none
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: backup
+            securityContext:
+              capabilities:
+                drop:
+                - ALL
+`
+	if output != expected {
+		t.Fatalf("Unexpected patches:\n%s\nExpected:\n%s", output, expected)
+	}
+}
+
+func TestRecordWithoutAuditOnly(t *testing.T) {
+	r := NewRecorder(false)
+	r.Record(capEvent("nginx-1", "nginx", "DAC_OVERRIDE", 0, "Allow", false))
+
+	containers := r.Containers(nil)
+	if len(containers) != 1 {
+		t.Fatalf("Expected 1 container, got %d", len(containers))
+	}
+	if _, ok := containers[0].Required["DAC_OVERRIDE"]; !ok {
+		t.Fatalf("Check without audit not recorded: %+v", containers[0])
+	}
+}
+
+func TestRecordSkipsRunc(t *testing.T) {
+	r := NewRecorder(false)
+
+	e := capEvent("nginx-1", "nginx", "SYS_ADMIN", 1, "Allow", false)
+	e.Comm = "runc:[2:INIT]"
+	r.Record(e)
+	e = capEvent("nginx-1", "nginx", "NET_BIND_SERVICE", 1, "Allow", false)
+	e.Comm = "nginx"
+	r.Record(e)
+
+	containers := r.Containers(nil)
+	if len(containers) != 1 {
+		t.Fatalf("Expected 1 container, got %d", len(containers))
+	}
+	if _, ok := containers[0].Required["SYS_ADMIN"]; ok {
+		t.Fatalf("Check of runc recorded: %+v", containers[0])
+	}
+	if _, ok := containers[0].Required["NET_BIND_SERVICE"]; !ok {
+		t.Fatalf("Check of the container not recorded: %+v", containers[0])
+	}
+}
+
+func TestMergePatches(t *testing.T) {
+	owner := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "nginx"}
+
+	// Replicas of the nginx deployment on two nodes, as decoded by the
+	// client from the output of each of them
+	patches := []*WorkloadPatch{}
+	for _, e := range []types.Event{
+		capEvent("nginx-1", "nginx", "NET_BIND_SERVICE", 1, "Allow", false),
+		capEvent("nginx-2", "nginx", "CHOWN", 1, "Allow", false),
+	} {
+		r := NewRecorder(true)
+		r.Record(e)
+		owners := map[string]*metav1.OwnerReference{"default/" + e.Pod: owner}
+
+		b, err := json.Marshal(GeneratePatches(r.Containers(nil), owners))
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := []*WorkloadPatch{}
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		patches = append(patches, decoded...)
+	}
+
+	output, err := FormatPatches(MergePatches(patches))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# kubectl patch deployment nginx -n default --type strategic --patch-file <file>
+# nginx-1/nginx: required: NET_BIND_SERVICE
+# nginx-2/nginx: required: CHOWN
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        securityContext:
+          capabilities:
+            add:
+            - CHOWN
+            - NET_BIND_SERVICE
+            drop:
+            - ALL
+`
+	if output != expected {
+		t.Fatalf("Unexpected patches:\n%s\nExpected:\n%s", output, expected)
+	}
+}
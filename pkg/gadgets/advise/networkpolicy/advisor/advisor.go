@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	k8syaml "sigs.k8s.io/yaml"
 
+	dnstypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/network/types"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
@@ -42,9 +43,34 @@ var defaultLabelsToIgnore = map[string]struct{}{
 type NetworkPolicyAdvisor struct {
 	Events []types.Event
 
+	// DNSEvents are the events recorded by trace dns. They are used to
+	// find the names resolved by pods for the external addresses they
+	// connect to.
+	DNSEvents []dnstypes.Event
+
 	LabelsToIgnore map[string]struct{}
 
-	Policies []networkingv1.NetworkPolicy
+	// FQDNPolicyKinds are the kinds of policies to generate, in addition
+	// to the Kubernetes network policies, for egress traffic to external
+	// addresses resolved through DNS. This traffic is then left out of the
+	// Kubernetes network policies.
+	FQDNPolicyKinds []FQDNPolicyKind
+
+	Policies       []networkingv1.NetworkPolicy
+	CiliumPolicies []CiliumNetworkPolicy
+	CalicoPolicies []CalicoNetworkPolicy
+
+	// ExistingPolicies are the network policies checked against the
+	// recorded traffic by VerifyPolicies()
+	ExistingPolicies []networkingv1.NetworkPolicy
+
+	// NamespaceLabels are the labels of the namespaces, used to evaluate
+	// the namespace selectors of ExistingPolicies
+	NamespaceLabels map[string]map[string]string
+
+	// resolved caches the addresses resolved by each pod, see
+	// resolveAddresses()
+	resolved map[string]map[string]map[string]struct{}
 }
 
 func NewAdvisor() *NetworkPolicyAdvisor {
@@ -62,12 +88,22 @@ func (a *NetworkPolicyAdvisor) LoadFile(filename string) error {
 }
 
 func (a *NetworkPolicyAdvisor) LoadBuffer(buf []byte) error {
+	events, err := loadEvents[types.Event](buf)
+	if err != nil {
+		return err
+	}
+	a.Events = events
+	return nil
+}
+
+// loadEvents reads events recorded either as a JSON array or as one JSON
+// object per line.
+func loadEvents[T any](buf []byte) ([]T, error) {
 	/* Try to read the file as an array */
-	events := []types.Event{}
+	events := []T{}
 	err := json.Unmarshal(buf, &events)
 	if err == nil {
-		a.Events = events
-		return nil
+		return events, nil
 	}
 
 	/* If it fails, read by line */
@@ -75,7 +111,7 @@ func (a *NetworkPolicyAdvisor) LoadBuffer(buf []byte) error {
 	line := 0
 	scanner := bufio.NewScanner(bytes.NewReader(buf))
 	for scanner.Scan() {
-		event := types.Event{}
+		var event T
 		text := strings.TrimSpace(scanner.Text())
 		if len(text) == 0 {
 			continue
@@ -83,18 +119,16 @@ func (a *NetworkPolicyAdvisor) LoadBuffer(buf []byte) error {
 		line++
 		err = json.Unmarshal([]byte(text), &event)
 		if err != nil {
-			return fmt.Errorf("cannot parse line %d: %w", line, err)
+			return nil, fmt.Errorf("cannot parse line %d: %w", line, err)
 		}
 		events = append(events, event)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return err
+		return nil, err
 	}
 
-	a.Events = events
-
-	return nil
+	return events, nil
 }
 
 /* labelFilteredKeyList returns a sorted list of label keys but without the labels to
@@ -319,7 +353,17 @@ func (a *NetworkPolicyAdvisor) GeneratePolicies() {
 			}
 		}
 		egressPolicies := []networkingv1.NetworkPolicyEgressRule{}
+		fqdnPeers := []fqdnPeer{}
 		for _, p := range egressNetworkPeer {
+			// Egress traffic to names resolved by the pod is allowed
+			// by name in the FQDN policies instead. Allowing the
+			// addresses in the Kubernetes network policy too would
+			// make the FQDN policies restrict nothing, as the allowed
+			// traffic is the union of both.
+			if names := a.resolvedNames(p); len(names) > 0 {
+				fqdnPeers = append(fqdnPeers, fqdnPeer{event: p, names: names})
+				continue
+			}
 			ports, peers := a.eventToRule(p)
 			if len(peers) > 0 {
 				rule := networkingv1.NetworkPolicyEgressRule{
@@ -364,21 +408,40 @@ func (a *NetworkPolicyAdvisor) GeneratePolicies() {
 			},
 		}
 		a.Policies = append(a.Policies, policy)
+
+		a.generateFQDNPolicies(name, events, fqdnPeers)
 	}
 
 	sort.Slice(a.Policies, func(i, j int) bool {
 		return a.Policies[i].Name < a.Policies[j].Name
 	})
+	sort.Slice(a.CiliumPolicies, func(i, j int) bool {
+		return a.CiliumPolicies[i].Name < a.CiliumPolicies[j].Name
+	})
+	sort.Slice(a.CalicoPolicies, func(i, j int) bool {
+		return a.CalicoPolicies[i].Name < a.CalicoPolicies[j].Name
+	})
 }
 
 func (a *NetworkPolicyAdvisor) FormatPolicies() (out string) {
-	for i, p := range a.Policies {
+	policies := []any{}
+	for _, p := range a.Policies {
+		policies = append(policies, p)
+	}
+	for _, p := range a.CiliumPolicies {
+		policies = append(policies, p)
+	}
+	for _, p := range a.CalicoPolicies {
+		policies = append(policies, p)
+	}
+
+	for i, p := range policies {
 		yamlOutput, err := k8syaml.Marshal(p)
 		if err != nil {
 			continue
 		}
 		sep := "---\n"
-		if i == len(a.Policies)-1 {
+		if i == len(policies)-1 {
 			sep = ""
 		}
 		out += fmt.Sprintf("%s%s", string(yamlOutput), sep)
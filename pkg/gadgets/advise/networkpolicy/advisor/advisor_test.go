@@ -34,6 +34,16 @@ func TestLoad(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		// DNS events, if any, are used to generate FQDN policies
+		dnsFile := inputFile[:len(inputFile)-len(".input")] + ".dns"
+		if _, err := os.Stat(dnsFile); err == nil {
+			err = a.LoadDNSFile(dnsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.FQDNPolicyKinds = []FQDNPolicyKind{FQDNPolicyKindCilium, FQDNPolicyKindCalico}
+		}
 		a.GeneratePolicies()
 		generatedOuput := a.FormatPolicies()
 
@@ -49,3 +59,54 @@ func TestLoad(t *testing.T) {
 		}
 	}
 }
+
+func TestVerify(t *testing.T) {
+	match, err := filepath.Glob("testdata/verify/*.input")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, inputFile := range match {
+		a := NewAdvisor()
+
+		err := a.LoadFile(inputFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prefix := inputFile[:len(inputFile)-len(".input")]
+		err = a.LoadPoliciesFile(prefix + ".policies")
+		if err != nil {
+			t.Fatal(err)
+		}
+		generatedOuput := a.VerifyPolicies().Format()
+
+		goldenOutputBytes, err := os.ReadFile(prefix + ".golden")
+		if err != nil {
+			t.Fatal(err)
+		}
+		goldenOutput := string(goldenOutputBytes)
+
+		if generatedOuput != goldenOutput {
+			t.Errorf("Unexpected report from %s:\n%s\nExpected:\n%s\n", inputFile, generatedOuput, goldenOutput)
+		}
+	}
+}
+
+func TestCalicoSelector(t *testing.T) {
+	a := NewAdvisor()
+
+	tests := []struct {
+		labels   map[string]string
+		selector string
+	}{
+		{labels: map[string]string{}, selector: "all()"},
+		{labels: map[string]string{"app": "checkout", "tier": "web"}, selector: "app == 'checkout' && tier == 'web'"},
+		{labels: map[string]string{"app": "it's"}, selector: `app == "it's"`},
+		{labels: map[string]string{"app": `it's "quoted"`, "tier": "web"}, selector: "!all() && tier == 'web'"},
+	}
+	for _, test := range tests {
+		if selector := a.calicoSelector(test.labels); selector != test.selector {
+			t.Errorf("Unexpected selector for %v: %q, expected %q", test.labels, selector, test.selector)
+		}
+	}
+}
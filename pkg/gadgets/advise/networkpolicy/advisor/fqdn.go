@@ -0,0 +1,403 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisor
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnstypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/network/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// FQDNPolicyKind is a kind of policy able to allow egress traffic by domain
+// name instead of by IP address.
+type FQDNPolicyKind string
+
+const (
+	// FQDNPolicyKindCilium generates CiliumNetworkPolicy objects using
+	// toFQDNs rules
+	FQDNPolicyKindCilium FQDNPolicyKind = "cilium"
+
+	// FQDNPolicyKindCalico generates Calico NetworkPolicy objects using
+	// domains in the destination of the rules
+	FQDNPolicyKindCalico FQDNPolicyKind = "calico"
+)
+
+func ParseFQDNPolicyKind(s string) (FQDNPolicyKind, error) {
+	switch kind := FQDNPolicyKind(strings.ToLower(s)); kind {
+	case FQDNPolicyKindCilium, FQDNPolicyKindCalico:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("unknown policy kind %q, supported kinds are %q and %q",
+			s, FQDNPolicyKindCilium, FQDNPolicyKindCalico)
+	}
+}
+
+// CiliumNetworkPolicy contains the subset of the cilium.io/v2
+// CiliumNetworkPolicy resource used by the advisor.
+type CiliumNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec CiliumRule `json:"spec"`
+}
+
+type CiliumRule struct {
+	EndpointSelector metav1.LabelSelector `json:"endpointSelector"`
+	Egress           []CiliumEgressRule   `json:"egress,omitempty"`
+}
+
+type CiliumEgressRule struct {
+	ToEndpoints []metav1.LabelSelector `json:"toEndpoints,omitempty"`
+	ToFQDNs     []CiliumFQDNSelector   `json:"toFQDNs,omitempty"`
+	ToPorts     []CiliumPortRule       `json:"toPorts,omitempty"`
+}
+
+type CiliumFQDNSelector struct {
+	MatchName    string `json:"matchName,omitempty"`
+	MatchPattern string `json:"matchPattern,omitempty"`
+}
+
+type CiliumPortRule struct {
+	Ports []CiliumPortProtocol `json:"ports"`
+	Rules *CiliumL7Rules       `json:"rules,omitempty"`
+}
+
+type CiliumPortProtocol struct {
+	Port     string `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+type CiliumL7Rules struct {
+	DNS []CiliumFQDNSelector `json:"dns,omitempty"`
+}
+
+// CalicoNetworkPolicy contains the subset of the projectcalico.org/v3
+// NetworkPolicy resource used by the advisor.
+type CalicoNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec CalicoPolicySpec `json:"spec"`
+}
+
+type CalicoPolicySpec struct {
+	Selector string       `json:"selector"`
+	Types    []string     `json:"types"`
+	Egress   []CalicoRule `json:"egress,omitempty"`
+}
+
+type CalicoRule struct {
+	Action      string           `json:"action"`
+	Protocol    string           `json:"protocol,omitempty"`
+	Destination CalicoEntityRule `json:"destination"`
+}
+
+type CalicoEntityRule struct {
+	Domains []string `json:"domains,omitempty"`
+	Ports   []uint16 `json:"ports,omitempty"`
+}
+
+// fqdnPeer is an egress peer whose address was resolved by the pod from
+// the given names.
+type fqdnPeer struct {
+	event types.Event
+	names []string
+}
+
+type fqdnPort struct {
+	proto string
+	port  uint16
+}
+
+func (a *NetworkPolicyAdvisor) LoadDNSFile(filename string) error {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return a.LoadDNSBuffer(buf)
+}
+
+func (a *NetworkPolicyAdvisor) LoadDNSBuffer(buf []byte) error {
+	events, err := loadEvents[dnstypes.Event](buf)
+	if err != nil {
+		return err
+	}
+	a.DNSEvents = events
+	a.resolved = nil
+	return nil
+}
+
+func podKey(namespace, pod string) string {
+	return namespace + "/" + pod
+}
+
+/* resolveAddresses indexes the answers of the DNS responses received by each
+ * pod: namespace/pod -> address -> names
+ */
+func (a *NetworkPolicyAdvisor) resolveAddresses() map[string]map[string]map[string]struct{} {
+	resolved := map[string]map[string]map[string]struct{}{}
+	for _, e := range a.DNSEvents {
+		if e.Type != eventtypes.NORMAL || e.Qr != dnstypes.DNSPktTypeResponse {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSuffix(e.DNSName, "."))
+		if name == "" {
+			continue
+		}
+
+		key := podKey(e.Namespace, e.Pod)
+		for _, addr := range e.Addresses {
+			// Answers also contain the canonical names of CNAME
+			// records. Policies use the name the pod asked for.
+			if net.ParseIP(addr) == nil {
+				continue
+			}
+			if _, ok := resolved[key]; !ok {
+				resolved[key] = map[string]map[string]struct{}{}
+			}
+			if _, ok := resolved[key][addr]; !ok {
+				resolved[key][addr] = map[string]struct{}{}
+			}
+			resolved[key][addr][name] = struct{}{}
+		}
+	}
+	return resolved
+}
+
+/* resolvedNames returns the sorted names resolved by the pod of the event for
+ * the remote address of the event, or nil if no FQDN policy is generated.
+ */
+func (a *NetworkPolicyAdvisor) resolvedNames(e types.Event) []string {
+	if len(a.FQDNPolicyKinds) == 0 || e.RemoteKind != types.RemoteKindOther {
+		return nil
+	}
+	if a.resolved == nil {
+		a.resolved = a.resolveAddresses()
+	}
+
+	names := []string{}
+	for name := range a.resolved[podKey(e.Namespace, e.Pod)][e.RemoteAddr] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/* fqdnPorts groups the names of the peers by protocol and port, and returns
+ * them sorted the same way as the rules of the Kubernetes network policies.
+ */
+func fqdnPorts(peers []fqdnPeer) ([]fqdnPort, map[fqdnPort][]string) {
+	set := map[fqdnPort]map[string]struct{}{}
+	for _, p := range peers {
+		key := fqdnPort{proto: strings.ToUpper(p.event.Proto), port: p.event.Port}
+		if _, ok := set[key]; !ok {
+			set[key] = map[string]struct{}{}
+		}
+		for _, name := range p.names {
+			set[key][name] = struct{}{}
+		}
+	}
+
+	keys := make([]fqdnPort, 0, len(set))
+	names := map[fqdnPort][]string{}
+	for key, s := range set {
+		keys = append(keys, key)
+		for name := range s {
+			names[key] = append(names[key], name)
+		}
+		sort.Strings(names[key])
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].proto != keys[j].proto {
+			return keys[i].proto < keys[j].proto
+		}
+		return keys[i].port < keys[j].port
+	})
+	return keys, names
+}
+
+/* dnsServerSelectors returns the Cilium selectors of the pods and services
+ * the events sent DNS queries to. Cilium needs to proxy DNS traffic in order
+ * to enforce toFQDNs rules.
+ */
+func (a *NetworkPolicyAdvisor) dnsServerSelectors(events []types.Event) []metav1.LabelSelector {
+	servers := map[string]metav1.LabelSelector{}
+	for _, e := range events {
+		if e.PktType != "OUTGOING" || e.Port != 53 {
+			continue
+		}
+		if e.RemoteKind != types.RemoteKindPod && e.RemoteKind != types.RemoteKindService {
+			continue
+		}
+		labels := a.labelFilter(e.RemoteLabels)
+		labels["io.kubernetes.pod.namespace"] = e.RemoteNamespace
+		servers[e.RemoteNamespace+":"+a.labelKeyString(e.RemoteLabels)] = metav1.LabelSelector{MatchLabels: labels}
+	}
+
+	if len(servers) == 0 {
+		return []metav1.LabelSelector{
+			{
+				MatchLabels: map[string]string{
+					"io.kubernetes.pod.namespace": "kube-system",
+					"k8s-app":                     "kube-dns",
+				},
+			},
+		}
+	}
+
+	keys := make([]string, 0, len(servers))
+	for k := range servers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	selectors := make([]metav1.LabelSelector, 0, len(keys))
+	for _, k := range keys {
+		selectors = append(selectors, servers[k])
+	}
+	return selectors
+}
+
+/* calicoSelector converts labels into a Calico selector expression:
+ * label1 == 'value1' && label2 == 'value2'
+ */
+func (a *NetworkPolicyAdvisor) calicoSelector(labels map[string]string) string {
+	keys := a.labelFilteredKeyList(labels)
+	if len(keys) == 0 {
+		return "all()"
+	}
+
+	exprs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		value, ok := quoteCalicoValue(labels[k])
+		if !ok {
+			// Safer to select no pod than too many
+			exprs = append(exprs, "!all()")
+			continue
+		}
+		exprs = append(exprs, fmt.Sprintf("%s == %s", k, value))
+	}
+	return strings.Join(exprs, " && ")
+}
+
+/* quoteCalicoValue returns the Calico string literal of a label value. Calico
+ * literals can't be escaped, so values containing single quotes are quoted
+ * with double quotes, and the ones containing both can't be represented.
+ */
+func quoteCalicoValue(value string) (string, bool) {
+	switch {
+	case !strings.Contains(value, "'"):
+		return "'" + value + "'", true
+	case !strings.Contains(value, `"`):
+		return `"` + value + `"`, true
+	default:
+		return "", false
+	}
+}
+
+func (a *NetworkPolicyAdvisor) generateFQDNPolicies(name string, events []types.Event, peers []fqdnPeer) {
+	if len(peers) == 0 {
+		return
+	}
+
+	keys, names := fqdnPorts(peers)
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: events[0].Namespace,
+		Labels:    map[string]string{},
+	}
+
+	for _, kind := range a.FQDNPolicyKinds {
+		switch kind {
+		case FQDNPolicyKindCilium:
+			egress := []CiliumEgressRule{}
+			for _, key := range keys {
+				fqdns := make([]CiliumFQDNSelector, 0, len(names[key]))
+				for _, n := range names[key] {
+					fqdns = append(fqdns, CiliumFQDNSelector{MatchName: n})
+				}
+				egress = append(egress, CiliumEgressRule{
+					ToFQDNs: fqdns,
+					ToPorts: []CiliumPortRule{
+						{
+							Ports: []CiliumPortProtocol{
+								{Port: strconv.Itoa(int(key.port)), Protocol: key.proto},
+							},
+						},
+					},
+				})
+			}
+			egress = append(egress, CiliumEgressRule{
+				ToEndpoints: a.dnsServerSelectors(events),
+				ToPorts: []CiliumPortRule{
+					{
+						Ports: []CiliumPortProtocol{
+							{Port: "53", Protocol: "ANY"},
+						},
+						Rules: &CiliumL7Rules{
+							DNS: []CiliumFQDNSelector{{MatchPattern: "*"}},
+						},
+					},
+				},
+			})
+
+			a.CiliumPolicies = append(a.CiliumPolicies, CiliumNetworkPolicy{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "cilium.io/v2",
+					Kind:       "CiliumNetworkPolicy",
+				},
+				ObjectMeta: objectMeta,
+				Spec: CiliumRule{
+					EndpointSelector: metav1.LabelSelector{MatchLabels: a.labelFilter(events[0].PodLabels)},
+					Egress:           egress,
+				},
+			})
+		case FQDNPolicyKindCalico:
+			egress := []CalicoRule{}
+			for _, key := range keys {
+				egress = append(egress, CalicoRule{
+					Action:   "Allow",
+					Protocol: key.proto,
+					Destination: CalicoEntityRule{
+						Domains: names[key],
+						Ports:   []uint16{key.port},
+					},
+				})
+			}
+
+			a.CalicoPolicies = append(a.CalicoPolicies, CalicoNetworkPolicy{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "projectcalico.org/v3",
+					Kind:       "NetworkPolicy",
+				},
+				ObjectMeta: objectMeta,
+				Spec: CalicoPolicySpec{
+					Selector: a.calicoSelector(events[0].PodLabels),
+					Types:    []string{"Egress"},
+					Egress:   egress,
+				},
+			})
+		}
+	}
+}
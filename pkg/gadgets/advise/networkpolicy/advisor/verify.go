@@ -0,0 +1,514 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8syamlutil "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/network/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// DeniedFlow is recorded traffic that the existing policies would deny.
+type DeniedFlow struct {
+	Namespace string
+	// Workload is the owner of the pod, or the pod itself if it has no
+	// owner
+	Workload  string
+	Direction networkingv1.PolicyType
+	Remote    string
+	Proto     string
+	Port      uint16
+
+	// Count is the number of recorded events for this flow
+	Count int
+}
+
+// UnusedRule is a rule of an existing policy that no recorded traffic
+// matched.
+type UnusedRule struct {
+	Namespace string
+	Policy    string
+	Direction networkingv1.PolicyType
+	// Index is the position of the rule in the ingress or egress rules
+	// of the policy
+	Index       int
+	Description string
+}
+
+type VerifyReport struct {
+	DeniedFlows []DeniedFlow
+	UnusedRules []UnusedRule
+
+	// UnusedPolicies are the policies, as namespace/name, that don't
+	// select any pod found in the recorded traffic. Their rules are not
+	// part of UnusedRules.
+	UnusedPolicies []string
+}
+
+func (a *NetworkPolicyAdvisor) LoadPoliciesFile(filename string) error {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return a.LoadPoliciesBuffer(buf)
+}
+
+/* LoadPoliciesBuffer adds the network policies found in YAML or JSON
+ * documents to the existing policies. Lists are expanded and objects of other
+ * kinds, like the FQDN policies generated by the advisor, are ignored.
+ */
+func (a *NetworkPolicyAdvisor) LoadPoliciesBuffer(buf []byte) error {
+	decoder := k8syamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(buf), 4096)
+	for {
+		var doc json.RawMessage
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("cannot parse policies: %w", err)
+		}
+		if len(doc) == 0 || string(doc) == "null" {
+			continue
+		}
+		if err := a.loadPolicyObject(doc); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *NetworkPolicyAdvisor) loadPolicyObject(doc []byte) error {
+	obj := struct {
+		metav1.TypeMeta `json:",inline"`
+		Items           []json.RawMessage `json:"items"`
+	}{}
+	if err := json.Unmarshal(doc, &obj); err != nil {
+		return fmt.Errorf("cannot parse policies: %w", err)
+	}
+
+	switch {
+	case obj.Kind == "NetworkPolicy" && strings.HasPrefix(obj.APIVersion, "networking.k8s.io/"):
+		policy := networkingv1.NetworkPolicy{}
+		if err := json.Unmarshal(doc, &policy); err != nil {
+			return fmt.Errorf("cannot parse network policy: %w", err)
+		}
+		if policy.Namespace == "" {
+			policy.Namespace = "default"
+		}
+		a.ExistingPolicies = append(a.ExistingPolicies, policy)
+	case strings.HasSuffix(obj.Kind, "List"):
+		for _, item := range obj.Items {
+			if err := a.loadPolicyObject(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Namespaces returns the sorted namespaces of the pods found in the
+// recorded traffic.
+func (a *NetworkPolicyAdvisor) Namespaces() []string {
+	set := map[string]struct{}{}
+	for _, e := range a.Events {
+		if e.Namespace != "" {
+			set[e.Namespace] = struct{}{}
+		}
+	}
+
+	namespaces := make([]string, 0, len(set))
+	for ns := range set {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+/* namespaceLabels returns the labels of the namespace. When they are not
+ * known, only the label added by Kubernetes 1.22 on all namespaces is used.
+ */
+func (a *NetworkPolicyAdvisor) namespaceLabels(namespace string) map[string]string {
+	if labels, ok := a.NamespaceLabels[namespace]; ok {
+		return labels
+	}
+	return map[string]string{"kubernetes.io/metadata.name": namespace}
+}
+
+func selectorMatches(selector *metav1.LabelSelector, labels map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(k8slabels.Set(labels))
+}
+
+func ipBlockMatches(block *networkingv1.IPBlock, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil || !cidr.Contains(ip) {
+		return false
+	}
+	for _, except := range block.Except {
+		_, cidr, err := net.ParseCIDR(except)
+		if err == nil && cidr.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+/* peerMatches returns whether the remote side of the event is selected by the
+ * peer of a policy in policyNamespace. Services are matched through the
+ * labels of their selector, the same way eventToRule() generates them.
+ */
+func (a *NetworkPolicyAdvisor) peerMatches(policyNamespace string, peer networkingv1.NetworkPolicyPeer, e types.Event) bool {
+	if peer.IPBlock != nil {
+		return ipBlockMatches(peer.IPBlock, e.RemoteAddr)
+	}
+	if e.RemoteKind != types.RemoteKindPod && e.RemoteKind != types.RemoteKindService {
+		return false
+	}
+
+	if peer.NamespaceSelector != nil {
+		if !selectorMatches(peer.NamespaceSelector, a.namespaceLabels(e.RemoteNamespace)) {
+			return false
+		}
+	} else if e.RemoteNamespace != policyNamespace {
+		return false
+	}
+
+	if peer.PodSelector != nil {
+		return selectorMatches(peer.PodSelector, e.RemoteLabels)
+	}
+	return true
+}
+
+/* portMatches returns whether the protocol and port of the event are allowed
+ * by the ports of a rule. Named ports can't be resolved from the recorded
+ * traffic and never match.
+ */
+func portMatches(ports []networkingv1.NetworkPolicyPort, e types.Event) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		protocol := v1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		if !strings.EqualFold(string(protocol), e.Proto) {
+			continue
+		}
+		if p.Port == nil {
+			return true
+		}
+		if p.Port.Type != intstr.Int {
+			continue
+		}
+		start, end := p.Port.IntVal, p.Port.IntVal
+		if p.EndPort != nil {
+			end = *p.EndPort
+		}
+		if int32(e.Port) >= start && int32(e.Port) <= end {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *NetworkPolicyAdvisor) ruleMatches(policyNamespace string, ports []networkingv1.NetworkPolicyPort, peers []networkingv1.NetworkPolicyPeer, e types.Event) bool {
+	if !portMatches(ports, e) {
+		return false
+	}
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if a.peerMatches(policyNamespace, peer, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyHasType(p networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(p.Spec.PolicyTypes) == 0 {
+		// Same defaults as the API server
+		return policyType == networkingv1.PolicyTypeIngress ||
+			(policyType == networkingv1.PolicyTypeEgress && len(p.Spec.Egress) > 0)
+	}
+	for _, t := range p.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteString(e types.Event) string {
+	switch e.RemoteKind {
+	case types.RemoteKindPod:
+		return fmt.Sprintf("pod %s/%s", e.RemoteNamespace, e.RemoteName)
+	case types.RemoteKindService:
+		return fmt.Sprintf("svc %s/%s", e.RemoteNamespace, e.RemoteName)
+	default:
+		return fmt.Sprintf("endpoint %s", e.RemoteAddr)
+	}
+}
+
+func portsString(ports []networkingv1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "all ports"
+	}
+	ret := []string{}
+	for _, p := range ports {
+		protocol := v1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		switch {
+		case p.Port == nil:
+			ret = append(ret, string(protocol))
+		case p.EndPort != nil:
+			ret = append(ret, fmt.Sprintf("%s/%s-%d", protocol, p.Port.String(), *p.EndPort))
+		default:
+			ret = append(ret, fmt.Sprintf("%s/%s", protocol, p.Port.String()))
+		}
+	}
+	return strings.Join(ret, ",")
+}
+
+func peersString(peers []networkingv1.NetworkPolicyPeer) string {
+	if len(peers) == 0 {
+		return "anywhere"
+	}
+	ret := []string{}
+	for _, p := range peers {
+		switch {
+		case p.IPBlock != nil:
+			s := "ipBlock " + p.IPBlock.CIDR
+			if len(p.IPBlock.Except) > 0 {
+				s += " except " + strings.Join(p.IPBlock.Except, ",")
+			}
+			ret = append(ret, s)
+		case p.NamespaceSelector != nil && p.PodSelector != nil:
+			ret = append(ret, fmt.Sprintf("pods %q in namespaces %q",
+				metav1.FormatLabelSelector(p.PodSelector), metav1.FormatLabelSelector(p.NamespaceSelector)))
+		case p.NamespaceSelector != nil:
+			ret = append(ret, fmt.Sprintf("namespaces %q", metav1.FormatLabelSelector(p.NamespaceSelector)))
+		case p.PodSelector != nil:
+			ret = append(ret, fmt.Sprintf("pods %q", metav1.FormatLabelSelector(p.PodSelector)))
+		}
+	}
+	return strings.Join(ret, ", ")
+}
+
+/* VerifyPolicies replays the recorded traffic through ExistingPolicies and
+ * reports the flows they would deny and the rules never used. Only the side
+ * of the pod that recorded the event is evaluated: the policies of the remote
+ * pod are verified with the events it recorded itself.
+ */
+func (a *NetworkPolicyAdvisor) VerifyPolicies() *VerifyReport {
+	// Keys are namespace/name/direction
+	ruleUsed := map[string][]bool{}
+	policyUsed := map[string]bool{}
+	for _, p := range a.ExistingPolicies {
+		key := p.Namespace + "/" + p.Name
+		policyUsed[key] = false
+		ruleUsed[key+"/"+string(networkingv1.PolicyTypeIngress)] = make([]bool, len(p.Spec.Ingress))
+		ruleUsed[key+"/"+string(networkingv1.PolicyTypeEgress)] = make([]bool, len(p.Spec.Egress))
+	}
+
+	denied := map[string]*DeniedFlow{}
+	for _, e := range a.Events {
+		if e.Type != eventtypes.NORMAL {
+			continue
+		}
+
+		var direction networkingv1.PolicyType
+		switch e.PktType {
+		case "OUTGOING":
+			direction = networkingv1.PolicyTypeEgress
+		case "HOST":
+			// Traffic from the pod's own node is always allowed,
+			// see GeneratePolicies()
+			if e.PodHostIP == e.RemoteAddr {
+				continue
+			}
+			direction = networkingv1.PolicyTypeIngress
+		default:
+			continue
+		}
+		if e.RemoteKind == types.RemoteKindOther && e.RemoteAddr == "127.0.0.1" {
+			continue
+		}
+
+		isolated := false
+		allowed := false
+		for _, p := range a.ExistingPolicies {
+			if p.Namespace != e.Namespace || !selectorMatches(&p.Spec.PodSelector, e.PodLabels) {
+				continue
+			}
+			key := p.Namespace + "/" + p.Name
+			policyUsed[key] = true
+			if !policyHasType(p, direction) {
+				continue
+			}
+			isolated = true
+
+			used := ruleUsed[key+"/"+string(direction)]
+			if direction == networkingv1.PolicyTypeEgress {
+				for i, rule := range p.Spec.Egress {
+					if a.ruleMatches(p.Namespace, rule.Ports, rule.To, e) {
+						used[i] = true
+						allowed = true
+					}
+				}
+			} else {
+				for i, rule := range p.Spec.Ingress {
+					if a.ruleMatches(p.Namespace, rule.Ports, rule.From, e) {
+						used[i] = true
+						allowed = true
+					}
+				}
+			}
+		}
+		if !isolated || allowed {
+			continue
+		}
+
+		workload := e.Pod
+		if e.PodOwner != "" {
+			workload = e.PodOwner
+		}
+		flow := DeniedFlow{
+			Namespace: e.Namespace,
+			Workload:  workload,
+			Direction: direction,
+			Remote:    remoteString(e),
+			Proto:     strings.ToUpper(e.Proto),
+			Port:      e.Port,
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s/%s/%d", flow.Namespace, flow.Workload, flow.Direction, flow.Remote, flow.Proto, flow.Port)
+		if _, ok := denied[key]; !ok {
+			denied[key] = &flow
+		}
+		denied[key].Count++
+	}
+
+	report := &VerifyReport{}
+	for _, flow := range denied {
+		report.DeniedFlows = append(report.DeniedFlows, *flow)
+	}
+	sort.Slice(report.DeniedFlows, func(i, j int) bool {
+		fi, fj := report.DeniedFlows[i], report.DeniedFlows[j]
+		switch {
+		case fi.Namespace != fj.Namespace:
+			return fi.Namespace < fj.Namespace
+		case fi.Workload != fj.Workload:
+			return fi.Workload < fj.Workload
+		case fi.Direction != fj.Direction:
+			return fi.Direction < fj.Direction
+		case fi.Remote != fj.Remote:
+			return fi.Remote < fj.Remote
+		case fi.Proto != fj.Proto:
+			return fi.Proto < fj.Proto
+		default:
+			return fi.Port < fj.Port
+		}
+	})
+
+	policies := make([]networkingv1.NetworkPolicy, len(a.ExistingPolicies))
+	copy(policies, a.ExistingPolicies)
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Namespace != policies[j].Namespace {
+			return policies[i].Namespace < policies[j].Namespace
+		}
+		return policies[i].Name < policies[j].Name
+	})
+	for _, p := range policies {
+		key := p.Namespace + "/" + p.Name
+		if !policyUsed[key] {
+			report.UnusedPolicies = append(report.UnusedPolicies, key)
+			continue
+		}
+		for i, rule := range p.Spec.Ingress {
+			if !ruleUsed[key+"/"+string(networkingv1.PolicyTypeIngress)][i] {
+				report.UnusedRules = append(report.UnusedRules, UnusedRule{
+					Namespace:   p.Namespace,
+					Policy:      p.Name,
+					Direction:   networkingv1.PolicyTypeIngress,
+					Index:       i,
+					Description: fmt.Sprintf("%s from %s", portsString(rule.Ports), peersString(rule.From)),
+				})
+			}
+		}
+		for i, rule := range p.Spec.Egress {
+			if !ruleUsed[key+"/"+string(networkingv1.PolicyTypeEgress)][i] {
+				report.UnusedRules = append(report.UnusedRules, UnusedRule{
+					Namespace:   p.Namespace,
+					Policy:      p.Name,
+					Direction:   networkingv1.PolicyTypeEgress,
+					Index:       i,
+					Description: fmt.Sprintf("%s to %s", portsString(rule.Ports), peersString(rule.To)),
+				})
+			}
+		}
+	}
+
+	return report
+}
+
+func (r *VerifyReport) Format() (out string) {
+	out += fmt.Sprintf("Denied flows: %d\n", len(r.DeniedFlows))
+	for _, f := range r.DeniedFlows {
+		way := "to"
+		if f.Direction == networkingv1.PolicyTypeIngress {
+			way = "from"
+		}
+		out += fmt.Sprintf("  %s/%s: %s %s/%d %s %s (%d events)\n",
+			f.Namespace, f.Workload, strings.ToLower(string(f.Direction)), f.Proto, f.Port, way, f.Remote, f.Count)
+	}
+
+	out += fmt.Sprintf("\nUnused rules: %d\n", len(r.UnusedRules))
+	for _, u := range r.UnusedRules {
+		out += fmt.Sprintf("  %s/%s: %s[%d]: %s\n",
+			u.Namespace, u.Policy, strings.ToLower(string(u.Direction)), u.Index, u.Description)
+	}
+
+	out += fmt.Sprintf("\nPolicies not selecting any recorded pod: %d\n", len(r.UnusedPolicies))
+	for _, p := range r.UnusedPolicies {
+		out += fmt.Sprintf("  %s\n", p)
+	}
+	return
+}
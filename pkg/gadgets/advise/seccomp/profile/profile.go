@@ -0,0 +1,402 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// allowedSyscall is how a syscall is allowed by a profile
+type allowedSyscall struct {
+	// any is set when the syscall is allowed whatever its arguments are
+	any bool
+
+	// argRules are the argument conditions of the rules allowing the
+	// syscall, by their string representation
+	argRules map[string][]specs.LinuxSeccompArg
+}
+
+// allowedSyscalls returns how each syscall is allowed by a profile and the
+// rules using other actions than SCMP_ACT_ALLOW
+func allowedSyscalls(profile *specs.LinuxSeccomp) (map[string]*allowedSyscall, []specs.LinuxSyscall) {
+	allowed := map[string]*allowedSyscall{}
+	others := []specs.LinuxSyscall{}
+
+	for _, rule := range profile.Syscalls {
+		if rule.Action != specs.ActAllow {
+			others = append(others, rule)
+			continue
+		}
+		for _, name := range rule.Names {
+			a, ok := allowed[name]
+			if !ok {
+				a = &allowedSyscall{argRules: map[string][]specs.LinuxSeccompArg{}}
+				allowed[name] = a
+			}
+			if len(rule.Args) == 0 {
+				a.any = true
+				continue
+			}
+			a.argRules[formatArgs(rule.Args)] = rule.Args
+		}
+	}
+
+	return allowed, others
+}
+
+// argsLess orders argument conditions by index, then by value
+func argsLess(a, b []specs.LinuxSeccompArg) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i].Index != b[i].Index:
+			return a[i].Index < b[i].Index
+		case a[i].Value != b[i].Value:
+			return a[i].Value < b[i].Value
+		case a[i].ValueTwo != b[i].ValueTwo:
+			return a[i].ValueTwo < b[i].ValueTwo
+		case a[i].Op != b[i].Op:
+			return a[i].Op < b[i].Op
+		}
+	}
+	return len(a) < len(b)
+}
+
+// allowedToRules converts back the allowed syscalls to rules: one rule for all
+// the syscalls allowed whatever their arguments are, then the rules with
+// argument conditions sorted by syscall name.
+func allowedToRules(allowed map[string]*allowedSyscall) []specs.LinuxSyscall {
+	names := []string{}
+	for name := range allowed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	anyNames := []string{}
+	restricted := []specs.LinuxSyscall{}
+	for _, name := range names {
+		a := allowed[name]
+		if a.any {
+			anyNames = append(anyNames, name)
+			continue
+		}
+		argRules := [][]specs.LinuxSeccompArg{}
+		for _, args := range a.argRules {
+			argRules = append(argRules, args)
+		}
+		sort.Slice(argRules, func(i, j int) bool { return argsLess(argRules[i], argRules[j]) })
+		for _, args := range argRules {
+			restricted = append(restricted, specs.LinuxSyscall{
+				Names:  []string{name},
+				Action: specs.ActAllow,
+				Args:   args,
+			})
+		}
+	}
+
+	rules := []specs.LinuxSyscall{}
+	if len(anyNames) > 0 {
+		rules = append(rules, specs.LinuxSyscall{
+			Names:  anyNames,
+			Action: specs.ActAllow,
+			Args:   []specs.LinuxSeccompArg{},
+		})
+	}
+	return append(rules, restricted...)
+}
+
+// Merge returns a profile allowing all the syscalls allowed by the given
+// profiles, e.g. the profiles recorded for the replicas of a pod. The rules
+// using other actions than SCMP_ACT_ALLOW are kept as they are. The profiles
+// must have the same default action.
+func Merge(profiles ...*specs.LinuxSeccomp) (*specs.LinuxSeccomp, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no profile to merge")
+	}
+
+	merged := &specs.LinuxSeccomp{
+		DefaultAction:    profiles[0].DefaultAction,
+		DefaultErrnoRet:  profiles[0].DefaultErrnoRet,
+		ListenerPath:     profiles[0].ListenerPath,
+		ListenerMetadata: profiles[0].ListenerMetadata,
+	}
+	allowed := map[string]*allowedSyscall{}
+	others := []specs.LinuxSyscall{}
+	seenOthers := map[string]struct{}{}
+	seenArches := map[specs.Arch]struct{}{}
+	seenFlags := map[specs.LinuxSeccompFlag]struct{}{}
+
+	for i, profile := range profiles {
+		if profile.DefaultAction != merged.DefaultAction {
+			return nil, fmt.Errorf("profile %d has default action %s instead of %s",
+				i+1, profile.DefaultAction, merged.DefaultAction)
+		}
+
+		for _, arch := range profile.Architectures {
+			if _, ok := seenArches[arch]; !ok {
+				seenArches[arch] = struct{}{}
+				merged.Architectures = append(merged.Architectures, arch)
+			}
+		}
+		for _, flag := range profile.Flags {
+			if _, ok := seenFlags[flag]; !ok {
+				seenFlags[flag] = struct{}{}
+				merged.Flags = append(merged.Flags, flag)
+			}
+		}
+
+		a, o := allowedSyscalls(profile)
+		for name, syscall := range a {
+			m, ok := allowed[name]
+			if !ok {
+				allowed[name] = syscall
+				continue
+			}
+			m.any = m.any || syscall.any
+			for key, args := range syscall.argRules {
+				m.argRules[key] = args
+			}
+		}
+		for _, rule := range o {
+			b, err := json.Marshal(rule)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seenOthers[string(b)]; !ok {
+				seenOthers[string(b)] = struct{}{}
+				others = append(others, rule)
+			}
+		}
+	}
+
+	merged.Syscalls = append(allowedToRules(allowed), others...)
+
+	return merged, nil
+}
+
+// Diff returns the rules of recorded allowing syscalls, or argument values,
+// which are not allowed by base: the syscalls newly required by an application
+// compared to an existing profile.
+//
+// The conditions of base are evaluated with the argument values of the rules
+// of recorded whose conditions are all equalities, like the ones of the
+// profiles recorded by the gadget. Other rules are only allowed by identical
+// rules. If the default action of base is SCMP_ACT_ALLOW, the syscalls are
+// allowed unless a rule of base using another action may match them.
+func Diff(base, recorded *specs.LinuxSeccomp) []specs.LinuxSyscall {
+	baseAllowed, baseOthers := allowedSyscalls(base)
+	recordedAllowed, _ := allowedSyscalls(recorded)
+
+	allows := func(name string, args []specs.LinuxSeccompArg) bool {
+		if base.DefaultAction == specs.ActAllow {
+			for _, rule := range baseOthers {
+				if containsName(rule.Names, name) && !excludes(args, rule.Args) {
+					return false
+				}
+			}
+			return true
+		}
+
+		b, ok := baseAllowed[name]
+		if !ok {
+			return false
+		}
+		if b.any {
+			return true
+		}
+		for _, conditions := range b.argRules {
+			if implies(args, conditions) {
+				return true
+			}
+		}
+		return false
+	}
+
+	missing := map[string]*allowedSyscall{}
+	for name, r := range recordedAllowed {
+		if r.any {
+			if !allows(name, nil) {
+				missing[name] = r
+			}
+			continue
+		}
+
+		m := &allowedSyscall{argRules: map[string][]specs.LinuxSeccompArg{}}
+		for key, args := range r.argRules {
+			if !allows(name, args) {
+				m.argRules[key] = args
+			}
+		}
+		if len(m.argRules) > 0 {
+			missing[name] = m
+		}
+	}
+
+	return allowedToRules(missing)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues returns the values of the arguments of the calls allowed by a
+// rule whose conditions are all equalities
+func equalValues(args []specs.LinuxSeccompArg) (map[uint]uint64, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+	values := map[uint]uint64{}
+	for _, arg := range args {
+		if arg.Op != specs.OpEqualTo {
+			return nil, false
+		}
+		if v, ok := values[arg.Index]; ok && v != arg.Value {
+			return nil, false
+		}
+		values[arg.Index] = arg.Value
+	}
+	return values, true
+}
+
+// evaluate returns whether a call with the given argument values matches all
+// the conditions. known is false if it can't be told, as some conditions are
+// on arguments whose value isn't given.
+func evaluate(conditions []specs.LinuxSeccompArg, values map[uint]uint64) (match, known bool) {
+	known = true
+	for _, c := range conditions {
+		v, ok := values[c.Index]
+		if !ok {
+			known = false
+			continue
+		}
+
+		var m bool
+		switch c.Op {
+		case specs.OpNotEqual:
+			m = v != c.Value
+		case specs.OpLessThan:
+			m = v < c.Value
+		case specs.OpLessEqual:
+			m = v <= c.Value
+		case specs.OpEqualTo:
+			m = v == c.Value
+		case specs.OpGreaterEqual:
+			m = v >= c.Value
+		case specs.OpGreaterThan:
+			m = v > c.Value
+		case specs.OpMaskedEqual:
+			m = v&c.Value == c.ValueTwo
+		default:
+			known = false
+			continue
+		}
+		if !m {
+			return false, true
+		}
+	}
+	return known, known
+}
+
+// implies returns whether all the calls allowed by the conditions args are
+// allowed by the ones of another rule. args is nil for all the calls.
+func implies(args, conditions []specs.LinuxSeccompArg) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+	if values, ok := equalValues(args); ok {
+		match, known := evaluate(conditions, values)
+		return known && match
+	}
+	return len(args) != 0 && formatArgs(args) == formatArgs(conditions)
+}
+
+// excludes returns whether none of the calls allowed by the conditions args
+// can match the ones of another rule. args is nil for all the calls.
+func excludes(args, conditions []specs.LinuxSeccompArg) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+	if values, ok := equalValues(args); ok {
+		match, known := evaluate(conditions, values)
+		return known && !match
+	}
+	return false
+}
+
+var opSymbols = map[specs.LinuxSeccompOperator]string{
+	specs.OpNotEqual:     "!=",
+	specs.OpLessThan:     "<",
+	specs.OpLessEqual:    "<=",
+	specs.OpEqualTo:      "==",
+	specs.OpGreaterEqual: ">=",
+	specs.OpGreaterThan:  ">",
+}
+
+func formatArgs(args []specs.LinuxSeccompArg) string {
+	conditions := []string{}
+	for _, arg := range args {
+		var condition string
+		if arg.Op == specs.OpMaskedEqual {
+			condition = fmt.Sprintf("arg%d & %#x == %#x", arg.Index, arg.Value, arg.ValueTwo)
+		} else if symbol, ok := opSymbols[arg.Op]; ok {
+			condition = fmt.Sprintf("arg%d %s %#x", arg.Index, symbol, arg.Value)
+		} else {
+			condition = fmt.Sprintf("arg%d %s %#x %#x", arg.Index, arg.Op, arg.Value, arg.ValueTwo)
+		}
+		conditions = append(conditions, condition)
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// FormatRules returns the syscalls allowed by rules, one per line
+// with the conditions on their arguments, e.g. "socket(arg0 == 0x2)".
+func FormatRules(rules []specs.LinuxSyscall) string {
+	var b strings.Builder
+	for _, rule := range rules {
+		for _, name := range rule.Names {
+			if len(rule.Args) == 0 {
+				fmt.Fprintln(&b, name)
+			} else {
+				fmt.Fprintf(&b, "%s(%s)\n", name, formatArgs(rule.Args))
+			}
+		}
+	}
+	return b.String()
+}
+
+// Read reads an OCI seccomp profile as used by Docker or containerd. Anything
+// following the profile, like the reports written with it by the gadget, is
+// ignored. Fields not part of the OCI specification, like the archMap or
+// includes fields of the Docker default profile, are rejected as ignoring them
+// could make the profile more permissive.
+func Read(r io.Reader) (*specs.LinuxSeccomp, error) {
+	profile := &specs.LinuxSeccomp{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(profile); err != nil {
+		return nil, fmt.Errorf("decoding seccomp profile: %w", err)
+	}
+	return profile, nil
+}
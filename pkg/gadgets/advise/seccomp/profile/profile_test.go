@@ -0,0 +1,204 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func eq(index uint, value uint64) specs.LinuxSeccompArg {
+	return specs.LinuxSeccompArg{Index: index, Value: value, Op: specs.OpEqualTo}
+}
+
+func allow(names []string, args ...specs.LinuxSeccompArg) specs.LinuxSyscall {
+	if args == nil {
+		args = []specs.LinuxSeccompArg{}
+	}
+	return specs.LinuxSyscall{Names: names, Action: specs.ActAllow, Args: args}
+}
+
+func TestMerge(t *testing.T) {
+	p1 := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Architectures: []specs.Arch{specs.ArchX86_64},
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"read", "write"}),
+			allow([]string{"socket"}, eq(0, 2)),
+		},
+	}
+	p2 := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Architectures: []specs.Arch{specs.ArchX86_64, specs.ArchX86},
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"openat", "read"}),
+			allow([]string{"socket"}, eq(0, 10)),
+			allow([]string{"socket"}, eq(0, 2)),
+			allow([]string{"personality"}, eq(0, 8)),
+			{Names: []string{"ptrace"}, Action: specs.ActErrno},
+		},
+	}
+	p3 := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"personality"}),
+		},
+	}
+
+	merged, err := Merge(p1, p2, p3)
+	if err != nil {
+		t.Fatalf("Failed to merge profiles: %v", err)
+	}
+
+	expected := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Architectures: []specs.Arch{specs.ArchX86_64, specs.ArchX86},
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"openat", "personality", "read", "write"}),
+			allow([]string{"socket"}, eq(0, 2)),
+			allow([]string{"socket"}, eq(0, 10)),
+			{Names: []string{"ptrace"}, Action: specs.ActErrno},
+		},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("Unexpected merged profile: %+v", merged)
+	}
+
+	if _, err := Merge(p1, &specs.LinuxSeccomp{DefaultAction: specs.ActLog}); err == nil {
+		t.Fatalf("Different default actions not detected")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"read", "write"}),
+			allow([]string{"socket"}, eq(0, 2)),
+			allow([]string{"clone"}, eq(0, 0x3d0f00)),
+		},
+	}
+	recorded := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"openat", "read"}),
+			allow([]string{"write"}, eq(0, 1)),
+			allow([]string{"socket"}, eq(0, 2)),
+			allow([]string{"socket"}, eq(0, 10)),
+			allow([]string{"clone"}),
+		},
+	}
+
+	diff := Diff(base, recorded)
+	expected := []specs.LinuxSyscall{
+		allow([]string{"clone", "openat"}),
+		allow([]string{"socket"}, eq(0, 10)),
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("Unexpected diff: %+v", diff)
+	}
+
+	output := FormatRules(diff)
+	if output != "clone\nopenat\nsocket(arg0 == 0xa)\n" {
+		t.Fatalf("Unexpected formatted rules: %q", output)
+	}
+
+	if len(Diff(recorded, recorded)) != 0 {
+		t.Fatalf("Unexpected diff of a profile with itself")
+	}
+}
+
+func TestDiffConditions(t *testing.T) {
+	base := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			// Any type of AF_INET socket
+			allow([]string{"socket"}, eq(0, 2)),
+			// Clone without CLONE_NEWUSER
+			allow([]string{"clone"}, specs.LinuxSeccompArg{Index: 0, Value: 0x10000000, Op: specs.OpMaskedEqual}),
+			allow([]string{"personality"}, specs.LinuxSeccompArg{Index: 0, Value: 8, Op: specs.OpLessEqual}),
+		},
+	}
+	recorded := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"socket"}, eq(0, 2), eq(1, 1)),
+			allow([]string{"socket"}, eq(0, 10), eq(1, 1)),
+			allow([]string{"clone"}, eq(0, 0x3d0f00)),
+			allow([]string{"clone"}, eq(0, 0x10000000)),
+			allow([]string{"personality"}, eq(0, 8)),
+			// Only identical rules allow conditions other than equalities
+			allow([]string{"personality"}, specs.LinuxSeccompArg{Index: 0, Value: 4, Op: specs.OpLessEqual}),
+		},
+	}
+
+	diff := Diff(base, recorded)
+	expected := []specs.LinuxSyscall{
+		allow([]string{"clone"}, eq(0, 0x10000000)),
+		allow([]string{"personality"}, specs.LinuxSeccompArg{Index: 0, Value: 4, Op: specs.OpLessEqual}),
+		allow([]string{"socket"}, eq(0, 10), eq(1, 1)),
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("Unexpected diff: %+v", diff)
+	}
+}
+
+func TestDiffDefaultAllow(t *testing.T) {
+	base := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActAllow,
+		Syscalls: []specs.LinuxSyscall{
+			{Names: []string{"ptrace", "reboot"}, Action: specs.ActErrno},
+			{Names: []string{"socket"}, Action: specs.ActErrno, Args: []specs.LinuxSeccompArg{eq(0, 16)}},
+		},
+	}
+	recorded := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			allow([]string{"openat", "ptrace", "read"}),
+			allow([]string{"socket"}, eq(0, 2), eq(1, 1)),
+			allow([]string{"socket"}, eq(0, 16), eq(1, 3)),
+		},
+	}
+
+	diff := Diff(base, recorded)
+	expected := []specs.LinuxSyscall{
+		allow([]string{"ptrace"}),
+		allow([]string{"socket"}, eq(0, 16), eq(1, 3)),
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("Unexpected diff: %+v", diff)
+	}
+}
+
+func TestRead(t *testing.T) {
+	profile, err := Read(strings.NewReader(`{"defaultAction": "SCMP_ACT_ERRNO", "syscalls": [{"names": ["read"], "action": "SCMP_ACT_ALLOW"}]}
+
+SYSCALL  COUNT
+read     10
+`))
+	if err != nil {
+		t.Fatalf("Failed to read profile: %v", err)
+	}
+	if profile.DefaultAction != specs.ActErrno || len(profile.Syscalls) != 1 {
+		t.Fatalf("Unexpected profile: %+v", profile)
+	}
+
+	if _, err := Read(strings.NewReader(`{"defaultAction": "SCMP_ACT_ERRNO", "archMap": []}`)); err == nil {
+		t.Fatalf("Unknown field not detected")
+	}
+}
@@ -6,4 +6,32 @@
 #define SYSCALLS_MAP_VALUE_FOOTER_SIZE	1
 #define SYSCALLS_MAP_VALUE_SIZE		(SYSCALLS_COUNT + SYSCALLS_MAP_VALUE_FOOTER_SIZE)
 
+// Flags of the syscalls in the syscalls_per_mntns bitmap
+#define SYSCALL_RECORDED		0x01
+#define SYSCALL_ARGS_INCOMPLETE		0x02
+#define SYSCALL_COUNT_INCOMPLETE	0x04
+
+#define MAX_SYSCALL_COUNTS		10240
+#define MAX_SYSCALL_ARGS		10240
+
+// Maximum number of arguments recorded for a syscall
+#define MAX_SYSCALL_ARG_VALUES		2
+
+struct syscall_key {
+	__u64 mntns;
+	__u32 nr;
+	__u32 pad;
+};
+
+// The values of the arguments recorded in a call to a syscall. They are
+// recorded together so profiles only allow the combinations that were seen.
+struct syscall_arg_key {
+	__u64 mntns;
+	__u64 values[MAX_SYSCALL_ARG_VALUES];
+	__u32 nr;
+	// Bitmap of the indexes of the arguments whose values are in values,
+	// in the same order
+	__u32 indexes;
+};
+
 #endif
@@ -50,6 +50,26 @@
 # endif
 #endif
 
+// Syscall numbers of the high-risk syscalls whose arguments are recorded from
+// https://github.com/seccomp/libseccomp/blob/abad8a8f41fc13efbb95fc1ccaa3e181342bade7/src/syscalls.csv
+#if defined(bpf_target_x86)
+# define __NR_ioctl 16
+# define __NR_socket 41
+# define __NR_clone 56
+# define __NR_personality 135
+#elif defined(bpf_target_arm64)
+# define __NR_ioctl 29
+# define __NR_personality 92
+# define __NR_socket 198
+# define __NR_clone 220
+#endif
+
+#ifndef EEXIST
+# define EEXIST 17
+#endif
+
+const volatile bool record_details = false;
+
 struct {
 	__uint(type, BPF_MAP_TYPE_HASH);
 	__type(key, __u64);
@@ -57,6 +77,23 @@ struct {
 	__uint(max_entries, 1024);
 } syscalls_per_mntns SEC(".maps");
 
+// Number of calls to each syscall, only used with record_details
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__type(key, struct syscall_key);
+	__type(value, __u64);
+	__uint(max_entries, MAX_SYSCALL_COUNTS);
+} syscall_counts SEC(".maps");
+
+// Values of the arguments of the high-risk syscalls, only used with
+// record_details
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__type(key, struct syscall_arg_key);
+	__type(value, __u8);
+	__uint(max_entries, MAX_SYSCALL_ARGS);
+} syscall_args SEC(".maps");
+
 #ifdef __TARGET_ARCH_x86
 static __always_inline int is_x86_compat(struct task_struct *task)
 {
@@ -64,6 +101,74 @@ static __always_inline int is_x86_compat(struct task_struct *task)
 }
 #endif
 
+// count_syscall counts a call to a syscall. It returns a non-zero value if it
+// couldn't be counted, i.e. the map is full.
+static __always_inline int count_syscall(__u64 mntns, unsigned int id)
+{
+	struct syscall_key key = {
+		.mntns = mntns,
+		.nr = id,
+	};
+	__u64 one = 1;
+	__u64 *count;
+
+	count = bpf_map_lookup_elem(&syscall_counts, &key);
+	if (count) {
+		__sync_fetch_and_add(count, 1);
+		return 0;
+	}
+	if (bpf_map_update_elem(&syscall_counts, &key, &one, BPF_NOEXIST) == 0)
+		return 0;
+
+	// The key could have been added by another CPU in the meantime
+	count = bpf_map_lookup_elem(&syscall_counts, &key);
+	if (count) {
+		__sync_fetch_and_add(count, 1);
+		return 0;
+	}
+	return -1;
+}
+
+// record_args records the arguments of the high-risk syscalls. It returns a
+// non-zero value if they couldn't be recorded, i.e. the map is full.
+static __always_inline int record_args(__u64 mntns, unsigned int id, struct pt_regs *regs)
+{
+	struct syscall_arg_key key = {
+		.mntns = mntns,
+		.nr = id,
+	};
+	__u8 seen = 1;
+	int err;
+
+	switch (id) {
+	case __NR_clone:
+	case __NR_personality:
+	case __NR_prctl:
+		key.values[0] = PT_REGS_PARM1(regs);
+		key.indexes = 1 << 0;
+		break;
+	case __NR_socket:
+		// The family and the type are recorded in the same key, so a
+		// profile doesn't allow a type with all the families seen
+		key.values[0] = PT_REGS_PARM1(regs);
+		key.values[1] = PT_REGS_PARM2(regs);
+		key.indexes = 1 << 0 | 1 << 1;
+		break;
+	case __NR_ioctl:
+		key.values[0] = PT_REGS_PARM2(regs);
+		key.indexes = 1 << 1;
+		break;
+	default:
+		return 0;
+	}
+
+	if (bpf_map_lookup_elem(&syscall_args, &key))
+		return 0;
+	err = bpf_map_update_elem(&syscall_args, &key, &seen, BPF_NOEXIST);
+	// The key could have been added by another CPU in the meantime
+	return err == -EEXIST ? 0 : err;
+}
+
 SEC("raw_tracepoint/sys_enter")
 int ig_seccomp_e(struct bpf_raw_tracepoint_args *ctx)
 {
@@ -133,7 +238,17 @@ int ig_seccomp_e(struct bpf_raw_tracepoint_args *ctx)
 	}
 
 	// Record the syscall
-	syscall_bitmap[id] = 0x01;
+	syscall_bitmap[id] |= SYSCALL_RECORDED;
+
+	if (record_details) {
+		// Counts are a lower bound if some calls couldn't be counted
+		if (count_syscall(mntns, id))
+			syscall_bitmap[id] |= SYSCALL_COUNT_INCOMPLETE;
+		// Profiles can't restrict the arguments of a syscall if some of
+		// its values are missing.
+		if (record_args(mntns, id, &regs))
+			syscall_bitmap[id] |= SYSCALL_ARGS_INCOMPLETE;
+	}
 
 	return 0;
 }
@@ -0,0 +1,64 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// SyscallFrequencyReport returns a table of how many times each syscall was
+// called, the most used first. The counts that are a lower bound, as some
+// calls couldn't be counted, are followed by a '+'.
+func SyscallFrequencyReport(details *SyscallDetails) string {
+	counts := details.Counts
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	// Syscalls may not have been counted at all
+	for name := range details.IncompleteCounts {
+		if _, ok := counts[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "SYSCALL\tCOUNT")
+	for _, name := range names {
+		suffix := ""
+		if details.IncompleteCounts[name] {
+			suffix = "+"
+		}
+		fmt.Fprintf(w, "%s\t%d%s\n", name, counts[name], suffix)
+	}
+	w.Flush()
+
+	if len(details.IncompleteCounts) != 0 {
+		b.WriteString("\n+: Some calls weren't counted because the map of the counts was full\n")
+	}
+
+	return b.String()
+}
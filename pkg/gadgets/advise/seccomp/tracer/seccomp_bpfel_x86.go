@@ -13,6 +13,19 @@ import (
 	"github.com/cilium/ebpf"
 )
 
+type seccompSyscallArgKey struct {
+	Mntns   uint64
+	Values  [2]uint64
+	Nr      uint32
+	Indexes uint32
+}
+
+type seccompSyscallKey struct {
+	Mntns uint64
+	Nr    uint32
+	Pad   uint32
+}
+
 // loadSeccomp returns the embedded CollectionSpec for seccomp.
 func loadSeccomp() (*ebpf.CollectionSpec, error) {
 	reader := bytes.NewReader(_SeccompBytes)
@@ -61,6 +74,8 @@ type seccompProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type seccompMapSpecs struct {
+	SyscallArgs      *ebpf.MapSpec `ebpf:"syscall_args"`
+	SyscallCounts    *ebpf.MapSpec `ebpf:"syscall_counts"`
 	SyscallsPerMntns *ebpf.MapSpec `ebpf:"syscalls_per_mntns"`
 }
 
@@ -83,11 +98,15 @@ func (o *seccompObjects) Close() error {
 //
 // It can be passed to loadSeccompObjects or ebpf.CollectionSpec.LoadAndAssign.
 type seccompMaps struct {
+	SyscallArgs      *ebpf.Map `ebpf:"syscall_args"`
+	SyscallCounts    *ebpf.Map `ebpf:"syscall_counts"`
 	SyscallsPerMntns *ebpf.Map `ebpf:"syscalls_per_mntns"`
 }
 
 func (m *seccompMaps) Close() error {
 	return _SeccompClose(
+		m.SyscallArgs,
+		m.SyscallCounts,
 		m.SyscallsPerMntns,
 	)
 }
@@ -115,5 +134,6 @@ func _SeccompClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed seccomp_bpfel_x86.o
 var _SeccompBytes []byte
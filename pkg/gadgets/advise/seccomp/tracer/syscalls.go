@@ -48,13 +48,57 @@ func Arches() []specs.Arch {
 }
 
 func SyscallNamesToLinuxSeccomp(syscallNames []string) *specs.LinuxSeccomp {
+	return SyscallDetailsToLinuxSeccomp(syscallNames, nil)
+}
+
+// SyscallArgRules returns the argument conditions of the rules allowing only
+// the given calls to a syscall, one rule for each of them: A call is only
+// allowed if all its argument values were seen together in a call, not just
+// in different ones.
+func SyscallArgRules(calls [][]SyscallArg) [][]specs.LinuxSeccompArg {
+	rules := make([][]specs.LinuxSeccompArg, 0, len(calls))
+	for _, call := range calls {
+		rule := make([]specs.LinuxSeccompArg, 0, len(call))
+		for _, arg := range call {
+			rule = append(rule, specs.LinuxSeccompArg{
+				Index: arg.Index,
+				Value: arg.Value,
+				Op:    specs.OpEqualTo,
+			})
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// SyscallDetailsToLinuxSeccomp is like SyscallNamesToLinuxSeccomp but the
+// syscalls whose argument values were recorded in details are only allowed
+// with those values. details can be nil.
+func SyscallDetailsToLinuxSeccomp(syscallNames []string, details *SyscallDetails) *specs.LinuxSeccomp {
+	names := []string{}
+	restricted := []specs.LinuxSyscall{}
+	for _, name := range syscallNames {
+		if details == nil || len(details.Args[name]) == 0 {
+			names = append(names, name)
+			continue
+		}
+		for _, args := range SyscallArgRules(details.Args[name]) {
+			restricted = append(restricted, specs.LinuxSyscall{
+				Names:  []string{name},
+				Action: specs.ActAllow,
+				Args:   args,
+			})
+		}
+	}
+
 	syscalls := []specs.LinuxSyscall{
 		{
-			Names:  syscallNames,
+			Names:  names,
 			Action: specs.ActAllow,
 			Args:   []specs.LinuxSeccompArg{},
 		},
 	}
+	syscalls = append(syscalls, restricted...)
 
 	s := &specs.LinuxSeccomp{
 		DefaultAction: specs.ActErrno,
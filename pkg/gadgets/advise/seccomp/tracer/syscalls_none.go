@@ -25,3 +25,13 @@ func SyscallNamesToLinuxSeccomp(syscallNames []string) *specs.LinuxSeccomp {
 	panic("Not implemented")
 	return nil
 }
+
+func SyscallArgRules(calls [][]SyscallArg) [][]specs.LinuxSeccompArg {
+	panic("Not implemented")
+	return nil
+}
+
+func SyscallDetailsToLinuxSeccomp(syscallNames []string, details *SyscallDetails) *specs.LinuxSeccomp {
+	panic("Not implemented")
+	return nil
+}
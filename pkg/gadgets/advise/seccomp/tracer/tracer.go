@@ -24,17 +24,52 @@ import (
 	libseccomp "github.com/seccomp/libseccomp-golang"
 )
 
-//go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target $TARGET -cc clang seccomp ./bpf/seccomp.bpf.c -- -I./bpf/ -I../../../../${TARGET}
+//go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target $TARGET -type syscall_key -type syscall_arg_key -cc clang seccomp ./bpf/seccomp.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 const (
 	// Please update these values also in bpf/seccomp-common.h
 	syscallsCount              = 500
 	syscallsMapValueFooterSize = 1
 	syscallsMapValueSize       = syscallsCount + syscallsMapValueFooterSize
+
+	syscallArgsIncomplete  = 0x02
+	syscallCountIncomplete = 0x04
 )
 
+type Config struct {
+	// RecordDetails makes the tracer count the calls to each syscall and
+	// record the argument values of the high-risk syscalls: clone flags,
+	// socket family and type, ioctl request, personality and prctl option.
+	RecordDetails bool
+}
+
+// SyscallDetails are the details recorded by a tracer with RecordDetails for
+// the syscalls of a mount namespace
+type SyscallDetails struct {
+	// Counts is the number of calls to each syscall
+	Counts map[string]uint64
+
+	// IncompleteCounts are the syscalls whose calls couldn't all be
+	// counted because the map of the counts was full. Their count is a
+	// lower bound.
+	IncompleteCounts map[string]bool
+
+	// Args are the argument values seen in the calls to the high-risk
+	// syscalls, by syscall name. Each element holds the values of one or
+	// more calls, sorted by index. The syscalls whose values couldn't all
+	// be recorded are missing.
+	Args map[string][][]SyscallArg
+}
+
+// SyscallArg is the value of an argument in a call to a syscall
+type SyscallArg struct {
+	Index uint
+	Value uint64
+}
+
 type Tracer struct {
-	objs seccompObjects
+	config *Config
+	objs   seccompObjects
 
 	// progLink links the BPF program to the tracepoint.
 	// A reference is kept so it can be closed it explicitly, otherwise
@@ -43,8 +78,8 @@ type Tracer struct {
 	progLink link.Link
 }
 
-func NewTracer() (*Tracer, error) {
-	t := &Tracer{}
+func NewTracer(config *Config) (*Tracer, error) {
+	t := &Tracer{config: config}
 
 	if err := t.start(); err != nil {
 		t.Close()
@@ -60,6 +95,13 @@ func (t *Tracer) start() error {
 		return fmt.Errorf("failed to load asset: %w", err)
 	}
 
+	consts := map[string]interface{}{
+		"record_details": t.config.RecordDetails,
+	}
+	if err := spec.RewriteConstants(consts); err != nil {
+		return fmt.Errorf("error RewriteConstants: %w", err)
+	}
+
 	if err := spec.LoadAndAssign(&t.objs, nil); err != nil {
 		return fmt.Errorf("failed to load ebpf program: %w", err)
 	}
@@ -77,24 +119,28 @@ func (t *Tracer) start() error {
 	return nil
 }
 
+func syscallName(nr uint32) string {
+	call1 := libseccomp.ScmpSyscall(nr)
+	name, err := call1.GetName()
+	if err != nil {
+		name = fmt.Sprintf("syscall%d", nr)
+	}
+	return name
+}
+
 func syscallArrToNameList(v []byte) []string {
 	names := []string{}
 	for i, val := range v {
 		if val == 0 {
 			continue
 		}
-		call1 := libseccomp.ScmpSyscall(i)
-		name, err := call1.GetName()
-		if err != nil {
-			name = fmt.Sprintf("syscall%d", i)
-		}
-		names = append(names, name)
+		names = append(names, syscallName(uint32(i)))
 	}
 	sort.Strings(names)
 	return names
 }
 
-func (t *Tracer) Peek(mntns uint64) ([]string, error) {
+func (t *Tracer) lookupBitmap(mntns uint64) ([]byte, error) {
 	b, err := t.objs.SyscallsPerMntns.LookupBytes(mntns)
 	if err != nil {
 		return nil, fmt.Errorf("looking up the seccomp map: %w", err)
@@ -108,11 +154,134 @@ func (t *Tracer) Peek(mntns uint64) ([]string, error) {
 	if len(b) < syscallsCount {
 		return nil, fmt.Errorf("looking up the seccomp map: wrong length: %d", len(b))
 	}
-	return syscallArrToNameList(b[:syscallsCount]), nil
+	return b[:syscallsCount], nil
+}
+
+func (t *Tracer) Peek(mntns uint64) ([]string, error) {
+	b, err := t.lookupBitmap(mntns)
+	if err != nil {
+		return nil, err
+	}
+	return syscallArrToNameList(b), nil
+}
+
+// PeekDetails returns the details recorded for the syscalls of a mount
+// namespace. The tracer has to be created with RecordDetails.
+func (t *Tracer) PeekDetails(mntns uint64) (*SyscallDetails, error) {
+	if !t.config.RecordDetails {
+		return nil, fmt.Errorf("syscall details are not recorded")
+	}
+
+	b, err := t.lookupBitmap(mntns)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &SyscallDetails{
+		Counts:           map[string]uint64{},
+		IncompleteCounts: map[string]bool{},
+		Args:             map[string][][]SyscallArg{},
+	}
+
+	for nr, flags := range b {
+		if flags&syscallCountIncomplete != 0 {
+			details.IncompleteCounts[syscallName(uint32(nr))] = true
+		}
+	}
+
+	var key seccompSyscallKey
+	var count uint64
+	counts := t.objs.SyscallCounts.Iterate()
+	for counts.Next(&key, &count) {
+		if key.Mntns == mntns {
+			details.Counts[syscallName(key.Nr)] = count
+		}
+	}
+	if err := counts.Err(); err != nil {
+		return nil, fmt.Errorf("iterating the syscall counts map: %w", err)
+	}
+
+	var argKey seccompSyscallArgKey
+	var seen uint8
+	args := t.objs.SyscallArgs.Iterate()
+	for args.Next(&argKey, &seen) {
+		if argKey.Mntns != mntns || int(argKey.Nr) >= len(b) || b[argKey.Nr]&syscallArgsIncomplete != 0 {
+			continue
+		}
+		name := syscallName(argKey.Nr)
+		details.Args[name] = append(details.Args[name], callArgs(&argKey))
+	}
+	if err := args.Err(); err != nil {
+		return nil, fmt.Errorf("iterating the syscall args map: %w", err)
+	}
+
+	// Calls to the same syscall record the same arguments, so they can be
+	// sorted by their values
+	for _, calls := range details.Args {
+		sort.Slice(calls, func(i, j int) bool {
+			for k := range calls[i] {
+				if k == len(calls[j]) {
+					return false
+				}
+				if calls[i][k].Value != calls[j][k].Value {
+					return calls[i][k].Value < calls[j][k].Value
+				}
+			}
+			return len(calls[i]) < len(calls[j])
+		})
+	}
+
+	return details, nil
+}
+
+// callArgs returns the arguments recorded in a key of the syscall args map
+func callArgs(key *seccompSyscallArgKey) []SyscallArg {
+	args := []SyscallArg{}
+	values := key.Values[:]
+	for index := uint(0); key.Indexes>>index != 0 && len(values) != 0; index++ {
+		if key.Indexes&(1<<index) == 0 {
+			continue
+		}
+		args = append(args, SyscallArg{Index: index, Value: values[0]})
+		values = values[1:]
+	}
+	return args
 }
 
 func (t *Tracer) Delete(mntns uint64) {
 	t.objs.SyscallsPerMntns.Delete(mntns)
+
+	if !t.config.RecordDetails {
+		return
+	}
+
+	// Keys can't be deleted while iterating a hash map without restarting
+	// the iteration
+	keys := []seccompSyscallKey{}
+	var key seccompSyscallKey
+	var count uint64
+	counts := t.objs.SyscallCounts.Iterate()
+	for counts.Next(&key, &count) {
+		if key.Mntns == mntns {
+			keys = append(keys, key)
+		}
+	}
+	for _, k := range keys {
+		t.objs.SyscallCounts.Delete(k)
+	}
+
+	argKeys := []seccompSyscallArgKey{}
+	var argKey seccompSyscallArgKey
+	var seen uint8
+	args := t.objs.SyscallArgs.Iterate()
+	for args.Next(&argKey, &seen) {
+		if argKey.Mntns == mntns {
+			argKeys = append(argKeys, argKey)
+		}
+	}
+	for _, k := range argKeys {
+		t.objs.SyscallArgs.Delete(k)
+	}
 }
 
 func (t *Tracer) Close() {
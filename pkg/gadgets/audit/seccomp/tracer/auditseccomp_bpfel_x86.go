@@ -77,10 +77,11 @@ type auditseccompProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type auditseccompMapSpecs struct {
-	Containers    *ebpf.MapSpec `ebpf:"containers"`
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	TmpEvent      *ebpf.MapSpec `ebpf:"tmp_event"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Containers     *ebpf.MapSpec `ebpf:"containers"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	TmpEvent       *ebpf.MapSpec `ebpf:"tmp_event"`
 }
 
 // auditseccompObjects contains all objects after they have been loaded into the kernel.
@@ -102,14 +103,16 @@ func (o *auditseccompObjects) Close() error {
 //
 // It can be passed to loadAuditseccompObjects or ebpf.CollectionSpec.LoadAndAssign.
 type auditseccompMaps struct {
-	Containers    *ebpf.Map `ebpf:"containers"`
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	TmpEvent      *ebpf.Map `ebpf:"tmp_event"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Containers     *ebpf.Map `ebpf:"containers"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	TmpEvent       *ebpf.Map `ebpf:"tmp_event"`
 }
 
 func (m *auditseccompMaps) Close() error {
 	return _AuditseccompClose(
+		m.CgroupIdFilter,
 		m.Containers,
 		m.Events,
 		m.MountNsFilter,
@@ -140,5 +143,6 @@ func _AuditseccompClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed auditseccomp_bpfel_x86.o
 var _AuditseccompBytes []byte
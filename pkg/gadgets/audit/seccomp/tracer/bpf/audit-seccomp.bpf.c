@@ -20,6 +20,13 @@ struct {
 	__uint(max_entries, MAX_CONTAINERS_PER_NODE);
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__type(key, __u64);
+	__type(value, __u32);
+	__uint(max_entries, MAX_CONTAINERS_PER_NODE);
+} cgroup_id_filter SEC(".maps");
+
 /* The stack is limited, so use a map to build the event */
 struct {
 	__uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
@@ -33,6 +40,18 @@ struct {
 } events SEC(".maps");
 
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
 
 SEC("kprobe/audit_seccomp")
 int ig_audit_secc(struct pt_regs *ctx)
@@ -47,6 +66,8 @@ int ig_audit_secc(struct pt_regs *ctx)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	__u32 zero = 0;
 	struct event *event = bpf_map_lookup_elem(&tmp_event, &zero);
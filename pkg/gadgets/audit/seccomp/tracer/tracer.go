@@ -47,6 +47,7 @@ type Tracer struct {
 type Config struct {
 	ContainersMap *ebpf.Map
 	MountnsMap    *ebpf.Map
+	CgroupIDMap   *ebpf.Map
 }
 
 func NewTracer(config *Config, eventCallback func(types.Event)) (*Tracer, error) {
@@ -84,6 +85,11 @@ func (t *Tracer) start() error {
 		"filter_by_mnt_ns": filterByMntNs,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -38,3 +38,8 @@ func Base(ev eventtypes.Event) Event {
 		Event: ev,
 	}
 }
+
+// GetPid is needed to implement the gadgets.EventWithPid interface.
+func (e Event) GetPid() uint32 {
+	return e.Pid
+}
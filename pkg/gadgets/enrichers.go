@@ -0,0 +1,147 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gadgets
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// The events of the gadgets expose the keys that enrichers can use to add
+// data to them by implementing the following interfaces. Enrichers implement
+// one or more of the DataEnricherBy* interfaces below and are only called
+// for the events exposing the corresponding key.
+
+// EventWithEnrichedData is implemented by all the events embedding
+// types.Event. It gives the enrichers access to the data they add.
+type EventWithEnrichedData interface {
+	GetEnrichedData() *types.EnrichedData
+}
+
+// EventWithPid is implemented by the events generated by a process
+type EventWithPid interface {
+	GetPid() uint32
+}
+
+// EventWithUID is implemented by the events containing the user ID of the
+// process that generated them. They also implement EventWithPid.
+type EventWithUID interface {
+	EventWithPid
+	GetUID() uint32
+}
+
+// EventWithAddrs is implemented by the events containing IP addresses. Any of
+// them can be empty.
+type EventWithAddrs interface {
+	GetAddrs() (src, dst string)
+}
+
+// DataEnricherByPid is used to enrich events with information about the
+// process that generated them, like its command line.
+type DataEnricherByPid interface {
+	EnrichByPid(data *types.EnrichedData, pid uint32)
+}
+
+// DataEnricherByUID is used to enrich events with information about the user
+// of the process that generated them, like its name. The pid is given to find
+// the container of the process.
+type DataEnricherByUID interface {
+	EnrichByUID(data *types.EnrichedData, pid, uid uint32)
+}
+
+// DataEnricherByAddrs is used to enrich events with information about the IP
+// addresses they contain, like the Kubernetes resources using them.
+type DataEnricherByAddrs interface {
+	EnrichByAddrs(data *types.EnrichedData, src, dst string)
+}
+
+// EnricherChain calls a list of enrichers on events.
+type EnricherChain struct {
+	enrichers []any
+}
+
+// NewEnricherChain returns a chain calling the given enrichers in order. They
+// must implement at least one of the DataEnricherBy* interfaces.
+func NewEnricherChain(enrichers ...any) (*EnricherChain, error) {
+	for _, enricher := range enrichers {
+		switch enricher.(type) {
+		case DataEnricherByPid, DataEnricherByUID, DataEnricherByAddrs:
+		default:
+			return nil, fmt.Errorf("%T is not an enricher", enricher)
+		}
+	}
+
+	return &EnricherChain{enrichers: enrichers}, nil
+}
+
+// Len returns the number of enrichers of the chain
+func (c *EnricherChain) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.enrichers)
+}
+
+// Enrich calls the enrichers of the chain on event, an event of a gadget or a
+// pointer to it, and returns the enriched event. Events given by value are
+// copied and returned by value too.
+func (c *EnricherChain) Enrich(event any) any {
+	if c.Len() == 0 {
+		return event
+	}
+
+	ptr := event
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Pointer {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		ptr = p.Interface()
+	} else if v.IsNil() {
+		return event
+	}
+
+	withData, ok := ptr.(EventWithEnrichedData)
+	if !ok {
+		return event
+	}
+	data := withData.GetEnrichedData()
+
+	for _, enricher := range c.enrichers {
+		if e, ok := enricher.(DataEnricherByPid); ok {
+			if ev, ok := ptr.(EventWithPid); ok && ev.GetPid() != 0 {
+				e.EnrichByPid(data, ev.GetPid())
+			}
+		}
+		if e, ok := enricher.(DataEnricherByUID); ok {
+			if ev, ok := ptr.(EventWithUID); ok && ev.GetPid() != 0 {
+				e.EnrichByUID(data, ev.GetPid(), ev.GetUID())
+			}
+		}
+		if e, ok := enricher.(DataEnricherByAddrs); ok {
+			if ev, ok := ptr.(EventWithAddrs); ok {
+				if src, dst := ev.GetAddrs(); src != "" || dst != "" {
+					e.EnrichByAddrs(data, src, dst)
+				}
+			}
+		}
+	}
+
+	if v.Kind() != reflect.Pointer {
+		return reflect.ValueOf(ptr).Elem().Interface()
+	}
+	return ptr
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// MaxAncestors is the maximum number of parents added to the events
+const MaxAncestors = 8
+
+type parentInfo struct {
+	comm string
+	ppid uint32
+}
+
+// AncestorsEnricher adds the parents of the process to the events, the
+// closest first, as "comm(pid)".
+type AncestorsEnricher struct {
+	cache *ttlCache[uint32, parentInfo]
+}
+
+func NewAncestorsEnricher() *AncestorsEnricher {
+	return &AncestorsEnricher{
+		cache: newTTLCache[uint32, parentInfo](cacheTTL),
+	}
+}
+
+func (e *AncestorsEnricher) EnrichByPid(data *types.EnrichedData, pid uint32) {
+	info, ok := e.parent(pid)
+	if !ok {
+		return
+	}
+
+	ancestors := []string{}
+	for pid = info.ppid; pid != 0 && len(ancestors) < MaxAncestors; pid = info.ppid {
+		if info, ok = e.parent(pid); !ok {
+			break
+		}
+		ancestors = append(ancestors, fmt.Sprintf("%s(%d)", info.comm, pid))
+	}
+
+	data.Ancestors = strings.Join(ancestors, " > ")
+}
+
+// parent returns the name of the process and the pid of its parent
+func (e *AncestorsEnricher) parent(pid uint32) (parentInfo, bool) {
+	if info, ok := e.cache.get(pid); ok {
+		return info, true
+	}
+
+	info, err := readParentInfo(pid)
+	if err != nil {
+		return parentInfo{}, false
+	}
+	e.cache.set(pid, info)
+
+	return info, true
+}
+
+func readParentInfo(pid uint32) (parentInfo, error) {
+	stat, err := os.ReadFile(filepath.Join(hostRoot, fmt.Sprintf("/proc/%d/stat", pid)))
+	if err != nil {
+		return parentInfo{}, err
+	}
+
+	// The name of the process is between parentheses and can contain
+	// spaces and parentheses too: Look for the last one.
+	// Format: "pid (comm) state ppid ..."
+	start := strings.IndexByte(string(stat), '(')
+	end := strings.LastIndexByte(string(stat), ')')
+	if start < 0 || end < start {
+		return parentInfo{}, fmt.Errorf("invalid stat of pid %d", pid)
+	}
+
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 2 {
+		return parentInfo{}, fmt.Errorf("invalid stat of pid %d", pid)
+	}
+	ppid, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return parentInfo{}, fmt.Errorf("invalid ppid of pid %d: %w", pid, err)
+	}
+
+	return parentInfo{
+		comm: string(stat[start+1 : end]),
+		ppid: uint32(ppid),
+	}, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichers
+
+import (
+	"net/netip"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// ipIndex is the name of the index of pods and services by IP address
+const ipIndex = "ip"
+
+// EndpointsEnricher adds the Kubernetes pods and services using the source
+// and destination IP addresses to the events, as "pod/<namespace>/<name>" and
+// "svc/<namespace>/<name>". Pods using the network of their host are ignored
+// as their addresses aren't theirs.
+type EndpointsEnricher struct {
+	pods     cache.Indexer
+	services cache.Indexer
+	stop     chan struct{}
+}
+
+// NewEndpointsEnricher creates an enricher watching the pods and services of
+// the cluster with the in-cluster configuration.
+func NewEndpointsEnricher() (*EndpointsEnricher, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	restClient := clientset.CoreV1().RESTClient()
+	podListWatcher := cache.NewListWatchFromClient(restClient, "pods", "", fields.Everything())
+	serviceListWatcher := cache.NewListWatchFromClient(restClient, "services", "", fields.Everything())
+
+	e := &EndpointsEnricher{
+		stop: make(chan struct{}),
+	}
+
+	var podInformer, serviceInformer cache.Controller
+	e.pods, podInformer = cache.NewIndexerInformer(podListWatcher, &v1.Pod{}, 0,
+		cache.ResourceEventHandlerFuncs{}, cache.Indexers{ipIndex: podIPs})
+	e.services, serviceInformer = cache.NewIndexerInformer(serviceListWatcher, &v1.Service{}, 0,
+		cache.ResourceEventHandlerFuncs{}, cache.Indexers{ipIndex: serviceIPs})
+
+	go podInformer.Run(e.stop)
+	go serviceInformer.Run(e.stop)
+
+	return e, nil
+}
+
+func newEndpointsEnricherFromIndexers(pods, services cache.Indexer) *EndpointsEnricher {
+	return &EndpointsEnricher{
+		pods:     pods,
+		services: services,
+		stop:     make(chan struct{}),
+	}
+}
+
+// podIPs is the index function of the pods by IP address
+func podIPs(obj any) ([]string, error) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok || pod.Spec.HostNetwork {
+		return nil, nil
+	}
+
+	ips := []string{}
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, normalizeIP(podIP.IP))
+	}
+	if len(ips) == 0 && pod.Status.PodIP != "" {
+		ips = append(ips, normalizeIP(pod.Status.PodIP))
+	}
+	return ips, nil
+}
+
+// serviceIPs is the index function of the services by IP address
+func serviceIPs(obj any) ([]string, error) {
+	svc, ok := obj.(*v1.Service)
+	if !ok {
+		return nil, nil
+	}
+
+	ips := []string{}
+	for _, clusterIP := range svc.Spec.ClusterIPs {
+		if clusterIP != v1.ClusterIPNone {
+			ips = append(ips, normalizeIP(clusterIP))
+		}
+	}
+	if len(ips) == 0 && svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != v1.ClusterIPNone {
+		ips = append(ips, normalizeIP(svc.Spec.ClusterIP))
+	}
+	return ips, nil
+}
+
+// normalizeIP returns the canonical form of an IP address, with IPv4-mapped
+// IPv6 addresses converted to IPv4, so the addresses of the events and of the
+// resources can be compared.
+func normalizeIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	return addr.Unmap().String()
+}
+
+func (e *EndpointsEnricher) EnrichByAddrs(data *types.EnrichedData, src, dst string) {
+	if src != "" {
+		data.SrcEndpoint = e.lookup(src)
+	}
+	if dst != "" {
+		data.DstEndpoint = e.lookup(dst)
+	}
+}
+
+// lookup returns the pod or service using ip, or an empty string
+func (e *EndpointsEnricher) lookup(ip string) string {
+	ip = normalizeIP(ip)
+
+	if objs, err := e.pods.ByIndex(ipIndex, ip); err == nil && len(objs) > 0 {
+		// Several pods can have had the same IP, e.g. when a completed
+		// pod is not deleted yet: Prefer the running one.
+		pod := objs[0].(*v1.Pod)
+		for _, obj := range objs {
+			if p := obj.(*v1.Pod); p.Status.Phase == v1.PodRunning {
+				pod = p
+				break
+			}
+		}
+		return "pod/" + pod.Namespace + "/" + pod.Name
+	}
+
+	if objs, err := e.services.ByIndex(ipIndex, ip); err == nil && len(objs) > 0 {
+		svc := objs[0].(*v1.Service)
+		return "svc/" + svc.Namespace + "/" + svc.Name
+	}
+
+	return ""
+}
+
+// Close stops watching the pods and services
+func (e *EndpointsEnricher) Close() {
+	close(e.stop)
+}
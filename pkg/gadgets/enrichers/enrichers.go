@@ -0,0 +1,230 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enrichers provides the built-in enrichers that can be enabled per
+// trace to add data to the events of the gadgets. The information about
+// processes is read from /proc, so HOST_ROOT has to be set when running in a
+// container.
+package enrichers
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
+)
+
+// Names of the built-in enrichers
+const (
+	// User adds the names of the user and of its group
+	User = "user"
+
+	// Process adds the command line and the executable of the process
+	Process = "process"
+
+	// Ancestors adds the parents of the process
+	Ancestors = "ancestors"
+
+	// Endpoints adds the Kubernetes pods and services using the IP
+	// addresses of the event
+	Endpoints = "endpoints"
+)
+
+var hostRoot string
+
+func init() {
+	hostRoot = os.Getenv("HOST_ROOT")
+}
+
+// Registry creates the enricher chains of the traces. The enrichers are
+// created once and shared by all the chains, so are their caches.
+type Registry struct {
+	mu        sync.Mutex
+	enrichers map[string]any
+
+	// newEndpoints creates the endpoints enricher, which needs access to
+	// the Kubernetes API. It's nil when it's not available.
+	newEndpoints func() (*EndpointsEnricher, error)
+}
+
+// RegistryOption configures a Registry
+type RegistryOption func(*Registry)
+
+// WithEndpoints makes the endpoints enricher available, watching pods and
+// services with the in-cluster configuration.
+func WithEndpoints() RegistryOption {
+	return func(r *Registry) {
+		r.newEndpoints = NewEndpointsEnricher
+	}
+}
+
+func NewRegistry(options ...RegistryOption) *Registry {
+	r := &Registry{
+		enrichers: make(map[string]any),
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Available returns the names of the enrichers that can be used
+func (r *Registry) Available() []string {
+	names := []string{User, Process, Ancestors}
+	if r.newEndpoints != nil {
+		names = append(names, Endpoints)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that all the names are names of available enrichers
+func (r *Registry) Validate(names []string) error {
+	available := r.Available()
+	for _, name := range names {
+		i := sort.SearchStrings(available, name)
+		if i == len(available) || available[i] != name {
+			return fmt.Errorf("unknown enricher %q, available enrichers are: %s",
+				name, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// NewChain returns a chain with the enrichers with the given names, in that
+// order.
+func (r *Registry) NewChain(names []string) (*gadgets.EnricherChain, error) {
+	if err := r.Validate(names); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	enrichers := make([]any, 0, len(names))
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		enricher, ok := r.enrichers[name]
+		if !ok {
+			var err error
+			if enricher, err = r.newEnricher(name); err != nil {
+				return nil, fmt.Errorf("creating enricher %q: %w", name, err)
+			}
+			r.enrichers[name] = enricher
+		}
+		enrichers = append(enrichers, enricher)
+	}
+
+	return gadgets.NewEnricherChain(enrichers...)
+}
+
+func (r *Registry) newEnricher(name string) (any, error) {
+	switch name {
+	case User:
+		return NewUserEnricher(), nil
+	case Process:
+		return NewProcessEnricher(), nil
+	case Ancestors:
+		return NewAncestorsEnricher(), nil
+	case Endpoints:
+		return r.newEndpoints()
+	}
+	return nil, fmt.Errorf("unknown enricher %q", name)
+}
+
+// Close stops the enrichers watching resources
+func (r *Registry) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e, ok := r.enrichers[Endpoints].(*EndpointsEnricher); ok {
+		e.Close()
+	}
+	r.enrichers = make(map[string]any)
+}
+
+// Default limits of the caches of the enrichers
+const (
+	cacheSize = 4096
+	cacheTTL  = 2 * time.Second
+)
+
+type cacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// ttlCache caches values for a short time. It's used for the information
+// read from /proc, which changes when pids are reused or processes call
+// execve(). It's safe for concurrent use.
+type ttlCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]cacheEntry[V]
+	ttl     time.Duration
+
+	// now can be replaced for testing
+	now func() time.Time
+}
+
+func newTTLCache[K comparable, V any](ttl time.Duration) *ttlCache[K, V] {
+	return &ttlCache[K, V]{
+		entries: make(map[K]cacheEntry[V]),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+func (c *ttlCache[K, V]) get(key K) (v V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || c.now().After(entry.expires) {
+		return v, false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache[K, V]) set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= cacheSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		// Still full: Start again rather than tracking the usage
+		if len(c.entries) >= cacheSize {
+			c.entries = make(map[K]cacheEntry[V])
+		}
+	}
+
+	c.entries[key] = cacheEntry[V]{
+		value:   value,
+		expires: now.Add(c.ttl),
+	}
+}
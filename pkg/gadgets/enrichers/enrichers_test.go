@@ -0,0 +1,208 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	exectypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/exec/types"
+	tcptypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/tcp/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// fakeProcess is a process of the fake /proc created by newFakeHostRoot
+type fakeProcess struct {
+	pid     uint32
+	ppid    uint32
+	comm    string
+	cmdline string
+	exe     string
+	passwd  string
+	group   string
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %s", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %s", path, err)
+	}
+}
+
+// newFakeHostRoot creates a host root with the given processes and makes the
+// enrichers use it
+func newFakeHostRoot(t *testing.T, processes ...fakeProcess) {
+	t.Helper()
+
+	root := t.TempDir()
+	for _, p := range processes {
+		procDir := filepath.Join(root, fmt.Sprintf("proc/%d", p.pid))
+
+		writeFile(t, filepath.Join(procDir, "stat"),
+			fmt.Sprintf("%d (%s) S %d 1 1 0 -1 4194560", p.pid, p.comm, p.ppid))
+		writeFile(t, filepath.Join(procDir, "cmdline"), p.cmdline)
+		if p.exe != "" {
+			if err := os.Symlink(p.exe, filepath.Join(procDir, "exe")); err != nil {
+				t.Fatalf("Failed to create exe link: %s", err)
+			}
+		}
+		if p.passwd != "" {
+			writeFile(t, filepath.Join(procDir, "root/etc/passwd"), p.passwd)
+			writeFile(t, filepath.Join(procDir, "root/etc/group"), p.group)
+		}
+	}
+
+	oldHostRoot := hostRoot
+	hostRoot = root
+	t.Cleanup(func() { hostRoot = oldHostRoot })
+}
+
+func TestProcessEnrichers(t *testing.T) {
+	newFakeHostRoot(t,
+		fakeProcess{pid: 1, comm: "systemd", cmdline: "/sbin/init\x00"},
+		fakeProcess{pid: 10, ppid: 1, comm: "containerd-shim"},
+		fakeProcess{pid: 20, ppid: 10, comm: "my (weird) sh"},
+		fakeProcess{
+			pid:     30,
+			ppid:    20,
+			comm:    "cat",
+			cmdline: "cat\x00/etc/hosts\x00",
+			exe:     "/usr/bin/cat",
+			passwd:  "# users\nroot:x:0:0:root:/root:/bin/sh\nnginx:x:101:101::/nonexistent:/bin/false\n",
+			group:   "root:x:0:\nnginx:x:101:\n",
+		},
+	)
+
+	registry := NewRegistry()
+	chain, err := registry.NewChain([]string{User, Process, Ancestors})
+	if err != nil {
+		t.Fatalf("Failed to create chain: %s", err)
+	}
+
+	event := exectypes.Event{Pid: 30, UID: 101}
+	enriched, ok := chain.Enrich(event).(exectypes.Event)
+	if !ok {
+		t.Fatalf("Events given by value must be returned by value")
+	}
+
+	expected := eventtypes.EnrichedData{
+		User:      "nginx",
+		Group:     "nginx",
+		Cmdline:   "cat /etc/hosts",
+		Exe:       "/usr/bin/cat",
+		Ancestors: "my (weird) sh(20) > containerd-shim(10) > systemd(1)",
+	}
+	if enriched.EnrichedData != expected {
+		t.Fatalf("Unexpected enriched data:\n%+v\nexpected:\n%+v", enriched.EnrichedData, expected)
+	}
+	if event.EnrichedData != (eventtypes.EnrichedData{}) {
+		t.Fatalf("Event given by value modified")
+	}
+
+	// Unknown users and terminated processes are ignored
+	ptr := &exectypes.Event{Pid: 30, UID: 1000}
+	chain.Enrich(ptr)
+	if ptr.User != "" || ptr.Cmdline != "cat /etc/hosts" {
+		t.Fatalf("Unexpected enriched data: %+v", ptr.EnrichedData)
+	}
+
+	ptr = &exectypes.Event{Pid: 404}
+	chain.Enrich(ptr)
+	if ptr.EnrichedData != (eventtypes.EnrichedData{}) {
+		t.Fatalf("Unexpected enriched data for terminated process: %+v", ptr.EnrichedData)
+	}
+}
+
+func TestEndpointsEnricher(t *testing.T) {
+	pods := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{ipIndex: podIPs})
+	services := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{ipIndex: serviceIPs})
+
+	pods.Add(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+		Status: v1.PodStatus{
+			Phase:  v1.PodRunning,
+			PodIP:  "10.0.0.5",
+			PodIPs: []v1.PodIP{{IP: "10.0.0.5"}, {IP: "fd00::5"}},
+		},
+	})
+	pods.Add(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "kube-proxy"},
+		Spec:       v1.PodSpec{HostNetwork: true},
+		Status:     v1.PodStatus{PodIP: "192.168.1.10"},
+	})
+	services.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "kube-dns"},
+		Spec:       v1.ServiceSpec{ClusterIP: "10.96.0.10", ClusterIPs: []string{"10.96.0.10"}},
+	})
+
+	chain, err := (&Registry{
+		enrichers: map[string]any{Endpoints: newEndpointsEnricherFromIndexers(pods, services)},
+		newEndpoints: func() (*EndpointsEnricher, error) {
+			t.Fatalf("Endpoints enricher created again")
+			return nil, nil
+		},
+	}).NewChain([]string{Endpoints})
+	if err != nil {
+		t.Fatalf("Failed to create chain: %s", err)
+	}
+
+	for _, test := range []struct {
+		saddr, daddr string
+		src, dst     string
+	}{
+		{"10.0.0.5", "10.96.0.10", "pod/default/nginx", "svc/kube-system/kube-dns"},
+		{"::ffff:10.0.0.5", "fd00::5", "pod/default/nginx", "pod/default/nginx"},
+		{"192.168.1.10", "1.1.1.1", "", ""},
+	} {
+		event := chain.Enrich(&tcptypes.Event{Saddr: test.saddr, Daddr: test.daddr}).(*tcptypes.Event)
+		if event.SrcEndpoint != test.src || event.DstEndpoint != test.dst {
+			t.Fatalf("Unexpected endpoints for %s -> %s: %q -> %q",
+				test.saddr, test.daddr, event.SrcEndpoint, event.DstEndpoint)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	if err := registry.Validate([]string{Process, User}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// The endpoints enricher needs the Kubernetes API
+	if err := registry.Validate([]string{Endpoints}); err == nil {
+		t.Fatalf("Expected error for unavailable enricher")
+	}
+	if _, err := registry.NewChain([]string{"unknown"}); err == nil {
+		t.Fatalf("Expected error for unknown enricher")
+	}
+
+	chain, err := registry.NewChain([]string{Process, Process})
+	if err != nil {
+		t.Fatalf("Failed to create chain: %s", err)
+	}
+	if chain.Len() != 1 {
+		t.Fatalf("Duplicated enricher not ignored")
+	}
+}
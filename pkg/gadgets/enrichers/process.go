@@ -0,0 +1,75 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// maxCmdlineLen is the maximum length of the command lines added to the
+// events. Longer ones are truncated.
+const maxCmdlineLen = 4096
+
+type processInfo struct {
+	cmdline string
+	exe     string
+}
+
+// ProcessEnricher adds the command line and the path of the executable of
+// the process to the events. Nothing is added when the process already
+// terminated.
+type ProcessEnricher struct {
+	cache *ttlCache[uint32, processInfo]
+}
+
+func NewProcessEnricher() *ProcessEnricher {
+	return &ProcessEnricher{
+		cache: newTTLCache[uint32, processInfo](cacheTTL),
+	}
+}
+
+func (e *ProcessEnricher) EnrichByPid(data *types.EnrichedData, pid uint32) {
+	info, ok := e.cache.get(pid)
+	if !ok {
+		info = readProcessInfo(pid)
+		e.cache.set(pid, info)
+	}
+
+	data.Cmdline = info.cmdline
+	data.Exe = info.exe
+}
+
+func readProcessInfo(pid uint32) (info processInfo) {
+	procDir := filepath.Join(hostRoot, fmt.Sprintf("/proc/%d", pid))
+
+	if cmdline, err := os.ReadFile(filepath.Join(procDir, "cmdline")); err == nil {
+		if len(cmdline) > maxCmdlineLen {
+			cmdline = cmdline[:maxCmdlineLen]
+		}
+		// The arguments are separated by null characters
+		cmdline = bytes.TrimRight(cmdline, "\x00")
+		info.cmdline = string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '}))
+	}
+
+	// The link is the path in the mount namespace of the process
+	info.exe, _ = os.Readlink(filepath.Join(procDir, "exe"))
+
+	return info
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// maxAccounts is the maximum number of different /etc/passwd and /etc/group
+// files kept in memory
+const maxAccounts = 256
+
+type account struct {
+	name string
+	gid  uint32
+}
+
+// accounts contains the users and groups of a container
+type accounts struct {
+	users  map[uint32]account
+	groups map[uint32]string
+}
+
+// fileKey identifies a version of a file
+type fileKey struct {
+	dev     uint64
+	ino     uint64
+	size    int64
+	modTime time.Time
+}
+
+// UserEnricher adds the names of the user and of its primary group to the
+// events, as found in the /etc/passwd and /etc/group files of the container
+// of the process. The files are only read again when they change.
+type UserEnricher struct {
+	// byPid caches the accounts used by processes
+	byPid *ttlCache[uint32, *accounts]
+
+	mu sync.Mutex
+	// byFiles caches the accounts read from the files, keyed by the
+	// versions of /etc/passwd and /etc/group
+	byFiles map[[2]fileKey]*accounts
+}
+
+func NewUserEnricher() *UserEnricher {
+	return &UserEnricher{
+		byPid:   newTTLCache[uint32, *accounts](cacheTTL),
+		byFiles: make(map[[2]fileKey]*accounts),
+	}
+}
+
+func (e *UserEnricher) EnrichByUID(data *types.EnrichedData, pid, uid uint32) {
+	accounts, ok := e.byPid.get(pid)
+	if !ok {
+		var err error
+		if accounts, err = e.accounts(pid); err != nil {
+			return
+		}
+		e.byPid.set(pid, accounts)
+	}
+
+	user, ok := accounts.users[uid]
+	if !ok {
+		return
+	}
+	data.User = user.name
+	data.Group = accounts.groups[user.gid]
+}
+
+// accounts returns the users and groups of the container of pid
+func (e *UserEnricher) accounts(pid uint32) (*accounts, error) {
+	etc := filepath.Join(hostRoot, fmt.Sprintf("/proc/%d/root/etc", pid))
+	passwdPath := filepath.Join(etc, "passwd")
+	groupPath := filepath.Join(etc, "group")
+
+	var key [2]fileKey
+	var err error
+	if key[0], err = statFile(passwdPath); err != nil {
+		return nil, err
+	}
+	// The group file is optional
+	key[1], _ = statFile(groupPath)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if a, ok := e.byFiles[key]; ok {
+		return a, nil
+	}
+
+	a := &accounts{
+		users:  make(map[uint32]account),
+		groups: make(map[uint32]string),
+	}
+
+	// name:password:UID:GID:GECOS:directory:shell
+	err = parseColonFile(passwdPath, func(fields []string) {
+		if len(fields) < 4 {
+			return
+		}
+		uid, err1 := strconv.ParseUint(fields[2], 10, 32)
+		gid, err2 := strconv.ParseUint(fields[3], 10, 32)
+		if err1 != nil || err2 != nil {
+			return
+		}
+		if _, ok := a.users[uint32(uid)]; !ok {
+			a.users[uint32(uid)] = account{name: fields[0], gid: uint32(gid)}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// name:password:GID:members
+	parseColonFile(groupPath, func(fields []string) {
+		if len(fields) < 3 {
+			return
+		}
+		gid, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return
+		}
+		if _, ok := a.groups[uint32(gid)]; !ok {
+			a.groups[uint32(gid)] = fields[0]
+		}
+	})
+
+	if len(e.byFiles) >= maxAccounts {
+		e.byFiles = make(map[[2]fileKey]*accounts)
+	}
+	e.byFiles[key] = a
+
+	return a, nil
+}
+
+func statFile(path string) (fileKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileKey{}, err
+	}
+
+	key := fileKey{
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		key.dev = uint64(stat.Dev)
+		key.ino = uint64(stat.Ino)
+	}
+
+	return key, nil
+}
+
+// parseColonFile calls fn with the fields of the lines of files like
+// /etc/passwd, skipping comments.
+func parseColonFile(path string, fn func(fields []string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fn(strings.Split(line, ":"))
+	}
+
+	return scanner.Err()
+}
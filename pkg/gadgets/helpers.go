@@ -17,9 +17,14 @@ package gadgets
 import (
 	"encoding/binary"
 	"net/netip"
+	"time"
 	"unsafe"
 
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/features"
 	"github.com/cilium/ebpf/link"
+	"golang.org/x/sys/unix"
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
@@ -100,3 +105,34 @@ func IPStringFromBytes(ipBytes [16]byte, ipType int) string {
 		return ""
 	}
 }
+
+// FixBpfKtimeGetBootNs replaces the calls to bpf_ktime_get_boot_ns() with
+// bpf_ktime_get_ns() in the given programs when the kernel doesn't support the
+// former (added in 5.8). The timestamps then don't include the time the system
+// was suspended, which is usually negligible on the nodes running the gadgets.
+func FixBpfKtimeGetBootNs(programSpecs map[string]*ebpf.ProgramSpec) {
+	for _, s := range programSpecs {
+		if features.HaveProgramHelper(s.Type, asm.FnKtimeGetBootNs) == nil {
+			continue
+		}
+
+		iter := s.Instructions.Iterate()
+		for iter.Next() {
+			in := iter.Ins
+			if in.IsBuiltinCall() && in.Constant == int64(asm.FnKtimeGetBootNs) {
+				in.Constant = int64(asm.FnKtimeGetNs)
+			}
+		}
+	}
+}
+
+// WallTimeFromBootTime converts a time obtained with bpf_ktime_get_boot_ns()
+// to the wall clock time. CLOCK_BOOTTIME is used because, contrary to
+// CLOCK_MONOTONIC, it also includes the time the system was suspended.
+func WallTimeFromBootTime(ts uint64) types.Time {
+	var now unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_BOOTTIME, &now); err != nil {
+		return types.Time(time.Now().UnixNano())
+	}
+	return types.Time(time.Now().Add(time.Duration(int64(ts) - now.Nano())).UnixNano())
+}
@@ -18,13 +18,18 @@
 package test
 
 import (
+	"bufio"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/cilium/ebpf"
 	"github.com/google/go-cmp/cmp"
 	"github.com/moby/moby/pkg/parsers/kernel"
 	"golang.org/x/sys/unix"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/cgroups"
 )
 
 // CreateMntNsFilterMap creates and fills an eBPF map that can be used
@@ -57,6 +62,69 @@ func CreateMntNsFilterMap(t testing.TB, mountNsIDs ...uint64) *ebpf.Map {
 	return m
 }
 
+// CreateCgroupIDFilterMap creates and fills an eBPF map that can be used
+// to filter by cgroup ID in the different tracers.
+func CreateCgroupIDFilterMap(t testing.TB, cgroupIDs ...uint64) *ebpf.Map {
+	t.Helper()
+
+	const one = uint32(1)
+
+	cgroupSpec := &ebpf.MapSpec{
+		Name:       "cgroup_map_" + t.Name(),
+		Type:       ebpf.Hash,
+		KeySize:    8,
+		ValueSize:  4,
+		MaxEntries: 1024,
+	}
+	m, err := ebpf.NewMap(cgroupSpec)
+	if err != nil {
+		t.Fatalf("Failed to create eBPF map: %s", err)
+	}
+	t.Cleanup(func() { m.Close() })
+
+	for _, cgroupID := range cgroupIDs {
+		if err := m.Put(cgroupID, one); err != nil {
+			m.Close()
+			t.Fatalf("Failed to update eBPF map: %s", err)
+		}
+	}
+
+	return m
+}
+
+// CurrentCgroupID returns the ID of the cgroup v2 of the current process. It
+// skips the test if cgroup v2 isn't available.
+func CurrentCgroupID(t testing.TB) uint64 {
+	t.Helper()
+
+	f, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		t.Fatalf("Failed to open cgroup file: %s", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		path, ok := strings.CutPrefix(scanner.Text(), "0::")
+		if !ok {
+			continue
+		}
+
+		pathWithMountpoint, err := cgroups.CgroupPathV2AddMountpoint(path)
+		if err != nil {
+			t.Skipf("cgroup v2 not available: %s", err)
+		}
+		cgroupID, err := cgroups.GetCgroupID(pathWithMountpoint)
+		if err != nil {
+			t.Fatalf("Failed to get cgroup ID: %s", err)
+		}
+		return cgroupID
+	}
+
+	t.Skip("cgroup v2 not available")
+	return 0
+}
+
 // RequireRoot skips the test if the not running as root
 func RequireRoot(t testing.TB) {
 	t.Helper()
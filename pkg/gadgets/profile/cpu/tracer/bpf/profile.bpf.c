@@ -9,6 +9,7 @@
 
 const volatile bool kernel_stacks_only = false;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 const volatile bool user_stacks_only = false;
 const volatile bool include_idle = false;
 const volatile pid_t targ_pid = -1;
@@ -36,6 +37,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 /*
  * If PAGE_OFFSET macro is not available in vmlinux.h, determine ip whose MSB
  * (Most Significant Bit) is 1 as the kernel address.
@@ -82,6 +101,8 @@ int ig_prof_cpu(struct bpf_perf_event_data *ctx)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	key.pid = pid;
 	key.mntns_id = mntns_id;
@@ -71,9 +71,10 @@ type profileProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type profileMapSpecs struct {
-	Counts        *ebpf.MapSpec `ebpf:"counts"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Stackmap      *ebpf.MapSpec `ebpf:"stackmap"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Counts         *ebpf.MapSpec `ebpf:"counts"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Stackmap       *ebpf.MapSpec `ebpf:"stackmap"`
 }
 
 // profileObjects contains all objects after they have been loaded into the kernel.
@@ -95,13 +96,15 @@ func (o *profileObjects) Close() error {
 //
 // It can be passed to loadProfileObjects or ebpf.CollectionSpec.LoadAndAssign.
 type profileMaps struct {
-	Counts        *ebpf.Map `ebpf:"counts"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Stackmap      *ebpf.Map `ebpf:"stackmap"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Counts         *ebpf.Map `ebpf:"counts"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Stackmap       *ebpf.Map `ebpf:"stackmap"`
 }
 
 func (m *profileMaps) Close() error {
 	return _ProfileClose(
+		m.CgroupIdFilter,
 		m.Counts,
 		m.MountNsFilter,
 		m.Stackmap,
@@ -131,5 +134,6 @@ func _ProfileClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed profile_bpfel_arm64.o
 var _ProfileBytes []byte
@@ -35,26 +35,29 @@ import (
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/profile/cpu/types"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/symbolizer"
 )
 
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target $TARGET -type key_t -cc clang profile ./bpf/profile.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 type Config struct {
 	MountnsMap      *ebpf.Map
+	CgroupIDMap     *ebpf.Map
 	UserStackOnly   bool
 	KernelStackOnly bool
 }
 
 type Tracer struct {
-	enricher gadgets.DataEnricherByMntNs
-	objs     profileObjects
-	perfFds  []int
-	config   *Config
+	enricher   gadgets.DataEnricherByMntNs
+	objs       profileObjects
+	perfFds    []int
+	config     *Config
+	symbolizer *symbolizer.Symbolizer
 }
 
 const (
 	perfMaxStackDepth = 127
-	perfSampleFreq    = 49
+	perfSampleFreq    = types.SampleFrequency
 	// In C, struct perf_event_attr has a freq field which is a bit in a
 	// 64-length bitfield.
 	// In Golang, there is a Bits field which 64 bits long.
@@ -65,8 +68,9 @@ const (
 
 func NewTracer(enricher gadgets.DataEnricherByMntNs, config *Config) (*Tracer, error) {
 	t := &Tracer{
-		enricher: enricher,
-		config:   config,
+		enricher:   enricher,
+		config:     config,
+		symbolizer: symbolizer.New(),
 	}
 
 	if err := t.start(); err != nil {
@@ -228,16 +232,17 @@ func getReport(t *Tracer, kAllSyms []kernelSymbol, stack *ebpf.Map, keyCount key
 		}
 	}
 
-	userSymbols := []string{}
+	userIPs := []uint64{}
 	for _, ip := range userInstructionPointers {
 		if ip == 0 {
 			break
 		}
 
-		// We will not support getting userland symbols.
-		userSymbols = append(userSymbols, "[unknown]")
+		userIPs = append(userIPs, ip)
 	}
 
+	userSymbols := t.symbolizer.Resolve(k.Pid, userIPs)
+
 	kernelSymbols := []string{}
 	for _, ip := range kernelInstructionPointers {
 		if ip == 0 {
@@ -333,6 +338,11 @@ func (t *Tracer) start() error {
 		"filter_by_mnt_ns":   filterByMntNs,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -24,6 +24,10 @@ const (
 	ProfileKernelParam = "kernel"
 )
 
+// SampleFrequency is the number of stack traces sampled per second on each
+// CPU.
+const SampleFrequency = 49
+
 type Report struct {
 	eventtypes.CommonData
 
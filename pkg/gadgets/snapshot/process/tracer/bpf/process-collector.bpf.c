@@ -19,7 +19,15 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 SEC("iter/task")
 int ig_snap_proc(struct bpf_iter__task *ctx)
@@ -39,6 +47,11 @@ int ig_snap_proc(struct bpf_iter__task *ctx)
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
 
+	__u64 cgroup_id = task->cgroups->dfl_cgrp->kn->id;
+
+	if (filter_by_cgroup_id && !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id))
+		return 0;
+
 	parent = task->real_parent;
 	if (!parent)
 		parent_pid = -1;
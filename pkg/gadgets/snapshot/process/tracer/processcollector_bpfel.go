@@ -61,7 +61,8 @@ type processCollectorProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type processCollectorMapSpecs struct {
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
 }
 
 // processCollectorObjects contains all objects after they have been loaded into the kernel.
@@ -83,11 +84,13 @@ func (o *processCollectorObjects) Close() error {
 //
 // It can be passed to loadProcessCollectorObjects or ebpf.CollectionSpec.LoadAndAssign.
 type processCollectorMaps struct {
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
 }
 
 func (m *processCollectorMaps) Close() error {
 	return _ProcessCollectorClose(
+		m.CgroupIdFilter,
 		m.MountNsFilter,
 	)
 }
@@ -115,5 +118,6 @@ func _ProcessCollectorClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed processcollector_bpfel.o
 var _ProcessCollectorBytes []byte
@@ -27,6 +27,7 @@ import (
 	"github.com/cilium/ebpf/link"
 
 	containerutils "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils/cgroups"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
 	processcollectortypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/snapshot/process/types"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
@@ -35,7 +36,8 @@ import (
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target bpfel -cc clang processCollector ./bpf/process-collector.bpf.c -- -I../../../../${TARGET} -Werror -O2 -g -c -x c
 
 type Config struct {
-	MountnsMap *ebpf.Map
+	MountnsMap  *ebpf.Map
+	CgroupIDMap *ebpf.Map
 }
 
 var hostRoot string
@@ -80,6 +82,11 @@ func runeBPFCollector(config *Config, enricher gadgets.DataEnricherByMntNs) ([]*
 		"filter_by_mnt_ns": filterByMntNs,
 	}
 
+	if config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return nil, fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -189,6 +196,16 @@ func getPidEvents(config *Config, enricher gadgets.DataEnricherByMntNs, pid int)
 			}
 		}
 
+		if config.CgroupIDMap != nil {
+			cgroupID, err := getCgroupID(tid)
+			if err != nil {
+				continue
+			}
+			if err := config.CgroupIDMap.Lookup(&cgroupID, &val); err != nil {
+				continue
+			}
+		}
+
 		f, err := os.Open(filepath.Join(hostRoot, fmt.Sprintf("/proc/%d/status", tid)))
 		if err != nil {
 			continue
@@ -232,6 +249,20 @@ func getPidEvents(config *Config, enricher gadgets.DataEnricherByMntNs, pid int)
 	return events, nil
 }
 
+// getCgroupID returns the cgroup2 ID of a process, as given by
+// bpf_get_current_cgroup_id()
+func getCgroupID(pid int) (uint64, error) {
+	_, cgroupPathV2, err := cgroups.GetCgroupPaths(pid)
+	if err != nil {
+		return 0, err
+	}
+	cgroupPathV2WithMountpoint, err := cgroups.CgroupPathV2AddMountpoint(cgroupPathV2)
+	if err != nil {
+		return 0, err
+	}
+	return cgroups.GetCgroupID(cgroupPathV2WithMountpoint)
+}
+
 func runProcfsCollector(config *Config, enricher gadgets.DataEnricherByMntNs) ([]*processcollectortypes.Event, error) {
 	items, err := os.ReadDir(filepath.Join(hostRoot, "/proc/"))
 	if err != nil {
@@ -91,10 +91,11 @@ type biotopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type biotopMapSpecs struct {
-	Counts        *ebpf.MapSpec `ebpf:"counts"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Start         *ebpf.MapSpec `ebpf:"start"`
-	Whobyreq      *ebpf.MapSpec `ebpf:"whobyreq"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Counts         *ebpf.MapSpec `ebpf:"counts"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Start          *ebpf.MapSpec `ebpf:"start"`
+	Whobyreq       *ebpf.MapSpec `ebpf:"whobyreq"`
 }
 
 // biotopObjects contains all objects after they have been loaded into the kernel.
@@ -116,14 +117,16 @@ func (o *biotopObjects) Close() error {
 //
 // It can be passed to loadBiotopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type biotopMaps struct {
-	Counts        *ebpf.Map `ebpf:"counts"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Start         *ebpf.Map `ebpf:"start"`
-	Whobyreq      *ebpf.Map `ebpf:"whobyreq"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Counts         *ebpf.Map `ebpf:"counts"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Start          *ebpf.Map `ebpf:"start"`
+	Whobyreq       *ebpf.Map `ebpf:"whobyreq"`
 }
 
 func (m *biotopMaps) Close() error {
 	return _BiotopClose(
+		m.CgroupIdFilter,
 		m.Counts,
 		m.MountNsFilter,
 		m.Start,
@@ -158,5 +161,6 @@ func _BiotopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed biotop_bpfel_arm64.o
 var _BiotopBytes []byte
@@ -9,6 +9,7 @@
 #include "maps.bpf.h"
 
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 struct {
 	__uint(type, BPF_MAP_TYPE_HASH);
@@ -38,6 +39,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 SEC("kprobe/blk_account_io_start")
 int BPF_KPROBE(ig_topio_start, struct request *req)
 {
@@ -49,6 +68,8 @@ int BPF_KPROBE(ig_topio_start, struct request *req)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	struct who_t who = {};
 
@@ -74,6 +95,8 @@ int BPF_KPROBE(ig_topio_req, struct request *req)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	start_req.ts = bpf_ktime_get_ns();
 	start_req.data_len = BPF_CORE_READ(req, __data_len);
@@ -38,11 +38,12 @@ import (
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target $TARGET -type info_t -type val_t -cc clang biotop ./bpf/biotop.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 type Config struct {
-	TargetPid  int
-	MaxRows    int
-	Interval   time.Duration
-	SortBy     []string
-	MountnsMap *ebpf.Map
+	TargetPid   int
+	MaxRows     int
+	Interval    time.Duration
+	SortBy      []string
+	MountnsMap  *ebpf.Map
+	CgroupIDMap *ebpf.Map
 }
 
 type Tracer struct {
@@ -150,6 +151,11 @@ func (t *Tracer) start() error {
 		"filter_by_mnt_ns": filterByMntNs,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -39,9 +39,9 @@ type Stats struct {
 	Write      bool   `json:"write,omitempty" column:"r/w,maxWidth:3"`
 	Major      int    `json:"major,omitempty" column:"major"`
 	Minor      int    `json:"minor,omitempty" column:"minor"`
-	Bytes      uint64 `json:"bytes,omitempty" column:"bytes"`
-	MicroSecs  uint64 `json:"us,omitempty" column:"time"`
-	Operations uint32 `json:"ops,omitempty" column:"ops"`
+	Bytes      uint64 `json:"bytes,omitempty" column:"bytes,group:sum,unit:B"`
+	MicroSecs  uint64 `json:"us,omitempty" column:"time,group:sum,unit:us"`
+	Operations uint32 `json:"ops,omitempty" column:"ops,group:sum"`
 	MountNsID  uint64 `json:"mountnsid,omitempty" column:"mountnsid,template:ns,hide"`
 }
 
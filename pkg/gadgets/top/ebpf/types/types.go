@@ -31,14 +31,14 @@ type Stats struct {
 	Type               string     `json:"type,omitempty" column:"type"`
 	Name               string     `json:"name,omitempty" column:"name"`
 	Pids               []*PidInfo `json:"pids,omitempty" column:"pid"`
-	CurrentRuntime     int64      `json:"currentRuntime,omitempty" column:"runtime,order:1001,align:right"`
-	CurrentRunCount    uint64     `json:"currentRunCount,omitempty" column:"runcount,order:1002,width:10"`
-	CumulativeRuntime  int64      `json:"cumulRuntime,omitempty" column:"cumulruntime,order:1003,hide"`
-	CumulativeRunCount uint64     `json:"cumulRunCount,omitempty" column:"cumulruncount,order:1004,hide"`
-	TotalRuntime       int64      `json:"totalRuntime,omitempty" column:"totalruntime,order:1005,align:right,hide"`
-	TotalRunCount      uint64     `json:"totalRunCount,omitempty" column:"totalRunCount,order:1006,align:right,hide"`
-	MapMemory          uint64     `json:"mapMemory,omitempty" column:"mapmemory,order:1007,align:right"`
-	MapCount           uint32     `json:"mapCount,omitempty" column:"mapcount,order:1008"`
+	CurrentRuntime     int64      `json:"currentRuntime,omitempty" column:"runtime,order:1001,align:right,group:sum"`
+	CurrentRunCount    uint64     `json:"currentRunCount,omitempty" column:"runcount,order:1002,width:10,group:sum"`
+	CumulativeRuntime  int64      `json:"cumulRuntime,omitempty" column:"cumulruntime,order:1003,hide,group:sum"`
+	CumulativeRunCount uint64     `json:"cumulRunCount,omitempty" column:"cumulruncount,order:1004,hide,group:sum"`
+	TotalRuntime       int64      `json:"totalRuntime,omitempty" column:"totalruntime,order:1005,align:right,hide,group:sum"`
+	TotalRunCount      uint64     `json:"totalRunCount,omitempty" column:"totalRunCount,order:1006,align:right,hide,group:sum"`
+	MapMemory          uint64     `json:"mapMemory,omitempty" column:"mapmemory,order:1007,align:right,group:sum"`
+	MapCount           uint32     `json:"mapCount,omitempty" column:"mapcount,order:1008,group:sum"`
 }
 
 func GetColumns() *columns.Columns[Stats] {
@@ -12,6 +12,7 @@
 const volatile pid_t target_pid = 0;
 const volatile bool regular_file_only = true;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 static struct file_stat zero_value = {};
 
 struct {
@@ -28,6 +29,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static void get_file_path(struct file *file, __u8 *buf, size_t size)
 {
 	struct qstr dname;
@@ -55,6 +74,8 @@ static int probe_entry(struct pt_regs *ctx, struct file *file, size_t count, enu
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	mode = BPF_CORE_READ(file, f_inode, i_mode);
 	if (regular_file_only && !S_ISREG(mode))
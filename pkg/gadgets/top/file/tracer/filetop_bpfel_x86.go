@@ -84,8 +84,9 @@ type filetopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type filetopMapSpecs struct {
-	Entries       *ebpf.MapSpec `ebpf:"entries"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Entries        *ebpf.MapSpec `ebpf:"entries"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
 }
 
 // filetopObjects contains all objects after they have been loaded into the kernel.
@@ -107,12 +108,14 @@ func (o *filetopObjects) Close() error {
 //
 // It can be passed to loadFiletopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type filetopMaps struct {
-	Entries       *ebpf.Map `ebpf:"entries"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Entries        *ebpf.Map `ebpf:"entries"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
 }
 
 func (m *filetopMaps) Close() error {
 	return _FiletopClose(
+		m.CgroupIdFilter,
 		m.Entries,
 		m.MountNsFilter,
 	)
@@ -143,5 +146,6 @@ func _FiletopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed filetop_bpfel_x86.o
 var _FiletopBytes []byte
@@ -35,12 +35,13 @@ import (
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target $TARGET -type file_stat -type file_id -cc clang filetop ./bpf/filetop.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 type Config struct {
-	MountnsMap *ebpf.Map
-	TargetPid  int
-	AllFiles   bool
-	MaxRows    int
-	Interval   time.Duration
-	SortBy     []string
+	MountnsMap  *ebpf.Map
+	CgroupIDMap *ebpf.Map
+	TargetPid   int
+	AllFiles    bool
+	MaxRows     int
+	Interval    time.Duration
+	SortBy      []string
 }
 
 type Tracer struct {
@@ -108,6 +109,11 @@ func (t *Tracer) start() error {
 		"filter_by_mnt_ns":  filterByMntNs,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -39,10 +39,10 @@ type Stats struct {
 	Pid        uint32 `json:"pid,omitempty" column:"pid,template:pid"`
 	Tid        uint32 `json:"tid,omitempty" column:"tid,template:pid,hide"`
 	Comm       string `json:"comm,omitempty" column:"comm,template:comm"`
-	Reads      uint64 `json:"reads,omitempty" column:"reads"`
-	Writes     uint64 `json:"writes,omitempty" column:"writes"`
-	ReadBytes  uint64 `json:"rbytes,omitempty" column:"rbytes"`
-	WriteBytes uint64 `json:"wbytes,omitempty" column:"wbytes"`
+	Reads      uint64 `json:"reads,omitempty" column:"reads,group:sum"`
+	Writes     uint64 `json:"writes,omitempty" column:"writes,group:sum"`
+	ReadBytes  uint64 `json:"rbytes,omitempty" column:"rbytes,group:sum,unit:B"`
+	WriteBytes uint64 `json:"wbytes,omitempty" column:"wbytes,group:sum,unit:B"`
 	MountNsID  uint64 `json:"mountnsid,omitempty" column:"mountnsid,template:ns,hide"`
 	FileType   byte   `json:"fileType,omitempty" column:"T,maxWidth:1"` // R = Regular File, S = Socket, O = Other
 	Filename   string `json:"filename,omitempty" column:"file"`
@@ -15,6 +15,7 @@
 const volatile pid_t target_pid = -1;
 const volatile int target_family = -1;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 struct {
 	__uint(type, BPF_MAP_TYPE_HASH);
@@ -30,6 +31,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static int probe_ip(bool receiving, struct sock *sk, size_t size)
 {
 	struct ip_key_t ip_key = {};
@@ -56,6 +75,8 @@ static int probe_ip(bool receiving, struct sock *sk, size_t size)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	ip_key.pid = pid;
 	bpf_get_current_comm(&ip_key.name, sizeof(ip_key.name));
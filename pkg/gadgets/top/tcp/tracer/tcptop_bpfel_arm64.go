@@ -79,8 +79,9 @@ type tcptopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type tcptopMapSpecs struct {
-	IpMap         *ebpf.MapSpec `ebpf:"ip_map"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	IpMap          *ebpf.MapSpec `ebpf:"ip_map"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
 }
 
 // tcptopObjects contains all objects after they have been loaded into the kernel.
@@ -102,12 +103,14 @@ func (o *tcptopObjects) Close() error {
 //
 // It can be passed to loadTcptopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type tcptopMaps struct {
-	IpMap         *ebpf.Map `ebpf:"ip_map"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	IpMap          *ebpf.Map `ebpf:"ip_map"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
 }
 
 func (m *tcptopMaps) Close() error {
 	return _TcptopClose(
+		m.CgroupIdFilter,
 		m.IpMap,
 		m.MountNsFilter,
 	)
@@ -138,5 +141,6 @@ func _TcptopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed tcptop_bpfel_arm64.o
 var _TcptopBytes []byte
@@ -37,6 +37,7 @@ import (
 
 type Config struct {
 	MountnsMap   *ebpf.Map
+	CgroupIDMap  *ebpf.Map
 	TargetPid    int32
 	TargetFamily int32
 	MaxRows      int
@@ -109,6 +110,11 @@ func (t *Tracer) start() error {
 		"target_family":    t.config.TargetFamily,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
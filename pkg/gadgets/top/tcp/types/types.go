@@ -53,8 +53,8 @@ type Stats struct {
 	Daddr     string `json:"daddr,omitempty" column:"daddr,template:ipaddr,hide"`
 	Sport     uint16 `json:"sport,omitempty" column:"sport,template:ipport,hide"`
 	Dport     uint16 `json:"dport,omitempty" column:"dport,template:ipport,hide"`
-	Sent      uint64 `json:"sent,omitempty" column:"sent,order:1002"`
-	Received  uint64 `json:"received,omitempty" column:"recv,order:1003"`
+	Sent      uint64 `json:"sent,omitempty" column:"sent,order:1002,group:sum,unit:B"`
+	Received  uint64 `json:"received,omitempty" column:"recv,order:1003,group:sum,unit:B"`
 }
 
 func GetColumns() *columns.Columns[Stats] {
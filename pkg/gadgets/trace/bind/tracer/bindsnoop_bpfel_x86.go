@@ -16,7 +16,7 @@ import (
 type bindsnoopBindEvent struct {
 	Addr       [16]uint8
 	MountNsId  uint64
-	TsUs       uint64
+	Timestamp  uint64
 	Pid        uint32
 	BoundDevIf uint32
 	Ret        int32
@@ -79,10 +79,11 @@ type bindsnoopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type bindsnoopMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Ports         *ebpf.MapSpec `ebpf:"ports"`
-	Sockets       *ebpf.MapSpec `ebpf:"sockets"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Ports          *ebpf.MapSpec `ebpf:"ports"`
+	Sockets        *ebpf.MapSpec `ebpf:"sockets"`
 }
 
 // bindsnoopObjects contains all objects after they have been loaded into the kernel.
@@ -104,14 +105,16 @@ func (o *bindsnoopObjects) Close() error {
 //
 // It can be passed to loadBindsnoopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type bindsnoopMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Ports         *ebpf.Map `ebpf:"ports"`
-	Sockets       *ebpf.Map `ebpf:"sockets"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Ports          *ebpf.Map `ebpf:"ports"`
+	Sockets        *ebpf.Map `ebpf:"sockets"`
 }
 
 func (m *bindsnoopMaps) Close() error {
 	return _BindsnoopClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 		m.Ports,
@@ -148,5 +151,6 @@ func _BindsnoopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed bindsnoop_bpfel_x86.o
 var _BindsnoopBytes []byte
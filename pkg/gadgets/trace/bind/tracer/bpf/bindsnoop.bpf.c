@@ -14,6 +14,7 @@ const volatile pid_t target_pid = 0;
 const volatile bool ignore_errors = true;
 const volatile bool filter_by_port = false;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 // we need this to make sure the compiler doesn't remove our struct
 const struct bind_event *unusedbindevent __attribute__((unused));
@@ -45,6 +46,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static int probe_entry(struct pt_regs *ctx, struct socket *socket)
 {
 	__u64 pid_tgid = bpf_get_current_pid_tgid();
@@ -82,6 +101,8 @@ static int probe_exit(struct pt_regs *ctx, short ver)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		goto cleanup;
+	if (filtered_by_cgroup_id())
+		goto cleanup;
 
 	ret = PT_REGS_RC(ctx);
 	if (ignore_errors && ret != 0)
@@ -102,7 +123,7 @@ static int probe_exit(struct pt_regs *ctx, short ver)
 	opts.fields.reuseaddress         = BPF_CORE_READ_BITFIELD_PROBED(sock, __sk_common.skc_reuse);
 	opts.fields.reuseport            = BPF_CORE_READ_BITFIELD_PROBED(sock, __sk_common.skc_reuseport);
 	event.opts = opts.data;
-	event.ts_us = bpf_ktime_get_ns() / 1000;
+	event.timestamp = bpf_ktime_get_boot_ns();
 	event.pid = pid;
 	event.port = sport;
 	event.bound_dev_if = BPF_CORE_READ(sock, __sk_common.skc_bound_dev_if);
@@ -37,6 +37,7 @@ import (
 
 type Config struct {
 	MountnsMap   *ebpf.Map
+	CgroupIDMap  *ebpf.Map
 	TargetPid    int32
 	TargetPorts  []uint16
 	IgnoreErrors bool
@@ -91,6 +92,8 @@ func (t *Tracer) start() error {
 		return fmt.Errorf("failed to load ebpf program: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	mapReplacements := map[string]*ebpf.Map{}
 	filterByMntNs := false
 
@@ -116,6 +119,11 @@ func (t *Tracer) start() error {
 		"ignore_errors":    t.config.IgnoreErrors,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -264,7 +272,8 @@ func (t *Tracer) run() {
 
 		event := types.Event{
 			Event: eventtypes.Event{
-				Type: eventtypes.NORMAL,
+				Type:      eventtypes.NORMAL,
+				Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
 			},
 			Pid:       bpfEvent.Pid,
 			Protocol:  protocolToString(bpfEvent.Proto),
@@ -326,6 +326,9 @@ func TestBindTracer(t *testing.T) {
 
 			events := []types.Event{}
 			eventCallback := func(event types.Event) {
+				// normalize
+				event.Timestamp = 0
+
 				events = append(events, event)
 			}
 
@@ -457,6 +460,9 @@ func TestBindTracerOpts(t *testing.T) {
 
 			events := []types.Event{}
 			eventCallback := func(event types.Event) {
+				// normalize
+				event.Timestamp = 0
+
 				events = append(events, event)
 			}
 
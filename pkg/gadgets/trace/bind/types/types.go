@@ -41,3 +41,13 @@ func Base(ev eventtypes.Event) Event {
 		Event: ev,
 	}
 }
+
+// GetPid is needed to implement the gadgets.EventWithPid interface.
+func (e Event) GetPid() uint32 {
+	return e.Pid
+}
+
+// GetAddrs is needed to implement the gadgets.EventWithAddrs interface.
+func (e Event) GetAddrs() (src, dst string) {
+	return e.Addr, ""
+}
@@ -8,6 +8,7 @@
 #define TASK_COMM_LEN	16
 
 struct cap_event {
+	__u64	timestamp;
 	__u64	mntnsid;
 	__u32	pid;
 	int	cap;
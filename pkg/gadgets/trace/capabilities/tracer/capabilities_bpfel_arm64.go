@@ -19,14 +19,15 @@ type capabilitiesArgsT struct {
 }
 
 type capabilitiesCapEvent struct {
-	Mntnsid uint64
-	Pid     uint32
-	Cap     int32
-	Tgid    uint32
-	Uid     uint32
-	CapOpt  int32
-	Ret     int32
-	Task    [16]uint8
+	Timestamp uint64
+	Mntnsid   uint64
+	Pid       uint32
+	Cap       int32
+	Tgid      uint32
+	Uid       uint32
+	CapOpt    int32
+	Ret       int32
+	Task      [16]uint8
 }
 
 type capabilitiesUniqueKey struct {
@@ -84,10 +85,11 @@ type capabilitiesProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type capabilitiesMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Seen          *ebpf.MapSpec `ebpf:"seen"`
-	Start         *ebpf.MapSpec `ebpf:"start"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Seen           *ebpf.MapSpec `ebpf:"seen"`
+	Start          *ebpf.MapSpec `ebpf:"start"`
 }
 
 // capabilitiesObjects contains all objects after they have been loaded into the kernel.
@@ -109,14 +111,16 @@ func (o *capabilitiesObjects) Close() error {
 //
 // It can be passed to loadCapabilitiesObjects or ebpf.CollectionSpec.LoadAndAssign.
 type capabilitiesMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Seen          *ebpf.Map `ebpf:"seen"`
-	Start         *ebpf.Map `ebpf:"start"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Seen           *ebpf.Map `ebpf:"seen"`
+	Start          *ebpf.Map `ebpf:"start"`
 }
 
 func (m *capabilitiesMaps) Close() error {
 	return _CapabilitiesClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 		m.Seen,
@@ -149,5 +153,6 @@ func _CapabilitiesClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed capabilities_bpfel_arm64.o
 var _CapabilitiesBytes []byte
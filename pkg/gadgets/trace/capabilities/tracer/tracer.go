@@ -35,9 +35,10 @@ import (
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target $TARGET -cc clang -type cap_event capabilities ./bpf/capable.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 type Config struct {
-	MountnsMap *ebpf.Map
-	AuditOnly  bool
-	Unique     bool
+	MountnsMap  *ebpf.Map
+	CgroupIDMap *ebpf.Map
+	AuditOnly   bool
+	Unique      bool
 }
 
 type Tracer struct {
@@ -135,6 +136,8 @@ func (t *Tracer) start() error {
 		return fmt.Errorf("failed to load ebpf program: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	mapReplacements := map[string]*ebpf.Map{}
 	filterByMntNs := false
 
@@ -150,6 +153,11 @@ func (t *Tracer) start() error {
 		"unique":             t.config.Unique,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -247,7 +255,8 @@ func (t *Tracer) run() {
 
 		event := types.Event{
 			Event: eventtypes.Event{
-				Type: eventtypes.NORMAL,
+				Type:      eventtypes.NORMAL,
+				Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
 			},
 			MountNsID: bpfEvent.Mntnsid,
 			Pid:       bpfEvent.Pid,
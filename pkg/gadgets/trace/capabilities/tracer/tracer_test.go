@@ -272,6 +272,9 @@ func TestCapabilitiesTracer(t *testing.T) {
 
 			events := []types.Event{}
 			eventCallback := func(event types.Event) {
+				// normalize
+				event.Timestamp = 0
+
 				events = append(events, event)
 			}
 
@@ -5,7 +5,13 @@
 // https://datatracker.ietf.org/doc/html/rfc1034#section-3.1
 #define MAX_DNS_NAME 255
 
+// Max length of the packet appended to response events. It's enough to
+// hold the answers of the vast majority of DNS responses over UDP.
+// https://datatracker.ietf.org/doc/html/rfc1035#section-4.2.1
+#define MAX_PKT_LEN 1024
+
 struct event_t {
+	__u64 timestamp;
 	union {
 		__u8 saddr_v6[16];
 		__u32 saddr_v4;
@@ -18,6 +24,11 @@ struct event_t {
 
 	__u16 id;
 	unsigned short qtype;
+	__u16 sport;
+	__u16 dport;
+
+	// number of answer entries, only set for responses
+	__u16 ancount;
 
 	// qr says if the dns message is a query (0), or a response (1)
 	unsigned char qr;
@@ -13,7 +13,8 @@
 
 #include "dns-common.h"
 
-#define DNS_OFF (ETH_HLEN + sizeof(struct iphdr) + sizeof(struct udphdr))
+#define UDP_OFF (ETH_HLEN + sizeof(struct iphdr))
+#define DNS_OFF (UDP_OFF + sizeof(struct udphdr))
 
 /* llvm builtin functions that eBPF C program may use to
  * emit BPF_LD_ABS and BPF_LD_IND instructions
@@ -117,6 +118,7 @@ int ig_trace_dns(struct __sk_buff *skb)
 		return 0;
 
 	struct event_t event = {0,};
+	event.timestamp = bpf_ktime_get_boot_ns();
 	event.id = load_half(skb, DNS_OFF + offsetof(struct dnshdr, id));
 	event.af = AF_INET;
 	event.daddr_v4 = load_word(skb, ETH_HLEN + offsetof(struct iphdr, daddr));
@@ -125,12 +127,15 @@ int ig_trace_dns(struct __sk_buff *skb)
 	// network endianness because inet_ntop() requires it.
 	event.daddr_v4 = bpf_htonl(event.daddr_v4);
 	event.saddr_v4 = bpf_htonl(event.saddr_v4);
+	event.sport = load_half(skb, UDP_OFF + offsetof(struct udphdr, source));
+	event.dport = load_half(skb, UDP_OFF + offsetof(struct udphdr, dest));
 
 	event.qr = flags.qr;
 
 	if (flags.qr == 1) {
-		// Response code set only for replies.
+		// Response code and answers set only for replies.
 		event.rcode = flags.rcode;
+		event.ancount = ancount;
 	}
 
 	bpf_skb_load_bytes(skb, DNS_OFF + sizeof(struct dnshdr), event.name, len);
@@ -141,7 +146,14 @@ int ig_trace_dns(struct __sk_buff *skb)
 	// https://datatracker.ietf.org/doc/html/rfc1035#section-4.1.2
 	event.qtype = load_half(skb, DNS_OFF + sizeof(struct dnshdr) + len + 1);
 
-	bpf_perf_event_output(skb, &events, BPF_F_CURRENT_CPU, &event, sizeof(event));
+	// Append the packet to responses with answers, the answer section is
+	// parsed in userspace as it's too complex to be handled here.
+	__u64 pkt_len = 0;
+	if (flags.qr == 1 && ancount > 0)
+		pkt_len = skb->len < MAX_PKT_LEN ? skb->len : MAX_PKT_LEN;
+
+	bpf_perf_event_output(skb, &events, (pkt_len << 32) | BPF_F_CURRENT_CPU,
+			      &event, sizeof(event));
 
 	return 0;
 }
@@ -14,16 +14,19 @@ import (
 )
 
 type dnsEventT struct {
-	SaddrV6 [16]uint8
-	DaddrV6 [16]uint8
-	Af      uint32
-	Id      uint16
-	Qtype   uint16
-	Qr      uint8
-	PktType uint8
-	Rcode   uint8
-	Name    [255]uint8
-	_       [2]byte
+	Timestamp uint64
+	SaddrV6   [16]uint8
+	DaddrV6   [16]uint8
+	Af        uint32
+	Id        uint16
+	Qtype     uint16
+	Sport     uint16
+	Dport     uint16
+	Ancount   uint16
+	Qr        uint8
+	PktType   uint8
+	Rcode     uint8
+	Name      [255]uint8
 }
 
 // loadDns returns the embedded CollectionSpec for dns.
@@ -128,5 +131,6 @@ func _DnsClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed dns_bpfel.o
 var _DnsBytes []byte
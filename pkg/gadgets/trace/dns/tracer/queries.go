@@ -0,0 +1,169 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// queryKey identifies a DNS query. A response matches a query when they have
+// the same DNS ID and were seen in the same network namespace between the
+// same client and server.
+type queryKey struct {
+	netns      uint64
+	id         string
+	clientIP   string
+	clientPort uint16
+	serverIP   string
+	serverPort uint16
+}
+
+type pendingQuery struct {
+	event         types.Event
+	seen          time.Time
+	eventCallback func(types.Event)
+}
+
+// queryTracker pairs DNS queries with their responses to compute the
+// latency, and reports queries without response after timeout.
+type queryTracker struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	queries map[queryKey]*pendingQuery
+
+	done chan struct{}
+}
+
+func newQueryTracker(timeout time.Duration) *queryTracker {
+	q := &queryTracker{
+		timeout: timeout,
+		queries: make(map[queryKey]*pendingQuery),
+		done:    make(chan struct{}),
+	}
+
+	go q.run()
+
+	return q
+}
+
+func (q *queryTracker) run() {
+	interval := time.Second
+	if q.timeout < interval {
+		interval = q.timeout
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.done:
+			return
+		case now := <-ticker.C:
+			q.expire(now)
+		}
+	}
+}
+
+// handle is called for each event traced in the network namespace netns.
+// Queries are kept until their response arrives or they time out, responses
+// get the latency set if the query was seen.
+func (q *queryTracker) handle(netns uint64, event types.Event, eventCallback func(types.Event)) {
+	if event.Type != eventtypes.NORMAL {
+		eventCallback(event)
+		return
+	}
+
+	switch event.Qr {
+	case types.DNSPktTypeQuery:
+		key := queryKey{
+			netns:      netns,
+			id:         event.ID,
+			clientIP:   event.SrcIP,
+			clientPort: event.SrcPort,
+			serverIP:   event.DstIP,
+			serverPort: event.DstPort,
+		}
+
+		q.mu.Lock()
+		// The same query can be seen more than once, e.g. when it goes
+		// through the loopback interface. Keep the first one.
+		if _, ok := q.queries[key]; !ok {
+			q.queries[key] = &pendingQuery{
+				event:         event,
+				seen:          time.Now(),
+				eventCallback: eventCallback,
+			}
+		}
+		q.mu.Unlock()
+	case types.DNSPktTypeResponse:
+		key := queryKey{
+			netns:      netns,
+			id:         event.ID,
+			clientIP:   event.DstIP,
+			clientPort: event.DstPort,
+			serverIP:   event.SrcIP,
+			serverPort: event.SrcPort,
+		}
+
+		q.mu.Lock()
+		query, ok := q.queries[key]
+		if ok {
+			delete(q.queries, key)
+		}
+		q.mu.Unlock()
+
+		if ok && event.Timestamp > query.event.Timestamp {
+			event.Latency = time.Duration(event.Timestamp - query.event.Timestamp)
+		}
+	}
+
+	eventCallback(event)
+}
+
+// expire reports the queries that didn't get a response in time.
+func (q *queryTracker) expire(now time.Time) {
+	var expired []*pendingQuery
+
+	q.mu.Lock()
+	for key, query := range q.queries {
+		if now.Sub(query.seen) >= q.timeout {
+			expired = append(expired, query)
+			delete(q.queries, key)
+		}
+	}
+	q.mu.Unlock()
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].seen.Before(expired[j].seen)
+	})
+
+	for _, query := range expired {
+		event := query.event
+		event.Timestamp = eventtypes.Time(now.UnixNano())
+		event.Rcode = types.RcodeTimeout
+		query.eventCallback(event)
+	}
+}
+
+func (q *queryTracker) close() {
+	close(q.done)
+}
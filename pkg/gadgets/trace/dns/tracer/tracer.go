@@ -17,9 +17,14 @@ package tracer
 import (
 	"errors"
 	"fmt"
+	"net"
 	"syscall"
+	"time"
 	"unsafe"
 
+	"golang.org/x/net/dns/dnsmessage"
+
+	containerutils "github.com/inspektor-gadget/inspektor-gadget/pkg/container-utils"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/internal/networktracer"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
@@ -34,16 +39,31 @@ const (
 	BPFSocketAttach = 50
 )
 
+type Config struct {
+	// DNSTimeout is the time after which a query without response is
+	// reported as timed out. types.DNSTimeoutDefault is used if it's zero.
+	DNSTimeout time.Duration
+}
+
 type Tracer struct {
 	*networktracer.Tracer[types.Event]
+
+	queries *queryTracker
 }
 
-func NewTracer() (*Tracer, error) {
+func NewTracer(config *Config) (*Tracer, error) {
 	spec, err := loadDns()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load asset: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
+	timeout := config.DNSTimeout
+	if timeout == 0 {
+		timeout = types.DNSTimeoutDefault
+	}
+
 	return &Tracer{
 		Tracer: networktracer.NewTracer(
 			spec,
@@ -53,9 +73,29 @@ func NewTracer() (*Tracer, error) {
 			types.Base,
 			parseDNSEvent,
 		),
+		queries: newQueryTracker(timeout),
 	}, nil
 }
 
+// Attach starts tracing the DNS packets in the network namespace of pid.
+// Responses are paired with their queries to compute the latency, and
+// queries without response are reported once they time out.
+func (t *Tracer) Attach(pid uint32, eventCallback func(types.Event)) error {
+	netns, err := containerutils.GetNetNs(int(pid))
+	if err != nil {
+		return fmt.Errorf("getting network namespace of pid %d: %w", pid, err)
+	}
+
+	return t.Tracer.Attach(pid, func(event types.Event) {
+		t.queries.handle(netns, event, eventCallback)
+	})
+}
+
+func (t *Tracer) Close() {
+	t.queries.close()
+	t.Tracer.Close()
+}
+
 // pkt_type definitions:
 // https://github.com/torvalds/linux/blob/v5.14-rc7/include/uapi/linux/if_packet.h#L26
 var pktTypeNames = []string{
@@ -165,6 +205,10 @@ var qTypeNames = map[uint]string{
 
 const MaxDNSName = int(unsafe.Sizeof(dnsEventT{}.Name))
 
+// dnsOff is the offset of the DNS message in the packets appended to the
+// events. Keep in sync with DNS_OFF in bpf/dns.c.
+const dnsOff = 14 + 20 + 8
+
 // DNS header RCODE (response code) field.
 // https://datatracker.ietf.org/doc/rfc1035#section-4.1.1
 var rCodeNames = map[uint8]string{
@@ -195,34 +239,87 @@ func parseLabelSequence(sample []byte) (ret string) {
 	return ret
 }
 
-func parseDNSEvent(rawSample []byte) (*types.Event, error) {
-	event := types.Event{
-		Event: eventtypes.Event{
-			Type: eventtypes.NORMAL,
-		},
+// parseAddresses returns the addresses (A and AAAA records) and canonical
+// names (CNAME records) found in the answer section of the DNS message
+// contained in pkt. Parsing stops at the first malformed or truncated record.
+func parseAddresses(pkt []byte) (addresses []string) {
+	if len(pkt) <= dnsOff {
+		return nil
 	}
 
+	var p dnsmessage.Parser
+	if _, err := p.Start(pkt[dnsOff:]); err != nil {
+		return nil
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return nil
+	}
+
+	for {
+		h, err := p.AnswerHeader()
+		if err != nil {
+			// dnsmessage.ErrSectionDone or truncated message
+			return addresses
+		}
+
+		switch h.Type {
+		case dnsmessage.TypeA:
+			r, err := p.AResource()
+			if err != nil {
+				return addresses
+			}
+			addresses = append(addresses, net.IP(r.A[:]).String())
+		case dnsmessage.TypeAAAA:
+			r, err := p.AAAAResource()
+			if err != nil {
+				return addresses
+			}
+			addresses = append(addresses, net.IP(r.AAAA[:]).String())
+		case dnsmessage.TypeCNAME:
+			r, err := p.CNAMEResource()
+			if err != nil {
+				return addresses
+			}
+			addresses = append(addresses, r.CNAME.String())
+		default:
+			if err := p.SkipAnswer(); err != nil {
+				return addresses
+			}
+		}
+	}
+}
+
+func parseDNSEvent(rawSample []byte) (*types.Event, error) {
 	bpfEvent := (*dnsEventT)(unsafe.Pointer(&rawSample[0]))
 	if len(rawSample) < int(unsafe.Sizeof(*bpfEvent)) {
 		return nil, errors.New("invalid sample size")
 	}
 
+	event := types.Event{
+		Event: eventtypes.Event{
+			Type:      eventtypes.NORMAL,
+			Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
+		},
+	}
+
 	event.ID = fmt.Sprintf("%.4x", bpfEvent.Id)
 
+	if bpfEvent.Af == syscall.AF_INET {
+		event.SrcIP = gadgets.IPStringFromBytes(bpfEvent.SaddrV6, 4)
+		event.DstIP = gadgets.IPStringFromBytes(bpfEvent.DaddrV6, 4)
+	} else if bpfEvent.Af == syscall.AF_INET6 {
+		event.SrcIP = gadgets.IPStringFromBytes(bpfEvent.SaddrV6, 6)
+		event.DstIP = gadgets.IPStringFromBytes(bpfEvent.DaddrV6, 6)
+	}
+	event.SrcPort = bpfEvent.Sport
+	event.DstPort = bpfEvent.Dport
+
 	if bpfEvent.Qr == 1 {
 		event.Qr = types.DNSPktTypeResponse
-		if bpfEvent.Af == syscall.AF_INET {
-			event.Nameserver = gadgets.IPStringFromBytes(bpfEvent.SaddrV6, 4)
-		} else if bpfEvent.Af == syscall.AF_INET6 {
-			event.Nameserver = gadgets.IPStringFromBytes(bpfEvent.SaddrV6, 6)
-		}
+		event.Nameserver = event.SrcIP
 	} else {
 		event.Qr = types.DNSPktTypeQuery
-		if bpfEvent.Af == syscall.AF_INET {
-			event.Nameserver = gadgets.IPStringFromBytes(bpfEvent.DaddrV6, 4)
-		} else if bpfEvent.Af == syscall.AF_INET6 {
-			event.Nameserver = gadgets.IPStringFromBytes(bpfEvent.DaddrV6, 6)
-		}
+		event.Nameserver = event.DstIP
 	}
 
 	// Convert name into a string with dots
@@ -248,6 +345,13 @@ func parseDNSEvent(rawSample []byte) (*types.Event, error) {
 		if !ok {
 			event.Rcode = "UNKNOWN"
 		}
+
+		event.NumAnswers = int(bpfEvent.Ancount)
+
+		// The packet is appended to the event only for responses with answers
+		if len(rawSample) > int(unsafe.Sizeof(*bpfEvent)) {
+			event.Addresses = parseAddresses(rawSample[unsafe.Sizeof(*bpfEvent):])
+		}
 	}
 
 	return &event, nil
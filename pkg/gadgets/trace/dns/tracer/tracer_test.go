@@ -15,7 +15,18 @@
 package tracer
 
 import (
+	"net"
+	"os"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	utilstest "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/internal/test"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
 
 func TestParsing(t *testing.T) {
@@ -67,3 +78,327 @@ func TestParsing(t *testing.T) {
 		}
 	}
 }
+
+func buildResponse(t *testing.T, answers ...dnsmessage.Resource) []byte {
+	t.Helper()
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 42, Response: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		t.Fatalf("starting questions: %s", err)
+	}
+	if err := b.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName("www.kinvolk.io."),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		t.Fatalf("adding question: %s", err)
+	}
+	if err := b.StartAnswers(); err != nil {
+		t.Fatalf("starting answers: %s", err)
+	}
+	for _, answer := range answers {
+		var err error
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			err = b.AResource(answer.Header, *body)
+		case *dnsmessage.AAAAResource:
+			err = b.AAAAResource(answer.Header, *body)
+		case *dnsmessage.CNAMEResource:
+			err = b.CNAMEResource(answer.Header, *body)
+		case *dnsmessage.TXTResource:
+			err = b.TXTResource(answer.Header, *body)
+		}
+		if err != nil {
+			t.Fatalf("adding answer: %s", err)
+		}
+	}
+
+	msg, err := b.Finish()
+	if err != nil {
+		t.Fatalf("building message: %s", err)
+	}
+
+	// Headers before the DNS message in the packet
+	return append(make([]byte, dnsOff), msg...)
+}
+
+func TestParseAddresses(t *testing.T) {
+	header := func(name string) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName(name),
+			Class: dnsmessage.ClassINET,
+			TTL:   60,
+		}
+	}
+
+	pkt := buildResponse(t,
+		dnsmessage.Resource{
+			Header: header("www.kinvolk.io."),
+			Body:   &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("kinvolk.io.")},
+		},
+		dnsmessage.Resource{
+			Header: header("kinvolk.io."),
+			Body:   &dnsmessage.TXTResource{TXT: []string{"ignored"}},
+		},
+		dnsmessage.Resource{
+			Header: header("kinvolk.io."),
+			Body:   &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}},
+		},
+		dnsmessage.Resource{
+			Header: header("kinvolk.io."),
+			Body:   &dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}},
+		},
+	)
+
+	expected := []string{"kinvolk.io.", "1.2.3.4", "2001:db8::1"}
+	if addresses := parseAddresses(pkt); !reflect.DeepEqual(addresses, expected) {
+		t.Fatalf("Failed to parse addresses: got %q, expected %q", addresses, expected)
+	}
+
+	// Truncated messages return the addresses parsed so far
+	expected = []string{"kinvolk.io.", "1.2.3.4"}
+	if addresses := parseAddresses(pkt[:len(pkt)-4]); !reflect.DeepEqual(addresses, expected) {
+		t.Fatalf("Failed to parse truncated addresses: got %q, expected %q", addresses, expected)
+	}
+
+	if addresses := parseAddresses(pkt[:dnsOff]); addresses != nil {
+		t.Fatalf("Failed to parse empty packet: got %q, expected nil", addresses)
+	}
+}
+
+func TestQueryTracker(t *testing.T) {
+	q := &queryTracker{
+		timeout: time.Second,
+		queries: make(map[queryKey]*pendingQuery),
+	}
+
+	var events []types.Event
+	eventCallback := func(event types.Event) {
+		events = append(events, event)
+	}
+
+	query := func(id string, ts eventtypes.Time) types.Event {
+		return types.Event{
+			Event:   eventtypes.Event{Type: eventtypes.NORMAL, Timestamp: ts},
+			ID:      id,
+			SrcIP:   "10.0.0.1",
+			SrcPort: 40000,
+			DstIP:   "8.8.4.4",
+			DstPort: 53,
+			Qr:      types.DNSPktTypeQuery,
+		}
+	}
+	response := func(id string, ts eventtypes.Time) types.Event {
+		return types.Event{
+			Event:   eventtypes.Event{Type: eventtypes.NORMAL, Timestamp: ts},
+			ID:      id,
+			SrcIP:   "8.8.4.4",
+			SrcPort: 53,
+			DstIP:   "10.0.0.1",
+			DstPort: 40000,
+			Qr:      types.DNSPktTypeResponse,
+		}
+	}
+
+	q.handle(1, query("0001", 1000), eventCallback)
+	q.handle(1, query("0002", 2000), eventCallback)
+	// Same query in a different network namespace
+	q.handle(2, query("0001", 3000), eventCallback)
+
+	q.handle(1, response("0001", 5000), eventCallback)
+	// Response without query
+	q.handle(1, response("0003", 6000), eventCallback)
+
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(events))
+	}
+	if events[3].Latency != 4000 {
+		t.Fatalf("Expected latency of 4µs, got %s", events[3].Latency)
+	}
+	if events[4].Latency != 0 {
+		t.Fatalf("Expected no latency for response without query, got %s", events[4].Latency)
+	}
+
+	// Nothing expires before the timeout
+	q.expire(time.Now())
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(events))
+	}
+
+	q.expire(time.Now().Add(2 * time.Second))
+	if len(events) != 7 {
+		t.Fatalf("Expected 7 events, got %d", len(events))
+	}
+	for _, event := range events[5:] {
+		if event.Qr != types.DNSPktTypeQuery || event.Rcode != types.RcodeTimeout {
+			t.Fatalf("Expected timed out query, got %+v", event)
+		}
+	}
+	if len(q.queries) != 0 {
+		t.Fatalf("Expected no pending queries, got %d", len(q.queries))
+	}
+}
+
+// TestDNSTracer checks the events decoded from the packets captured by the
+// loaded eBPF program, so that the layout of dnsEventT is validated against
+// the one of the compiled object.
+func TestDNSTracer(t *testing.T) {
+	utilstest.RequireRoot(t)
+
+	tracer, err := NewTracer(&Config{})
+	if err != nil {
+		t.Fatalf("Error creating tracer: %s", err)
+	}
+	t.Cleanup(tracer.Close)
+
+	var mu sync.Mutex
+	var events []types.Event
+	received := make(chan struct{}, 16)
+	if err := tracer.Attach(uint32(os.Getpid()), func(event types.Event) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+		received <- struct{}{}
+	}); err != nil {
+		t.Fatalf("Error attaching tracer: %s", err)
+	}
+
+	// Local DNS server answering every query with an A record
+	server, err := net.ListenPacket("udp4", "127.0.0.1:53")
+	if err != nil {
+		t.Fatalf("Error listening: %s", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	response := buildResponse(t, dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName("www.kinvolk.io."),
+			Class: dnsmessage.ClassINET,
+			TTL:   60,
+		},
+		Body: &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}},
+	})[dnsOff:]
+
+	go func() {
+		buf := make([]byte, 512)
+		_, addr, err := server.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		server.WriteTo(response, addr)
+	}()
+
+	query := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 42, RecursionDesired: true})
+	if err := query.StartQuestions(); err != nil {
+		t.Fatalf("starting questions: %s", err)
+	}
+	if err := query.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName("www.kinvolk.io."),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		t.Fatalf("adding question: %s", err)
+	}
+	msg, err := query.Finish()
+	if err != nil {
+		t.Fatalf("building message: %s", err)
+	}
+
+	conn, err := net.Dial("udp4", "127.0.0.1:53")
+	if err != nil {
+		t.Fatalf("Error dialing: %s", err)
+	}
+	defer conn.Close()
+	clientPort := uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatalf("Error sending query: %s", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Read(make([]byte, 512)); err != nil {
+		t.Fatalf("Error reading response: %s", err)
+	}
+
+	// The query is reported together with its response, wait for the latter
+	findResponse := func() *types.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range events {
+			if events[i].Qr == types.DNSPktTypeResponse {
+				return &events[i]
+			}
+		}
+		return nil
+	}
+
+	timeout := time.After(5 * time.Second)
+	for findResponse() == nil {
+		select {
+		case <-received:
+		case <-timeout:
+			t.Fatalf("Timeout waiting for the response event")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Packets on the loopback interface are seen twice, keep the first ones
+	var queryEvent, responseEvent *types.Event
+	for i := range events {
+		switch {
+		case events[i].Qr == types.DNSPktTypeQuery && queryEvent == nil:
+			queryEvent = &events[i]
+		case events[i].Qr == types.DNSPktTypeResponse && responseEvent == nil:
+			responseEvent = &events[i]
+		}
+	}
+	if queryEvent == nil {
+		t.Fatalf("No query event captured")
+	}
+
+	checkCommon := func(event *types.Event) {
+		t.Helper()
+
+		if event.ID != "002a" {
+			t.Fatalf("Expected ID 002a, got %q", event.ID)
+		}
+		if event.DNSName != "www.kinvolk.io." {
+			t.Fatalf("Expected name www.kinvolk.io., got %q", event.DNSName)
+		}
+		if event.QType != "A" {
+			t.Fatalf("Expected query type A, got %q", event.QType)
+		}
+		if event.Nameserver != "127.0.0.1" {
+			t.Fatalf("Expected nameserver 127.0.0.1, got %q", event.Nameserver)
+		}
+		if event.Timestamp == 0 {
+			t.Fatalf("Expected timestamp to be set")
+		}
+	}
+
+	checkCommon(queryEvent)
+	if queryEvent.SrcPort != clientPort || queryEvent.DstPort != 53 {
+		t.Fatalf("Expected query from port %d to 53, got %d to %d",
+			clientPort, queryEvent.SrcPort, queryEvent.DstPort)
+	}
+
+	checkCommon(responseEvent)
+	if responseEvent.SrcPort != 53 || responseEvent.DstPort != clientPort {
+		t.Fatalf("Expected response from port 53 to %d, got %d to %d",
+			clientPort, responseEvent.SrcPort, responseEvent.DstPort)
+	}
+	if responseEvent.Rcode != "NoError" {
+		t.Fatalf("Expected rcode NoError, got %q", responseEvent.Rcode)
+	}
+	if responseEvent.NumAnswers != 1 {
+		t.Fatalf("Expected 1 answer, got %d", responseEvent.NumAnswers)
+	}
+	if expected := []string{"1.2.3.4"}; !reflect.DeepEqual(responseEvent.Addresses, expected) {
+		t.Fatalf("Expected addresses %q, got %q", expected, responseEvent.Addresses)
+	}
+	if responseEvent.Latency <= 0 {
+		t.Fatalf("Expected latency to be set, got %s", responseEvent.Latency)
+	}
+}
@@ -15,6 +15,9 @@
 package types
 
 import (
+	"strings"
+	"time"
+
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
@@ -26,16 +29,31 @@ const (
 	DNSPktTypeResponse DNSPktType = "R"
 )
 
+const (
+	// RcodeTimeout is used as Rcode in the events reporting queries that
+	// didn't get any response.
+	RcodeTimeout = "Timeout"
+
+	DNSTimeoutDefault = 10 * time.Second
+)
+
 type Event struct {
 	eventtypes.Event
 
-	ID         string     `json:"id,omitempty" column:"id,width:4,fixed,hide"`
-	Qr         DNSPktType `json:"qr,omitempty" column:"qr,width:2,fixed"`
-	Nameserver string     `json:"nameserver,omitempty" column:"nameserver,template:ipaddr"`
-	PktType    string     `json:"pktType,omitempty" column:"type,minWidth:7,maxWidth:9"`
-	QType      string     `json:"qtype,omitempty" column:"qtype,minWidth:5,maxWidth:10"`
-	DNSName    string     `json:"name,omitempty" column:"name,width:30"`
-	Rcode      string     `json:"rcode,omitempty" column:"rcode,minWidth:8"`
+	ID         string        `json:"id,omitempty" column:"id,width:4,fixed,hide"`
+	SrcIP      string        `json:"srcIP,omitempty" column:"srcIP,template:ipaddr,hide"`
+	DstIP      string        `json:"dstIP,omitempty" column:"dstIP,template:ipaddr,hide"`
+	SrcPort    uint16        `json:"srcPort,omitempty" column:"srcPort,template:ipport,hide"`
+	DstPort    uint16        `json:"dstPort,omitempty" column:"dstPort,template:ipport,hide"`
+	Qr         DNSPktType    `json:"qr,omitempty" column:"qr,width:2,fixed"`
+	Nameserver string        `json:"nameserver,omitempty" column:"nameserver,template:ipaddr"`
+	PktType    string        `json:"pktType,omitempty" column:"type,minWidth:7,maxWidth:9"`
+	QType      string        `json:"qtype,omitempty" column:"qtype,minWidth:5,maxWidth:10"`
+	DNSName    string        `json:"name,omitempty" column:"name,width:30"`
+	Rcode      string        `json:"rcode,omitempty" column:"rcode,minWidth:8"`
+	Latency    time.Duration `json:"latency,omitempty" column:"latency,minWidth:8,maxWidth:12,align:right,unit:ns"`
+	NumAnswers int           `json:"numAnswers,omitempty" column:"numAnswers,width:10,maxWidth:10,align:right,hide"`
+	Addresses  []string      `json:"addresses,omitempty" column:"addresses,width:32,hide"`
 }
 
 func GetColumns() *columns.Columns[Event] {
@@ -44,6 +62,17 @@ func GetColumns() *columns.Columns[Event] {
 	col, _ := cols.GetColumn("container")
 	col.Visible = false
 
+	// Latency is only known for responses
+	cols.MustSetExtractor("latency", func(event *Event) string {
+		if event.Latency == 0 {
+			return ""
+		}
+		return event.Latency.String()
+	})
+	cols.MustSetExtractor("addresses", func(event *Event) string {
+		return strings.Join(event.Addresses, ",")
+	})
+
 	return cols
 }
 
@@ -11,6 +11,7 @@ const volatile bool ignore_failed = true;
 const volatile uid_t targ_uid = INVALID_UID;
 const volatile int max_args = DEFAULT_MAXARGS;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 static const struct event empty_event = {};
 
@@ -34,6 +35,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static __always_inline bool valid_uid(uid_t uid) {
 	return uid != INVALID_UID;
 }
@@ -67,6 +86,8 @@ int ig_execve_e(struct trace_event_raw_sys_enter* ctx)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	id = bpf_get_current_pid_tgid();
 	pid = (pid_t)id;
@@ -78,6 +99,7 @@ int ig_execve_e(struct trace_event_raw_sys_enter* ctx)
 	if (!event)
 		return 0;
 
+	event->timestamp = bpf_ktime_get_boot_ns();
 	event->pid = tgid;
 	event->uid = uid;
 	event->ppid = (pid_t)BPF_CORE_READ(task, real_parent, tgid);
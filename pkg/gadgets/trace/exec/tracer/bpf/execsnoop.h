@@ -13,6 +13,7 @@
 #define LAST_ARG (FULL_MAX_ARGS_ARR - ARGSIZE)
 
 struct event {
+	__u64 timestamp;
 	__u64 mntns_id;
 	__u32 pid;
 	__u32 ppid;
@@ -14,6 +14,7 @@ import (
 )
 
 type execsnoopEvent struct {
+	Timestamp uint64
 	MntnsId   uint64
 	Pid       uint32
 	Ppid      uint32
@@ -74,9 +75,10 @@ type execsnoopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type execsnoopMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	Execs         *ebpf.MapSpec `ebpf:"execs"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	Execs          *ebpf.MapSpec `ebpf:"execs"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
 }
 
 // execsnoopObjects contains all objects after they have been loaded into the kernel.
@@ -98,13 +100,15 @@ func (o *execsnoopObjects) Close() error {
 //
 // It can be passed to loadExecsnoopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type execsnoopMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	Execs         *ebpf.Map `ebpf:"execs"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	Execs          *ebpf.Map `ebpf:"execs"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
 }
 
 func (m *execsnoopMaps) Close() error {
 	return _ExecsnoopClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.Execs,
 		m.MountNsFilter,
@@ -136,5 +140,6 @@ func _ExecsnoopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed execsnoop_bpfel_x86.o
 var _ExecsnoopBytes []byte
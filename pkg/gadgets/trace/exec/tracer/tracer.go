@@ -34,7 +34,8 @@ import (
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -target ${TARGET} -cc clang -type event execsnoop ./bpf/execsnoop.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 type Config struct {
-	MountnsMap *ebpf.Map
+	MountnsMap  *ebpf.Map
+	CgroupIDMap *ebpf.Map
 }
 
 type Tracer struct {
@@ -82,6 +83,8 @@ func (t *Tracer) start() error {
 		return fmt.Errorf("failed to load ebpf program: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	mapReplacements := map[string]*ebpf.Map{}
 	filterByMntNs := false
 
@@ -94,6 +97,11 @@ func (t *Tracer) start() error {
 		"filter_by_mnt_ns": filterByMntNs,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -146,7 +154,8 @@ func (t *Tracer) run() {
 
 		event := types.Event{
 			Event: eventtypes.Event{
-				Type: eventtypes.NORMAL,
+				Type:      eventtypes.NORMAL,
+				Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
 			},
 			Pid:       bpfEvent.Pid,
 			Ppid:      bpfEvent.Ppid,
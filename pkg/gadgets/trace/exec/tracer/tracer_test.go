@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os/exec"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -176,6 +177,9 @@ func TestExecTracer(t *testing.T) {
 
 			events := []types.Event{}
 			eventCallback := func(event types.Event) {
+				// normalize
+				event.Timestamp = 0
+
 				events = append(events, event)
 			}
 
@@ -268,6 +272,71 @@ func TestExecTracerMultipleMntNsIDsFilter(t *testing.T) {
 	}
 }
 
+func TestExecTracerCgroupIDFilter(t *testing.T) {
+	t.Parallel()
+
+	utilstest.RequireRoot(t)
+
+	cgroupID := utilstest.CurrentCgroupID(t)
+
+	for name, test := range map[string]struct {
+		cgroupIDs []uint64
+		captured  bool
+	}{
+		"captures_no_events_with_no_matching_filter": {
+			cgroupIDs: []uint64{0},
+			captured:  false,
+		},
+		"captures_events_with_matching_filter": {
+			cgroupIDs: []uint64{cgroupID},
+			captured:  true,
+		},
+	} {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			events := []types.Event{}
+			eventCallback := func(event types.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				events = append(events, event)
+			}
+
+			config := &tracer.Config{
+				CgroupIDMap: utilstest.CreateCgroupIDFilterMap(t, test.cgroupIDs...),
+			}
+			createTracer(t, config, eventCallback)
+
+			catPid, err := generateEvent()
+			if err != nil {
+				t.Fatalf("Error generating event: %s", err)
+			}
+
+			// Give some time for the tracer to capture the events
+			time.Sleep(100 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			// Other processes in the same cgroup can be captured too
+			captured := false
+			for _, event := range events {
+				if event.Pid == uint32(catPid) {
+					captured = true
+					break
+				}
+			}
+			if captured != test.captured {
+				t.Fatalf("Event of pid %d captured: %t, expected %t", catPid, captured, test.captured)
+			}
+		})
+	}
+}
+
 func createTracer(
 	t *testing.T, config *tracer.Config, callback func(types.Event),
 ) *tracer.Tracer {
@@ -11,6 +11,7 @@
 const volatile pid_t target_pid = 0;
 const volatile __u64 min_lat_ns = 0;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 // we need this to make sure the compiler doesn't remove our struct
 const struct event *unusedevent __attribute__((unused));
@@ -42,6 +43,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static int probe_entry(struct file *fp, loff_t start, loff_t end)
 {
 	__u64 pid_tgid = bpf_get_current_pid_tgid();
@@ -64,6 +83,8 @@ static int probe_entry(struct file *fp, loff_t start, loff_t end)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	data.ts = bpf_ktime_get_ns();
 	data.start = start;
@@ -95,6 +116,8 @@ static int probe_exit(void *ctx, enum fs_file_op op, ssize_t size)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	datap = bpf_map_lookup_elem(&starts, &tid);
 	if (!datap)
@@ -107,6 +130,7 @@ static int probe_exit(void *ctx, enum fs_file_op op, ssize_t size)
 	if (delta_ns <= min_lat_ns)
 		return 0;
 
+	event.timestamp = bpf_ktime_get_boot_ns();
 	event.delta_us = delta_ns / 1000;
 	event.end_ns = end_ns;
 	event.offset = datap->start;
@@ -14,6 +14,7 @@ enum fs_file_op {
 };
 
 struct event {
+	__u64 timestamp;
 	__u64 delta_us;
 	__u64 end_ns;
 	__s64 offset;
@@ -14,15 +14,16 @@ import (
 )
 
 type fsslowerEvent struct {
-	DeltaUs uint64
-	EndNs   uint64
-	Offset  int64
-	Size    uint64
-	MntnsId uint64
-	Pid     uint32
-	Op      uint32
-	File    [32]uint8
-	Task    [16]uint8
+	Timestamp uint64
+	DeltaUs   uint64
+	EndNs     uint64
+	Offset    int64
+	Size      uint64
+	MntnsId   uint64
+	Pid       uint32
+	Op        uint32
+	File      [32]uint8
+	Task      [16]uint8
 }
 
 // loadFsslower returns the embedded CollectionSpec for fsslower.
@@ -80,9 +81,10 @@ type fsslowerProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type fsslowerMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Starts        *ebpf.MapSpec `ebpf:"starts"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Starts         *ebpf.MapSpec `ebpf:"starts"`
 }
 
 // fsslowerObjects contains all objects after they have been loaded into the kernel.
@@ -104,13 +106,15 @@ func (o *fsslowerObjects) Close() error {
 //
 // It can be passed to loadFsslowerObjects or ebpf.CollectionSpec.LoadAndAssign.
 type fsslowerMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Starts        *ebpf.Map `ebpf:"starts"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Starts         *ebpf.Map `ebpf:"starts"`
 }
 
 func (m *fsslowerMaps) Close() error {
 	return _FsslowerClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 		m.Starts,
@@ -154,5 +158,6 @@ func _FsslowerClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed fsslower_bpfel_x86.o
 var _FsslowerBytes []byte
@@ -34,7 +34,8 @@ import (
 //go:generate go run github.com/cilium/ebpf/cmd/bpf2go -no-global-types -target $TARGET -cc clang -type event fsslower ./bpf/fsslower.bpf.c -- -I./bpf/ -I../../../../${TARGET}
 
 type Config struct {
-	MountnsMap *ebpf.Map
+	MountnsMap  *ebpf.Map
+	CgroupIDMap *ebpf.Map
 
 	Filesystem string
 	MinLatency uint
@@ -140,6 +141,8 @@ func (t *Tracer) start() error {
 		return fmt.Errorf("failed to load ebpf program: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	mapReplacements := map[string]*ebpf.Map{}
 	filterByMntNs := false
 
@@ -153,6 +156,11 @@ func (t *Tracer) start() error {
 		"min_lat_ns":       uint64(t.config.MinLatency * 1000 * 1000),
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -246,7 +254,8 @@ func (t *Tracer) run() {
 
 		event := types.Event{
 			Event: eventtypes.Event{
-				Type: eventtypes.NORMAL,
+				Type:      eventtypes.NORMAL,
+				Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
 			},
 			MountNsID: bpfEvent.MntnsId,
 			Comm:      gadgets.FromCString(bpfEvent.Task[:]),
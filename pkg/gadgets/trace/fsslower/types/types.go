@@ -30,9 +30,9 @@ type Event struct {
 	Pid       uint32 `json:"pid,omitempty" column:"pid,template:pid"`
 	Comm      string `json:"comm,omitempty" column:"comm,template:comm"`
 	Op        string `json:"op,omitempty" column:"T,width:1,fixed"`
-	Bytes     uint64 `json:"bytes,omitempty" column:"bytes,width:10,align:right"`
+	Bytes     uint64 `json:"bytes,omitempty" column:"bytes,width:10,align:right,group:sum,unit:B"`
 	Offset    int64  `json:"offset,omitempty" column:"offset,width:10,align:right"`
-	Latency   uint64 `json:"latency,omitempty" column:"lat,width:10,align:right"`
+	Latency   uint64 `json:"latency,omitempty" column:"lat,width:10,align:right,group:avg,unit:us"`
 	File      string `json:"file,omitempty" column:"file,width:24,maxWidth:32"`
 }
 
@@ -45,3 +45,8 @@ func Base(ev eventtypes.Event) Event {
 		Event: ev,
 	}
 }
+
+// GetPid is needed to implement the gadgets.EventWithPid interface.
+func (e Event) GetPid() uint32 {
+	return e.Pid
+}
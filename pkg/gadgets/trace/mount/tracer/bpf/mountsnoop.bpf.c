@@ -9,6 +9,7 @@
 
 const volatile pid_t target_pid = 0;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 // we need this to make sure the compiler doesn't remove our struct
 const struct event *unusedevent __attribute__((unused));
@@ -40,6 +41,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 // TODO: have to use "inline" to avoid this error:
 // bpf/mountsnoop.bpf.c:41:12: error: defined with too many args
 // static int probe_entry(const char *src, const char *dest, const char *fs,
@@ -58,6 +77,8 @@ static inline int probe_entry(const char *src, const char *dest, const char *fs,
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	if (target_pid && target_pid != pid)
 		return 0;
@@ -90,6 +111,8 @@ static int probe_exit(void *ctx, int ret)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	argp = bpf_map_lookup_elem(&args, &tid);
 	if (!argp)
@@ -99,6 +122,7 @@ static int probe_exit(void *ctx, int ret)
 	if (!eventp)
 		return 0;
 
+	eventp->timestamp = bpf_ktime_get_boot_ns();
 	eventp->mount_ns_id = mntns_id;
 	eventp->delta = bpf_ktime_get_ns() - argp->ts;
 	eventp->flags = argp->flags;
@@ -23,6 +23,7 @@ struct arg {
 };
 
 struct event {
+	__u64 timestamp;
 	__u64 delta;
 	__u64 flags;
 	__u32 pid;
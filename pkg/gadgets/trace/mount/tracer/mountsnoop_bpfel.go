@@ -14,6 +14,7 @@ import (
 )
 
 type mountsnoopEvent struct {
+	Timestamp uint64
 	Delta     uint64
 	Flags     uint64
 	Pid       uint32
@@ -88,10 +89,11 @@ type mountsnoopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type mountsnoopMapSpecs struct {
-	Args          *ebpf.MapSpec `ebpf:"args"`
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	Heap          *ebpf.MapSpec `ebpf:"heap"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Args           *ebpf.MapSpec `ebpf:"args"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	Heap           *ebpf.MapSpec `ebpf:"heap"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
 }
 
 // mountsnoopObjects contains all objects after they have been loaded into the kernel.
@@ -113,15 +115,17 @@ func (o *mountsnoopObjects) Close() error {
 //
 // It can be passed to loadMountsnoopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type mountsnoopMaps struct {
-	Args          *ebpf.Map `ebpf:"args"`
-	Events        *ebpf.Map `ebpf:"events"`
-	Heap          *ebpf.Map `ebpf:"heap"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
+	Args           *ebpf.Map `ebpf:"args"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	Heap           *ebpf.Map `ebpf:"heap"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
 }
 
 func (m *mountsnoopMaps) Close() error {
 	return _MountsnoopClose(
 		m.Args,
+		m.CgroupIdFilter,
 		m.Events,
 		m.Heap,
 		m.MountNsFilter,
@@ -157,5 +161,6 @@ func _MountsnoopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed mountsnoop_bpfel.o
 var _MountsnoopBytes []byte
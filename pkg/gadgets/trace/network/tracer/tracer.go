@@ -123,6 +123,8 @@ func (t *Tracer) Attach(pid uint32) (err error) {
 		return fmt.Errorf("failed to load asset: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	consts := map[string]interface{}{
 		"container_netns": netns,
 	}
@@ -211,12 +213,14 @@ func (t *Tracer) Pop() (events []*types.Event, err error) {
 		t.cache = nil
 	}()
 
-	convertKeyToEvent := func(key graphmapGraphKeyT) *types.Event {
+	// The value of each entry is the last time the edge was seen
+	convertKeyToEvent := func(key graphmapGraphKeyT, val uint64) *types.Event {
 		ip := make(net.IP, 4)
 		binary.BigEndian.PutUint32(ip, gadgets.Htonl(key.Ip))
 		e := &types.Event{
 			Event: eventtypes.Event{
-				Type: eventtypes.NORMAL,
+				Type:      eventtypes.NORMAL,
+				Timestamp: gadgets.WallTimeFromBootTime(val),
 			},
 			PktType:    pktTypeString(int(key.PktType)),
 			Proto:      protoString(int(key.Proto)),
@@ -238,7 +242,7 @@ func (t *Tracer) Pop() (events []*types.Event, err error) {
 		deleteValues := make([]uint64, 256)
 		count, err := graphmap.BatchLookupAndDelete(nil, &nextKey, deleteKeys, deleteValues, nil)
 		for i := 0; i < count; i++ {
-			events = append(events, convertKeyToEvent(deleteKeys[i]))
+			events = append(events, convertKeyToEvent(deleteKeys[i], deleteValues[i]))
 		}
 		if errors.Is(err, ebpf.ErrKeyNotExist) {
 			return events, nil
@@ -257,7 +261,7 @@ func (t *Tracer) Pop() (events []*types.Event, err error) {
 	entries := graphmap.Iterate()
 
 	for entries.Next(&key, &val) {
-		events = append(events, convertKeyToEvent(key))
+		events = append(events, convertKeyToEvent(key, val))
 
 		// Deleting an entry during the iteration causes the iteration
 		// to restart from the first key in the hash map. But in this
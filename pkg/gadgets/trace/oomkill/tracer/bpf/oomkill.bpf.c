@@ -24,19 +24,34 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 SEC("kprobe/oom_kill_process")
 int BPF_KPROBE(ig_oom_kill, struct oom_control *oc, const char *message)
 {
 	struct data_t data;
-	u64 mntns_id;
+	u64 mntns_id, cgroup_id;
 
 	mntns_id = (u64) BPF_CORE_READ(oc, chosen, nsproxy, mnt_ns, ns.inum);
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
 
+	// The killed process is not the current one: Use its cgroup
+	cgroup_id = BPF_CORE_READ(oc, chosen, cgroups, dfl_cgrp, kn, id);
+
+	if (filter_by_cgroup_id && !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id))
+		return 0;
+
+	data.timestamp = bpf_ktime_get_boot_ns();
 	data.fpid = bpf_get_current_pid_tgid() >> 32;
 	data.tpid = BPF_CORE_READ(oc, chosen, tgid);
 	data.pages = BPF_CORE_READ(oc, totalpages);
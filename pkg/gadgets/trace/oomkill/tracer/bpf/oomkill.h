@@ -5,6 +5,7 @@
 #define TASK_COMM_LEN 16
 
 struct data_t {
+	__u64 timestamp;
 	__u32 fpid;
 	__u32 tpid;
 	__u64 pages;
@@ -14,6 +14,7 @@ import (
 )
 
 type oomkillDataT struct {
+	Timestamp uint64
 	Fpid      uint32
 	Tpid      uint32
 	Pages     uint64
@@ -70,8 +71,9 @@ type oomkillProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type oomkillMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
 }
 
 // oomkillObjects contains all objects after they have been loaded into the kernel.
@@ -93,12 +95,14 @@ func (o *oomkillObjects) Close() error {
 //
 // It can be passed to loadOomkillObjects or ebpf.CollectionSpec.LoadAndAssign.
 type oomkillMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
 }
 
 func (m *oomkillMaps) Close() error {
 	return _OomkillClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 	)
@@ -127,5 +131,6 @@ func _OomkillClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed oomkill_bpfel_arm64.o
 var _OomkillBytes []byte
@@ -13,7 +13,7 @@ struct args_t {
 
 struct event {
 	/* user terminology for pid: */
-	__u64 ts;
+	__u64 timestamp;
 	__u32 pid;
 	__u32 uid;
 	__u64 mntns_id;
@@ -14,15 +14,15 @@ import (
 )
 
 type opensnoopEvent struct {
-	Ts      uint64
-	Pid     uint32
-	Uid     uint32
-	MntnsId uint64
-	Ret     int32
-	Flags   int32
-	Comm    [16]uint8
-	Fname   [255]uint8
-	_       [1]byte
+	Timestamp uint64
+	Pid       uint32
+	Uid       uint32
+	MntnsId   uint64
+	Ret       int32
+	Flags     int32
+	Comm      [16]uint8
+	Fname     [255]uint8
+	_         [1]byte
 }
 
 // loadOpensnoop returns the embedded CollectionSpec for opensnoop.
@@ -76,9 +76,10 @@ type opensnoopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type opensnoopMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Start         *ebpf.MapSpec `ebpf:"start"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Start          *ebpf.MapSpec `ebpf:"start"`
 }
 
 // opensnoopObjects contains all objects after they have been loaded into the kernel.
@@ -100,13 +101,15 @@ func (o *opensnoopObjects) Close() error {
 //
 // It can be passed to loadOpensnoopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type opensnoopMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Start         *ebpf.Map `ebpf:"start"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Start          *ebpf.Map `ebpf:"start"`
 }
 
 func (m *opensnoopMaps) Close() error {
 	return _OpensnoopClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 		m.Start,
@@ -142,5 +145,6 @@ func _OpensnoopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed opensnoop_bpfel.o
 var _OpensnoopBytes []byte
@@ -207,6 +207,9 @@ func TestOpenTracer(t *testing.T) {
 
 			events := []types.Event{}
 			eventCallback := func(event types.Event) {
+				// normalize
+				event.Timestamp = 0
+
 				events = append(events, event)
 			}
 
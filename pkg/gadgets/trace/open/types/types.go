@@ -41,3 +41,13 @@ func Base(ev eventtypes.Event) Event {
 		Event: ev,
 	}
 }
+
+// GetPid is needed to implement the gadgets.EventWithPid interface.
+func (e Event) GetPid() uint32 {
+	return e.Pid
+}
+
+// GetUID is needed to implement the gadgets.EventWithUID interface.
+func (e Event) GetUID() uint32 {
+	return e.UID
+}
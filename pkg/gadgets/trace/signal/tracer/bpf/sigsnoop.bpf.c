@@ -11,6 +11,7 @@ const volatile pid_t filtered_pid = 0;
 const volatile int target_signal = 0;
 const volatile bool failed_only = false;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 // we need this to make sure the compiler doesn't remove our struct
 const struct event *unusedevent __attribute__((unused));
@@ -35,6 +36,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static int probe_entry(pid_t tpid, int sig)
 {
 	struct event event = {};
@@ -47,6 +66,8 @@ static int probe_entry(pid_t tpid, int sig)
 	mntns_id = (u64) BPF_CORE_READ(task, nsproxy, mnt_ns, ns.inum);
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	if (target_signal && sig != target_signal)
 		return 0;
@@ -57,6 +78,7 @@ static int probe_entry(pid_t tpid, int sig)
 	if (filtered_pid && pid != filtered_pid)
 		return 0;
 
+	event.timestamp = bpf_ktime_get_boot_ns();
 	event.pid = pid;
 	event.tpid = tpid;
 	event.sig = sig;
@@ -148,6 +170,8 @@ int ig_sig_generate(struct trace_event_raw_signal_generate *ctx)
 	mntns_id = (u64) BPF_CORE_READ(task, nsproxy, mnt_ns, ns.inum);
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	if (failed_only && ret == 0)
 		return 0;
@@ -160,6 +184,7 @@ int ig_sig_generate(struct trace_event_raw_signal_generate *ctx)
 	if (filtered_pid && pid != filtered_pid)
 		return 0;
 
+	event.timestamp = bpf_ktime_get_boot_ns();
 	event.pid = pid;
 	event.tpid = tpid;
 	event.mntns_id = mntns_id;
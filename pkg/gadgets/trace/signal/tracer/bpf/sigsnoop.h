@@ -5,6 +5,7 @@
 #define TASK_COMM_LEN	16
 
 struct event {
+	__u64 timestamp;
 	__u32 pid;
 	__u32 tpid;
 	__u64 mntns_id;
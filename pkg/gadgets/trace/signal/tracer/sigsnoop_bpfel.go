@@ -14,12 +14,13 @@ import (
 )
 
 type sigsnoopEvent struct {
-	Pid     uint32
-	Tpid    uint32
-	MntnsId uint64
-	Sig     int32
-	Ret     int32
-	Comm    [16]uint8
+	Timestamp uint64
+	Pid       uint32
+	Tpid      uint32
+	MntnsId   uint64
+	Sig       int32
+	Ret       int32
+	Comm      [16]uint8
 }
 
 // loadSigsnoop returns the embedded CollectionSpec for sigsnoop.
@@ -76,9 +77,10 @@ type sigsnoopProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type sigsnoopMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Values        *ebpf.MapSpec `ebpf:"values"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Values         *ebpf.MapSpec `ebpf:"values"`
 }
 
 // sigsnoopObjects contains all objects after they have been loaded into the kernel.
@@ -100,13 +102,15 @@ func (o *sigsnoopObjects) Close() error {
 //
 // It can be passed to loadSigsnoopObjects or ebpf.CollectionSpec.LoadAndAssign.
 type sigsnoopMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Values        *ebpf.Map `ebpf:"values"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Values         *ebpf.Map `ebpf:"values"`
 }
 
 func (m *sigsnoopMaps) Close() error {
 	return _SigsnoopClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 		m.Values,
@@ -148,5 +152,6 @@ func _SigsnoopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed sigsnoop_bpfel.o
 var _SigsnoopBytes []byte
@@ -29,6 +29,7 @@ import (
 
 type Config struct {
 	MountnsMap   *ebpf.Map
+	CgroupIDMap  *ebpf.Map
 	TargetSignal string
 	TargetPid    int32
 	FailedOnly   bool
@@ -121,6 +122,8 @@ func (t *Tracer) start() error {
 		return fmt.Errorf("failed to load ebpf program: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	mapReplacements := map[string]*ebpf.Map{}
 	filterByMntNs := false
 
@@ -141,6 +144,11 @@ func (t *Tracer) start() error {
 		"failed_only":      t.config.FailedOnly,
 	}
 
+	if t.config.CgroupIDMap != nil {
+		mapReplacements["cgroup_id_filter"] = t.config.CgroupIDMap
+		consts["filter_by_cgroup_id"] = true
+	}
+
 	if err := spec.RewriteConstants(consts); err != nil {
 		return fmt.Errorf("error RewriteConstants: %w", err)
 	}
@@ -224,7 +232,8 @@ func (t *Tracer) run() {
 
 		event := types.Event{
 			Event: eventtypes.Event{
-				Type: eventtypes.NORMAL,
+				Type:      eventtypes.NORMAL,
+				Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
 			},
 			Pid:       bpfEvent.Pid,
 			TargetPid: bpfEvent.Tpid,
@@ -36,6 +36,7 @@
 
 
 struct event_t {
+	__u64 timestamp;
 	__u8 name[TLS_MAX_SERVER_NAME_LEN];
 };
 
@@ -13,7 +13,10 @@ import (
 	"github.com/cilium/ebpf"
 )
 
-type snisnoopEventT struct{ Name [128]uint8 }
+type snisnoopEventT struct {
+	Timestamp uint64
+	Name      [128]uint8
+}
 
 // loadSnisnoop returns the embedded CollectionSpec for snisnoop.
 func loadSnisnoop() (*ebpf.CollectionSpec, error) {
@@ -117,5 +120,6 @@ func _SnisnoopClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed snisnoop_bpfel.o
 var _SnisnoopBytes []byte
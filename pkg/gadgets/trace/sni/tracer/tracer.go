@@ -15,7 +15,9 @@
 package tracer
 
 import (
+	"errors"
 	"fmt"
+	"unsafe"
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/internal/networktracer"
@@ -42,6 +44,8 @@ func NewTracer() (*Tracer, error) {
 		return nil, fmt.Errorf("failed to load asset: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	return &Tracer{
 		Tracer: networktracer.NewTracer(
 			spec,
@@ -55,18 +59,20 @@ func NewTracer() (*Tracer, error) {
 }
 
 func parseSNIEvent(sample []byte) (*types.Event, error) {
-	if len(sample) > TLSMaxServerNameLen {
-		sample = sample[:TLSMaxServerNameLen]
+	bpfEvent := (*snisnoopEventT)(unsafe.Pointer(&sample[0]))
+	if len(sample) < int(unsafe.Sizeof(*bpfEvent)) {
+		return nil, errors.New("invalid sample size")
 	}
 
-	name := gadgets.FromCString(sample)
+	name := gadgets.FromCString(bpfEvent.Name[:])
 	if len(name) == 0 {
 		return nil, nil
 	}
 
 	event := types.Event{
 		Event: eventtypes.Event{
-			Type: eventtypes.NORMAL,
+			Type:      eventtypes.NORMAL,
+			Timestamp: gadgets.WallTimeFromBootTime(bpfEvent.Timestamp),
 		},
 		Name: name,
 	}
@@ -14,6 +14,7 @@
 const volatile uid_t filter_uid = -1;
 const volatile pid_t filter_pid = 0;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 /* Define here, because there are conflicts with include files */
 #define AF_INET		2
@@ -76,6 +77,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 static __always_inline bool
 fill_tuple(struct tuple_key_t *tuple, struct sock *sk, int family)
 {
@@ -125,7 +144,7 @@ static __always_inline void
 fill_event(struct tuple_key_t *tuple, struct event *event, __u32 pid,
 	   __u32 uid, __u16 family, __u8 type, __u64 mntns_id)
 {
-	event->ts_us = bpf_ktime_get_ns() / 1000;
+	event->timestamp = bpf_ktime_get_boot_ns();
 	event->type = type;
 	event->pid = pid;
 	event->uid = uid;
@@ -155,6 +174,8 @@ filter_event(struct sock *sk, __u32 uid, __u32 pid, __u64 mntns_id)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return true;
+	if (filtered_by_cgroup_id())
+		return true;
 
 	if (filter_pid && pid != filter_pid)
 		return true;
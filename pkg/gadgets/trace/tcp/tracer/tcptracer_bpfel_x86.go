@@ -14,19 +14,19 @@ import (
 )
 
 type tcptracerEvent struct {
-	Saddr   [16]uint8
-	Daddr   [16]uint8
-	Task    [16]uint8
-	MntnsId uint64
-	TsUs    uint64
-	Af      uint32
-	Pid     uint32
-	Uid     uint32
-	Netns   uint32
-	Dport   uint16
-	Sport   uint16
-	Type    tcptracerEventType
-	_       [11]byte
+	Saddr     [16]uint8
+	Daddr     [16]uint8
+	Task      [16]uint8
+	MntnsId   uint64
+	Timestamp uint64
+	Af        uint32
+	Pid       uint32
+	Uid       uint32
+	Netns     uint32
+	Dport     uint16
+	Sport     uint16
+	Type      tcptracerEventType
+	_         [11]byte
 }
 
 type tcptracerEventType uint8
@@ -91,10 +91,11 @@ type tcptracerProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type tcptracerMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Sockets       *ebpf.MapSpec `ebpf:"sockets"`
-	Tuplepid      *ebpf.MapSpec `ebpf:"tuplepid"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Sockets        *ebpf.MapSpec `ebpf:"sockets"`
+	Tuplepid       *ebpf.MapSpec `ebpf:"tuplepid"`
 }
 
 // tcptracerObjects contains all objects after they have been loaded into the kernel.
@@ -116,14 +117,16 @@ func (o *tcptracerObjects) Close() error {
 //
 // It can be passed to loadTcptracerObjects or ebpf.CollectionSpec.LoadAndAssign.
 type tcptracerMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Sockets       *ebpf.Map `ebpf:"sockets"`
-	Tuplepid      *ebpf.Map `ebpf:"tuplepid"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Sockets        *ebpf.Map `ebpf:"sockets"`
+	Tuplepid       *ebpf.Map `ebpf:"tuplepid"`
 }
 
 func (m *tcptracerMaps) Close() error {
 	return _TcptracerClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.MountNsFilter,
 		m.Sockets,
@@ -166,5 +169,6 @@ func _TcptracerClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed tcptracer_bpfel_x86.o
 var _TcptracerBytes []byte
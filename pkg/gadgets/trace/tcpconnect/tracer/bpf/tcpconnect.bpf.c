@@ -17,6 +17,7 @@ const volatile uid_t filter_uid = -1;
 const volatile pid_t filter_pid = 0;
 const volatile bool do_count = 0;
 const volatile bool filter_by_mnt_ns = false;
+const volatile bool filter_by_cgroup_id = false;
 
 /* Define here, because there are conflicts with include files */
 #define AF_INET		2
@@ -59,6 +60,24 @@ struct {
 	__uint(value_size, sizeof(u32));
 } mount_ns_filter SEC(".maps");
 
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__uint(key_size, sizeof(u64));
+	__uint(value_size, sizeof(u32));
+} cgroup_id_filter SEC(".maps");
+
+static __always_inline bool filtered_by_cgroup_id(void)
+{
+	u64 cgroup_id;
+
+	if (!filter_by_cgroup_id)
+		return false;
+
+	cgroup_id = bpf_get_current_cgroup_id();
+	return !bpf_map_lookup_elem(&cgroup_id_filter, &cgroup_id);
+}
+
 
 static __always_inline bool filter_port(__u16 port)
 {
@@ -136,7 +155,7 @@ trace_v4(struct pt_regs *ctx, pid_t pid, struct sock *sk, __u16 dport, __u64 mnt
 	event.af = AF_INET;
 	event.pid = pid;
 	event.uid = bpf_get_current_uid_gid();
-	event.ts_us = bpf_ktime_get_ns() / 1000;
+	event.timestamp = bpf_ktime_get_boot_ns();
 	BPF_CORE_READ_INTO(&event.saddr_v4, sk, __sk_common.skc_rcv_saddr);
 	BPF_CORE_READ_INTO(&event.daddr_v4, sk, __sk_common.skc_daddr);
 	event.dport = dport;
@@ -155,7 +174,7 @@ trace_v6(struct pt_regs *ctx, pid_t pid, struct sock *sk, __u16 dport, __u64 mnt
 	event.af = AF_INET6;
 	event.pid = pid;
 	event.uid = bpf_get_current_uid_gid();
-	event.ts_us = bpf_ktime_get_ns() / 1000;
+	event.timestamp = bpf_ktime_get_boot_ns();
 	event.mntns_id = mntns_id;
 	BPF_CORE_READ_INTO(&event.saddr_v6, sk,
 			   __sk_common.skc_v6_rcv_saddr.in6_u.u6_addr32);
@@ -198,6 +217,8 @@ exit_tcp_connect(struct pt_regs *ctx, int ret, int ip_ver)
 
 	if (filter_by_mnt_ns && !bpf_map_lookup_elem(&mount_ns_filter, &mntns_id))
 		return 0;
+	if (filtered_by_cgroup_id())
+		return 0;
 
 	if (do_count) {
 		if (ip_ver == 4)
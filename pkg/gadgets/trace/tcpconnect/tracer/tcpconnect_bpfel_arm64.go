@@ -14,16 +14,16 @@ import (
 )
 
 type tcpconnectEvent struct {
-	SaddrV6 [16]uint8
-	DaddrV6 [16]uint8
-	Task    [16]uint8
-	TsUs    uint64
-	Af      uint32
-	Pid     uint32
-	Uid     uint32
-	Dport   uint16
-	_       [2]byte
-	MntnsId uint64
+	SaddrV6   [16]uint8
+	DaddrV6   [16]uint8
+	Task      [16]uint8
+	Timestamp uint64
+	Af        uint32
+	Pid       uint32
+	Uid       uint32
+	Dport     uint16
+	_         [2]byte
+	MntnsId   uint64
 }
 
 type tcpconnectIpv4FlowKey struct {
@@ -90,11 +90,12 @@ type tcpconnectProgramSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type tcpconnectMapSpecs struct {
-	Events        *ebpf.MapSpec `ebpf:"events"`
-	Ipv4Count     *ebpf.MapSpec `ebpf:"ipv4_count"`
-	Ipv6Count     *ebpf.MapSpec `ebpf:"ipv6_count"`
-	MountNsFilter *ebpf.MapSpec `ebpf:"mount_ns_filter"`
-	Sockets       *ebpf.MapSpec `ebpf:"sockets"`
+	CgroupIdFilter *ebpf.MapSpec `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.MapSpec `ebpf:"events"`
+	Ipv4Count      *ebpf.MapSpec `ebpf:"ipv4_count"`
+	Ipv6Count      *ebpf.MapSpec `ebpf:"ipv6_count"`
+	MountNsFilter  *ebpf.MapSpec `ebpf:"mount_ns_filter"`
+	Sockets        *ebpf.MapSpec `ebpf:"sockets"`
 }
 
 // tcpconnectObjects contains all objects after they have been loaded into the kernel.
@@ -116,15 +117,17 @@ func (o *tcpconnectObjects) Close() error {
 //
 // It can be passed to loadTcpconnectObjects or ebpf.CollectionSpec.LoadAndAssign.
 type tcpconnectMaps struct {
-	Events        *ebpf.Map `ebpf:"events"`
-	Ipv4Count     *ebpf.Map `ebpf:"ipv4_count"`
-	Ipv6Count     *ebpf.Map `ebpf:"ipv6_count"`
-	MountNsFilter *ebpf.Map `ebpf:"mount_ns_filter"`
-	Sockets       *ebpf.Map `ebpf:"sockets"`
+	CgroupIdFilter *ebpf.Map `ebpf:"cgroup_id_filter"`
+	Events         *ebpf.Map `ebpf:"events"`
+	Ipv4Count      *ebpf.Map `ebpf:"ipv4_count"`
+	Ipv6Count      *ebpf.Map `ebpf:"ipv6_count"`
+	MountNsFilter  *ebpf.Map `ebpf:"mount_ns_filter"`
+	Sockets        *ebpf.Map `ebpf:"sockets"`
 }
 
 func (m *tcpconnectMaps) Close() error {
 	return _TcpconnectClose(
+		m.CgroupIdFilter,
 		m.Events,
 		m.Ipv4Count,
 		m.Ipv6Count,
@@ -162,5 +165,6 @@ func _TcpconnectClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed tcpconnect_bpfel_arm64.o
 var _TcpconnectBytes []byte
@@ -107,6 +107,8 @@ func NewTracer(enricher gadgets.DataEnricherByMntNs) (*Tracer, error) {
 		return nil, fmt.Errorf("loading ebpf program: %w", err)
 	}
 
+	gadgets.FixBpfKtimeGetBootNs(spec.Programs)
+
 	syscallsOnce.Do(func() {
 		syscallsDeclarations, err = gatherSyscallsDeclarations()
 	})
@@ -335,9 +337,9 @@ func (t *Tracer) Read(containerID string) ([]*types.Event, error) {
 
 			event := &types.Event{
 				Event: eventtypes.Event{
-					Type: eventtypes.NORMAL,
+					Type:      eventtypes.NORMAL,
+					Timestamp: gadgets.WallTimeFromBootTime(enterTimestamp),
 				},
-				Timestamp: enterTimestamp,
 				CPU:       enterEvent.cpu,
 				Pid:       enterEvent.pid,
 				Comm:      enterEvent.comm,
@@ -432,8 +434,8 @@ func (t *Tracer) Read(containerID string) ([]*types.Event, error) {
 	// but they will be incomplete.
 	// One possible reason would be that the buffer is full and so it only remains
 	// some exit events and not the corresponding enter/
-	for _, enterTimestampEvents := range syscallEnterEventsMap {
-		for enterTimestamp, enterEvent := range enterTimestampEvents {
+	for enterTimestamp, enterTimestampEvents := range syscallEnterEventsMap {
+		for _, enterEvent := range enterTimestampEvents {
 			syscallName, err := syscallGetName(enterEvent.id)
 			if err != nil {
 				// It is best effort, so just long and continue in case of troubles.
@@ -444,9 +446,9 @@ func (t *Tracer) Read(containerID string) ([]*types.Event, error) {
 
 			incompleteEnterEvent := &types.Event{
 				Event: eventtypes.Event{
-					Type: eventtypes.NORMAL,
+					Type:      eventtypes.NORMAL,
+					Timestamp: gadgets.WallTimeFromBootTime(enterTimestamp),
 				},
-				Timestamp: uint64(enterTimestamp),
 				CPU:       enterEvent.cpu,
 				Pid:       enterEvent.pid,
 				Comm:      enterEvent.comm,
@@ -464,8 +466,8 @@ func (t *Tracer) Read(containerID string) ([]*types.Event, error) {
 		}
 	}
 
-	for _, exitTimestampEvents := range syscallExitEventsMap {
-		for exitTimestamp, exitEvent := range exitTimestampEvents {
+	for exitTimestamp, exitTimestampEvents := range syscallExitEventsMap {
+		for _, exitEvent := range exitTimestampEvents {
 			syscallName, err := syscallGetName(exitEvent.id)
 			if err != nil {
 				log.Errorf("incomplete exit event: getting name of syscall number %d: %v", exitEvent.id, err)
@@ -475,9 +477,9 @@ func (t *Tracer) Read(containerID string) ([]*types.Event, error) {
 
 			incompleteExitEvent := &types.Event{
 				Event: eventtypes.Event{
-					Type: eventtypes.NORMAL,
+					Type:      eventtypes.NORMAL,
+					Timestamp: gadgets.WallTimeFromBootTime(exitTimestamp),
 				},
-				Timestamp: uint64(exitTimestamp),
 				CPU:       exitEvent.cpu,
 				Pid:       exitEvent.pid,
 				Comm:      exitEvent.comm,
@@ -0,0 +1,440 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gadgetservice implements the GadgetService gRPC service, running
+// the gadgets of the gadget collection on the node without Trace resources.
+// It's used by the gadget DaemonSet and local-gadget.
+package gadgetservice
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	gadgetstream "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
+)
+
+// traceNamespace is the namespace of the traces created to run the gadgets,
+// they aren't Kubernetes resources
+const traceNamespace = "gadget-service"
+
+// TracerManager is the interface that GadgetTracerManager and
+// LocalGadgetManager implement to run gadgets.
+type TracerManager interface {
+	gadgets.GadgetHelpers
+
+	AddTracer(tracerID string, containerSelector containercollection.ContainerSelector) error
+	RemoveTracer(tracerID string) error
+	Stream(tracerID string) (*gadgetstream.GadgetStream, error)
+}
+
+// enricherManager is implemented by the tracer managers supporting
+// enrichers
+type enricherManager interface {
+	ValidateEnrichers(names []string) error
+	SetTracerEnrichers(tracerID string, names []string) error
+}
+
+// gadgetRun is a gadget started by a client
+type gadgetRun struct {
+	id      string
+	trace   *gadgetv1alpha1.Trace
+	factory gadgets.TraceFactory
+}
+
+func (r *gadgetRun) namespacedName() string {
+	return r.trace.ObjectMeta.Namespace + "/" + r.trace.ObjectMeta.Name
+}
+
+func (r *gadgetRun) tracerID() string {
+	return gadgets.TraceName(r.trace.ObjectMeta.Namespace, r.trace.ObjectMeta.Name)
+}
+
+func (r *gadgetRun) proto() *pb.GadgetRun {
+	spec := &r.trace.Spec
+	run := &pb.GadgetRun{
+		Id:               r.id,
+		Gadget:           spec.Gadget,
+		OutputMode:       string(spec.OutputMode),
+		Parameters:       spec.Parameters,
+		State:            string(r.trace.Status.State),
+		Output:           r.trace.Status.Output,
+		OperationError:   r.trace.Status.OperationError,
+		OperationWarning: r.trace.Status.OperationWarning,
+	}
+	if f := spec.Filter; f != nil {
+		run.Selector = &pb.ContainerSelector{
+			Namespace:     f.Namespace,
+			Podname:       f.Podname,
+			Labels:        f.Labels,
+			ContainerName: f.ContainerName,
+			Image:         f.Image,
+		}
+	}
+	return run
+}
+
+// Service runs the gadgets requested by the clients of the GadgetService
+// gRPC service on a node.
+type Service struct {
+	pb.UnimplementedGadgetServiceServer
+
+	node      string
+	manager   TracerManager
+	factories map[string]gadgets.TraceFactory
+
+	// mu protects runs and serializes the operations on the gadgets, as
+	// the controller does for the Trace resources
+	mu   sync.Mutex
+	runs map[string]*gadgetRun
+}
+
+// New creates a service running the gadgets of the given factories on node,
+// using the manager to resolve the containers and to publish the events.
+func New(manager TracerManager, factories map[string]gadgets.TraceFactory, node string) *Service {
+	for _, factory := range factories {
+		factory.Initialize(manager, nil)
+	}
+
+	return &Service{
+		node:      node,
+		manager:   manager,
+		factories: factories,
+		runs:      make(map[string]*gadgetRun),
+	}
+}
+
+// outputModes returns the output modes supported by both the gadget and the
+// service, the preferred one first
+func outputModes(factory gadgets.TraceFactory) (ret []gadgetv1alpha1.TraceOutputMode) {
+	supported := factory.OutputModesSupported()
+	for _, mode := range []gadgetv1alpha1.TraceOutputMode{
+		gadgetv1alpha1.TraceOutputModeStream,
+		gadgetv1alpha1.TraceOutputModeStatus,
+	} {
+		if _, ok := supported[mode]; ok {
+			ret = append(ret, mode)
+		}
+	}
+	return ret
+}
+
+func (s *Service) ListGadgets(_ context.Context, _ *pb.ListGadgetsRequest) (*pb.GadgetList, error) {
+	list := &pb.GadgetList{}
+
+	for name, factory := range s.factories {
+		modes := outputModes(factory)
+		if len(modes) == 0 {
+			continue
+		}
+
+		info := &pb.GadgetInfo{Name: name}
+		if f, ok := factory.(gadgets.TraceFactoryWithDocumentation); ok {
+			info.Description = f.Description()
+		}
+		for _, mode := range modes {
+			info.OutputModes = append(info.OutputModes, string(mode))
+		}
+		for op, traceOp := range factory.Operations() {
+			info.Operations = append(info.Operations, &pb.GadgetOperation{
+				Name: string(op),
+				Doc:  traceOp.Doc,
+			})
+		}
+		sort.Slice(info.Operations, func(i, j int) bool {
+			return info.Operations[i].Name < info.Operations[j].Name
+		})
+		if f, ok := factory.(gadgets.TraceFactoryWithParameters); ok {
+			for _, p := range f.Parameters() {
+				info.Parameters = append(info.Parameters, &pb.GadgetParameter{
+					Name:         p.Name,
+					Doc:          p.Doc,
+					DefaultValue: p.Default,
+				})
+			}
+		}
+
+		list.Gadgets = append(list.Gadgets, info)
+	}
+
+	sort.Slice(list.Gadgets, func(i, j int) bool {
+		return list.Gadgets[i].Name < list.Gadgets[j].Name
+	})
+
+	return list, nil
+}
+
+func (s *Service) StartGadget(_ context.Context, req *pb.StartGadgetRequest) (*pb.GadgetRun, error) {
+	factory, ok := s.factories[req.Gadget]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown gadget %q", req.Gadget)
+	}
+
+	modes := outputModes(factory)
+	if len(modes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "gadget %q can't be run by the gadget service", req.Gadget)
+	}
+	outputMode := modes[0]
+	if req.OutputMode != "" {
+		outputMode = gadgetv1alpha1.TraceOutputMode(req.OutputMode)
+		supported := false
+		for _, mode := range modes {
+			supported = supported || mode == outputMode
+		}
+		if !supported {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported output mode %q for gadget %q", outputMode, req.Gadget)
+		}
+	}
+
+	id := req.Id
+	if id == "" {
+		id = req.Gadget + "-" + rand.String(5)
+	}
+	if errs := validation.IsDNS1123Label(id); len(errs) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id %q: %s", id, strings.Join(errs, ", "))
+	}
+
+	trace := &gadgetv1alpha1.Trace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      id,
+			Namespace: traceNamespace,
+		},
+		Spec: gadgetv1alpha1.TraceSpec{
+			Node:       s.node,
+			Gadget:     req.Gadget,
+			RunMode:    gadgetv1alpha1.RunModeManual,
+			OutputMode: outputMode,
+			Parameters: req.Parameters,
+			Enrichers:  req.Enrichers,
+		},
+	}
+	if sel := req.Selector; sel != nil {
+		trace.Spec.Filter = &gadgetv1alpha1.ContainerFilter{
+			Namespace:     sel.Namespace,
+			Podname:       sel.Podname,
+			Labels:        sel.Labels,
+			ContainerName: sel.ContainerName,
+			Image:         sel.Image,
+		}
+	}
+	if req.FilterByCgroup {
+		trace.Spec.FilterMode = gadgetv1alpha1.FilterModeCgroupID
+	}
+
+	selector := gadgets.ContainerSelectorFromContainerFilter(trace.Spec.Filter)
+	if err := containercollection.ValidateContainerSelector(selector); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid selector: %s", err)
+	}
+
+	em, supportsEnrichers := s.manager.(enricherManager)
+	if len(req.Enrichers) > 0 {
+		if !supportsEnrichers {
+			return nil, status.Error(codes.InvalidArgument, "enrichers are not supported")
+		}
+		if err := em.ValidateEnrichers(req.Enrichers); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid enrichers: %s", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.runs[id]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "gadget run %q already exists", id)
+	}
+
+	run := &gadgetRun{
+		id:      id,
+		trace:   trace,
+		factory: factory,
+	}
+
+	if err := s.manager.AddTracer(run.tracerID(), *selector); err != nil {
+		return nil, status.Errorf(codes.Internal, "adding tracer: %s", err)
+	}
+	if len(req.Enrichers) > 0 {
+		if err := em.SetTracerEnrichers(run.tracerID(), req.Enrichers); err != nil {
+			s.manager.RemoveTracer(run.tracerID())
+			return nil, status.Errorf(codes.Internal, "setting enrichers: %s", err)
+		}
+	}
+
+	// Gadgets using the Status output mode, like the snapshot ones, don't
+	// have a start operation: They're run with RunGadgetOperation.
+	if op, ok := factory.Operations()[gadgetv1alpha1.OperationStart]; ok {
+		op.Operation(run.namespacedName(), trace)
+		if trace.Status.OperationError != "" {
+			s.release(run)
+			return nil, status.Errorf(codes.FailedPrecondition, "starting gadget %q: %s", req.Gadget, trace.Status.OperationError)
+		}
+	}
+
+	s.runs[id] = run
+	log.Infof("Gadget service: gadget %q started as %q", req.Gadget, id)
+
+	return run.proto(), nil
+}
+
+func (s *Service) RunGadgetOperation(_ context.Context, req *pb.GadgetOperationRequest) (*pb.GadgetRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "cannot find gadget run %q", req.Id)
+	}
+
+	op, ok := run.factory.Operations()[gadgetv1alpha1.Operation(req.Operation)]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported operation %q for gadget %q",
+			req.Operation, run.trace.Spec.Gadget)
+	}
+
+	run.trace.Status.OperationError = ""
+	run.trace.Status.OperationWarning = ""
+	op.Operation(run.namespacedName(), run.trace)
+
+	return run.proto(), nil
+}
+
+func (s *Service) GetGadgetRun(_ context.Context, req *pb.GadgetRunID) (*pb.GadgetRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "cannot find gadget run %q", req.Id)
+	}
+
+	return run.proto(), nil
+}
+
+func (s *Service) ListGadgetRuns(_ context.Context, _ *pb.ListGadgetRunsRequest) (*pb.GadgetRunList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := &pb.GadgetRunList{}
+	for _, run := range s.runs {
+		list.Runs = append(list.Runs, run.proto())
+	}
+	sort.Slice(list.Runs, func(i, j int) bool {
+		return list.Runs[i].Id < list.Runs[j].Id
+	})
+
+	return list, nil
+}
+
+func (s *Service) StopGadget(_ context.Context, req *pb.GadgetRunID) (*pb.GadgetRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "cannot find gadget run %q", req.Id)
+	}
+
+	// Not all the gadgets release their resources when deleting the
+	// trace, as the controller does, so stop them first
+	if op, ok := run.factory.Operations()[gadgetv1alpha1.OperationStop]; ok {
+		op.Operation(run.namespacedName(), run.trace)
+	}
+	s.release(run)
+	delete(s.runs, req.Id)
+
+	log.Infof("Gadget service: gadget run %q stopped", req.Id)
+
+	return run.proto(), nil
+}
+
+// release deletes the trace of a run and its tracer, closing its stream
+func (s *Service) release(run *gadgetRun) {
+	run.factory.Delete(run.namespacedName())
+
+	if err := s.manager.RemoveTracer(run.tracerID()); err != nil {
+		log.Warnf("Gadget service: removing tracer of %q: %s", run.id, err)
+	}
+}
+
+func (s *Service) StreamGadget(req *pb.StreamGadgetRequest, stream pb.GadgetService_StreamGadgetServer) error {
+	s.mu.Lock()
+
+	run, ok := s.runs[req.Id]
+	if !ok {
+		s.mu.Unlock()
+		return status.Errorf(codes.NotFound, "cannot find gadget run %q", req.Id)
+	}
+	if run.trace.Spec.OutputMode != gadgetv1alpha1.TraceOutputModeStream {
+		s.mu.Unlock()
+		return status.Errorf(codes.FailedPrecondition, "gadget run %q doesn't use the Stream output mode", req.Id)
+	}
+
+	gadgetStream, err := s.manager.Stream(run.tracerID())
+	if err != nil {
+		s.mu.Unlock()
+		return status.Errorf(codes.Internal, "cannot find stream of gadget run %q: %s", req.Id, err)
+	}
+
+	ch := gadgetStream.SubscribeFrom(req.ResumeAfter)
+	defer gadgetStream.Unsubscribe(ch)
+
+	s.mu.Unlock()
+
+	if ch == nil {
+		return errors.New("channel is nil, ranging over it will make us wait forever")
+	}
+
+	binary := req.Encoding == pb.StreamEncoding_BINARY
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case l, ok := <-ch:
+			// The channel is closed when the gadget is stopped
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(l.StreamData(binary, s.node)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close stops all the gadgets
+func (s *Service) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, run := range s.runs {
+		if op, ok := run.factory.Operations()[gadgetv1alpha1.OperationStop]; ok {
+			op.Operation(run.namespacedName(), run.trace)
+		}
+		s.release(run)
+		delete(s.runs, id)
+	}
+}
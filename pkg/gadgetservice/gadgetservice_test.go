@@ -0,0 +1,230 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gadgetservice
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// fakeFactory is a gadget publishing an event when started and setting its
+// output when collecting
+type fakeFactory struct {
+	gadgets.BaseFactory
+	outputMode gadgetv1alpha1.TraceOutputMode
+}
+
+func (f *fakeFactory) Operations() map[gadgetv1alpha1.Operation]gadgets.TraceOperation {
+	if f.outputMode == gadgetv1alpha1.TraceOutputModeStatus {
+		return map[gadgetv1alpha1.Operation]gadgets.TraceOperation{
+			gadgetv1alpha1.OperationCollect: {
+				Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+					trace.Status.Output = "collected " + trace.Spec.Parameters["what"]
+					trace.Status.State = gadgetv1alpha1.TraceStateCompleted
+				},
+			},
+		}
+	}
+
+	return map[gadgetv1alpha1.Operation]gadgets.TraceOperation{
+		gadgetv1alpha1.OperationStart: {
+			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+				if trace.Spec.Parameters["fail"] != "" {
+					trace.Status.OperationError = "failing as requested"
+					return
+				}
+				traceName := gadgets.TraceName(trace.ObjectMeta.Namespace, trace.ObjectMeta.Name)
+				f.Helpers.PublishTypedEvent(traceName, eventtypes.Debug("started"))
+				trace.Status.State = gadgetv1alpha1.TraceStateStarted
+			},
+		},
+		gadgetv1alpha1.OperationStop: {
+			Operation: func(name string, trace *gadgetv1alpha1.Trace) {
+				trace.Status.State = gadgetv1alpha1.TraceStateStopped
+			},
+		},
+	}
+}
+
+func (f *fakeFactory) OutputModesSupported() map[gadgetv1alpha1.TraceOutputMode]struct{} {
+	return map[gadgetv1alpha1.TraceOutputMode]struct{}{f.outputMode: {}}
+}
+
+func (f *fakeFactory) Parameters() []gadgets.TraceParameter {
+	return []gadgets.TraceParameter{{Name: "fail", Doc: "Fail to start"}}
+}
+
+type fakeStreamServer struct {
+	grpc.ServerStream
+	data chan *pb.StreamData
+}
+
+func (s *fakeStreamServer) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeStreamServer) Send(data *pb.StreamData) error {
+	s.data <- data
+	return nil
+}
+
+func newService(t *testing.T) *Service {
+	g, err := gadgettracermanager.NewServer(&gadgettracermanager.Conf{NodeName: "fake-node", HookMode: "none", TestOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create new server: %v", err)
+	}
+	t.Cleanup(g.Close)
+
+	s := New(g, map[string]gadgets.TraceFactory{
+		"streamer":  &fakeFactory{outputMode: gadgetv1alpha1.TraceOutputModeStream},
+		"collector": &fakeFactory{outputMode: gadgetv1alpha1.TraceOutputModeStatus},
+		"exporter":  &fakeFactory{outputMode: gadgetv1alpha1.TraceOutputModeOTLP},
+	}, "fake-node")
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestListGadgets(t *testing.T) {
+	s := newService(t)
+
+	list, err := s.ListGadgets(context.Background(), &pb.ListGadgetsRequest{})
+	if err != nil {
+		t.Fatalf("Failed to list gadgets: %v", err)
+	}
+
+	// Gadgets not supporting the Stream or Status output modes are hidden
+	if len(list.Gadgets) != 2 || list.Gadgets[0].Name != "collector" || list.Gadgets[1].Name != "streamer" {
+		t.Fatalf("Unexpected gadgets: %v", list.Gadgets)
+	}
+	streamer := list.Gadgets[1]
+	if len(streamer.Operations) != 2 || streamer.Operations[0].Name != "start" {
+		t.Fatalf("Unexpected operations: %v", streamer.Operations)
+	}
+	if len(streamer.Parameters) != 1 || streamer.Parameters[0].Name != "fail" {
+		t.Fatalf("Unexpected parameters: %v", streamer.Parameters)
+	}
+}
+
+func TestStreamGadget(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	run, err := s.StartGadget(ctx, &pb.StartGadgetRequest{
+		Gadget:   "streamer",
+		Id:       "my-run",
+		Selector: &pb.ContainerSelector{Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to start gadget: %v", err)
+	}
+	if run.State != string(gadgetv1alpha1.TraceStateStarted) || run.OutputMode != "Stream" {
+		t.Fatalf("Unexpected run: %v", run)
+	}
+
+	if _, err := s.StartGadget(ctx, &pb.StartGadgetRequest{Gadget: "streamer", Id: "my-run"}); err == nil {
+		t.Fatalf("Duplicate run not detected")
+	}
+	if _, err := s.StartGadget(ctx, &pb.StartGadgetRequest{Gadget: "streamer", Id: "Invalid_ID"}); err == nil {
+		t.Fatalf("Invalid id not detected")
+	}
+	if _, err := s.StartGadget(ctx, &pb.StartGadgetRequest{Gadget: "streamer", OutputMode: "Status"}); err == nil {
+		t.Fatalf("Unsupported output mode not detected")
+	}
+	if _, err := s.StartGadget(ctx, &pb.StartGadgetRequest{
+		Gadget:     "streamer",
+		Parameters: map[string]string{"fail": "true"},
+	}); err == nil {
+		t.Fatalf("Operation error not returned")
+	}
+
+	list, err := s.ListGadgetRuns(ctx, &pb.ListGadgetRunsRequest{})
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(list.Runs) != 1 || list.Runs[0].Id != "my-run" || list.Runs[0].Selector.Namespace != "default" {
+		t.Fatalf("Unexpected runs: %v", list.Runs)
+	}
+
+	// The events published before subscribing are kept in the history of
+	// the stream. The stream ends when the gadget is stopped.
+	stream := &fakeStreamServer{data: make(chan *pb.StreamData, 1)}
+	done := make(chan error)
+	go func() {
+		done <- s.StreamGadget(&pb.StreamGadgetRequest{
+			Id:       "my-run",
+			Encoding: pb.StreamEncoding_BINARY,
+		}, stream)
+	}()
+
+	data := <-stream.data
+	if data.Event == nil || data.Event.Message != "started" || data.Seq != 1 {
+		t.Fatalf("Unexpected stream data: %v", data)
+	}
+
+	run, err = s.StopGadget(ctx, &pb.GadgetRunID{Id: "my-run"})
+	if err != nil {
+		t.Fatalf("Failed to stop gadget: %v", err)
+	}
+	if run.State != string(gadgetv1alpha1.TraceStateStopped) {
+		t.Fatalf("Unexpected state: %v", run)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Failed to stream gadget: %v", err)
+	}
+
+	if _, err := s.GetGadgetRun(ctx, &pb.GadgetRunID{Id: "my-run"}); err == nil {
+		t.Fatalf("Stopped run still found")
+	}
+}
+
+func TestRunGadgetOperation(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	run, err := s.StartGadget(ctx, &pb.StartGadgetRequest{
+		Gadget:     "collector",
+		Parameters: map[string]string{"what": "sockets"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to start gadget: %v", err)
+	}
+	if run.OutputMode != "Status" || run.Output != "" {
+		t.Fatalf("Unexpected run: %v", run)
+	}
+
+	if _, err := s.RunGadgetOperation(ctx, &pb.GadgetOperationRequest{Id: run.Id, Operation: "start"}); err == nil {
+		t.Fatalf("Unsupported operation not detected")
+	}
+	if err := s.StreamGadget(&pb.StreamGadgetRequest{Id: run.Id}, nil); err == nil {
+		t.Fatalf("Streaming a gadget using the Status output mode not detected")
+	}
+
+	run, err = s.RunGadgetOperation(ctx, &pb.GadgetOperationRequest{Id: run.Id, Operation: "collect"})
+	if err != nil {
+		t.Fatalf("Failed to run operation: %v", err)
+	}
+	if run.Output != "collected sockets" || run.State != string(gadgetv1alpha1.TraceStateCompleted) {
+		t.Fatalf("Unexpected run: %v", run)
+	}
+}
@@ -23,6 +23,8 @@ type GadgetTracerManagerClient interface {
 	// Methods called by OCI Hooks
 	AddContainer(ctx context.Context, in *ContainerDefinition, opts ...grpc.CallOption) (*AddContainerResponse, error)
 	RemoveContainer(ctx context.Context, in *ContainerDefinition, opts ...grpc.CallOption) (*RemoveContainerResponse, error)
+	// Methods to query the containers
+	GetContainerHistory(ctx context.Context, in *ContainerHistoryRequest, opts ...grpc.CallOption) (*ContainerHistory, error)
 	// Methods called for debugging
 	DumpState(ctx context.Context, in *DumpStateRequest, opts ...grpc.CallOption) (*Dump, error)
 }
@@ -85,6 +87,15 @@ func (c *gadgetTracerManagerClient) RemoveContainer(ctx context.Context, in *Con
 	return out, nil
 }
 
+func (c *gadgetTracerManagerClient) GetContainerHistory(ctx context.Context, in *ContainerHistoryRequest, opts ...grpc.CallOption) (*ContainerHistory, error) {
+	out := new(ContainerHistory)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetTracerManager/GetContainerHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gadgetTracerManagerClient) DumpState(ctx context.Context, in *DumpStateRequest, opts ...grpc.CallOption) (*Dump, error) {
 	out := new(Dump)
 	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetTracerManager/DumpState", in, out, opts...)
@@ -103,6 +114,8 @@ type GadgetTracerManagerServer interface {
 	// Methods called by OCI Hooks
 	AddContainer(context.Context, *ContainerDefinition) (*AddContainerResponse, error)
 	RemoveContainer(context.Context, *ContainerDefinition) (*RemoveContainerResponse, error)
+	// Methods to query the containers
+	GetContainerHistory(context.Context, *ContainerHistoryRequest) (*ContainerHistory, error)
 	// Methods called for debugging
 	DumpState(context.Context, *DumpStateRequest) (*Dump, error)
 	mustEmbedUnimplementedGadgetTracerManagerServer()
@@ -121,6 +134,9 @@ func (UnimplementedGadgetTracerManagerServer) AddContainer(context.Context, *Con
 func (UnimplementedGadgetTracerManagerServer) RemoveContainer(context.Context, *ContainerDefinition) (*RemoveContainerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveContainer not implemented")
 }
+func (UnimplementedGadgetTracerManagerServer) GetContainerHistory(context.Context, *ContainerHistoryRequest) (*ContainerHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerHistory not implemented")
+}
 func (UnimplementedGadgetTracerManagerServer) DumpState(context.Context, *DumpStateRequest) (*Dump, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DumpState not implemented")
 }
@@ -194,6 +210,24 @@ func _GadgetTracerManager_RemoveContainer_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GadgetTracerManager_GetContainerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetTracerManagerServer).GetContainerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetTracerManager/GetContainerHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetTracerManagerServer).GetContainerHistory(ctx, req.(*ContainerHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GadgetTracerManager_DumpState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DumpStateRequest)
 	if err := dec(in); err != nil {
@@ -227,6 +261,10 @@ var GadgetTracerManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveContainer",
 			Handler:    _GadgetTracerManager_RemoveContainer_Handler,
 		},
+		{
+			MethodName: "GetContainerHistory",
+			Handler:    _GadgetTracerManager_GetContainerHistory_Handler,
+		},
 		{
 			MethodName: "DumpState",
 			Handler:    _GadgetTracerManager_DumpState_Handler,
@@ -241,3 +279,349 @@ var GadgetTracerManager_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "api/gadgettracermanager.proto",
 }
+
+// GadgetServiceClient is the client API for GadgetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GadgetServiceClient interface {
+	// ListGadgets lists the gadgets that can be run, with their parameters
+	ListGadgets(ctx context.Context, in *ListGadgetsRequest, opts ...grpc.CallOption) (*GadgetList, error)
+	// StartGadget starts a gadget on the containers matching the selector
+	StartGadget(ctx context.Context, in *StartGadgetRequest, opts ...grpc.CallOption) (*GadgetRun, error)
+	// RunGadgetOperation calls an operation of a started gadget, like
+	// "collect" for gadgets using the Status output mode
+	RunGadgetOperation(ctx context.Context, in *GadgetOperationRequest, opts ...grpc.CallOption) (*GadgetRun, error)
+	GetGadgetRun(ctx context.Context, in *GadgetRunID, opts ...grpc.CallOption) (*GadgetRun, error)
+	ListGadgetRuns(ctx context.Context, in *ListGadgetRunsRequest, opts ...grpc.CallOption) (*GadgetRunList, error)
+	// StopGadget stops a gadget and releases its resources. Its stream is
+	// closed.
+	StopGadget(ctx context.Context, in *GadgetRunID, opts ...grpc.CallOption) (*GadgetRun, error)
+	// StreamGadget sends the events of a gadget using the Stream output
+	// mode until it's stopped
+	StreamGadget(ctx context.Context, in *StreamGadgetRequest, opts ...grpc.CallOption) (GadgetService_StreamGadgetClient, error)
+}
+
+type gadgetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGadgetServiceClient(cc grpc.ClientConnInterface) GadgetServiceClient {
+	return &gadgetServiceClient{cc}
+}
+
+func (c *gadgetServiceClient) ListGadgets(ctx context.Context, in *ListGadgetsRequest, opts ...grpc.CallOption) (*GadgetList, error) {
+	out := new(GadgetList)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetService/ListGadgets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gadgetServiceClient) StartGadget(ctx context.Context, in *StartGadgetRequest, opts ...grpc.CallOption) (*GadgetRun, error) {
+	out := new(GadgetRun)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetService/StartGadget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gadgetServiceClient) RunGadgetOperation(ctx context.Context, in *GadgetOperationRequest, opts ...grpc.CallOption) (*GadgetRun, error) {
+	out := new(GadgetRun)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetService/RunGadgetOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gadgetServiceClient) GetGadgetRun(ctx context.Context, in *GadgetRunID, opts ...grpc.CallOption) (*GadgetRun, error) {
+	out := new(GadgetRun)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetService/GetGadgetRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gadgetServiceClient) ListGadgetRuns(ctx context.Context, in *ListGadgetRunsRequest, opts ...grpc.CallOption) (*GadgetRunList, error) {
+	out := new(GadgetRunList)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetService/ListGadgetRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gadgetServiceClient) StopGadget(ctx context.Context, in *GadgetRunID, opts ...grpc.CallOption) (*GadgetRun, error) {
+	out := new(GadgetRun)
+	err := c.cc.Invoke(ctx, "/gadgettracermanager.GadgetService/StopGadget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gadgetServiceClient) StreamGadget(ctx context.Context, in *StreamGadgetRequest, opts ...grpc.CallOption) (GadgetService_StreamGadgetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GadgetService_ServiceDesc.Streams[0], "/gadgettracermanager.GadgetService/StreamGadget", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gadgetServiceStreamGadgetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GadgetService_StreamGadgetClient interface {
+	Recv() (*StreamData, error)
+	grpc.ClientStream
+}
+
+type gadgetServiceStreamGadgetClient struct {
+	grpc.ClientStream
+}
+
+func (x *gadgetServiceStreamGadgetClient) Recv() (*StreamData, error) {
+	m := new(StreamData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GadgetServiceServer is the server API for GadgetService service.
+// All implementations must embed UnimplementedGadgetServiceServer
+// for forward compatibility
+type GadgetServiceServer interface {
+	// ListGadgets lists the gadgets that can be run, with their parameters
+	ListGadgets(context.Context, *ListGadgetsRequest) (*GadgetList, error)
+	// StartGadget starts a gadget on the containers matching the selector
+	StartGadget(context.Context, *StartGadgetRequest) (*GadgetRun, error)
+	// RunGadgetOperation calls an operation of a started gadget, like
+	// "collect" for gadgets using the Status output mode
+	RunGadgetOperation(context.Context, *GadgetOperationRequest) (*GadgetRun, error)
+	GetGadgetRun(context.Context, *GadgetRunID) (*GadgetRun, error)
+	ListGadgetRuns(context.Context, *ListGadgetRunsRequest) (*GadgetRunList, error)
+	// StopGadget stops a gadget and releases its resources. Its stream is
+	// closed.
+	StopGadget(context.Context, *GadgetRunID) (*GadgetRun, error)
+	// StreamGadget sends the events of a gadget using the Stream output
+	// mode until it's stopped
+	StreamGadget(*StreamGadgetRequest, GadgetService_StreamGadgetServer) error
+	mustEmbedUnimplementedGadgetServiceServer()
+}
+
+// UnimplementedGadgetServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGadgetServiceServer struct {
+}
+
+func (UnimplementedGadgetServiceServer) ListGadgets(context.Context, *ListGadgetsRequest) (*GadgetList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGadgets not implemented")
+}
+func (UnimplementedGadgetServiceServer) StartGadget(context.Context, *StartGadgetRequest) (*GadgetRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGadget not implemented")
+}
+func (UnimplementedGadgetServiceServer) RunGadgetOperation(context.Context, *GadgetOperationRequest) (*GadgetRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunGadgetOperation not implemented")
+}
+func (UnimplementedGadgetServiceServer) GetGadgetRun(context.Context, *GadgetRunID) (*GadgetRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGadgetRun not implemented")
+}
+func (UnimplementedGadgetServiceServer) ListGadgetRuns(context.Context, *ListGadgetRunsRequest) (*GadgetRunList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGadgetRuns not implemented")
+}
+func (UnimplementedGadgetServiceServer) StopGadget(context.Context, *GadgetRunID) (*GadgetRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopGadget not implemented")
+}
+func (UnimplementedGadgetServiceServer) StreamGadget(*StreamGadgetRequest, GadgetService_StreamGadgetServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamGadget not implemented")
+}
+func (UnimplementedGadgetServiceServer) mustEmbedUnimplementedGadgetServiceServer() {}
+
+// UnsafeGadgetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GadgetServiceServer will
+// result in compilation errors.
+type UnsafeGadgetServiceServer interface {
+	mustEmbedUnimplementedGadgetServiceServer()
+}
+
+func RegisterGadgetServiceServer(s grpc.ServiceRegistrar, srv GadgetServiceServer) {
+	s.RegisterService(&GadgetService_ServiceDesc, srv)
+}
+
+func _GadgetService_ListGadgets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGadgetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetServiceServer).ListGadgets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetService/ListGadgets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetServiceServer).ListGadgets(ctx, req.(*ListGadgetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GadgetService_StartGadget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGadgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetServiceServer).StartGadget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetService/StartGadget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetServiceServer).StartGadget(ctx, req.(*StartGadgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GadgetService_RunGadgetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GadgetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetServiceServer).RunGadgetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetService/RunGadgetOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetServiceServer).RunGadgetOperation(ctx, req.(*GadgetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GadgetService_GetGadgetRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GadgetRunID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetServiceServer).GetGadgetRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetService/GetGadgetRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetServiceServer).GetGadgetRun(ctx, req.(*GadgetRunID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GadgetService_ListGadgetRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGadgetRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetServiceServer).ListGadgetRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetService/ListGadgetRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetServiceServer).ListGadgetRuns(ctx, req.(*ListGadgetRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GadgetService_StopGadget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GadgetRunID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GadgetServiceServer).StopGadget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gadgettracermanager.GadgetService/StopGadget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GadgetServiceServer).StopGadget(ctx, req.(*GadgetRunID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GadgetService_StreamGadget_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamGadgetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GadgetServiceServer).StreamGadget(m, &gadgetServiceStreamGadgetServer{stream})
+}
+
+type GadgetService_StreamGadgetServer interface {
+	Send(*StreamData) error
+	grpc.ServerStream
+}
+
+type gadgetServiceStreamGadgetServer struct {
+	grpc.ServerStream
+}
+
+func (x *gadgetServiceStreamGadgetServer) Send(m *StreamData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GadgetService_ServiceDesc is the grpc.ServiceDesc for GadgetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GadgetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gadgettracermanager.GadgetService",
+	HandlerType: (*GadgetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListGadgets",
+			Handler:    _GadgetService_ListGadgets_Handler,
+		},
+		{
+			MethodName: "StartGadget",
+			Handler:    _GadgetService_StartGadget_Handler,
+		},
+		{
+			MethodName: "RunGadgetOperation",
+			Handler:    _GadgetService_RunGadgetOperation_Handler,
+		},
+		{
+			MethodName: "GetGadgetRun",
+			Handler:    _GadgetService_GetGadgetRun_Handler,
+		},
+		{
+			MethodName: "ListGadgetRuns",
+			Handler:    _GadgetService_ListGadgetRuns_Handler,
+		},
+		{
+			MethodName: "StopGadget",
+			Handler:    _GadgetService_StopGadget_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamGadget",
+			Handler:       _GadgetService_StreamGadget_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/gadgettracermanager.proto",
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gadgettracermanager
+
+import (
+	"fmt"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
+)
+
+// ValidateEnrichers checks that enrichers with the given names are available
+func (g *GadgetTracerManager) ValidateEnrichers(names []string) error {
+	return g.enricherRegistry.Validate(names)
+}
+
+// SetTracerEnrichers enables the enrichers with the given names on the events
+// published with PublishTypedEvent by the given tracer. An empty list
+// disables them. The enrichers are removed with the tracer.
+func (g *GadgetTracerManager) SetTracerEnrichers(tracerID string, names []string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.tracerCollection.TracerExists(tracerID) {
+		return fmt.Errorf("cannot find tracer %q", tracerID)
+	}
+
+	if len(names) == 0 {
+		g.enricherChains.Delete(tracerID)
+		return nil
+	}
+
+	chain, err := g.enricherRegistry.NewChain(names)
+	if err != nil {
+		return err
+	}
+	g.enricherChains.Store(tracerID, chain)
+
+	return nil
+}
+
+// enrich calls the enrichers of the given tracer on event
+func (g *GadgetTracerManager) enrich(tracerID string, event any) any {
+	chain, ok := g.enricherChains.Load(tracerID)
+	if !ok {
+		return event
+	}
+	return chain.(*gadgets.EnricherChain).Enrich(event)
+}
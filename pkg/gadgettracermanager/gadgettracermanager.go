@@ -28,8 +28,10 @@ import (
 
 	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/enrichers"
 	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
 	containersmap "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/containers-map"
+	gadgetstream "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/runcfanotify"
 	tracercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/tracer-collection"
 	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
@@ -52,6 +54,18 @@ type GadgetTracerManager struct {
 	// containersMap is the global map at /sys/fs/bpf/gadget/containers
 	// exposing container details for each mount namespace.
 	containersMap *containersmap.ContainersMap
+
+	// otlpExporters contains the exporters of the tracers whose events are
+	// exported to an OTLP collector, keyed by tracer ID
+	otlpExporters map[string]*otlpExporter
+
+	// enricherRegistry creates the enricher chains of the tracers
+	enricherRegistry *enrichers.Registry
+
+	// enricherChains contains the enrichers enabled for the tracers,
+	// keyed by tracer ID. It's read without holding mu when publishing
+	// events.
+	enricherChains sync.Map
 }
 
 func (g *GadgetTracerManager) AddTracer(tracerID string, containerSelector containercollection.ContainerSelector) error {
@@ -62,10 +76,26 @@ func (g *GadgetTracerManager) AddTracer(tracerID string, containerSelector conta
 }
 
 func (g *GadgetTracerManager) RemoveTracer(tracerID string) error {
+	g.mu.Lock()
+	exporter := g.takeOTLPExporter(tracerID)
+	g.enricherChains.Delete(tracerID)
+	err := g.tracerCollection.RemoveTracer(tracerID)
+	g.mu.Unlock()
+
+	if exporter != nil {
+		exporter.close()
+	}
+
+	return err
+}
+
+// Stream returns the stream where the events of the given tracer are
+// published
+func (g *GadgetTracerManager) Stream(tracerID string) (*gadgetstream.GadgetStream, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	return g.tracerCollection.RemoveTracer(tracerID)
+	return g.tracerCollection.Stream(tracerID)
 }
 
 func (g *GadgetTracerManager) ReceiveStream(tracerID *pb.TracerID, stream pb.GadgetTracerManager_ReceiveStreamServer) error {
@@ -81,7 +111,7 @@ func (g *GadgetTracerManager) ReceiveStream(tracerID *pb.TracerID, stream pb.Gad
 		return fmt.Errorf("cannot find stream for tracer %q", tracerID.Id)
 	}
 
-	ch := gadgetStream.Subscribe()
+	ch := gadgetStream.SubscribeFrom(tracerID.ResumeAfter)
 	defer gadgetStream.Unsubscribe(ch)
 
 	g.mu.Unlock()
@@ -90,26 +120,11 @@ func (g *GadgetTracerManager) ReceiveStream(tracerID *pb.TracerID, stream pb.Gad
 		return errors.New("channel is nil, ranging over it will make us wait forever")
 	}
 
-	for l := range ch {
-		if l.EventLost {
-			ev := eventtypes.Event{
-				Type: eventtypes.ERR,
-				CommonData: eventtypes.CommonData{
-					Node: g.nodeName,
-				},
-				Message: "events lost in gadget tracer manager",
-			}
-			line, _ := json.Marshal(ev)
-			err := stream.Send(&pb.StreamData{Line: string(line)})
-			if err != nil {
-				return err
-			}
-
-			continue
-		}
+	binary := tracerID.Encoding == pb.StreamEncoding_BINARY
 
-		line := &pb.StreamData{Line: l.Line}
-		if err := stream.Send(line); err != nil {
+	for l := range ch {
+		data := l.StreamData(binary, g.nodeName)
+		if err := stream.Send(data); err != nil {
 			return err
 		}
 	}
@@ -131,6 +146,16 @@ func (g *GadgetTracerManager) PublishEvent(tracerID string, line string) error {
 	return nil
 }
 
+func (g *GadgetTracerManager) PublishTypedEvent(tracerID string, event any) error {
+	stream, err := g.tracerCollection.Stream(tracerID)
+	if err != nil {
+		return fmt.Errorf("cannot find stream for tracer %q", tracerID)
+	}
+
+	stream.PublishEvent(g.enrich(tracerID, event))
+	return nil
+}
+
 func (g *GadgetTracerManager) TracerMountNsMap(tracerID string) (*ebpf.Map, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -138,6 +163,13 @@ func (g *GadgetTracerManager) TracerMountNsMap(tracerID string) (*ebpf.Map, erro
 	return g.tracerCollection.TracerMountNsMap(tracerID)
 }
 
+func (g *GadgetTracerManager) TracerCgroupIDMap(tracerID string) (*ebpf.Map, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.tracerCollection.TracerCgroupIDMap(tracerID)
+}
+
 func (g *GadgetTracerManager) ContainersMap() *ebpf.Map {
 	if g.containersMap == nil {
 		return nil
@@ -199,6 +231,33 @@ func (g *GadgetTracerManager) RemoveContainer(_ context.Context, containerDefini
 	return &pb.RemoveContainerResponse{}, nil
 }
 
+func (g *GadgetTracerManager) GetContainerHistory(_ context.Context, req *pb.ContainerHistoryRequest) (*pb.ContainerHistory, error) {
+	selector := containercollection.ContainerSelector{
+		Namespace: req.Namespace,
+		Podname:   req.Podname,
+		Name:      req.Name,
+		Image:     req.Image,
+	}
+	if err := containercollection.ValidateContainerSelector(&selector); err != nil {
+		return nil, err
+	}
+
+	history := &pb.ContainerHistory{}
+	for _, e := range g.ContainerCollection.LifecycleEvents(&selector) {
+		history.Events = append(history.Events, &pb.ContainerLifecycleEvent{
+			Timestamp: e.Timestamp,
+			Type:      e.Type.String(),
+			Id:        e.Container.ID,
+			Namespace: e.Container.Namespace,
+			Podname:   e.Container.Podname,
+			Name:      e.Container.Name,
+			Reason:    e.Reason,
+		})
+	}
+
+	return history, nil
+}
+
 func (g *GadgetTracerManager) DumpState(_ context.Context, req *pb.DumpStateRequest) (*pb.Dump, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -224,6 +283,12 @@ func NewServer(conf *Conf) (*GadgetTracerManager, error) {
 		nodeName: conf.NodeName,
 	}
 
+	if conf.TestOnly {
+		g.enricherRegistry = enrichers.NewRegistry()
+	} else {
+		g.enricherRegistry = enrichers.NewRegistry(enrichers.WithEndpoints())
+	}
+
 	eventtypes.Init(conf.NodeName)
 	var err error
 	if conf.TestOnly {
@@ -316,6 +381,20 @@ type Conf struct {
 // Close releases any resource that could be in use by the tracer manager, like
 // ebpf maps.
 func (g *GadgetTracerManager) Close() {
+	g.mu.Lock()
+	exporters := make([]*otlpExporter, 0, len(g.otlpExporters))
+	for tracerID := range g.otlpExporters {
+		exporters = append(exporters, g.takeOTLPExporter(tracerID))
+	}
+	g.mu.Unlock()
+
+	for _, exporter := range exporters {
+		exporter.close()
+	}
+
+	if g.enricherRegistry != nil {
+		g.enricherRegistry.Close()
+	}
 	if g.containersMap != nil {
 		g.containersMap.Close()
 	}
@@ -15,10 +15,23 @@
 package gadgettracermanager
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
 	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	gadgetcollection "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/enrichers"
+	exectypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/exec/types"
 )
 
 func TestTracer(t *testing.T) {
@@ -78,3 +91,135 @@ func TestTracer(t *testing.T) {
 		t.Fatalf("Error while checking tracer %s: not found", "my_tracer_id2")
 	}
 }
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan *collogspb.ExportLogsServiceRequest, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := &collogspb.ExportLogsServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- req
+	}))
+	defer collector.Close()
+
+	g, err := NewServer(&Conf{NodeName: "fake-node", HookMode: "none", TestOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create new server: %v", err)
+	}
+
+	tracerID := "trace_default_dns"
+	if err := g.AddTracer(tracerID, containercollection.ContainerSelector{}); err != nil {
+		t.Fatalf("Failed to add tracer: %v", err)
+	}
+	if err := g.AddOTLPExporter(tracerID, "dns", collector.URL); err != nil {
+		t.Fatalf("Failed to add OTLP exporter: %v", err)
+	}
+	if err := g.AddOTLPExporter(tracerID, "dns", collector.URL); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("Error while adding a duplicate OTLP exporter: %v", err)
+	}
+
+	if err := g.PublishEvent(tracerID, `{"type":"normal","namespace":"default","qtype":"A"}`); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+
+	// Removing the tracer exports the queued events
+	if err := g.RemoveTracer(tracerID); err != nil {
+		t.Fatalf("Failed to remove tracer: %v", err)
+	}
+
+	select {
+	case req := <-received:
+		records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+		if len(records) != 1 || records[0].Attributes[0].Value.GetStringValue() != "A" {
+			t.Fatalf("Unexpected log records: %v", records)
+		}
+	default:
+		t.Fatal("No logs received by the collector")
+	}
+}
+
+func TestTracerEnrichers(t *testing.T) {
+	g, err := NewServer(&Conf{NodeName: "fake-node", HookMode: "none", TestOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create new server: %v", err)
+	}
+	defer g.Close()
+
+	tracerID := "trace_default_exec"
+	if err := g.SetTracerEnrichers(tracerID, []string{enrichers.Process}); err == nil {
+		t.Fatal("Expected error for unknown tracer")
+	}
+	if err := g.AddTracer(tracerID, containercollection.ContainerSelector{}); err != nil {
+		t.Fatalf("Failed to add tracer: %v", err)
+	}
+	if err := g.SetTracerEnrichers(tracerID, []string{"unknown"}); err == nil {
+		t.Fatal("Expected error for unknown enricher")
+	}
+	if err := g.SetTracerEnrichers(tracerID, []string{enrichers.Process}); err != nil {
+		t.Fatalf("Failed to set enrichers: %v", err)
+	}
+
+	// The test process is used as the process of the event
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("Failed to get executable: %v", err)
+	}
+	if err := g.PublishTypedEvent(tracerID, exectypes.Event{Pid: uint32(os.Getpid())}); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+
+	gadgetStream, err := g.tracerCollection.Stream(tracerID)
+	if err != nil {
+		t.Fatalf("Failed to get stream: %v", err)
+	}
+	ch := gadgetStream.Subscribe()
+	defer gadgetStream.Unsubscribe(ch)
+
+	l := <-ch
+	var event exectypes.Event
+	if err := json.Unmarshal([]byte(l.JSON()), &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Exe != exe || event.Cmdline == "" {
+		t.Fatalf("Event not enriched: %+v", event.EnrichedData)
+	}
+}
+
+func TestTracerFilterMaps(t *testing.T) {
+	g, err := NewServer(&Conf{NodeName: "fake-node", HookMode: "none", TestOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create new server: %v", err)
+	}
+	defer g.Close()
+
+	tracerID := "trace_default_exec"
+	for _, filterMode := range []gadgetv1alpha1.FilterMode{
+		"",
+		gadgetv1alpha1.FilterModeMountNamespace,
+		gadgetv1alpha1.FilterModeCgroupID,
+	} {
+		if _, _, err := gadgetcollection.TracerFilterMaps(g, tracerID, filterMode); err == nil {
+			t.Fatalf("Expected error for unknown tracer with filter mode %q", filterMode)
+		}
+	}
+
+	if err := g.AddTracer(tracerID, containercollection.ContainerSelector{}); err != nil {
+		t.Fatalf("Failed to add tracer: %v", err)
+	}
+	if _, _, err := gadgetcollection.TracerFilterMaps(g, tracerID, gadgetv1alpha1.FilterModeCgroupID); err != nil {
+		t.Fatalf("Failed to get filter maps: %v", err)
+	}
+	if _, _, err := gadgetcollection.TracerFilterMaps(g, tracerID, "Unknown"); err == nil {
+		t.Fatal("Expected error for unknown filter mode")
+	}
+
+	if err := g.RemoveTracer(tracerID); err != nil {
+		t.Fatalf("Failed to remove tracer: %v", err)
+	}
+	if _, err := g.TracerCgroupIDMap(tracerID); err == nil {
+		t.Fatal("Expected error for removed tracer")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gadgettracermanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/otlp"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// otlpExporter forwards the events published in the stream of a tracer to an
+// OTLP collector
+type otlpExporter struct {
+	exporter *otlp.Exporter
+	stream   *stream.GadgetStream
+	ch       chan stream.TimestampedLine
+	done     chan struct{}
+}
+
+// AddOTLPExporter exports the events of the given tracer, as published by its
+// gadget, to the OTLP collector at endpoint. It's used for traces with
+// OutputMode=OTLP. The exporter is removed with the tracer.
+func (g *GadgetTracerManager) AddOTLPExporter(tracerID, gadget, endpoint string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.otlpExporters[tracerID]; ok {
+		return os.ErrExist
+	}
+
+	gadgetStream, err := g.tracerCollection.Stream(tracerID)
+	if err != nil {
+		return fmt.Errorf("cannot find stream for tracer %q", tracerID)
+	}
+
+	resource := map[string]string{
+		"service.name": "inspektor-gadget",
+		"gadget.name":  gadget,
+		"gadget.trace": tracerID,
+	}
+	exporter, err := otlp.NewExporter(endpoint, resource, gadget, otlp.DefaultConfig())
+	if err != nil {
+		return err
+	}
+
+	ch := gadgetStream.Subscribe()
+	if ch == nil {
+		exporter.Close()
+		return errors.New("stream closed")
+	}
+
+	e := &otlpExporter{
+		exporter: exporter,
+		stream:   gadgetStream,
+		ch:       ch,
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(e.done)
+
+		for l := range ch {
+			line := l.JSON()
+			if l.EventLost {
+				line = eventtypes.EventString(stream.LostEvents(l.LostCount))
+			}
+
+			if err := exporter.Export(line); err != nil && !errors.Is(err, otlp.ErrQueueFull) {
+				log.Debugf("OTLP: not exporting event of tracer %q: %s", tracerID, err)
+			}
+		}
+	}()
+
+	if g.otlpExporters == nil {
+		g.otlpExporters = make(map[string]*otlpExporter)
+	}
+	g.otlpExporters[tracerID] = e
+
+	return nil
+}
+
+// takeOTLPExporter removes the exporter of the given tracer from the manager
+// and returns it, or nil if there is none. It must be called with g.mu held;
+// the exporter must then be closed without it, since closing waits for the
+// queued events to be exported.
+func (g *GadgetTracerManager) takeOTLPExporter(tracerID string) *otlpExporter {
+	e, ok := g.otlpExporters[tracerID]
+	if !ok {
+		return nil
+	}
+	delete(g.otlpExporters, tracerID)
+
+	return e
+}
+
+// close stops exporting the events of the tracer, after exporting the queued
+// ones.
+func (e *otlpExporter) close() {
+	// Unsubscribing closes the channel, making the forwarding goroutine
+	// terminate
+	e.stream.Unsubscribe(e.ch)
+	<-e.done
+	e.exporter.Close()
+}
@@ -0,0 +1,591 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// The binary encoding of events puts the fields of eventtypes.Event in the
+// pb.Event envelope and encodes the other fields of the event in its payload
+// using the protobuf wire format, so clients decode the payload with the same
+// struct, without needing a message per gadget. The number of a field is
+// given by its pb tag, e.g. `pb:"3"`, or derived from its JSON name, or Go
+// name if it has none, so fields can be added, removed or reordered without
+// breaking older clients. Unknown fields are skipped and missing ones keep
+// their zero value.
+//
+// Supported fields are booleans, integers, floats, strings and byte slices,
+// pointers and slices of them, maps with keys and values of these types and
+// structs with supported fields.
+
+// ErrUnsupportedEvent is returned for events that can't be encoded with the
+// binary encoding. They have to be sent as JSON.
+var ErrUnsupportedEvent = errors.New("event not supported by the binary encoding")
+
+var (
+	errWireType = errors.New("unexpected wire type")
+
+	eventType = reflect.TypeOf(eventtypes.Event{})
+)
+
+type (
+	encodeFunc func(b []byte, num protowire.Number, v reflect.Value) []byte
+	decodeFunc func(b []byte, typ protowire.Type, v reflect.Value) (int, error)
+)
+
+type structField struct {
+	index  int
+	num    protowire.Number
+	encode encodeFunc
+	decode decodeFunc
+}
+
+type structCodec struct {
+	// eventIndex is the index of the embedded eventtypes.Event, or -1
+	eventIndex int
+
+	fields []structField
+	byNum  map[protowire.Number]*structField
+}
+
+type codecEntry struct {
+	codec *structCodec
+	err   error
+}
+
+// codecs caches the codecs of the types of events
+var codecs sync.Map
+
+func codecFor(t reflect.Type) (*structCodec, error) {
+	if entry, ok := codecs.Load(t); ok {
+		return entry.(codecEntry).codec, entry.(codecEntry).err
+	}
+
+	var c *structCodec
+	var err error
+
+	if t.Kind() != reflect.Struct {
+		err = ErrUnsupportedEvent
+	} else if c, err = newStructCodec(t, true); err == nil && c.eventIndex < 0 && t != eventType {
+		// The fields of the envelope are mandatory
+		c, err = nil, fmt.Errorf("%w: %s doesn't embed eventtypes.Event", ErrUnsupportedEvent, t)
+	}
+
+	codecs.Store(t, codecEntry{codec: c, err: err})
+
+	return c, err
+}
+
+func newStructCodec(t reflect.Type, top bool) (*structCodec, error) {
+	c := &structCodec{
+		eventIndex: -1,
+		byNum:      make(map[protowire.Number]*structField),
+	}
+
+	if top && t == eventType {
+		// All the fields are in the envelope
+		return c, nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if top && field.Anonymous && field.Type == eventType {
+			c.eventIndex = i
+			continue
+		}
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		encode, decode, err := fieldCodec(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+		}
+
+		num, err := fieldNumber(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+		}
+
+		c.fields = append(c.fields, structField{
+			index:  i,
+			num:    num,
+			encode: encode,
+			decode: decode,
+		})
+	}
+
+	for i := range c.fields {
+		f := &c.fields[i]
+		if other, ok := c.byNum[f.num]; ok {
+			return nil, fmt.Errorf("%w: fields %s and %s of %s have the same number %d, set a pb tag on one of them",
+				ErrUnsupportedEvent, t.Field(other.index).Name, t.Field(f.index).Name, t, f.num)
+		}
+		c.byNum[f.num] = f
+	}
+
+	return c, nil
+}
+
+// fieldNumber returns the number of a field in the payload: The one given by
+// its pb tag or, by default, a hash of its name, so it doesn't depend on the
+// position of the field in the struct.
+func fieldNumber(field reflect.StructField) (protowire.Number, error) {
+	if tag, ok := field.Tag.Lookup("pb"); ok {
+		n, err := strconv.ParseInt(tag, 10, 32)
+		if err != nil || !protowire.Number(n).IsValid() {
+			return 0, fmt.Errorf("%w: invalid pb tag %q", ErrUnsupportedEvent, tag)
+		}
+		return protowire.Number(n), nil
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name))
+
+	// Map the hash to the valid numbers, skipping the reserved ones
+	const reserved = protowire.LastReservedNumber - protowire.FirstReservedNumber + 1
+	num := protowire.Number(h.Sum32()%uint32(protowire.MaxValidNumber-reserved)) + protowire.MinValidNumber
+	if num >= protowire.FirstReservedNumber {
+		num += reserved
+	}
+	return num, nil
+}
+
+func (c *structCodec) encode(b []byte, v reflect.Value) []byte {
+	for i := range c.fields {
+		f := &c.fields[i]
+		b = f.encode(b, f.num, v.Field(f.index))
+	}
+	return b
+}
+
+func (c *structCodec) decode(b []byte, v reflect.Value) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f, ok := c.byNum[num]
+		if !ok {
+			// Field unknown by this version
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+		} else {
+			var err error
+			n, err = f.decode(b, typ, v.Field(f.index))
+			if err != nil {
+				return fmt.Errorf("decoding field %d: %w", num, err)
+			}
+		}
+		b = b[n:]
+	}
+
+	return nil
+}
+
+func consumeVarint(b []byte, typ protowire.Type) (uint64, int, error) {
+	if typ != protowire.VarintType {
+		return 0, 0, errWireType
+	}
+	x, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, 0, protowire.ParseError(n)
+	}
+	return x, n, nil
+}
+
+func consumeFixed64(b []byte, typ protowire.Type) (uint64, int, error) {
+	if typ != protowire.Fixed64Type {
+		return 0, 0, errWireType
+	}
+	x, n := protowire.ConsumeFixed64(b)
+	if n < 0 {
+		return 0, 0, protowire.ParseError(n)
+	}
+	return x, n, nil
+}
+
+func consumeBytes(b []byte, typ protowire.Type) ([]byte, int, error) {
+	if typ != protowire.BytesType {
+		return nil, 0, errWireType
+	}
+	x, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return nil, 0, protowire.ParseError(n)
+	}
+	return x, n, nil
+}
+
+// scalarCodec returns the functions encoding and decoding a value of type t
+// as a single field. The encode function always writes the field, even for
+// zero values.
+func scalarCodec(t reflect.Type) (encodeFunc, decodeFunc, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				b = protowire.AppendTag(b, num, protowire.VarintType)
+				return protowire.AppendVarint(b, protowire.EncodeBool(v.Bool()))
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeVarint(b, typ)
+				v.SetBool(protowire.DecodeBool(x))
+				return n, err
+			}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				b = protowire.AppendTag(b, num, protowire.VarintType)
+				return protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int()))
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeVarint(b, typ)
+				v.SetInt(protowire.DecodeZigZag(x))
+				return n, err
+			}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				b = protowire.AppendTag(b, num, protowire.VarintType)
+				return protowire.AppendVarint(b, v.Uint())
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeVarint(b, typ)
+				v.SetUint(x)
+				return n, err
+			}, nil
+	case reflect.Float32, reflect.Float64:
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+				return protowire.AppendFixed64(b, math.Float64bits(v.Float()))
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeFixed64(b, typ)
+				v.SetFloat(math.Float64frombits(x))
+				return n, err
+			}, nil
+	case reflect.String:
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				b = protowire.AppendTag(b, num, protowire.BytesType)
+				return protowire.AppendString(b, v.String())
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeBytes(b, typ)
+				v.SetString(string(x))
+				return n, err
+			}, nil
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				b = protowire.AppendTag(b, num, protowire.BytesType)
+				return protowire.AppendBytes(b, v.Bytes())
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeBytes(b, typ)
+				v.SetBytes(append([]byte{}, x...))
+				return n, err
+			}, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: type %s", ErrUnsupportedEvent, t)
+}
+
+// fieldCodec returns the functions encoding and decoding a field of type t.
+// Zero values aren't encoded.
+func fieldCodec(t reflect.Type) (encodeFunc, decodeFunc, error) {
+	if encode, decode, err := scalarCodec(t); err == nil {
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+			if v.IsZero() {
+				return b
+			}
+			return encode(b, num, v)
+		}, decode, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		encode, decode, err := scalarCodec(t.Elem())
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				if v.IsNil() {
+					return b
+				}
+				return encode(b, num, v.Elem())
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				if v.IsNil() {
+					v.Set(reflect.New(t.Elem()))
+				}
+				return decode(b, typ, v.Elem())
+			}, nil
+	case reflect.Slice:
+		// Repeated fields
+		encode, decode, err := scalarCodec(t.Elem())
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				for i := 0; i < v.Len(); i++ {
+					b = encode(b, num, v.Index(i))
+				}
+				return b
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				elem := reflect.New(t.Elem()).Elem()
+				n, err := decode(b, typ, elem)
+				if err != nil {
+					return 0, err
+				}
+				v.Set(reflect.Append(v, elem))
+				return n, nil
+			}, nil
+	case reflect.Map:
+		// Repeated entries with the key as field 1 and the value as field
+		// 2, like protobuf maps
+		encodeKey, decodeKey, err := scalarCodec(t.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		encodeValue, decodeValue, err := scalarCodec(t.Elem())
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				var entry []byte
+				iter := v.MapRange()
+				for iter.Next() {
+					entry = encodeKey(entry[:0], 1, iter.Key())
+					entry = encodeValue(entry, 2, iter.Value())
+					b = protowire.AppendTag(b, num, protowire.BytesType)
+					b = protowire.AppendBytes(b, entry)
+				}
+				return b
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				entry, n, err := consumeBytes(b, typ)
+				if err != nil {
+					return 0, err
+				}
+
+				key := reflect.New(t.Key()).Elem()
+				value := reflect.New(t.Elem()).Elem()
+				for len(entry) > 0 {
+					num, typ, m := protowire.ConsumeTag(entry)
+					if m < 0 {
+						return 0, protowire.ParseError(m)
+					}
+					entry = entry[m:]
+
+					switch num {
+					case 1:
+						m, err = decodeKey(entry, typ, key)
+					case 2:
+						m, err = decodeValue(entry, typ, value)
+					default:
+						m = protowire.ConsumeFieldValue(num, typ, entry)
+						if m < 0 {
+							err = protowire.ParseError(m)
+						}
+					}
+					if err != nil {
+						return 0, err
+					}
+					entry = entry[m:]
+				}
+
+				if v.IsNil() {
+					v.Set(reflect.MakeMap(t))
+				}
+				v.SetMapIndex(key, value)
+				return n, nil
+			}, nil
+	case reflect.Struct:
+		c, err := newStructCodec(t, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(b []byte, num protowire.Number, v reflect.Value) []byte {
+				if v.IsZero() {
+					return b
+				}
+				b = protowire.AppendTag(b, num, protowire.BytesType)
+				return protowire.AppendBytes(b, c.encode(nil, v))
+			}, func(b []byte, typ protowire.Type, v reflect.Value) (int, error) {
+				x, n, err := consumeBytes(b, typ)
+				if err != nil {
+					return 0, err
+				}
+				return n, c.decode(x, v)
+			}, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: type %s", ErrUnsupportedEvent, t)
+}
+
+// EncodeEvent encodes an event of a gadget, a struct embedding
+// eventtypes.Event or a pointer to it, with the binary encoding.
+func EncodeEvent(ev any) (*pb.Event, error) {
+	v := reflect.ValueOf(ev)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, ErrUnsupportedEvent
+		}
+		v = v.Elem()
+	}
+
+	c, err := codecFor(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	var base eventtypes.Event
+	if c.eventIndex >= 0 {
+		base = v.Field(c.eventIndex).Interface().(eventtypes.Event)
+	} else {
+		base = v.Interface().(eventtypes.Event)
+	}
+
+	return &pb.Event{
+		Type:        string(base.Type),
+		Node:        base.Node,
+		Namespace:   base.Namespace,
+		Pod:         base.Pod,
+		Container:   base.Container,
+		Image:       base.Image,
+		ImageDigest: base.ImageDigest,
+		Timestamp:   int64(base.Timestamp),
+		Message:     base.Message,
+		Payload:     c.encode(nil, v),
+		Enriched:    encodeEnrichedData(&base.EnrichedData),
+	}, nil
+}
+
+func encodeEnrichedData(data *eventtypes.EnrichedData) *pb.EnrichedData {
+	if *data == (eventtypes.EnrichedData{}) {
+		return nil
+	}
+	return &pb.EnrichedData{
+		User:        data.User,
+		Group:       data.Group,
+		Cmdline:     data.Cmdline,
+		Exe:         data.Exe,
+		Ancestors:   data.Ancestors,
+		SrcEndpoint: data.SrcEndpoint,
+		DstEndpoint: data.DstEndpoint,
+	}
+}
+
+func decodeEnrichedData(in *pb.EnrichedData) eventtypes.EnrichedData {
+	if in == nil {
+		return eventtypes.EnrichedData{}
+	}
+	return eventtypes.EnrichedData{
+		User:        in.User,
+		Group:       in.Group,
+		Cmdline:     in.Cmdline,
+		Exe:         in.Exe,
+		Ancestors:   in.Ancestors,
+		SrcEndpoint: in.SrcEndpoint,
+		DstEndpoint: in.DstEndpoint,
+	}
+}
+
+// DecodeEvent decodes an event encoded with EncodeEvent into ev, a pointer to
+// a struct of the same type as the encoded one.
+func DecodeEvent(in *pb.Event, ev any) error {
+	v := reflect.ValueOf(ev)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("decoding event into %T: not a pointer", ev)
+	}
+	v = v.Elem()
+
+	c, err := codecFor(v.Type())
+	if err != nil {
+		return err
+	}
+
+	var base *eventtypes.Event
+	if c.eventIndex >= 0 {
+		base = v.Field(c.eventIndex).Addr().Interface().(*eventtypes.Event)
+	} else {
+		base = v.Addr().Interface().(*eventtypes.Event)
+	}
+
+	*base = eventtypes.Event{
+		CommonData: eventtypes.CommonData{
+			Node:        in.Node,
+			Namespace:   in.Namespace,
+			Pod:         in.Pod,
+			Container:   in.Container,
+			Image:       in.Image,
+			ImageDigest: in.ImageDigest,
+		},
+		EnrichedData: decodeEnrichedData(in.Enriched),
+		Timestamp:    eventtypes.Time(in.Timestamp),
+		Type:         eventtypes.EventType(in.Type),
+		Message:      in.Message,
+	}
+
+	return c.decode(in.Payload, v)
+}
+
+// AppendStreamData appends a message to b, prefixed by its size as a varint.
+// It's used to write the events received with the binary encoding to the
+// standard output of "gadgettracermanager -call receive-stream".
+func AppendStreamData(b []byte, data *pb.StreamData) ([]byte, error) {
+	b = protowire.AppendVarint(b, uint64(proto.Size(data)))
+	return proto.MarshalOptions{}.MarshalAppend(b, data)
+}
+
+// ConsumeStreamData parses a message written by AppendStreamData at the
+// beginning of b. It returns the number of bytes read, or 0 if b doesn't
+// contain the whole message yet.
+func ConsumeStreamData(b []byte) (*pb.StreamData, int, error) {
+	size, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		if len(b) < binary.MaxVarintLen64 {
+			// The size isn't complete
+			return nil, 0, nil
+		}
+		return nil, 0, protowire.ParseError(n)
+	}
+	if uint64(len(b)-n) < size {
+		return nil, 0, nil
+	}
+
+	data := &pb.StreamData{}
+	if err := proto.Unmarshal(b[n:n+int(size)], data); err != nil {
+		return nil, 0, err
+	}
+
+	return data, n + int(size), nil
+}
@@ -0,0 +1,418 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	execTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/exec/types"
+	openTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/open/types"
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+var baseEvent = eventtypes.Event{
+	CommonData: eventtypes.CommonData{
+		Node:        "node1",
+		Namespace:   "default",
+		Pod:         "nginx",
+		Container:   "nginx",
+		Image:       "docker.io/library/nginx:1.23",
+		ImageDigest: "sha256:5f3d2a8e1b7c6d5e4f3a2b1c0d9e8f7a",
+	},
+	EnrichedData: eventtypes.EnrichedData{
+		User:    "nginx",
+		Cmdline: "nginx: worker process",
+	},
+	Timestamp: 1665000000000000000,
+	Type:      eventtypes.NORMAL,
+}
+
+var openEvent = openTypes.Event{
+	Event:     baseEvent,
+	MountNsID: 4026532345,
+	Pid:       1234,
+	UID:       1000,
+	Comm:      "nginx",
+	Fd:        3,
+	Err:       -2,
+	Path:      "/etc/nginx/nginx.conf",
+}
+
+type complexEvent struct {
+	eventtypes.Event
+
+	Flag    bool
+	Ratio   float64
+	Small   int8
+	Ptr     *uint16
+	NilPtr  *string
+	Data    []byte
+	Values  []int32
+	Labels  map[string]string
+	Nested  struct{ A, B string }
+	private int
+	Ignored string `json:"-"`
+}
+
+func TestEncodeEventRoundTrip(t *testing.T) {
+	ptr := uint16(8080)
+	nested := complexEvent{
+		Event:   baseEvent,
+		Flag:    true,
+		Ratio:   -0.5,
+		Small:   -3,
+		Ptr:     &ptr,
+		Data:    []byte{0, 1, 2},
+		Values:  []int32{-1, 0, 1},
+		Labels:  map[string]string{"app": "web", "tier": ""},
+		private: 1,
+		Ignored: "ignored",
+	}
+	nested.Nested.A = "a"
+
+	for _, test := range []struct {
+		name     string
+		event    any
+		decoded  any
+		expected any
+	}{
+		{
+			name:     "open",
+			event:    openEvent,
+			decoded:  &openTypes.Event{},
+			expected: &openEvent,
+		},
+		{
+			name: "exec",
+			event: &execTypes.Event{
+				Event: baseEvent,
+				Pid:   42,
+				Comm:  "cat",
+				Args:  []string{"/bin/cat", "", "/etc/hosts"},
+			},
+			decoded: &execTypes.Event{},
+			expected: &execTypes.Event{
+				Event: baseEvent,
+				Pid:   42,
+				Comm:  "cat",
+				Args:  []string{"/bin/cat", "", "/etc/hosts"},
+			},
+		},
+		{
+			name:    "complex",
+			event:   nested,
+			decoded: &complexEvent{},
+			expected: func() *complexEvent {
+				e := nested
+				e.private = 0
+				e.Ignored = ""
+				return &e
+			}(),
+		},
+		{
+			name: "message",
+			event: eventtypes.Event{
+				Type:    eventtypes.ERR,
+				Message: "tracer failed",
+			},
+			decoded: &eventtypes.Event{},
+			expected: &eventtypes.Event{
+				Type:    eventtypes.ERR,
+				Message: "tracer failed",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			encoded, err := EncodeEvent(test.event)
+			if err != nil {
+				t.Fatalf("Failed to encode event: %s", err)
+			}
+
+			if err := DecodeEvent(encoded, test.decoded); err != nil {
+				t.Fatalf("Failed to decode event: %s", err)
+			}
+
+			if !reflect.DeepEqual(test.decoded, test.expected) {
+				t.Fatalf("Unexpected event %+v, expected %+v", test.decoded, test.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeEventUnknownFields(t *testing.T) {
+	type newEvent struct {
+		eventtypes.Event
+
+		Pid  uint32
+		Comm string
+		Cwd  string
+	}
+	type oldEvent struct {
+		eventtypes.Event
+
+		Pid  uint32
+		Comm string
+	}
+
+	encoded, err := EncodeEvent(newEvent{Pid: 1, Comm: "sh", Cwd: "/"})
+	if err != nil {
+		t.Fatalf("Failed to encode event: %s", err)
+	}
+
+	var decoded oldEvent
+	if err := DecodeEvent(encoded, &decoded); err != nil {
+		t.Fatalf("Failed to decode event: %s", err)
+	}
+
+	if decoded.Pid != 1 || decoded.Comm != "sh" {
+		t.Fatalf("Unexpected event %+v", decoded)
+	}
+}
+
+func TestDecodeEventInsertedField(t *testing.T) {
+	type oldEvent struct {
+		eventtypes.Event
+
+		Pid  uint32 `json:"pid"`
+		Comm string `json:"comm"`
+		Path string `json:"path"`
+	}
+	// The new version inserts a field and moves another one
+	type newEvent struct {
+		eventtypes.Event
+
+		Path string `json:"path"`
+		Pid  uint32 `json:"pid"`
+		Tid  uint32 `json:"tid"`
+		Comm string `json:"comm"`
+	}
+
+	encoded, err := EncodeEvent(newEvent{Path: "/etc/hosts", Pid: 1, Tid: 2, Comm: "sh"})
+	if err != nil {
+		t.Fatalf("Failed to encode event: %s", err)
+	}
+
+	var decodedOld oldEvent
+	if err := DecodeEvent(encoded, &decodedOld); err != nil {
+		t.Fatalf("Failed to decode event: %s", err)
+	}
+	if expected := (oldEvent{Pid: 1, Comm: "sh", Path: "/etc/hosts"}); decodedOld != expected {
+		t.Fatalf("Unexpected event %+v, expected %+v", decodedOld, expected)
+	}
+
+	encoded, err = EncodeEvent(oldEvent{Pid: 1, Comm: "sh", Path: "/etc/hosts"})
+	if err != nil {
+		t.Fatalf("Failed to encode event: %s", err)
+	}
+
+	var decodedNew newEvent
+	if err := DecodeEvent(encoded, &decodedNew); err != nil {
+		t.Fatalf("Failed to decode event: %s", err)
+	}
+	if expected := (newEvent{Path: "/etc/hosts", Pid: 1, Comm: "sh"}); decodedNew != expected {
+		t.Fatalf("Unexpected event %+v, expected %+v", decodedNew, expected)
+	}
+}
+
+func TestDecodeEventFieldTags(t *testing.T) {
+	type oldEvent struct {
+		eventtypes.Event
+
+		Pid uint32 `json:"pid" pb:"1"`
+	}
+	// Renaming a field with a pb tag keeps it compatible
+	type newEvent struct {
+		eventtypes.Event
+
+		Tgid uint32 `json:"tgid" pb:"1"`
+	}
+
+	encoded, err := EncodeEvent(oldEvent{Pid: 42})
+	if err != nil {
+		t.Fatalf("Failed to encode event: %s", err)
+	}
+
+	var decoded newEvent
+	if err := DecodeEvent(encoded, &decoded); err != nil {
+		t.Fatalf("Failed to decode event: %s", err)
+	}
+	if decoded.Tgid != 42 {
+		t.Fatalf("Unexpected event %+v", decoded)
+	}
+}
+
+func TestEncodeEventUnsupported(t *testing.T) {
+	type chanEvent struct {
+		eventtypes.Event
+
+		C chan int
+	}
+	type sameNumberEvent struct {
+		eventtypes.Event
+
+		A string `pb:"1"`
+		B string `pb:"1"`
+	}
+	type invalidTagEvent struct {
+		eventtypes.Event
+
+		A string `pb:"19000"`
+	}
+
+	for _, event := range []any{
+		chanEvent{},
+		sameNumberEvent{},
+		invalidTagEvent{},
+		struct{ Pid uint32 }{},
+		(*openTypes.Event)(nil),
+	} {
+		if _, err := EncodeEvent(event); !errors.Is(err, ErrUnsupportedEvent) {
+			t.Fatalf("Expected ErrUnsupportedEvent encoding %T, got %v", event, err)
+		}
+	}
+}
+
+func TestStreamData(t *testing.T) {
+	encoded, err := EncodeEvent(openEvent)
+	if err != nil {
+		t.Fatalf("Failed to encode event: %s", err)
+	}
+
+	var b []byte
+	messages := []*pb.StreamData{
+		{Event: encoded},
+		{Line: `{"type":"normal"}`},
+		{},
+	}
+	for _, data := range messages {
+		if b, err = AppendStreamData(b, data); err != nil {
+			t.Fatalf("Failed to append message: %s", err)
+		}
+	}
+
+	// Parse the messages as they would be received, byte by byte
+	var received []*pb.StreamData
+	var buf []byte
+	for i := range b {
+		buf = append(buf, b[i])
+
+		data, n, err := ConsumeStreamData(buf)
+		if err != nil {
+			t.Fatalf("Failed to consume message: %s", err)
+		}
+		if n == 0 {
+			continue
+		}
+
+		received = append(received, data)
+		buf = buf[n:]
+	}
+
+	if len(buf) != 0 {
+		t.Fatalf("%d bytes left", len(buf))
+	}
+	if len(received) != len(messages) {
+		t.Fatalf("Received %d messages, expected %d", len(received), len(messages))
+	}
+
+	var decoded openTypes.Event
+	if err := DecodeEvent(received[0].Event, &decoded); err != nil {
+		t.Fatalf("Failed to decode event: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, openEvent) {
+		t.Fatalf("Unexpected event %+v", decoded)
+	}
+	if received[1].Line != messages[1].Line {
+		t.Fatalf("Unexpected line %q", received[1].Line)
+	}
+}
+
+func TestPublishEvent(t *testing.T) {
+	g := NewGadgetStream()
+	ch := g.Subscribe()
+
+	g.PublishEvent(openEvent)
+	g.Publish(`{"type":"normal"}`)
+
+	l := <-ch
+	expected, _ := json.Marshal(openEvent)
+	if l.JSON() != string(expected) {
+		t.Fatalf("Unexpected JSON %q", l.JSON())
+	}
+	if _, err := l.Binary(); err != nil {
+		t.Fatalf("Failed to encode event: %s", err)
+	}
+
+	l = <-ch
+	if l.JSON() != `{"type":"normal"}` {
+		t.Fatalf("Unexpected JSON %q", l.JSON())
+	}
+	if _, err := l.Binary(); !errors.Is(err, ErrUnsupportedEvent) {
+		t.Fatalf("Expected ErrUnsupportedEvent, got %v", err)
+	}
+}
+
+// The benchmarks below measure the cost of sending an event from the tracer
+// to the client: encoding it, writing it to the stream of receive-stream and
+// decoding it.
+
+func BenchmarkJSON(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		line, err := json.Marshal(openEvent)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		buf := append(line, '\n')
+
+		var e openTypes.Event
+		if err := json.Unmarshal(buf[:len(buf)-1], &e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBinary(b *testing.B) {
+	b.ReportAllocs()
+
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeEvent(openEvent)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		buf, err = AppendStreamData(buf[:0], &pb.StreamData{Event: encoded})
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		data, _, err := ConsumeStreamData(buf)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var e openTypes.Event
+		if err := DecodeEvent(data.Event, &e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
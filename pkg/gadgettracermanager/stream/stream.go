@@ -15,8 +15,15 @@
 package stream
 
 import (
+	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	pb "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/api"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 )
 
 const (
@@ -25,29 +32,151 @@ const (
 )
 
 type TimestampedLine struct {
+	// Line is the event as published with Publish(). Use JSON() to get
+	// the events published with PublishEvent() too.
 	Line      string
 	Timestamp time.Time
+
+	// Seq is the sequence number of the line in the stream, starting at 1.
+	// It's 0 for the lines signaling lost events.
+	Seq uint64
+
+	// EventLost signals that LostCount lines weren't sent to the
+	// subscriber because its channel was full.
 	EventLost bool
+	LostCount uint64
+
+	event *event
+}
+
+// event is an event published with PublishEvent(). It's encoded when a
+// subscriber needs it, at most once for each encoding.
+type event struct {
+	value any
+
+	jsonOnce sync.Once
+	json     string
+
+	binaryOnce sync.Once
+	binary     *pb.Event
+	binaryErr  error
+}
+
+// JSON returns the event encoded as JSON.
+func (l *TimestampedLine) JSON() string {
+	e := l.event
+	if e == nil {
+		return l.Line
+	}
+
+	e.jsonOnce.Do(func() {
+		r, err := json.Marshal(e.value)
+		if err != nil {
+			log.Warnf("Error marshalling event: %s", err)
+			return
+		}
+		e.json = string(r)
+	})
+
+	return e.json
+}
+
+// Binary returns the event encoded with the binary encoding. It returns
+// ErrUnsupportedEvent for the events published as JSON with Publish() and
+// the ones whose type isn't supported by the encoding.
+func (l *TimestampedLine) Binary() (*pb.Event, error) {
+	e := l.event
+	if e == nil {
+		return nil, ErrUnsupportedEvent
+	}
+
+	e.binaryOnce.Do(func() {
+		e.binary, e.binaryErr = EncodeEvent(e.value)
+	})
+
+	return e.binary, e.binaryErr
+}
+
+// LostEvents returns the event sent to clients instead of count lost events.
+func LostEvents(count uint64) eventtypes.Event {
+	return eventtypes.Err(fmt.Sprintf("%d events lost in gadget tracer manager", count))
+}
+
+// StreamData returns the message sent to the clients receiving the line,
+// with the event encoded with the binary encoding if binary is true. node is
+// the node given in the events signaling lost events.
+func (l *TimestampedLine) StreamData(binary bool, node string) *pb.StreamData {
+	data := &pb.StreamData{
+		Seq:       l.Seq,
+		LostCount: l.LostCount,
+	}
+
+	if l.EventLost {
+		ev := LostEvents(l.LostCount)
+		ev.Node = node
+		if binary {
+			data.Event, _ = EncodeEvent(ev)
+		} else {
+			line, _ := json.Marshal(ev)
+			data.Line = string(line)
+		}
+		return data
+	}
+
+	if binary {
+		// Events not supported by the binary encoding are sent as JSON
+		data.Event, _ = l.Binary()
+	}
+	if data.Event == nil {
+		data.Line = l.JSON()
+	}
+
+	return data
 }
 
 type GadgetStream struct {
 	mu sync.RWMutex
 
-	previousLines []TimestampedLine
+	// history is a ring buffer with the last HistorySize lines, the next
+	// one being written at historyNext
+	history     []TimestampedLine
+	historyNext int
+
+	// seq is the sequence number of the last published line
+	seq uint64
 
 	// subs contains a list of subscribers
-	subs map[chan TimestampedLine]struct{}
+	subs map[chan TimestampedLine]*subscriber
 
 	closed bool
 }
 
+type subscriber struct {
+	// lost is the number of lines not sent to the subscriber since the
+	// last time its channel was full
+	lost uint64
+}
+
 func NewGadgetStream() *GadgetStream {
 	return &GadgetStream{
-		subs: make(map[chan TimestampedLine]struct{}),
+		history: make([]TimestampedLine, 0, HistorySize),
+		subs:    make(map[chan TimestampedLine]*subscriber),
 	}
 }
 
+// Subscribe returns a channel receiving the lines in the history of the
+// stream, then the new ones.
 func (g *GadgetStream) Subscribe() chan TimestampedLine {
+	return g.SubscribeFrom(0)
+}
+
+// SubscribeFrom returns a channel receiving the lines published after the
+// one with the sequence number seq, typically the last one received by a
+// client before reconnecting. The lines no longer in the history are
+// reported as lost. With seq 0, or a sequence number that this stream
+// didn't reach, e.g. because it was created again, the whole history is
+// sent as with Subscribe().
+func (g *GadgetStream) SubscribeFrom(seq uint64) chan TimestampedLine {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -55,15 +184,40 @@ func (g *GadgetStream) Subscribe() chan TimestampedLine {
 		return nil
 	}
 
+	if seq > g.seq {
+		seq = 0
+	}
+
+	// The channel can hold the whole history and a lost events signal
 	ch := make(chan TimestampedLine, SubChannelSize)
-	for _, l := range g.previousLines {
-		ch <- l
+
+	lines := g.historyLines()
+	if seq > 0 && len(lines) > 0 && lines[0].Seq > seq+1 {
+		ch <- TimestampedLine{
+			Timestamp: time.Now(),
+			EventLost: true,
+			LostCount: lines[0].Seq - seq - 1,
+		}
 	}
-	g.subs[ch] = struct{}{}
+	for _, l := range lines {
+		if l.Seq > seq {
+			ch <- l
+		}
+	}
+	g.subs[ch] = &subscriber{}
 
 	return ch
 }
 
+// historyLines returns the lines of the history, the oldest first.
+func (g *GadgetStream) historyLines() []TimestampedLine {
+	if len(g.history) < HistorySize {
+		return g.history
+	}
+
+	return append(g.history[g.historyNext:len(g.history):len(g.history)], g.history[:g.historyNext]...)
+}
+
 func (g *GadgetStream) Unsubscribe(ch chan TimestampedLine) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -79,7 +233,25 @@ func (g *GadgetStream) Unsubscribe(ch chan TimestampedLine) {
 	}
 }
 
+// Publish publishes an event encoded as JSON.
 func (g *GadgetStream) Publish(line string) {
+	g.publish(TimestampedLine{
+		Line:      line,
+		Timestamp: time.Now(),
+	})
+}
+
+// PublishEvent publishes an event of a gadget, typically a struct embedding
+// eventtypes.Event. It's encoded as needed by the subscribers, so it must not
+// be modified once published.
+func (g *GadgetStream) PublishEvent(ev any) {
+	g.publish(TimestampedLine{
+		Timestamp: time.Now(),
+		event:     &event{value: ev},
+	})
+}
+
+func (g *GadgetStream) publish(newLine TimestampedLine) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -87,37 +259,60 @@ func (g *GadgetStream) Publish(line string) {
 		return
 	}
 
-	newLine := TimestampedLine{
-		Line:      line,
-		Timestamp: time.Now(),
+	g.seq++
+	newLine.Seq = g.seq
+
+	if len(g.history) < HistorySize {
+		g.history = append(g.history, newLine)
+	} else {
+		g.history[g.historyNext] = newLine
+	}
+	g.historyNext = (g.historyNext + 1) % HistorySize
+
+	for ch, sub := range g.subs {
+		sendLine(ch, sub, newLine)
 	}
+}
+
+// sendLine sends a line to a subscriber without blocking. Only publish() and
+// Close() send to the channels, with g.mu held, so the free space of a
+// channel can only grow meanwhile. When the channel is full, the line is
+// counted as lost and the count is sent before the next line that fits.
+func sendLine(ch chan TimestampedLine, sub *subscriber, line TimestampedLine) {
+	free := cap(ch) - len(ch)
+
+	if sub.lost > 0 {
+		if free < 2 {
+			sub.lost++
+			return
+		}
 
-	if len(g.previousLines) == HistorySize {
-		// Force new array allocation to avoid an ever growing underlying array
-		// TODO: check possible performance issue
-		g.previousLines = append([]TimestampedLine{}, g.previousLines[1:]...)
-	}
-	g.previousLines = append(g.previousLines, newLine)
-
-	for ch := range g.subs {
-		queuedCount := len(ch)
-		switch {
-		case queuedCount == cap(ch):
-			// Channel full. There is nothing we can do.
-			continue
-		case queuedCount == cap(ch)-1:
-			// Channel almost full. Last chance to signal the problem.
-			ch <- TimestampedLine{EventLost: true}
-		case queuedCount < cap(ch)-1:
-			ch <- newLine
+		ch <- TimestampedLine{
+			Timestamp: time.Now(),
+			EventLost: true,
+			LostCount: sub.lost,
 		}
+		sub.lost = 0
+	} else if free < 1 {
+		sub.lost = 1
+		return
 	}
+
+	ch <- line
 }
 
 func (g *GadgetStream) Close() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	for ch := range g.subs {
+	for ch, sub := range g.subs {
+		// Signal the lines lost since the last one, if possible
+		if sub.lost > 0 && len(ch) < cap(ch) {
+			ch <- TimestampedLine{
+				Timestamp: time.Now(),
+				EventLost: true,
+				LostCount: sub.lost,
+			}
+		}
 		close(ch)
 	}
 	g.closed = true
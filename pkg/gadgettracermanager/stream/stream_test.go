@@ -0,0 +1,134 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"fmt"
+	"testing"
+)
+
+func publishLines(g *GadgetStream, count int) {
+	for i := 0; i < count; i++ {
+		g.Publish(fmt.Sprintf("line %d", i))
+	}
+}
+
+// receive returns the lines queued in a channel
+func receive(ch chan TimestampedLine) []TimestampedLine {
+	var lines []TimestampedLine
+	for len(ch) > 0 {
+		lines = append(lines, <-ch)
+	}
+	return lines
+}
+
+func checkSeqs(t *testing.T, lines []TimestampedLine, first, last uint64) {
+	t.Helper()
+
+	if uint64(len(lines)) != last-first+1 {
+		t.Fatalf("Received %d lines, expected %d", len(lines), last-first+1)
+	}
+	for i, l := range lines {
+		if l.EventLost || l.Seq != first+uint64(i) {
+			t.Fatalf("Unexpected line %d: %+v, expected sequence number %d", i, l, first+uint64(i))
+		}
+	}
+}
+
+func TestSequenceNumbers(t *testing.T) {
+	g := NewGadgetStream()
+	publishLines(g, 10)
+
+	ch := g.Subscribe()
+	publishLines(g, 5)
+
+	checkSeqs(t, receive(ch), 1, 15)
+}
+
+func TestHistory(t *testing.T) {
+	g := NewGadgetStream()
+	publishLines(g, HistorySize+42)
+
+	lines := receive(g.Subscribe())
+	checkSeqs(t, lines, 43, HistorySize+42)
+	if lines[0].Line != "line 42" {
+		t.Fatalf("Unexpected oldest line %q", lines[0].Line)
+	}
+}
+
+func TestLostCount(t *testing.T) {
+	g := NewGadgetStream()
+	ch := g.Subscribe()
+
+	publishLines(g, SubChannelSize+10)
+
+	// The channel is full and the lines published meanwhile are lost
+	lines := receive(ch)
+	checkSeqs(t, lines, 1, SubChannelSize)
+
+	g.Publish("after")
+
+	lines = receive(ch)
+	if len(lines) != 2 {
+		t.Fatalf("Received %d lines, expected 2", len(lines))
+	}
+	if !lines[0].EventLost || lines[0].LostCount != 10 {
+		t.Fatalf("Expected 10 lost lines, got %+v", lines[0])
+	}
+	if lines[1].Line != "after" || lines[1].Seq != SubChannelSize+11 {
+		t.Fatalf("Unexpected line %+v", lines[1])
+	}
+}
+
+func TestLostCountOnClose(t *testing.T) {
+	g := NewGadgetStream()
+	ch := g.Subscribe()
+
+	publishLines(g, SubChannelSize+3)
+	<-ch
+	g.Close()
+
+	var last TimestampedLine
+	for l := range ch {
+		last = l
+	}
+	if !last.EventLost || last.LostCount != 3 {
+		t.Fatalf("Expected 3 lost lines, got %+v", last)
+	}
+}
+
+func TestSubscribeFrom(t *testing.T) {
+	g := NewGadgetStream()
+	publishLines(g, 50)
+
+	// Resume from a line still in the history
+	checkSeqs(t, receive(g.SubscribeFrom(40)), 41, 50)
+
+	// Nothing to replay
+	if lines := receive(g.SubscribeFrom(50)); len(lines) != 0 {
+		t.Fatalf("Unexpected lines %+v", lines)
+	}
+
+	// Unknown sequence number: the whole history is sent
+	checkSeqs(t, receive(g.SubscribeFrom(1000)), 1, 50)
+
+	// Resume from a line no longer in the history
+	publishLines(g, HistorySize)
+	lines := receive(g.SubscribeFrom(20))
+	if !lines[0].EventLost || lines[0].LostCount != 30 {
+		t.Fatalf("Expected 30 lost lines, got %+v", lines[0])
+	}
+	checkSeqs(t, lines[1:], 51, HistorySize+50)
+}
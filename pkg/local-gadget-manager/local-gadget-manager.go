@@ -25,7 +25,9 @@ import (
 	gadgetcollection "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection"
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
 	containersmap "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/containers-map"
+	gadgetstream "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgettracermanager/stream"
 	tracercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/tracer-collection"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/ebpf/rlimit"
@@ -228,6 +230,18 @@ func (l *LocalGadgetManager) DeleteTraceResource(name string) error {
 	return nil
 }
 
+func (l *LocalGadgetManager) AddTracer(tracerID string, containerSelector containercollection.ContainerSelector) error {
+	return l.tracerCollection.AddTracer(tracerID, containerSelector)
+}
+
+func (l *LocalGadgetManager) RemoveTracer(tracerID string) error {
+	return l.tracerCollection.RemoveTracer(tracerID)
+}
+
+func (l *LocalGadgetManager) Stream(tracerID string) (*gadgetstream.GadgetStream, error) {
+	return l.tracerCollection.Stream(tracerID)
+}
+
 func (l *LocalGadgetManager) PublishEvent(tracerID string, line string) error {
 	gadgetStream, err := l.tracerCollection.Stream(tracerID)
 	if err != nil {
@@ -238,10 +252,24 @@ func (l *LocalGadgetManager) PublishEvent(tracerID string, line string) error {
 	return nil
 }
 
+func (l *LocalGadgetManager) PublishTypedEvent(tracerID string, event any) error {
+	gadgetStream, err := l.tracerCollection.Stream(tracerID)
+	if err != nil {
+		return fmt.Errorf("cannot find stream for tracer %q", tracerID)
+	}
+
+	gadgetStream.PublishEvent(event)
+	return nil
+}
+
 func (l *LocalGadgetManager) TracerMountNsMap(tracerID string) (*ebpf.Map, error) {
 	return l.tracerCollection.TracerMountNsMap(tracerID)
 }
 
+func (l *LocalGadgetManager) TracerCgroupIDMap(tracerID string) (*ebpf.Map, error) {
+	return l.tracerCollection.TracerCgroupIDMap(tracerID)
+}
+
 func (l *LocalGadgetManager) ContainersMap() *ebpf.Map {
 	if l.containersMap == nil {
 		return nil
@@ -250,6 +278,13 @@ func (l *LocalGadgetManager) ContainersMap() *ebpf.Map {
 	return l.containersMap.ContainersMap()
 }
 
+func lineJSON(line gadgetstream.TimestampedLine) string {
+	if line.EventLost {
+		return eventtypes.EventString(gadgetstream.LostEvents(line.LostCount))
+	}
+	return line.JSON()
+}
+
 func (l *LocalGadgetManager) StreamTraceResourceOutput(name string, stop chan struct{}) (chan string, error) {
 	gadgetStream, err := l.tracerCollection.Stream(traceName(name))
 	if err != nil {
@@ -264,7 +299,7 @@ func (l *LocalGadgetManager) StreamTraceResourceOutput(name string, stop chan st
 		if stop == nil {
 			for len(ch) > 0 {
 				line := <-ch
-				out <- line.Line
+				out <- lineJSON(line)
 			}
 			gadgetStream.Unsubscribe(ch)
 			close(out)
@@ -276,7 +311,7 @@ func (l *LocalGadgetManager) StreamTraceResourceOutput(name string, stop chan st
 					close(out)
 					return
 				case line := <-ch:
-					out <- line.Line
+					out <- lineJSON(line)
 				}
 			}
 		}
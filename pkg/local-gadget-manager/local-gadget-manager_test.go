@@ -272,7 +272,7 @@ func TestDNS(t *testing.T) {
 		},
 	}
 
-	if event != expectedEvent {
+	if !reflect.DeepEqual(event, expectedEvent) {
 		t.Fatalf("Received: %v, Expected: %v", event, expectedEvent)
 	}
 
@@ -293,6 +293,8 @@ func TestDNS(t *testing.T) {
 			},
 		},
 		ID:         "0000",
+		DstIP:      nameserver,
+		DstPort:    53,
 		Qr:         dnstypes.DNSPktTypeQuery,
 		Nameserver: nameserver,
 		DNSName:    "magic-1-2-3-4.nip.io.",
@@ -303,8 +305,12 @@ func TestDNS(t *testing.T) {
 	// normalize
 	id := event.ID
 	event.ID = "0000"
+	event.Timestamp = 0
+	srcIP, srcPort := event.SrcIP, event.SrcPort
+	event.SrcIP = ""
+	event.SrcPort = 0
 
-	if event != expectedEvent {
+	if !reflect.DeepEqual(event, expectedEvent) {
 		t.Fatalf("Received: %v, Expected: %v", event, expectedEvent)
 	}
 
@@ -315,16 +321,27 @@ func TestDNS(t *testing.T) {
 		t.Fatalf("failed to unmarshal json: %s", err)
 	}
 
+	expectedEvent.SrcIP = nameserver
+	expectedEvent.SrcPort = 53
+	expectedEvent.DstIP = srcIP
+	expectedEvent.DstPort = srcPort
 	expectedEvent.PktType = "HOST"
 	expectedEvent.Qr = dnstypes.DNSPktTypeResponse
 	expectedEvent.Rcode = "NoError"
+	expectedEvent.NumAnswers = 1
+	expectedEvent.Addresses = []string{"1.2.3.4"}
 
 	if event.ID != id {
 		t.Fatalf("Response ID: %v, Expected: %v", event.ID, id)
 	}
+	if event.Latency <= 0 {
+		t.Fatalf("Response latency: %v, Expected a positive value", event.Latency)
+	}
 	event.ID = "0000"
+	event.Timestamp = 0
+	event.Latency = 0
 
-	if event != expectedEvent {
+	if !reflect.DeepEqual(event, expectedEvent) {
 		t.Fatalf("Received: %v, Expected: %v", event, expectedEvent)
 	}
 
@@ -347,7 +364,7 @@ func TestDNS(t *testing.T) {
 		},
 	}
 
-	if event != expectedEvent {
+	if !reflect.DeepEqual(event, expectedEvent) {
 		t.Fatalf("Received: %v, Expected: %v", event, expectedEvent)
 	}
 
@@ -468,6 +485,9 @@ outerLoop:
 			t.Fatalf("failed to unmarshal json: %s", err)
 		}
 
+		// normalize
+		event.Timestamp = 0
+
 		// Network graph does not guarantee the order where the events are received.
 		// So, we check if the received event is part of the expected events.
 		// If this is not the case we would hit the below Fatalf.
@@ -0,0 +1,229 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns/filter"
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// secondsPerUnit contains the factors to convert values of columns with a time
+// unit to seconds, the base unit for time in Prometheus.
+var secondsPerUnit = map[string]float64{
+	"ns": 1e-9,
+	"us": 1e-6,
+	"ms": 1e-3,
+	"s":  1,
+}
+
+// eventCollector updates the metrics of a gadget with its events, received as
+// JSON lines.
+type eventCollector interface {
+	observe(line string) error
+}
+
+type baseEventGetter interface {
+	GetBaseEvent() *eventtypes.Event
+}
+
+// metric updates a single metric with events of type T
+type metric[T any] struct {
+	labels []*columns.Column[T]
+	field  *columns.Column[T]
+	scale  float64
+	filter *filter.FilterSpec[T]
+
+	counter   *prometheus.CounterVec
+	histogram *prometheus.HistogramVec
+}
+
+type gadgetCollector[T any] struct {
+	metrics []*metric[T]
+}
+
+func newGadgetCollector[T any](
+	cols *columns.Columns[T],
+	configs []MetricConfig,
+	registerer prometheus.Registerer,
+) (*gadgetCollector[T], error) {
+	colMap := cols.GetColumnMap()
+	collector := &gadgetCollector[T]{}
+
+	for _, config := range configs {
+		m, err := newMetric(colMap, &config)
+		if err != nil {
+			return nil, fmt.Errorf("metric %q: %w", config.Name, err)
+		}
+
+		var c prometheus.Collector = m.counter
+		if m.histogram != nil {
+			c = m.histogram
+		}
+		if err := registerer.Register(c); err != nil {
+			return nil, fmt.Errorf("registering metric %q: %w", config.Name, err)
+		}
+
+		collector.metrics = append(collector.metrics, m)
+	}
+
+	return collector, nil
+}
+
+func newMetric[T any](colMap columns.ColumnMap[T], config *MetricConfig) (*metric[T], error) {
+	m := &metric[T]{scale: 1}
+
+	labelNames := make([]string, 0, len(config.Labels))
+	for _, name := range config.Labels {
+		column, ok := colMap.GetColumn(strings.ToLower(name))
+		if !ok {
+			return nil, fmt.Errorf("label: column %q not found", name)
+		}
+		m.labels = append(m.labels, column)
+		labelNames = append(labelNames, strings.ToLower(name))
+	}
+
+	if config.Field != "" {
+		column, ok := colMap.GetColumn(strings.ToLower(config.Field))
+		if !ok {
+			return nil, fmt.Errorf("field: column %q not found", config.Field)
+		}
+		if !isNumeric(column.Kind()) {
+			return nil, fmt.Errorf("field: column %q isn't numeric", config.Field)
+		}
+		m.field = column
+
+		if factor, ok := secondsPerUnit[column.Unit]; ok {
+			m.scale = factor
+		}
+	}
+
+	if config.Filter != "" {
+		var err error
+		m.filter, err = filter.GetFilterFromExpression(colMap, config.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+	}
+
+	help := config.Help
+	if help == "" {
+		help = fmt.Sprintf("Inspektor Gadget metric %s", config.Name)
+	}
+
+	switch config.Type {
+	case MetricTypeCounter:
+		m.counter = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: config.Name,
+			Help: help,
+		}, labelNames)
+	case MetricTypeHistogram:
+		buckets := config.Buckets
+		if len(buckets) == 0 {
+			buckets = prometheus.DefBuckets
+		}
+		m.histogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    config.Name,
+			Help:    help,
+			Buckets: buckets,
+		}, labelNames)
+	}
+
+	return m, nil
+}
+
+func (c *gadgetCollector[T]) observe(line string) error {
+	var entry T
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return fmt.Errorf("unmarshaling event %q: %w", line, err)
+	}
+
+	// Errors, warnings, etc. aren't taken into account
+	if e, ok := any(&entry).(baseEventGetter); ok && e.GetBaseEvent().Type != eventtypes.NORMAL {
+		return nil
+	}
+
+	for _, m := range c.metrics {
+		m.observe(&entry)
+	}
+
+	return nil
+}
+
+func (m *metric[T]) observe(entry *T) {
+	if m.filter != nil && !m.filter.Match(entry) {
+		return
+	}
+
+	labels := make([]string, 0, len(m.labels))
+	for _, column := range m.labels {
+		labels = append(labels, formatLabel(column.Get(entry)))
+	}
+
+	if m.histogram != nil {
+		m.histogram.WithLabelValues(labels...).Observe(m.value(entry))
+		return
+	}
+
+	if m.field == nil {
+		m.counter.WithLabelValues(labels...).Inc()
+		return
+	}
+
+	// Counters can't decrease
+	if value := m.value(entry); value > 0 {
+		m.counter.WithLabelValues(labels...).Add(value)
+	}
+}
+
+func (m *metric[T]) value(entry *T) float64 {
+	v := m.field.GetRaw(entry)
+
+	var value float64
+	switch {
+	case v.CanInt():
+		value = float64(v.Int())
+	case v.CanUint():
+		value = float64(v.Uint())
+	case v.CanFloat():
+		value = v.Float()
+	}
+
+	return value * m.scale
+}
+
+func formatLabel(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+)
+
+type MetricType string
+
+const (
+	// MetricTypeCounter counts the events, or sums the values of a field of
+	// them if one is given.
+	MetricTypeCounter MetricType = "counter"
+
+	// MetricTypeHistogram observes the values of a field of the events.
+	MetricTypeHistogram MetricType = "histogram"
+)
+
+// Config is the declarative configuration of the metrics exporter. It lists
+// the gadgets to run and the metrics to produce from their events:
+//
+//	gadgets:
+//	- gadget: dns
+//	  metrics:
+//	  - name: dns_queries_total
+//	    type: counter
+//	    labels: [namespace, pod, container, qtype, rcode]
+//	    filter: qr=="R"
+//	- gadget: fsslower
+//	  parameters:
+//	    filesystem: ext4
+//	    minlatency: "0"
+//	  filter:
+//	    namespace: prod
+//	  metrics:
+//	  - name: fsslower_latency_seconds
+//	    type: histogram
+//	    field: lat
+//	    labels: [namespace, pod]
+type Config struct {
+	Gadgets []GadgetConfig `json:"gadgets"`
+}
+
+// GadgetConfig describes a gadget to run and the metrics to produce from its
+// events.
+type GadgetConfig struct {
+	// Gadget is the name of the gadget, as in Trace.Spec.Gadget
+	Gadget string `json:"gadget"`
+
+	// Parameters are the parameters of the gadget, as in
+	// Trace.Spec.Parameters
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Filter selects the containers to trace, as in Trace.Spec.Filter. All
+	// of them are traced if it's not set.
+	Filter *gadgetv1alpha1.ContainerFilter `json:"filter,omitempty"`
+
+	Metrics []MetricConfig `json:"metrics"`
+}
+
+// MetricConfig describes how to produce a metric from the events of a gadget.
+type MetricConfig struct {
+	// Name of the metric, e.g. tcp_connect_total
+	Name string `json:"name"`
+
+	// Help describes the metric. A generic description is used if it's empty.
+	Help string `json:"help,omitempty"`
+
+	Type MetricType `json:"type"`
+
+	// Labels are the columns of the events whose values are used as labels,
+	// e.g. namespace, pod and container, or any column of the gadget.
+	Labels []string `json:"labels,omitempty"`
+
+	// Field is the numeric column whose value is observed by histograms or
+	// added up by counters. Values of columns with a time unit (ns, us, ms)
+	// are converted to seconds.
+	Field string `json:"field,omitempty"`
+
+	// Buckets of histograms. Prometheus' default buckets are used if it's
+	// empty.
+	Buckets []float64 `json:"buckets,omitempty"`
+
+	// Filter is an expression, like the one of the --filter flag, that the
+	// events have to match to be taken into account.
+	Filter string `json:"filter,omitempty"`
+}
+
+// ParseConfig parses a configuration in YAML (or JSON) and checks that it's
+// valid. The columns used by the metrics are checked when creating the
+// exporter.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("parsing metrics config: %w", err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid metrics config: %w", err)
+	}
+
+	return config, nil
+}
+
+// ReadConfig reads and parses the configuration in the given file.
+func ReadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading metrics config: %w", err)
+	}
+
+	return ParseConfig(data)
+}
+
+func (c *Config) validate() error {
+	if len(c.Gadgets) == 0 {
+		return errors.New("no gadgets given")
+	}
+
+	names := map[string]struct{}{}
+	for _, gadget := range c.Gadgets {
+		if gadget.Gadget == "" {
+			return errors.New("gadget name can't be empty")
+		}
+		if len(gadget.Metrics) == 0 {
+			return fmt.Errorf("no metrics given for gadget %q", gadget.Gadget)
+		}
+
+		for _, metric := range gadget.Metrics {
+			if metric.Name == "" {
+				return fmt.Errorf("metric name can't be empty in gadget %q", gadget.Gadget)
+			}
+			if _, ok := names[metric.Name]; ok {
+				return fmt.Errorf("metric %q defined more than once", metric.Name)
+			}
+			names[metric.Name] = struct{}{}
+
+			switch metric.Type {
+			case MetricTypeCounter:
+				if len(metric.Buckets) != 0 {
+					return fmt.Errorf("metric %q: buckets can only be used with histograms", metric.Name)
+				}
+			case MetricTypeHistogram:
+				if metric.Field == "" {
+					return fmt.Errorf("metric %q: histograms need a field", metric.Name)
+				}
+			default:
+				return fmt.Errorf("metric %q: invalid type %q (must be %q or %q)",
+					metric.Name, metric.Type, MetricTypeCounter, MetricTypeHistogram)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig([]byte(`
+gadgets:
+- gadget: tcpconnect
+  filter:
+    namespace: default
+  metrics:
+  - name: tcp_connect_total
+    type: counter
+    labels: [namespace, pod, dport]
+- gadget: fsslower
+  parameters:
+    filesystem: ext4
+  metrics:
+  - name: fsslower_latency_seconds
+    type: histogram
+    field: lat
+    buckets: [0.001, 0.01, 0.1]
+`))
+	if err != nil {
+		t.Fatalf("parsing valid config: %s", err)
+	}
+
+	if len(config.Gadgets) != 2 {
+		t.Fatalf("expected 2 gadgets, got %d", len(config.Gadgets))
+	}
+
+	tcpconnect := config.Gadgets[0]
+	if tcpconnect.Gadget != "tcpconnect" || tcpconnect.Filter == nil || tcpconnect.Filter.Namespace != "default" {
+		t.Errorf("unexpected gadget config: %+v", tcpconnect)
+	}
+	if !reflect.DeepEqual(tcpconnect.Metrics[0].Labels, []string{"namespace", "pod", "dport"}) {
+		t.Errorf("unexpected labels: %v", tcpconnect.Metrics[0].Labels)
+	}
+
+	fsslower := config.Gadgets[1]
+	if fsslower.Parameters["filesystem"] != "ext4" {
+		t.Errorf("unexpected parameters: %v", fsslower.Parameters)
+	}
+	if fsslower.Metrics[0].Type != MetricTypeHistogram || fsslower.Metrics[0].Field != "lat" {
+		t.Errorf("unexpected metric config: %+v", fsslower.Metrics[0])
+	}
+	if !reflect.DeepEqual(fsslower.Metrics[0].Buckets, []float64{0.001, 0.01, 0.1}) {
+		t.Errorf("unexpected buckets: %v", fsslower.Metrics[0].Buckets)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty": ``,
+		"unknown field": `
+gadgets:
+- gadget: dns
+  metric:
+  - name: dns_queries_total
+    type: counter
+`,
+		"no metrics": `
+gadgets:
+- gadget: dns
+`,
+		"no gadget name": `
+gadgets:
+- metrics:
+  - name: dns_queries_total
+    type: counter
+`,
+		"no metric name": `
+gadgets:
+- gadget: dns
+  metrics:
+  - type: counter
+`,
+		"duplicated metric": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_total
+    type: counter
+- gadget: tcpconnect
+  metrics:
+  - name: dns_total
+    type: counter
+`,
+		"invalid type": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_queries_total
+    type: gauge
+`,
+		"histogram without field": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_latency_seconds
+    type: histogram
+`,
+		"counter with buckets": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_queries_total
+    type: counter
+    buckets: [1, 2]
+`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseConfig([]byte(data)); err == nil {
+				t.Errorf("expected error parsing config")
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exporter runs the gadgets described in a metrics configuration and
+// exposes the metrics produced from their events in an HTTP /metrics endpoint.
+// It's used by the gadget DaemonSet and local-gadget.
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gadgetv1alpha1 "github.com/inspektor-gadget/inspektor-gadget/pkg/apis/gadget/v1alpha1"
+	containercollection "github.com/inspektor-gadget/inspektor-gadget/pkg/container-collection"
+	gadgetcollection "github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics"
+)
+
+const (
+	// DefaultAddress is the default address of the HTTP server exposing the
+	// metrics
+	DefaultAddress = ":2223"
+
+	// traceNamespace is the namespace of the traces created to run the
+	// gadgets, they aren't Kubernetes resources
+	traceNamespace = "gadget"
+)
+
+// TracerManager is the interface that GadgetTracerManager and
+// LocalGadgetManager implement to run gadgets.
+type TracerManager interface {
+	gadgets.GadgetHelpers
+
+	AddTracer(tracerID string, containerSelector containercollection.ContainerSelector) error
+	RemoveTracer(tracerID string) error
+}
+
+// gadgetTrace is a gadget run by the exporter
+type gadgetTrace struct {
+	// index of the gadget in the configuration
+	index   int
+	trace   *gadgetv1alpha1.Trace
+	factory gadgets.TraceFactory
+	started bool
+}
+
+func (t *gadgetTrace) namespacedName() string {
+	return t.trace.ObjectMeta.Namespace + "/" + t.trace.ObjectMeta.Name
+}
+
+func (t *gadgetTrace) tracerID() string {
+	return gadgets.TraceName(t.trace.ObjectMeta.Namespace, t.trace.ObjectMeta.Name)
+}
+
+// Exporter runs the gadgets given in its configuration and updates the
+// metrics with their events.
+type Exporter struct {
+	metrics *metrics.Metrics
+
+	// traces contains the gadgets run by the exporter by their tracer ID. It
+	// isn't modified after creating the exporter.
+	traces map[string]*gadgetTrace
+
+	mu      sync.Mutex
+	manager TracerManager
+	server  *http.Server
+}
+
+// NewExporter creates an exporter with the metrics described by the given
+// configuration. The gadgets aren't run until Start is called.
+func NewExporter(config *metrics.Config) (*Exporter, error) {
+	m, err := metrics.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{
+		metrics: m,
+		traces:  make(map[string]*gadgetTrace),
+	}
+
+	for i, gadget := range config.Gadgets {
+		t := &gadgetTrace{
+			index: i,
+			trace: &gadgetv1alpha1.Trace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("metrics-%s-%d", gadget.Gadget, i),
+					Namespace: traceNamespace,
+				},
+				Spec: gadgetv1alpha1.TraceSpec{
+					Gadget:     gadget.Gadget,
+					Filter:     gadget.Filter,
+					RunMode:    gadgetv1alpha1.RunModeManual,
+					OutputMode: gadgetv1alpha1.TraceOutputModeStream,
+					Parameters: gadget.Parameters,
+				},
+			},
+		}
+		e.traces[t.tracerID()] = t
+	}
+
+	return e, nil
+}
+
+// Handler returns the HTTP handler serving the metrics.
+func (e *Exporter) Handler() http.Handler {
+	return e.metrics.Handler()
+}
+
+// PublishEvent updates the metrics with an event, given as a JSON line, of the
+// gadget run by the tracer with the given ID. Gadgets call it through the
+// GadgetHelpers given to them when the exporter is started.
+func (e *Exporter) PublishEvent(tracerID string, line string) error {
+	t, ok := e.traces[tracerID]
+	if !ok {
+		return fmt.Errorf("cannot find tracer %q", tracerID)
+	}
+
+	if err := e.metrics.Observe(t.index, line); err != nil {
+		log.Debugf("Metrics of gadget %q not updated: %s", t.trace.Spec.Gadget, err)
+		return err
+	}
+
+	return nil
+}
+
+// helpers are the GadgetHelpers given to the gadgets run by the exporter: The
+// ones of the manager, but publishing the events to the exporter.
+type helpers struct {
+	TracerManager
+	exporter *Exporter
+}
+
+func (h *helpers) PublishEvent(tracerID string, line string) error {
+	return h.exporter.PublishEvent(tracerID, line)
+}
+
+func (h *helpers) PublishTypedEvent(tracerID string, event any) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return h.exporter.PublishEvent(tracerID, string(line))
+}
+
+// Start starts the gadgets on the given node, using the manager to resolve
+// the containers and to filter the events.
+func (e *Exporter) Start(manager TracerManager, node string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.manager != nil {
+		return errors.New("exporter already started")
+	}
+	e.manager = manager
+
+	factories := gadgetcollection.TraceFactories()
+	h := &helpers{TracerManager: manager, exporter: e}
+
+	for _, t := range e.traces {
+		gadget := t.trace.Spec.Gadget
+
+		factory, ok := factories[gadget]
+		if !ok {
+			e.stop()
+			return fmt.Errorf("cannot find factory for gadget %q", gadget)
+		}
+		factory.Initialize(h, nil)
+		t.factory = factory
+		t.trace.Spec.Node = node
+
+		selector := gadgets.ContainerSelectorFromContainerFilter(t.trace.Spec.Filter)
+		if err := manager.AddTracer(t.tracerID(), *selector); err != nil {
+			e.stop()
+			return fmt.Errorf("adding tracer for gadget %q: %w", gadget, err)
+		}
+		t.started = true
+
+		factory.Operations()[gadgetv1alpha1.OperationStart].Operation(t.namespacedName(), t.trace)
+		if t.trace.Status.OperationError != "" {
+			e.stop()
+			return fmt.Errorf("starting gadget %q: %s", gadget, t.trace.Status.OperationError)
+		}
+
+		log.Infof("Metrics: gadget %q started", gadget)
+	}
+
+	return nil
+}
+
+// Serve serves the metrics in the /metrics endpoint of an HTTP server using
+// the given listener. It blocks until the exporter is stopped.
+func (e *Exporter) Serve(lis net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.Handler())
+
+	e.mu.Lock()
+	e.server = &http.Server{Handler: mux}
+	server := e.server
+	e.mu.Unlock()
+
+	err := server.Serve(lis)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Stop stops the gadgets and the HTTP server.
+func (e *Exporter) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stop()
+
+	if e.server != nil {
+		e.server.Close()
+		e.server = nil
+	}
+}
+
+func (e *Exporter) stop() {
+	for _, t := range e.traces {
+		if !t.started {
+			continue
+		}
+
+		// Not all the gadgets release their resources when deleting the
+		// trace, as the controller does, so stop them first
+		if op, ok := t.factory.Operations()[gadgetv1alpha1.OperationStop]; ok {
+			op.Operation(t.namespacedName(), t.trace)
+		}
+		t.factory.Delete(t.namespacedName())
+
+		if err := e.manager.RemoveTracer(t.tracerID()); err != nil {
+			log.Warnf("Metrics: removing tracer of gadget %q: %s", t.trace.Spec.Gadget, err)
+		}
+		t.started = false
+	}
+}
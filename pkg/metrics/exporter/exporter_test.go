@@ -0,0 +1,89 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/gadget-collection/gadgets"
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/metrics"
+)
+
+func TestExporterServe(t *testing.T) {
+	config, err := metrics.ParseConfig([]byte(`
+gadgets:
+- gadget: tcpconnect
+  metrics:
+  - name: tcp_connect_total
+    type: counter
+    labels: [namespace, dport]
+`))
+	if err != nil {
+		t.Fatalf("parsing config: %s", err)
+	}
+
+	exporter, err := NewExporter(config)
+	if err != nil {
+		t.Fatalf("creating exporter: %s", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %s", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- exporter.Serve(lis)
+	}()
+
+	// Events are published by the gadgets with the ID of their tracer
+	tracerID := gadgets.TraceName(traceNamespace, "metrics-tcpconnect-0")
+	for _, line := range []string{
+		`{"type":"normal","namespace":"default","dport":443}`,
+		`{"type":"normal","namespace":"default","dport":443}`,
+	} {
+		if err := exporter.PublishEvent(tracerID, line); err != nil {
+			t.Fatalf("publishing event: %s", err)
+		}
+	}
+	if err := exporter.PublishEvent("trace_foo_bar", `{"type":"normal"}`); err == nil {
+		t.Errorf("expected error publishing event of unknown tracer")
+	}
+
+	resp, err := http.Get("http://" + lis.Addr().String() + "/metrics")
+	if err != nil {
+		t.Fatalf("scraping metrics: %s", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("reading metrics: %s", err)
+	}
+
+	expected := `tcp_connect_total{dport="443",namespace="default"} 2`
+	if !strings.Contains(string(body), expected+"\n") {
+		t.Errorf("metric %q not found in:\n%s", expected, body)
+	}
+
+	exporter.Stop()
+	if err := <-errs; err != nil {
+		t.Errorf("serving metrics: %s", err)
+	}
+}
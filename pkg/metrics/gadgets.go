@@ -0,0 +1,76 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
+	seccompauditTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/audit/seccomp/types"
+	bindTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/bind/types"
+	capabilitiesTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/capabilities/types"
+	dnsTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/dns/types"
+	execTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/exec/types"
+	fsslowerTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/fsslower/types"
+	mountTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/mount/types"
+	networkTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/network/types"
+	oomkillTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/oomkill/types"
+	openTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/open/types"
+	signalTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/signal/types"
+	sniTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/sni/types"
+	tcpTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/tcp/types"
+	tcpconnectTypes "github.com/inspektor-gadget/inspektor-gadget/pkg/gadgets/trace/tcpconnect/types"
+)
+
+type newCollectorFunc func(configs []MetricConfig, registerer prometheus.Registerer) (eventCollector, error)
+
+// gadgetCollectors contains the gadgets whose events can be turned into
+// metrics, by the names used in Trace.Spec.Gadget: The gadgets streaming
+// events with columns.
+var gadgetCollectors = map[string]newCollectorFunc{
+	"audit-seccomp": collectorFor(seccompauditTypes.GetColumns),
+	"bindsnoop":     collectorFor(bindTypes.GetColumns),
+	"capabilities":  collectorFor(capabilitiesTypes.GetColumns),
+	"dns":           collectorFor(dnsTypes.GetColumns),
+	"execsnoop":     collectorFor(execTypes.GetColumns),
+	"fsslower":      collectorFor(fsslowerTypes.GetColumns),
+	"mountsnoop":    collectorFor(mountTypes.GetColumns),
+	"network-graph": collectorFor(networkTypes.GetColumns),
+	"oomkill":       collectorFor(oomkillTypes.GetColumns),
+	"opensnoop":     collectorFor(openTypes.GetColumns),
+	"sigsnoop":      collectorFor(signalTypes.GetColumns),
+	"snisnoop":      collectorFor(sniTypes.GetColumns),
+	"tcpconnect":    collectorFor(tcpconnectTypes.GetColumns),
+	"tcptracer":     collectorFor(tcpTypes.GetColumns),
+}
+
+func collectorFor[T any](getColumns func() *columns.Columns[T]) newCollectorFunc {
+	return func(configs []MetricConfig, registerer prometheus.Registerer) (eventCollector, error) {
+		return newGadgetCollector(getColumns(), configs, registerer)
+	}
+}
+
+// SupportedGadgets returns the names of the gadgets whose events can be turned
+// into metrics.
+func SupportedGadgets() []string {
+	gadgets := make([]string, 0, len(gadgetCollectors))
+	for name := range gadgetCollectors {
+		gadgets = append(gadgets, name)
+	}
+	sort.Strings(gadgets)
+	return gadgets
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics turns the events of gadgets into Prometheus metrics, as
+// described by a declarative configuration. The exporter subpackage runs the
+// gadgets and exposes the metrics in an HTTP /metrics endpoint.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics contains the metrics described by a configuration.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	// collectors contains the collector of each of the gadgets of the
+	// configuration, in the same order
+	collectors []eventCollector
+}
+
+// New creates the metrics described by the given configuration. It fails if
+// the gadgets aren't supported or the columns used by the metrics don't exist.
+func New(config *Config) (*Metrics, error) {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+	}
+
+	for _, gadget := range config.Gadgets {
+		newCollector, ok := gadgetCollectors[gadget.Gadget]
+		if !ok {
+			return nil, fmt.Errorf("gadget %q isn't supported, it must be one of %v",
+				gadget.Gadget, SupportedGadgets())
+		}
+
+		collector, err := newCollector(gadget.Metrics, m.registry)
+		if err != nil {
+			return nil, fmt.Errorf("gadget %q: %w", gadget.Gadget, err)
+		}
+
+		m.collectors = append(m.collectors, collector)
+	}
+
+	return m, nil
+}
+
+// Observe updates the metrics of the i-th gadget of the configuration with
+// one of its events, given as a JSON line as published by the gadget.
+func (m *Metrics) Observe(i int, line string) error {
+	if i < 0 || i >= len(m.collectors) {
+		return fmt.Errorf("no gadget %d in the configuration", i)
+	}
+
+	return m.collectors[i].observe(line)
+}
+
+// Handler returns the HTTP handler serving the metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,199 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_queries_total
+    type: counter
+    labels: [namespace, qtype, rcode]
+    filter: qr=="R"
+- gadget: tcpconnect
+  metrics:
+  - name: tcp_connect_total
+    type: counter
+    labels: [pod, dport]
+- gadget: fsslower
+  metrics:
+  - name: fsslower_latency_seconds
+    type: histogram
+    field: lat
+    labels: [container]
+    buckets: [0.001, 0.01]
+  - name: fsslower_bytes_total
+    type: counter
+    field: bytes
+`
+
+func newTestMetrics(t *testing.T) *Metrics {
+	t.Helper()
+
+	config, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("parsing config: %s", err)
+	}
+
+	m, err := New(config)
+	if err != nil {
+		t.Fatalf("creating metrics: %s", err)
+	}
+
+	return m
+}
+
+// scrape returns the metrics served by the handler, in the text format
+func scrape(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("scraping metrics: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading metrics: %s", err)
+	}
+
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	m := newTestMetrics(t)
+
+	events := []struct {
+		gadget int
+		line   string
+	}{
+		{0, `{"type":"normal","namespace":"default","qr":"Q","qtype":"A"}`},
+		{0, `{"type":"normal","namespace":"default","qr":"R","qtype":"A","rcode":"NoError"}`},
+		{0, `{"type":"normal","namespace":"default","qr":"R","qtype":"A","rcode":"NoError"}`},
+		{0, `{"type":"normal","namespace":"kube-system","qr":"R","qtype":"AAAA","rcode":"NXDomain"}`},
+		{0, `{"type":"err","message":"something went wrong"}`},
+		{1, `{"type":"normal","pod":"web","dport":443}`},
+		{1, `{"type":"normal","pod":"web","dport":80}`},
+		{1, `{"type":"normal","pod":"web","dport":443}`},
+		{2, `{"type":"normal","container":"db","latency":500,"bytes":4096}`},
+		{2, `{"type":"normal","container":"db","latency":5000,"bytes":1024}`},
+		{2, `{"type":"normal","container":"db","latency":50000}`},
+	}
+	for _, event := range events {
+		if err := m.Observe(event.gadget, event.line); err != nil {
+			t.Fatalf("observing event %q: %s", event.line, err)
+		}
+	}
+
+	metrics := scrape(t, m.Handler())
+
+	expected := []string{
+		`dns_queries_total{namespace="default",qtype="A",rcode="NoError"} 2`,
+		`dns_queries_total{namespace="kube-system",qtype="AAAA",rcode="NXDomain"} 1`,
+		`tcp_connect_total{dport="443",pod="web"} 2`,
+		`tcp_connect_total{dport="80",pod="web"} 1`,
+		`fsslower_latency_seconds_bucket{container="db",le="0.001"} 1`,
+		`fsslower_latency_seconds_bucket{container="db",le="0.01"} 2`,
+		`fsslower_latency_seconds_bucket{container="db",le="+Inf"} 3`,
+		`fsslower_latency_seconds_count{container="db"} 3`,
+		`fsslower_bytes_total 5120`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(metrics, line+"\n") {
+			t.Errorf("metric %q not found in:\n%s", line, metrics)
+		}
+	}
+
+	// Queries are filtered out
+	if strings.Contains(metrics, `rcode=""`) {
+		t.Errorf("unexpected metric for queries in:\n%s", metrics)
+	}
+}
+
+func TestMetricsErrors(t *testing.T) {
+	m := newTestMetrics(t)
+
+	if err := m.Observe(3, `{"type":"normal"}`); err == nil {
+		t.Errorf("expected error observing event of unknown gadget")
+	}
+	if err := m.Observe(0, `not json`); err == nil {
+		t.Errorf("expected error observing invalid event")
+	}
+
+	tests := map[string]string{
+		"unsupported gadget": `
+gadgets:
+- gadget: traceloop
+  metrics:
+  - name: traceloop_total
+    type: counter
+`,
+		"unknown label": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_queries_total
+    type: counter
+    labels: [foo]
+`,
+		"non numeric field": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_names
+    type: histogram
+    field: name
+`,
+		"invalid filter": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns_queries_total
+    type: counter
+    filter: foo=="bar"
+`,
+		"invalid metric name": `
+gadgets:
+- gadget: dns
+  metrics:
+  - name: dns-queries-total
+    type: counter
+`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseConfig([]byte(data))
+			if err != nil {
+				t.Fatalf("parsing config: %s", err)
+			}
+			if _, err := New(config); err == nil {
+				t.Errorf("expected error creating metrics")
+			}
+		})
+	}
+}
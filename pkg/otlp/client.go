@@ -0,0 +1,200 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// logsPath is the default path of the logs endpoint of collectors using
+// OTLP/HTTP
+const logsPath = "/v1/logs"
+
+// client sends the logs to a collector
+type client interface {
+	export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error
+	close() error
+}
+
+// retryableError is an error after which the export can be tried again
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+func isRetryable(err error) bool {
+	var r *retryableError
+	return errors.As(err, &r)
+}
+
+// newClient creates the client for the given endpoint, whose scheme selects
+// the protocol:
+//   - grpc://host:port uses gRPC without TLS and grpcs://host:port with TLS.
+//   - http://host:port[/path] and https://host:port[/path] use HTTP with
+//     protobuf payloads. The path defaults to /v1/logs.
+func newClient(endpoint string) (client, error) {
+	u, err := parseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "grpc", "grpcs":
+		creds := insecure.NewCredentials()
+		if u.Scheme == "grpcs" {
+			creds = credentials.NewTLS(&tls.Config{})
+		}
+
+		// The connection is established lazily, collectors not being
+		// available yet is handled by retrying the exports.
+		conn, err := grpc.Dial(u.Host, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("connecting to OTLP endpoint %q: %w", endpoint, err)
+		}
+
+		return &grpcClient{
+			conn:   conn,
+			client: collogspb.NewLogsServiceClient(conn),
+		}, nil
+	case "http", "https":
+		if u.Path == "" || u.Path == "/" {
+			u.Path = logsPath
+		}
+
+		return &httpClient{
+			url:    u.String(),
+			client: &http.Client{},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("invalid OTLP endpoint %q: scheme must be grpc, grpcs, http or https", endpoint)
+}
+
+// ValidateEndpoint verifies that endpoint can be used to create an Exporter,
+// without connecting to it.
+func ValidateEndpoint(endpoint string) error {
+	_, err := parseEndpoint(endpoint)
+	return err
+}
+
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing OTLP endpoint %q: %w", endpoint, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: no host given", endpoint)
+	}
+
+	switch u.Scheme {
+	case "grpc", "grpcs", "http", "https":
+		return u, nil
+	}
+
+	return nil, fmt.Errorf("invalid OTLP endpoint %q: scheme must be grpc, grpcs, http or https", endpoint)
+}
+
+type grpcClient struct {
+	conn   *grpc.ClientConn
+	client collogspb.LogsServiceClient
+}
+
+func (c *grpcClient) export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	_, err := c.client.Export(ctx, req)
+	if err == nil {
+		return nil
+	}
+
+	// Codes that can be retried according to the OTLP specification
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return &retryableError{err: err}
+	}
+	return err
+}
+
+func (c *grpcClient) close() error {
+	return c.conn.Close()
+}
+
+type httpClient struct {
+	url    string
+	client *http.Client
+}
+
+func (c *httpClient) export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshaling logs: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		// Connection errors: The collector can be unavailable for a while
+		return &retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("exporting logs to %q: %s: %s", c.url, resp.Status, strings.TrimSpace(string(msg)))
+
+	// Status codes that can be retried according to the OTLP specification
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return &retryableError{err: err}
+	}
+	return err
+}
+
+func (c *httpClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
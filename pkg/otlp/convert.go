@@ -0,0 +1,210 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	eventtypes "github.com/inspektor-gadget/inspektor-gadget/pkg/types"
+)
+
+// Resource attributes the fields of CommonData are mapped to, following the
+// OpenTelemetry semantic conventions.
+const (
+	AttributeNode      = "k8s.node.name"
+	AttributeNamespace = "k8s.namespace.name"
+	AttributePod       = "k8s.pod.name"
+	AttributeContainer = "k8s.container.name"
+)
+
+var severities = map[eventtypes.EventType]logspb.SeverityNumber{
+	eventtypes.NORMAL: logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	eventtypes.INFO:   logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	eventtypes.READY:  logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	eventtypes.DEBUG:  logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+	eventtypes.WARN:   logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+	eventtypes.ERR:    logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+}
+
+// event is an event of a gadget split in the parts mapped to the resource and
+// the ones mapped to the log record
+type event struct {
+	common     eventtypes.CommonData
+	timestamp  uint64
+	observed   time.Time
+	eventType  eventtypes.EventType
+	message    string
+	attributes []*commonpb.KeyValue
+}
+
+// parseEvent parses an event published by a gadget as a JSON line. The fields
+// of the base event are taken apart and all the other ones become attributes.
+func parseEvent(line string) (*event, error) {
+	var base eventtypes.Event
+	if err := json.Unmarshal([]byte(line), &base); err != nil {
+		return nil, fmt.Errorf("unmarshaling event %q: %w", line, err)
+	}
+
+	var fields map[string]any
+	decoder := json.NewDecoder(bytes.NewReader([]byte(line)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("unmarshaling event %q: %w", line, err)
+	}
+
+	for _, key := range []string{"node", "namespace", "pod", "container", "timestamp", "type", "message"} {
+		delete(fields, key)
+	}
+
+	e := &event{
+		common:     base.CommonData,
+		timestamp:  uint64(base.Timestamp),
+		eventType:  base.Type,
+		message:    base.Message,
+		attributes: keyValues(fields),
+	}
+
+	return e, nil
+}
+
+func keyValues(fields map[string]any) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		kvs = append(kvs, &commonpb.KeyValue{Key: key, Value: anyValue(fields[key])})
+	}
+	return kvs
+}
+
+func anyValue(v any) *commonpb.AnyValue {
+	switch v := v.(type) {
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: i}}
+		}
+		if f, err := v.Float64(); err == nil {
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: f}}
+		}
+		// Integers not fitting in an int64, like large uint64
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.String()}}
+	case []any:
+		values := make([]*commonpb.AnyValue, 0, len(v))
+		for _, item := range v {
+			values = append(values, anyValue(item))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+			ArrayValue: &commonpb.ArrayValue{Values: values},
+		}}
+	case map[string]any:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{Values: keyValues(v)},
+		}}
+	}
+
+	// null
+	return &commonpb.AnyValue{}
+}
+
+func stringKeyValue(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: anyValue(value)}
+}
+
+// resourceAttributes returns the attributes of the resource an event comes
+// from: The given ones and the non-empty fields of its CommonData.
+func resourceAttributes(base map[string]string, common *eventtypes.CommonData) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(base))
+	for key := range base {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]*commonpb.KeyValue, 0, len(keys)+4)
+	for _, key := range keys {
+		attributes = append(attributes, stringKeyValue(key, base[key]))
+	}
+
+	for _, attr := range []struct{ key, value string }{
+		{AttributeNode, common.Node},
+		{AttributeNamespace, common.Namespace},
+		{AttributePod, common.Pod},
+		{AttributeContainer, common.Container},
+	} {
+		if attr.value != "" {
+			attributes = append(attributes, stringKeyValue(attr.key, attr.value))
+		}
+	}
+
+	return attributes
+}
+
+// buildRequest builds the request exporting the given events: One
+// ResourceLogs for each different CommonData, containing the log records of
+// the events with it.
+func (e *Exporter) buildRequest(events []*event) *collogspb.ExportLogsServiceRequest {
+	req := &collogspb.ExportLogsServiceRequest{}
+	scopes := map[eventtypes.CommonData]*logspb.ScopeLogs{}
+
+	for _, ev := range events {
+		scope, ok := scopes[ev.common]
+		if !ok {
+			scope = &logspb.ScopeLogs{
+				Scope: &commonpb.InstrumentationScope{
+					Name: e.scope,
+				},
+			}
+			scopes[ev.common] = scope
+
+			req.ResourceLogs = append(req.ResourceLogs, &logspb.ResourceLogs{
+				Resource: &resourcepb.Resource{
+					Attributes: resourceAttributes(e.resource, &ev.common),
+				},
+				ScopeLogs: []*logspb.ScopeLogs{scope},
+			})
+		}
+
+		record := &logspb.LogRecord{
+			TimeUnixNano:         ev.timestamp,
+			ObservedTimeUnixNano: uint64(ev.observed.UnixNano()),
+			SeverityNumber:       severities[ev.eventType],
+			SeverityText:         string(ev.eventType),
+			Attributes:           ev.attributes,
+		}
+		if ev.message != "" {
+			record.Body = anyValue(ev.message)
+		}
+
+		scope.LogRecords = append(scope.LogRecords, record)
+	}
+
+	return req
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp exports the events of gadgets as OpenTelemetry logs to a
+// collector, using OTLP over gRPC or HTTP with protobuf payloads. The
+// CommonData of the events is mapped to resource attributes and the other
+// fields of the events to log record attributes.
+package otlp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrQueueFull is returned when an event is dropped because the queue of the
+// exporter is full, e.g. because the collector is too slow or unavailable.
+var ErrQueueFull = errors.New("OTLP export queue is full")
+
+// Config configures the batching and the retries of the exporter.
+type Config struct {
+	// QueueSize is the maximum number of events waiting to be exported.
+	// Events are dropped when the queue is full.
+	QueueSize int
+
+	// MaxBatchSize is the maximum number of events exported in a request.
+	MaxBatchSize int
+
+	// BatchTimeout is the maximum time events wait in the queue before being
+	// exported.
+	BatchTimeout time.Duration
+
+	// ExportTimeout is the timeout of each request to the collector.
+	ExportTimeout time.Duration
+
+	// RetryInitialInterval is the time to wait before retrying a failed
+	// request. It's doubled after each retry, up to RetryMaxInterval.
+	RetryInitialInterval time.Duration
+	RetryMaxInterval     time.Duration
+
+	// RetryMaxElapsedTime is the maximum time spent retrying a request
+	// before dropping its events.
+	RetryMaxElapsedTime time.Duration
+}
+
+// DefaultConfig returns the configuration used for traces with
+// OutputMode=OTLP.
+func DefaultConfig() Config {
+	return Config{
+		QueueSize:            2048,
+		MaxBatchSize:         512,
+		BatchTimeout:         time.Second,
+		ExportTimeout:        10 * time.Second,
+		RetryInitialInterval: 500 * time.Millisecond,
+		RetryMaxInterval:     5 * time.Second,
+		RetryMaxElapsedTime:  time.Minute,
+	}
+}
+
+// Exporter exports events to an OTLP collector. Events are queued and
+// exported in batches by a background goroutine.
+type Exporter struct {
+	config   Config
+	client   client
+	resource map[string]string
+	scope    string
+
+	queue chan *event
+
+	// mu protects closed, to avoid sending to the queue once it's closed
+	mu     sync.RWMutex
+	closed bool
+
+	// ctx is cancelled to stop retrying when closing the exporter takes too
+	// long
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	dropped uint64
+}
+
+// NewExporter creates an exporter sending the events to the collector at the
+// given endpoint, e.g. grpc://collector:4317 or http://collector:4318. The
+// resource attributes are added to the ones built from the CommonData of the
+// events, and scope is used as name of the instrumentation scope of the logs.
+func NewExporter(endpoint string, resource map[string]string, scope string, config Config) (*Exporter, error) {
+	c, err := newClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e := &Exporter{
+		config:   config,
+		client:   c,
+		resource: resource,
+		scope:    scope,
+		queue:    make(chan *event, config.QueueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go e.run()
+
+	return e, nil
+}
+
+// Export queues an event, given as a JSON line as published by gadgets. It
+// doesn't block: ErrQueueFull is returned if the event can't be queued.
+func (e *Exporter) Export(line string) error {
+	ev, err := parseEvent(line)
+	if err != nil {
+		return err
+	}
+	ev.observed = time.Now()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		return errors.New("OTLP exporter closed")
+	}
+
+	select {
+	case e.queue <- ev:
+		return nil
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+		return ErrQueueFull
+	}
+}
+
+// Dropped returns the number of events dropped, because the queue was full
+// or they couldn't be exported.
+func (e *Exporter) Dropped() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// Close exports the queued events and releases the resources of the
+// exporter. Events not exported within ExportTimeout are dropped.
+func (e *Exporter) Close() {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+	e.closed = true
+	close(e.queue)
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+	case <-time.After(e.config.ExportTimeout):
+		e.cancel()
+		<-e.done
+	}
+	e.cancel()
+
+	if err := e.client.close(); err != nil {
+		log.Warnf("OTLP: closing client: %s", err)
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.config.BatchTimeout)
+	defer ticker.Stop()
+
+	batch := make([]*event, 0, e.config.MaxBatchSize)
+	var reportedDrops uint64
+
+	flush := func() {
+		if len(batch) > 0 {
+			e.send(batch)
+			batch = make([]*event, 0, e.config.MaxBatchSize)
+		}
+
+		if dropped := e.Dropped(); dropped != reportedDrops {
+			log.Warnf("OTLP: %d events dropped so far", dropped)
+			reportedDrops = dropped
+		}
+	}
+
+	for {
+		select {
+		case ev, ok := <-e.queue:
+			if !ok {
+				flush()
+				return
+			}
+
+			batch = append(batch, ev)
+			if len(batch) >= e.config.MaxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send exports a batch of events, retrying with an exponential backoff when
+// the error allows it
+func (e *Exporter) send(events []*event) {
+	req := e.buildRequest(events)
+
+	interval := e.config.RetryInitialInterval
+	deadline := time.Now().Add(e.config.RetryMaxElapsedTime)
+
+	for {
+		ctx, cancel := context.WithTimeout(e.ctx, e.config.ExportTimeout)
+		err := e.client.export(ctx, req)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		if !isRetryable(err) || time.Now().Add(interval).After(deadline) {
+			log.Warnf("OTLP: dropping %d events: %s", len(events), err)
+			atomic.AddUint64(&e.dropped, uint64(len(events)))
+			return
+		}
+
+		log.Debugf("OTLP: retrying export in %s: %s", interval, err)
+
+		select {
+		case <-time.After(interval):
+		case <-e.ctx.Done():
+			log.Warnf("OTLP: dropping %d events: exporter closed", len(events))
+			atomic.AddUint64(&e.dropped, uint64(len(events)))
+			return
+		}
+
+		interval *= 2
+		if interval > e.config.RetryMaxInterval {
+			interval = e.config.RetryMaxInterval
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a stand-in OTLP collector storing the log records it receives
+type collector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+
+	// failures is the number of requests to fail before accepting them
+	failures int
+}
+
+func (c *collector) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests = append(c.requests, req)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if r.URL.Path != logsPath || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+
+	if c.failures > 0 {
+		c.failures--
+		http.Error(w, "try again later", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &collogspb.ExportLogsServiceRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.requests = append(c.requests, req)
+	w.Header().Set("Content-Type", "application/x-protobuf")
+}
+
+// resourceLogs returns the resource logs received by the collector
+func (c *collector) resourceLogs() []*logspb.ResourceLogs {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var logs []*logspb.ResourceLogs
+	for _, req := range c.requests {
+		logs = append(logs, req.ResourceLogs...)
+	}
+	return logs
+}
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.BatchTimeout = 10 * time.Millisecond
+	config.RetryInitialInterval = 10 * time.Millisecond
+	config.RetryMaxInterval = 10 * time.Millisecond
+	return config
+}
+
+func attributes(kvs []*commonpb.KeyValue) map[string]*commonpb.AnyValue {
+	m := make(map[string]*commonpb.AnyValue, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func checkLogs(t *testing.T, logs []*logspb.ResourceLogs) {
+	t.Helper()
+
+	// One resource for each pod
+	if len(logs) != 2 {
+		t.Fatalf("expected logs of 2 resources, got %d", len(logs))
+	}
+
+	resource := attributes(logs[0].Resource.Attributes)
+	for key, value := range map[string]string{
+		"service.name":     "inspektor-gadget",
+		AttributeNode:      "node1",
+		AttributeNamespace: "default",
+		AttributePod:       "web",
+		AttributeContainer: "nginx",
+	} {
+		if got := resource[key].GetStringValue(); got != value {
+			t.Errorf("expected resource attribute %q to be %q, got %q", key, value, got)
+		}
+	}
+
+	scopes := logs[0].ScopeLogs
+	if len(scopes) != 1 || scopes[0].Scope.Name != "tcpconnect" {
+		t.Fatalf("unexpected scope logs: %v", scopes)
+	}
+
+	records := scopes[0].LogRecords
+	if len(records) != 2 {
+		t.Fatalf("expected 2 log records, got %d", len(records))
+	}
+
+	record := records[0]
+	if record.TimeUnixNano != 1000 || record.ObservedTimeUnixNano == 0 {
+		t.Errorf("unexpected times in record: %v", record)
+	}
+	if record.SeverityNumber != logspb.SeverityNumber_SEVERITY_NUMBER_INFO || record.SeverityText != "normal" {
+		t.Errorf("unexpected severity in record: %v", record)
+	}
+
+	attrs := attributes(record.Attributes)
+	if len(attrs) != 3 {
+		t.Errorf("expected 3 attributes, got %v", record.Attributes)
+	}
+	if attrs["comm"].GetStringValue() != "curl" || attrs["dport"].GetIntValue() != 443 {
+		t.Errorf("unexpected attributes in record: %v", record.Attributes)
+	}
+	if addrs := attrs["addrs"].GetArrayValue().GetValues(); len(addrs) != 2 || addrs[1].GetStringValue() != "::1" {
+		t.Errorf("unexpected array attribute in record: %v", attrs["addrs"])
+	}
+
+	errRecord := logs[1].ScopeLogs[0].LogRecords[0]
+	if errRecord.SeverityNumber != logspb.SeverityNumber_SEVERITY_NUMBER_ERROR ||
+		errRecord.Body.GetStringValue() != "something went wrong" {
+		t.Errorf("unexpected error record: %v", errRecord)
+	}
+}
+
+func exportEvents(t *testing.T, endpoint string, config Config) *Exporter {
+	t.Helper()
+
+	exporter, err := NewExporter(endpoint, map[string]string{"service.name": "inspektor-gadget"}, "tcpconnect", config)
+	if err != nil {
+		t.Fatalf("creating exporter: %s", err)
+	}
+
+	for _, line := range []string{
+		`{"node":"node1","namespace":"default","pod":"web","container":"nginx","timestamp":1000,"type":"normal","comm":"curl","dport":443,"addrs":["127.0.0.1","::1"]}`,
+		`{"node":"node1","namespace":"default","pod":"web","container":"nginx","timestamp":2000,"type":"normal","comm":"wget","dport":80,"addrs":[]}`,
+		`{"node":"node1","type":"err","message":"something went wrong"}`,
+	} {
+		if err := exporter.Export(line); err != nil {
+			t.Fatalf("exporting event: %s", err)
+		}
+	}
+
+	return exporter
+}
+
+func TestExporterGRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %s", err)
+	}
+
+	c := &collector{}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, c)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	exporter := exportEvents(t, "grpc://"+lis.Addr().String(), testConfig())
+	exporter.Close()
+
+	checkLogs(t, c.resourceLogs())
+}
+
+func TestExporterHTTP(t *testing.T) {
+	// The first requests fail to check they're retried
+	c := &collector{failures: 2}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	exporter := exportEvents(t, server.URL, testConfig())
+	exporter.Close()
+
+	checkLogs(t, c.resourceLogs())
+	if exporter.Dropped() != 0 {
+		t.Errorf("expected no events dropped, got %d", exporter.Dropped())
+	}
+}
+
+func TestExporterBatching(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	config := testConfig()
+	config.MaxBatchSize = 2
+	config.BatchTimeout = time.Hour
+
+	exporter := exportEvents(t, server.URL, config)
+	exporter.Close()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A full batch and the remaining event when closing
+	if len(c.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(c.requests))
+	}
+}
+
+func TestExporterDropping(t *testing.T) {
+	// The collector never accepts the logs
+	c := &collector{failures: 1000}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	config := testConfig()
+	config.QueueSize = 1
+	config.MaxBatchSize = 1
+	config.RetryMaxElapsedTime = 50 * time.Millisecond
+
+	exporter, err := NewExporter(server.URL, nil, "tcpconnect", config)
+	if err != nil {
+		t.Fatalf("creating exporter: %s", err)
+	}
+
+	queueFull := false
+	for i := 0; i < 10; i++ {
+		if err := exporter.Export(`{"type":"normal"}`); errors.Is(err, ErrQueueFull) {
+			queueFull = true
+		}
+	}
+	if !queueFull {
+		t.Errorf("expected events to be dropped because the queue is full")
+	}
+
+	exporter.Close()
+
+	// All the events were dropped, either by the queue or after retrying
+	if exporter.Dropped() != 10 {
+		t.Errorf("expected 10 events dropped, got %d", exporter.Dropped())
+	}
+	if len(c.resourceLogs()) != 0 {
+		t.Errorf("expected no logs received by the collector")
+	}
+}
+
+func TestNewExporterErrors(t *testing.T) {
+	for _, endpoint := range []string{
+		"",
+		"collector:4317",
+		"ftp://collector:21",
+		"grpc://",
+	} {
+		if _, err := NewExporter(endpoint, nil, "dns", DefaultConfig()); err == nil {
+			t.Errorf("expected error creating exporter for endpoint %q", endpoint)
+		}
+		if err := ValidateEndpoint(endpoint); err == nil {
+			t.Errorf("expected error validating endpoint %q", endpoint)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package standard
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -36,6 +37,10 @@ type Tracer struct {
 }
 
 func NewTracer(config *tracer.Config) (*Tracer, error) {
+	if config.CgroupIDMap != nil {
+		return nil, errors.New("filtering by cgroup ID is not supported by the standard gadgets")
+	}
+
 	mountNsMapPinPath := filepath.Join(gadgets.PinPath, uuid.New().String())
 	if err := config.MountnsMap.Pin(mountNsMapPinPath); err != nil {
 		return nil, fmt.Errorf("failed to pin tracer's mount ns map: %w", err)
@@ -64,6 +64,7 @@ func NewTracer(config *tracer.Config, eventCallback func(types.Event)) (*trace.S
 		BaseEvent:     types.Base,
 		PrepareLine:   prepareLine,
 		MntnsMap:      config.MountnsMap,
+		CgroupIDMap:   config.CgroupIDMap,
 	}
 
 	return trace.NewStandardTracer(standardConfig)
@@ -42,6 +42,7 @@ func NewTracer(config *tracer.Config, eventCallback func(types.Event)) (*trace.S
 		PrepareLine:   prepareLine,
 		BaseEvent:     types.Base,
 		MntnsMap:      config.MountnsMap,
+		CgroupIDMap:   config.CgroupIDMap,
 	}
 
 	return trace.NewStandardTracer(standardConfig)
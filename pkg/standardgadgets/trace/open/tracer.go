@@ -26,6 +26,7 @@ func NewTracer(config *tracer.Config, eventCallback func(types.Event)) (*trace.S
 		EventCallback: eventCallback,
 		BaseEvent:     types.Base,
 		MntnsMap:      config.MountnsMap,
+		CgroupIDMap:   config.CgroupIDMap,
 	}
 
 	return trace.NewStandardTracer(standardConfig)
@@ -17,6 +17,7 @@ package trace
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -54,6 +55,10 @@ type StandardTracerConfig[E Event] struct {
 
 	// MntnsMap is the mount namespace map for filtering. Notice it is optional.
 	MntnsMap *ebpf.Map
+
+	// CgroupIDMap is the cgroup ID map for filtering. The BCC tools don't
+	// support it: NewStandardTracer fails if it's set.
+	CgroupIDMap *ebpf.Map
 }
 
 // StandardTracer is a type used by gadgets that have a BCC Python-based
@@ -70,6 +75,10 @@ type StandardTracer[E Event] struct {
 }
 
 func NewStandardTracer[E Event](config *StandardTracerConfig[E]) (*StandardTracer[E], error) {
+	if config.CgroupIDMap != nil {
+		return nil, errors.New("filtering by cgroup ID is not supported by the standard gadgets")
+	}
+
 	cmdName := "/usr/share/bcc/tools/" + config.ScriptName
 	args := []string{"--json", "--containersmap", "/sys/fs/bpf/gadget/containers"}
 
@@ -0,0 +1,289 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package symbolizer
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/gosym"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"syscall"
+
+	"github.com/ulikunitz/xz"
+)
+
+// NT_GNU_BUILD_ID note type
+// https://github.com/torvalds/linux/blob/v6.0/include/uapi/linux/elf.h#L371
+const ntGNUBuildID = 3
+
+type symbol struct {
+	addr uint64
+	size uint64
+	name string
+}
+
+type loadSegment struct {
+	offset uint64
+	vaddr  uint64
+	size   uint64
+}
+
+// symbolTable holds the function symbols of a binary.
+type symbolTable struct {
+	// Executable PT_LOAD segments, used to translate file offsets to the
+	// virtual addresses of the symbols.
+	loads []loadSegment
+
+	// Sorted by address
+	symbols []symbol
+}
+
+// lookup returns the name of the function at the given offset of the binary.
+func (t *symbolTable) lookup(offset uint64) (string, bool) {
+	var addr uint64
+	found := false
+	for _, load := range t.loads {
+		if offset >= load.offset && offset < load.offset+load.size {
+			addr = offset - load.offset + load.vaddr
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	i := sort.Search(len(t.symbols), func(i int) bool {
+		return t.symbols[i].addr > addr
+	}) - 1
+	if i < 0 {
+		return "", false
+	}
+
+	sym := t.symbols[i]
+	if sym.size != 0 && addr >= sym.addr+sym.size {
+		return "", false
+	}
+
+	return sym.name, true
+}
+
+type elfFile struct {
+	*elf.File
+	path string
+}
+
+func openELF(path string) (*elfFile, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &elfFile{File: f, path: path}, nil
+}
+
+// cacheKey returns the key identifying the binary in the symbol table cache:
+// its build ID, or its device and inode numbers when it doesn't have one.
+func (f *elfFile) cacheKey() (string, error) {
+	if id := f.buildID(); id != "" {
+		return "buildid:" + id, nil
+	}
+
+	fi, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", fmt.Errorf("getting inode of %q", f.path)
+	}
+
+	return fmt.Sprintf("inode:%d:%d", st.Dev, st.Ino), nil
+}
+
+// buildID returns the GNU build ID of the binary, or an empty string if it
+// doesn't have one.
+func (f *elfFile) buildID() string {
+	sec := f.Section(".note.gnu.build-id")
+	if sec == nil {
+		return ""
+	}
+
+	data, err := sec.Data()
+	if err != nil {
+		return ""
+	}
+
+	return parseBuildIDNote(data, f.ByteOrder)
+}
+
+// parseBuildIDNote looks for the GNU build ID in a notes section.
+// https://man7.org/linux/man-pages/man5/elf.5.html (Notes)
+func parseBuildIDNote(data []byte, order binary.ByteOrder) string {
+	align4 := func(n uint64) uint64 {
+		return (n + 3) &^ 3
+	}
+
+	for len(data) >= 12 {
+		nameSize := uint64(order.Uint32(data[0:4]))
+		descSize := uint64(order.Uint32(data[4:8]))
+		noteType := order.Uint32(data[8:12])
+
+		nameEnd := 12 + align4(nameSize)
+		descEnd := nameEnd + align4(descSize)
+		if descEnd > uint64(len(data)) {
+			return ""
+		}
+
+		name := data[12 : 12+nameSize]
+		if noteType == ntGNUBuildID && string(name) == "GNU\x00" {
+			return hex.EncodeToString(data[nameEnd : nameEnd+descSize])
+		}
+
+		data = data[descEnd:]
+	}
+
+	return ""
+}
+
+// symbolTable reads the function symbols of the binary. They're taken from
+// the first of these sources having any:
+// 1. .symtab section
+// 2. .dynsym section, along with the MiniDebugInfo in .gnu_debugdata
+// 3. .gopclntab section, present even in stripped Go binaries
+func (f *elfFile) symbolTable() (*symbolTable, error) {
+	table := &symbolTable{}
+
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_LOAD || prog.Flags&elf.PF_X == 0 {
+			continue
+		}
+
+		table.loads = append(table.loads, loadSegment{
+			offset: prog.Off,
+			vaddr:  prog.Vaddr,
+			size:   prog.Filesz,
+		})
+	}
+
+	symbols := funcSymbols(f.Symbols())
+	if len(symbols) == 0 {
+		symbols = funcSymbols(f.DynamicSymbols())
+		symbols = append(symbols, f.miniDebugInfoSymbols()...)
+	}
+	if len(symbols) == 0 {
+		symbols = f.goSymbols()
+	}
+	if len(symbols) == 0 {
+		return nil, errors.New("no symbols found")
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].addr < symbols[j].addr
+	})
+	table.symbols = symbols
+
+	return table, nil
+}
+
+func funcSymbols(elfSymbols []elf.Symbol, err error) []symbol {
+	if err != nil {
+		return nil
+	}
+
+	symbols := []symbol{}
+	for _, s := range elfSymbols {
+		if elf.ST_TYPE(s.Info) != elf.STT_FUNC || s.Value == 0 {
+			continue
+		}
+
+		symbols = append(symbols, symbol{
+			addr: s.Value,
+			size: s.Size,
+			name: s.Name,
+		})
+	}
+
+	return symbols
+}
+
+// miniDebugInfoSymbols returns the symbols in the xz-compressed ELF stored in
+// the .gnu_debugdata section, used by some distributions to keep the
+// symbols of stripped binaries.
+// https://sourceware.org/gdb/onlinedocs/gdb/MiniDebugInfo.html
+func (f *elfFile) miniDebugInfoSymbols() []symbol {
+	sec := f.Section(".gnu_debugdata")
+	if sec == nil {
+		return nil
+	}
+
+	data, err := sec.Data()
+	if err != nil {
+		return nil
+	}
+
+	r, err := xz.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	debugData, err := io.ReadAll(r)
+	if err != nil {
+		return nil
+	}
+
+	debugFile, err := elf.NewFile(bytes.NewReader(debugData))
+	if err != nil {
+		return nil
+	}
+
+	return funcSymbols(debugFile.Symbols())
+}
+
+// goSymbols returns the functions in the Go symbol table.
+func (f *elfFile) goSymbols() []symbol {
+	pclntab := f.Section(".gopclntab")
+	text := f.Section(".text")
+	if pclntab == nil || text == nil {
+		return nil
+	}
+
+	data, err := pclntab.Data()
+	if err != nil {
+		return nil
+	}
+
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(data, text.Addr))
+	if err != nil {
+		return nil
+	}
+
+	symbols := make([]symbol, 0, len(table.Funcs))
+	for _, fn := range table.Funcs {
+		symbols = append(symbols, symbol{
+			addr: fn.Entry,
+			size: fn.End - fn.Entry,
+			name: fn.Name,
+		})
+	}
+
+	return symbols
+}
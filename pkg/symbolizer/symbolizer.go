@@ -0,0 +1,221 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package symbolizer resolves instruction pointers of user space processes to
+// function names. Binaries are read through /proc/<pid>/root, so processes
+// running in containers are supported as well.
+package symbolizer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Unknown is returned for the addresses that can't be resolved.
+const Unknown = "[unknown]"
+
+var hostRoot string
+
+func init() {
+	hostRoot = os.Getenv("HOST_ROOT")
+}
+
+// mapping is an executable memory mapping of a file, as described in
+// /proc/<pid>/maps.
+type mapping struct {
+	start  uint64
+	end    uint64
+	offset uint64
+	path   string
+}
+
+// Symbolizer resolves instruction pointers to function names. Symbol tables
+// are cached by build ID, so binaries shared by several processes or
+// containers are only read once. It's not safe for concurrent use.
+type Symbolizer struct {
+	// key: build ID of the binary, or device and inode when it has none
+	tables map[string]*symbolTable
+
+	// key: pid
+	mappings map[uint32][]mapping
+
+	// key: pid and path of the binary in the mount namespace of pid
+	tablesByPath map[string]*symbolTable
+}
+
+func New() *Symbolizer {
+	return &Symbolizer{
+		tables:       make(map[string]*symbolTable),
+		mappings:     make(map[uint32][]mapping),
+		tablesByPath: make(map[string]*symbolTable),
+	}
+}
+
+// Resolve returns the name of the functions containing the instruction
+// pointers ips of the process pid. Unknown is used for the addresses that
+// can't be resolved, e.g. because the process already terminated or the
+// binary is stripped.
+func (s *Symbolizer) Resolve(pid uint32, ips []uint64) []string {
+	mappings, ok := s.mappings[pid]
+	if !ok {
+		// Keep going with no mappings if the process is gone, all the
+		// addresses will be unknown.
+		mappings, _ = readMappings(pid)
+		s.mappings[pid] = mappings
+	}
+
+	names := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		names = append(names, s.resolve(pid, mappings, ip))
+	}
+
+	return names
+}
+
+func (s *Symbolizer) resolve(pid uint32, mappings []mapping, ip uint64) string {
+	for _, m := range mappings {
+		if ip < m.start || ip >= m.end {
+			continue
+		}
+
+		table := s.symbolTable(pid, m.path)
+		if table == nil {
+			return Unknown
+		}
+
+		name, ok := table.lookup(ip - m.start + m.offset)
+		if !ok {
+			return Unknown
+		}
+
+		return name
+	}
+
+	return Unknown
+}
+
+// symbolTable returns the symbol table of the binary at path in the mount
+// namespace of pid, or nil if it can't be read.
+func (s *Symbolizer) symbolTable(pid uint32, path string) *symbolTable {
+	pathKey := fmt.Sprintf("%d:%s", pid, path)
+	if table, ok := s.tablesByPath[pathKey]; ok {
+		return table
+	}
+
+	// Failures are cached too to avoid reading the same binary again
+	table, _ := s.loadSymbolTable(filepath.Join(hostRoot, "/proc", strconv.FormatUint(uint64(pid), 10), "root", path))
+	s.tablesByPath[pathKey] = table
+
+	return table
+}
+
+func (s *Symbolizer) loadSymbolTable(path string) (*symbolTable, error) {
+	f, err := openELF(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	key, err := f.cacheKey()
+	if err != nil {
+		return nil, err
+	}
+	if table, ok := s.tables[key]; ok {
+		return table, nil
+	}
+
+	table, err := f.symbolTable()
+	if err != nil {
+		return nil, fmt.Errorf("reading symbols of %q: %w", path, err)
+	}
+	s.tables[key] = table
+
+	return table, nil
+}
+
+// readMappings returns the executable file mappings of the process pid.
+func readMappings(pid uint32) ([]mapping, error) {
+	file, err := os.Open(filepath.Join(hostRoot, fmt.Sprintf("/proc/%d/maps", pid)))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	mappings := []mapping{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		m, ok := parseMapping(scanner.Text())
+		if ok {
+			mappings = append(mappings, m)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// parseMapping parses a line of /proc/<pid>/maps like:
+// 55d0f3a6e000-55d0f3b3f000 r-xp 00026000 fd:01 3278049    /usr/bin/bash
+// It returns false for the mappings which aren't executable or don't
+// correspond to a file, like [vdso] or anonymous mappings.
+func parseMapping(line string) (mapping, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 6 {
+		return mapping{}, false
+	}
+
+	perms := fields[1]
+	if len(perms) < 3 || perms[2] != 'x' {
+		return mapping{}, false
+	}
+
+	// The path can contain spaces
+	path := strings.Join(fields[5:], " ")
+	if !strings.HasPrefix(path, "/") || strings.HasSuffix(path, " (deleted)") {
+		return mapping{}, false
+	}
+
+	addrs := strings.SplitN(fields[0], "-", 2)
+	if len(addrs) != 2 {
+		return mapping{}, false
+	}
+
+	start, err := strconv.ParseUint(addrs[0], 16, 64)
+	if err != nil {
+		return mapping{}, false
+	}
+	end, err := strconv.ParseUint(addrs[1], 16, 64)
+	if err != nil {
+		return mapping{}, false
+	}
+	offset, err := strconv.ParseUint(fields[2], 16, 64)
+	if err != nil {
+		return mapping{}, false
+	}
+
+	return mapping{
+		start:  start,
+		end:    end,
+		offset: offset,
+		path:   path,
+	}, true
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 The Inspektor Gadget authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package symbolizer
+
+import (
+	"encoding/binary"
+	"os"
+	"reflect"
+	"testing"
+)
+
+//go:noinline
+func symbolizeMe() int {
+	return 42
+}
+
+func TestResolve(t *testing.T) {
+	pid := uint32(os.Getpid())
+	ip := uint64(reflect.ValueOf(symbolizeMe).Pointer())
+
+	s := New()
+	names := s.Resolve(pid, []uint64{ip, ip + 1, 0})
+
+	expected := []string{
+		"github.com/inspektor-gadget/inspektor-gadget/pkg/symbolizer.symbolizeMe",
+		"github.com/inspektor-gadget/inspektor-gadget/pkg/symbolizer.symbolizeMe",
+		Unknown,
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Resolve() = %q, expected %q", names, expected)
+	}
+
+	if len(s.tables) != 1 {
+		t.Fatalf("Expected 1 cached symbol table, got %d", len(s.tables))
+	}
+
+	// Unknown process
+	names = s.Resolve(0, []uint64{ip})
+	if !reflect.DeepEqual(names, []string{Unknown}) {
+		t.Fatalf("Resolve() = %q, expected %q", names, []string{Unknown})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	table := []struct {
+		line     string
+		expected mapping
+		ok       bool
+	}{
+		{
+			line: "55d0f3a6e000-55d0f3b3f000 r-xp 00026000 fd:01 3278049    /usr/bin/bash",
+			expected: mapping{
+				start:  0x55d0f3a6e000,
+				end:    0x55d0f3b3f000,
+				offset: 0x26000,
+				path:   "/usr/bin/bash",
+			},
+			ok: true,
+		},
+		{
+			line: "7f5e1c000000-7f5e1c021000 r-xp 00000000 fd:01 1234    /opt/my app/lib.so",
+			expected: mapping{
+				start:  0x7f5e1c000000,
+				end:    0x7f5e1c021000,
+				offset: 0,
+				path:   "/opt/my app/lib.so",
+			},
+			ok: true,
+		},
+		{
+			// Not executable
+			line: "55d0f3a48000-55d0f3a6e000 r--p 00000000 fd:01 3278049    /usr/bin/bash",
+		},
+		{
+			line: "7ffd5a5e4000-7ffd5a5e6000 r-xp 00000000 00:00 0          [vdso]",
+		},
+		{
+			// Anonymous mapping
+			line: "7f5e1c021000-7f5e1c022000 r-xp 00000000 00:00 0",
+		},
+		{
+			line: "7f5e1c000000-7f5e1c021000 r-xp 00000000 fd:01 1234    /tmp/app (deleted)",
+		},
+	}
+
+	for _, entry := range table {
+		m, ok := parseMapping(entry.line)
+		if ok != entry.ok || m != entry.expected {
+			t.Fatalf("parseMapping(%q) = %+v, %t, expected %+v, %t", entry.line, m, ok, entry.expected, entry.ok)
+		}
+	}
+}
+
+func TestParseBuildIDNote(t *testing.T) {
+	note := func(name string, noteType uint32, desc []byte) []byte {
+		data := make([]byte, 12)
+		binary.LittleEndian.PutUint32(data[0:4], uint32(len(name)))
+		binary.LittleEndian.PutUint32(data[4:8], uint32(len(desc)))
+		binary.LittleEndian.PutUint32(data[8:12], noteType)
+		data = append(data, name...)
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+		data = append(data, desc...)
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+		return data
+	}
+
+	data := note("Go\x00", 4, []byte("go-build-id"))
+	data = append(data, note("GNU\x00", ntGNUBuildID, []byte{0xde, 0xad, 0xbe, 0xef, 0x42})...)
+
+	if id := parseBuildIDNote(data, binary.LittleEndian); id != "deadbeef42" {
+		t.Fatalf("parseBuildIDNote() = %q, expected %q", id, "deadbeef42")
+	}
+
+	// Truncated note
+	if id := parseBuildIDNote(data[:len(data)-4], binary.LittleEndian); id != "" {
+		t.Fatalf("parseBuildIDNote() = %q, expected empty build ID", id)
+	}
+}
@@ -30,6 +30,7 @@ import (
 const (
 	MaxContainersPerNode = 1024
 	MountMapPrefix       = "mntnsset_"
+	CgroupIDMapPrefix    = "cgidset_"
 )
 
 type TracerCollection struct {
@@ -55,6 +56,11 @@ type tracer struct {
 
 	mntnsSetMap *ebpf.Map
 
+	// cgroupIDSetMap contains the cgroup IDs of the selected containers.
+	// Unlike the mount namespace inode ids, the cgroup IDs aren't reused
+	// by the kernel so there is no need to keep the cgroups open.
+	cgroupIDSetMap *ebpf.Map
+
 	gadgetStream *stream.GadgetStream
 }
 
@@ -106,6 +112,9 @@ func (tc *TracerCollection) TracerMapsUpdater() containercollection.FuncNotify {
 					} else {
 						log.Errorf("new container with mntns=0")
 					}
+					if event.Container.CgroupID != 0 {
+						t.cgroupIDSetMap.Put(event.Container.CgroupID, one)
+					}
 				}
 			}
 
@@ -114,6 +123,9 @@ func (tc *TracerCollection) TracerMapsUpdater() containercollection.FuncNotify {
 				if containercollection.ContainerSelectorMatches(&t.containerSelector, event.Container) {
 					mntnsC := uint64(event.Container.Mntns)
 					t.mntnsSetMap.Delete(mntnsC)
+					if event.Container.CgroupID != 0 {
+						t.cgroupIDSetMap.Delete(event.Container.CgroupID)
+					}
 				}
 			}
 
@@ -131,7 +143,10 @@ func (tc *TracerCollection) AddTracer(id string, containerSelector containercoll
 	if _, ok := tc.tracers[id]; ok {
 		return fmt.Errorf("tracer id %q: %w", id, os.ErrExist)
 	}
-	var mntnsSetMap *ebpf.Map
+	if err := containercollection.ValidateContainerSelector(&containerSelector); err != nil {
+		return fmt.Errorf("tracer id %q: %w", id, err)
+	}
+	var mntnsSetMap, cgroupIDSetMap *ebpf.Map
 	if !tc.testOnly {
 		mntnsSpec := &ebpf.MapSpec{
 			Name:       MountMapPrefix + id,
@@ -146,18 +161,35 @@ func (tc *TracerCollection) AddTracer(id string, containerSelector containercoll
 			return fmt.Errorf("error creating mntnsset map: %w", err)
 		}
 
+		cgroupIDSpec := &ebpf.MapSpec{
+			Name:       CgroupIDMapPrefix + id,
+			Type:       ebpf.Hash,
+			KeySize:    8,
+			ValueSize:  4,
+			MaxEntries: MaxContainersPerNode,
+		}
+		cgroupIDSetMap, err = ebpf.NewMap(cgroupIDSpec)
+		if err != nil {
+			mntnsSetMap.Close()
+			return fmt.Errorf("error creating cgidset map: %w", err)
+		}
+
 		tc.containerCollection.ContainerRangeWithSelector(&containerSelector, func(c *containercollection.Container) {
 			one := uint32(1)
 			mntnsC := uint64(c.Mntns)
 			if mntnsC != 0 {
 				mntnsSetMap.Put(mntnsC, one)
 			}
+			if c.CgroupID != 0 {
+				cgroupIDSetMap.Put(c.CgroupID, one)
+			}
 		})
 	}
 	tc.tracers[id] = tracer{
 		tracerID:          id,
 		containerSelector: containerSelector,
 		mntnsSetMap:       mntnsSetMap,
+		cgroupIDSetMap:    cgroupIDSetMap,
 		gadgetStream:      stream.NewGadgetStream(),
 	}
 	return nil
@@ -176,6 +208,9 @@ func (tc *TracerCollection) RemoveTracer(id string) error {
 	if t.mntnsSetMap != nil {
 		t.mntnsSetMap.Close()
 	}
+	if t.cgroupIDSetMap != nil {
+		t.cgroupIDSetMap.Close()
+	}
 
 	t.gadgetStream.Close()
 
@@ -205,6 +240,9 @@ func (tc *TracerCollection) TracerDump() (out string) {
 		for k, v := range t.containerSelector.Labels {
 			out += fmt.Sprintf("                  %v: %v\n", k, v)
 		}
+		for _, r := range t.containerSelector.LabelExpressions {
+			out += fmt.Sprintf("                  %v %v %v\n", r.Key, r.Operator, r.Values)
+		}
 		out += "        Matches:\n"
 		tc.containerCollection.ContainerRangeWithSelector(&t.containerSelector, func(c *containercollection.Container) {
 			out += fmt.Sprintf("        - %s/%s [Mntns=%v CgroupID=%v]\n", c.Namespace, c.Podname, c.Mntns, c.CgroupID)
@@ -235,3 +273,15 @@ func (tc *TracerCollection) TracerMountNsMap(id string) (*ebpf.Map, error) {
 
 	return t.mntnsSetMap, nil
 }
+
+// TracerCgroupIDMap returns the map with the cgroup IDs of the containers
+// selected by the tracer, to be used as the CgroupIDMap of the tracers
+// filtering by cgroup ID
+func (tc *TracerCollection) TracerCgroupIDMap(id string) (*ebpf.Map, error) {
+	t, ok := tc.tracers[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown tracer %q", id)
+	}
+
+	return t.cgroupIDSetMap, nil
+}
@@ -17,6 +17,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/inspektor-gadget/inspektor-gadget/pkg/columns"
 )
@@ -31,10 +32,19 @@ func init() {
 	columns.MustRegisterTemplate("namespace", "width:30")
 	columns.MustRegisterTemplate("pod", "width:30,ellipsis:middle")
 	columns.MustRegisterTemplate("container", "width:30")
+
+	// Images are hidden by default. Digests are "sha256:" followed by 64
+	// hexadecimal characters = 71
+	columns.MustRegisterTemplate("image", "width:30,ellipsis:start,hide")
+	columns.MustRegisterTemplate("imageDigest", "width:19,maxWidth:71,hide")
 	columns.MustRegisterTemplate("comm", "maxWidth:16")
 	columns.MustRegisterTemplate("pid", "minWidth:7")
 	columns.MustRegisterTemplate("ns", "width:12,hide")
 
+	// Timestamps are printed using RFC3339 with nanoseconds, e.g.
+	// 2022-11-08T10:41:16.123456789+01:00 = 35
+	columns.MustRegisterTemplate("timestamp", "width:35,maxWidth:35,hide,stringer")
+
 	// For IPs (IPv4+IPv6):
 	// Min: XXX.XXX.XXX.XXX (IPv4) = 15
 	// Max: 0000:0000:0000:0000:0000:ffff:XXX.XXX.XXX.XXX (IPv4-mapped IPv6 address) = 45
@@ -66,6 +76,48 @@ type CommonData struct {
 	// Container where the event comes from, or empty for host-level or
 	// pod-level event
 	Container string `json:"container,omitempty" column:"container,template:container" columnTags:"kubernetes,runtime"`
+
+	// Image of the container where the event comes from
+	Image string `json:"image,omitempty" column:"image,template:image" columnTags:"kubernetes,runtime"`
+
+	// ImageDigest is the digest of the image of the container where the
+	// event comes from
+	ImageDigest string `json:"imageDigest,omitempty" column:"imageDigest,template:imageDigest" columnTags:"kubernetes,runtime"`
+}
+
+// EnrichedData contains the data added to the events by the enrichers
+// enabled for a trace. See pkg/gadgets/enrichers.
+type EnrichedData struct {
+	// User and Group are the names of the user and of its primary group,
+	// as found in the /etc/passwd and /etc/group files of the container
+	User  string `json:"user,omitempty" column:"user,width:16,hide"`
+	Group string `json:"group,omitempty" column:"group,width:16,hide"`
+
+	// Cmdline is the command line of the process and Exe the path of its
+	// executable
+	Cmdline string `json:"cmdline,omitempty" column:"cmdline,width:40,hide"`
+	Exe     string `json:"exe,omitempty" column:"exe,width:32,ellipsis:start,hide"`
+
+	// Ancestors are the parents of the process, the closest first, e.g.
+	// "sh(4242) > containerd-shim(1200) > systemd(1)"
+	Ancestors string `json:"ancestors,omitempty" column:"ancestors,width:40,hide"`
+
+	// SrcEndpoint and DstEndpoint are the Kubernetes pods or services
+	// using the source and destination addresses of the event, e.g.
+	// "pod/default/nginx" or "svc/kube-system/kube-dns"
+	SrcEndpoint string `json:"srcEndpoint,omitempty" column:"srcEndpoint,width:30,ellipsis:middle,hide"`
+	DstEndpoint string `json:"dstEndpoint,omitempty" column:"dstEndpoint,width:30,ellipsis:middle,hide"`
+}
+
+// Time is the wall clock time of an event in nanoseconds since the epoch
+type Time int64
+
+// timeLayout is like time.RFC3339Nano but keeps trailing zeros so all
+// timestamps have the same width
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+func (t Time) String() string {
+	return time.Unix(0, int64(t)).Format(timeLayout)
 }
 
 const (
@@ -91,6 +143,11 @@ const (
 
 type Event struct {
 	CommonData
+	EnrichedData
+
+	// Timestamp in nanoseconds since the epoch when the event was
+	// generated. Gadgets set it on the BPF side.
+	Timestamp Time `json:"timestamp,omitempty" column:"timestamp,template:timestamp"`
 
 	// Type indicates the kind of this event
 	Type EventType `json:"type"`
@@ -105,6 +162,12 @@ func (e Event) GetBaseEvent() *Event {
 	return &e
 }
 
+// GetEnrichedData is needed to implement the gadgets.EventWithEnrichedData
+// interface.
+func (e *Event) GetEnrichedData() *EnrichedData {
+	return &e.EnrichedData
+}
+
 func Err(msg string) Event {
 	return Event{
 		CommonData: CommonData{